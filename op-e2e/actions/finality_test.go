@@ -0,0 +1,273 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	plasma "github.com/ethereum-optimism/optimism/op-plasma"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// FinalityTests is the suite of finality scenarios, run in singular batch mode and span batch mode.
+// Forks that modify the finality rules can run the same scenarios against their changes.
+var FinalityTests = []struct {
+	Name string
+	F    func(gt *testing.T, deltaTimeOffset *hexutil.Uint64)
+}{
+	{"FinalizeDelayedSignal", FinalizeDelayedSignal},
+	{"FinalizeL1ReorgAboveFinalized", FinalizeL1ReorgAboveFinalized},
+	{"FinalizeL1ReorgBelowFinalized", FinalizeL1ReorgBelowFinalized},
+	{"FinalizeNonCanonicalSignal", FinalizeNonCanonicalSignal},
+	{"FinalizeAfterRestart", FinalizeAfterRestart},
+}
+
+// TestFinalityBatchType run each finality-related test case in singular batch mode and span batch mode.
+func TestFinalityBatchType(t *testing.T) {
+	for _, test := range FinalityTests {
+		test := test
+		t.Run(test.Name+"_SingularBatch", func(t *testing.T) {
+			test.F(t, nil)
+		})
+	}
+
+	deltaTimeOffset := hexutil.Uint64(0)
+	for _, test := range FinalityTests {
+		test := test
+		t.Run(test.Name+"_SpanBatch", func(t *testing.T) {
+			test.F(t, &deltaTimeOffset)
+		})
+	}
+}
+
+// buildAndSubmitFinalityTest builds L2 blocks up to the L1 head, and submits them to a new L1 block.
+func buildAndSubmitFinalityTest(t Testing, sd *e2eutils.SetupData, miner *L1Miner, sequencer *L2Sequencer, batcher *L2Batcher) {
+	sequencer.ActL1HeadSignal(t)
+	sequencer.ActBuildToL1Head(t)
+	batcher.ActSubmitAll(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(sd.RollupCfg.Genesis.SystemConfig.BatcherAddr)(t)
+	miner.ActL1EndBlock(t)
+}
+
+// FinalizeDelayedSignal tests that the L2 chain finalizes when the L1 finality signal arrives long after the batch was derived.
+func FinalizeDelayedSignal(gt *testing.T, deltaTimeOffset *hexutil.Uint64) {
+	t := NewDefaultTesting(gt)
+	sd, _, miner, sequencer, _, verifier, _, batcher := setupReorgTest(t, defaultRollupTestParams, deltaTimeOffset)
+
+	miner.ActEmptyBlock(t)
+	buildAndSubmitFinalityTest(t, sd, miner, sequencer, batcher)
+	batchL1Num := miner.UnsafeNum()
+
+	// L1 moves on for a while, without any finality
+	for i := 0; i < 10; i++ {
+		miner.ActEmptyBlock(t)
+	}
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Safe(), "verifier derived the L2 chain")
+	require.Zero(t, verifier.L2Finalized().Number, "nothing is finalized yet")
+
+	// the batch finalizes on L1, long after it was derived
+	miner.ActL1Safe(t, batchL1Num)
+	miner.ActL1Finalize(t, batchL1Num)
+	verifier.ActL1SafeSignal(t)
+	verifier.ActL1FinalizedSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, batchL1Num, verifier.SyncStatus().FinalizedL1.Number)
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Finalized(), "delayed signal finalizes the submitted L2 chain")
+}
+
+// FinalizeL1ReorgAboveFinalized tests that a L1 reorg of unfinalized L1 blocks does not affect the finalized L2 chain,
+// and that L2 blocks of reorged-out batches are not finalized.
+func FinalizeL1ReorgAboveFinalized(gt *testing.T, deltaTimeOffset *hexutil.Uint64) {
+	t := NewDefaultTesting(gt)
+	sd, _, miner, sequencer, _, verifier, _, batcher := setupReorgTest(t, defaultRollupTestParams, deltaTimeOffset)
+
+	// the first batch is finalized
+	miner.ActEmptyBlock(t)
+	buildAndSubmitFinalityTest(t, sd, miner, sequencer, batcher)
+	miner.ActL1SafeNext(t)
+	miner.ActL1SafeNext(t)
+	miner.ActL1FinalizeNext(t)
+	miner.ActL1FinalizeNext(t)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	verifier.ActL1FinalizedSignal(t)
+	verifier.ActL2PipelineFull(t)
+	finalizedL2 := verifier.L2Finalized()
+	require.Equal(t, sequencer.L2Unsafe(), finalizedL2, "first batch is finalized")
+
+	// the second batch is derived, but then reorged out of L1
+	buildAndSubmitFinalityTest(t, sd, miner, sequencer, batcher)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Safe(), "verifier derived the second batch")
+
+	miner.ActL1RewindToParent(t)
+	miner.ActL1SetFeeRecipient(common.Address{'B'})
+	miner.ActEmptyBlock(t)
+	miner.ActEmptyBlock(t)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, finalizedL2, verifier.L2Safe(), "safe head is reverted to the finalized head")
+	require.Equal(t, finalizedL2, verifier.L2Finalized(), "finalized head is not affected by reorg")
+
+	// the replacement L1 chain finalizes, without the second batch
+	miner.ActL1Safe(t, miner.UnsafeNum())
+	miner.ActL1Finalize(t, miner.UnsafeNum())
+	verifier.ActL1FinalizedSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, miner.UnsafeNum(), verifier.SyncStatus().FinalizedL1.Number)
+	require.Equal(t, verifier.L2Safe(), verifier.L2Finalized(), "safe chain on the new L1 chain is finalized")
+	require.Less(t, verifier.L2Finalized().Number, sequencer.L2Unsafe().Number, "reorged-out batch is not finalized")
+}
+
+// FinalizeL1ReorgBelowFinalized tests that a L1 reorg of the L1 block the finalized L2 chain was derived from,
+// which the L1 source signaled as finalized, does not revert the finalized L2 chain.
+func FinalizeL1ReorgBelowFinalized(gt *testing.T, deltaTimeOffset *hexutil.Uint64) {
+	t := NewDefaultTesting(gt)
+	sd, _, miner, sequencer, _, verifier, _, batcher := setupReorgTest(t, defaultRollupTestParams, deltaTimeOffset)
+
+	// the batch is finalized by the verifier, before the L1 chain of the miner finalizes it
+	miner.ActEmptyBlock(t)
+	buildAndSubmitFinalityTest(t, sd, miner, sequencer, batcher)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Safe(), "verifier derived the batch")
+	verifier.finalizer.Finalize(t.Ctx(), verifier.SyncStatus().HeadL1)
+	verifier.ActL2PipelineFull(t)
+	finalizedL2 := verifier.L2Finalized()
+	require.Equal(t, sequencer.L2Unsafe(), finalizedL2, "batch is finalized")
+
+	// the L1 block with the batch is reorged out, below the finalized L1 block of the verifier
+	miner.ActL1RewindToParent(t)
+	miner.ActL1SetFeeRecipient(common.Address{'B'})
+	miner.ActEmptyBlock(t)
+	miner.ActEmptyBlock(t)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, finalizedL2, verifier.L2Finalized(), "finalized head is not reverted by the reorg")
+
+	// the replacement L1 chain finalizes, without the batch
+	miner.ActL1Safe(t, miner.UnsafeNum())
+	miner.ActL1Finalize(t, miner.UnsafeNum())
+	verifier.ActL1FinalizedSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, finalizedL2, verifier.L2Finalized(), "finalized head does not move on the replacement L1 chain")
+}
+
+// FinalizeNonCanonicalSignal tests that a L1 finality signal for a block that is not canonical is ignored,
+// to defend against a L1 source that serves a different chain than the one the L2 chain was derived from.
+func FinalizeNonCanonicalSignal(gt *testing.T, deltaTimeOffset *hexutil.Uint64) {
+	t := NewDefaultTesting(gt)
+	sd, _, miner, sequencer, _, verifier, _, batcher := setupReorgTest(t, defaultRollupTestParams, deltaTimeOffset)
+
+	miner.ActEmptyBlock(t)
+	buildAndSubmitFinalityTest(t, sd, miner, sequencer, batcher)
+	miner.ActL1SafeNext(t)
+	miner.ActL1FinalizeNext(t)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	verifier.ActL1FinalizedSignal(t)
+	verifier.ActL2PipelineFull(t)
+	finalizedL1 := verifier.SyncStatus().FinalizedL1
+	finalizedL2 := verifier.L2Finalized()
+
+	// signal a block at the height of the batch, but with a different hash
+	alt := verifier.SyncStatus().HeadL1
+	alt.Hash = common.HexToHash("0xdead")
+	verifier.finalizer.Finalize(t.Ctx(), alt)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, finalizedL1, verifier.SyncStatus().FinalizedL1, "non-canonical signal is ignored")
+	require.Equal(t, finalizedL2, verifier.L2Finalized(), "non-canonical signal does not finalize L2 blocks")
+
+	// the canonical block is still finalized afterwards
+	miner.ActL1SafeNext(t)
+	miner.ActL1FinalizeNext(t)
+	verifier.ActL1FinalizedSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Finalized(), "canonical signal finalizes the batch")
+}
+
+// FinalizeAfterRestart tests that a restarted verifier keeps the finalized head of the engine,
+// and continues to finalize the L2 chain, even though the finality data buffer was lost.
+func FinalizeAfterRestart(gt *testing.T, deltaTimeOffset *hexutil.Uint64) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	dp.DeployConfig.L2GenesisDeltaTimeOffset = deltaTimeOffset
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LevelDebug)
+	sd, _, miner, sequencer, _, verifier, verifierEng, batcher := setupReorgTestActors(t, dp, sd, log)
+
+	miner.ActEmptyBlock(t)
+	buildAndSubmitFinalityTest(t, sd, miner, sequencer, batcher)
+	miner.ActL1SafeNext(t)
+	miner.ActL1SafeNext(t)
+	miner.ActL1FinalizeNext(t)
+	miner.ActL1FinalizeNext(t)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	verifier.ActL1FinalizedSignal(t)
+	verifier.ActL2PipelineFull(t)
+	finalizedL2 := verifier.L2Finalized()
+	require.NotZero(t, finalizedL2.Number)
+
+	// more L2 blocks are derived, but not finalized, before the restart
+	buildAndSubmitFinalityTest(t, sd, miner, sequencer, batcher)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+
+	// restart the verifier, attached to the same engine
+	verifier = NewL2Verifier(t, log, miner.L1Client(t, sd.RollupCfg), miner.BlobStore(), plasma.Disabled,
+		verifierEng.EngineClient(t, sd.RollupCfg), sd.RollupCfg, &sync.Config{}, safedb.Disabled)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, finalizedL2, verifier.L2Finalized(), "finalized head is kept across restarts")
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Safe(), "verifier re-derives the safe chain")
+
+	// the re-derived L2 chain finalizes
+	miner.ActL1SafeNext(t)
+	miner.ActL1FinalizeNext(t)
+	verifier.ActL1FinalizedSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Finalized(), "finality continues after restart")
+}
+
+// TestPlasma_FinalityChallengeWindow tests that plasma L2 blocks only finalize after the challenge window of the
+// input commitment passed, even if the L1 block with the commitment is finalized earlier.
+// The harness provides the DA challenge contract, so the test does not depend on a plasma devnet.
+func TestPlasma_FinalityChallengeWindow(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	harness := NewL2PlasmaDAWithChallengeContract(t)
+
+	// generate enough initial l1 blocks to have a finalized head.
+	harness.ActL1Blocks(t, 5)
+
+	// Include a new l2 transaction, submitting an input commitment to the l1.
+	harness.ActNewL2Tx(t)
+	blk := harness.GetLastTxBlock(t)
+
+	// the L1 block with the commitment finalizes, but the challenge window is still open.
+	harness.ActL1Finalized(t)
+	harness.sequencer.ActL2PipelineFull(t)
+	require.Less(t, harness.sequencer.SyncStatus().FinalizedL2.Number, blk.NumberU64(),
+		"L2 block is not finalized within the challenge window")
+
+	// create enough l1 blocks to expire the challenge window.
+	harness.ActL1Blocks(t, harness.plasmaCfg.ChallengeWindow+1)
+	harness.sequencer.ActL2PipelineFull(t)
+	harness.ActL1Finalized(t)
+
+	// move one more block for engine controller to update.
+	harness.ActL1Blocks(t, 1)
+	harness.sequencer.ActL2PipelineFull(t)
+	require.GreaterOrEqual(t, harness.sequencer.SyncStatus().FinalizedL2.Number, blk.NumberU64(),
+		"L2 block is finalized after the challenge window")
+}
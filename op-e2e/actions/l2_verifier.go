@@ -2,6 +2,7 @@ package actions
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 
@@ -45,7 +46,7 @@ type L2Verifier struct {
 
 	attributesHandler driver.AttributesHandler
 	safeHeadListener  rollup.SafeHeadListener
-	finalizer         driver.Finalizer
+	finalizer         driver.FinalizerBackend
 	syncCfg           *sync.Config
 
 	l1      derive.L1Fetcher
@@ -81,11 +82,12 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, blobsSrc deri
 
 	clSync := clsync.NewCLSync(log, cfg, metrics, engine)
 
-	var finalizer driver.Finalizer
+	finalityEngine := finality.NewControllerEngine(engine)
+	var finalizer driver.FinalizerBackend
 	if cfg.PlasmaEnabled() {
-		finalizer = finality.NewPlasmaFinalizer(log, cfg, l1, engine, plasmaSrc)
+		finalizer = finality.NewPlasmaFinalizer(log, cfg, l1, finalityEngine, plasmaSrc, finality.WithL2BlockSource(eng))
 	} else {
-		finalizer = finality.NewFinalizer(log, cfg, l1, engine)
+		finalizer = finality.NewFinalizer(log, cfg, l1, finalityEngine, finality.WithL2BlockSource(eng))
 	}
 
 	attributesHandler := attributes.NewAttributesHandler(log, cfg, engine, eng)
@@ -132,7 +134,7 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, blobsSrc deri
 		{
 			Namespace:     "admin",
 			Version:       "",
-			Service:       node.NewAdminAPI(backend, m, log),
+			Service:       node.NewAdminAPI(backend, backend, m, log),
 			Public:        true, // TODO: this field is deprecated. Do we even need this anymore?
 			Authenticated: false,
 		},
@@ -154,6 +156,50 @@ func (s *l2VerifierBackend) SyncStatus(ctx context.Context) (*eth.SyncStatus, er
 	return s.verifier.SyncStatus(), nil
 }
 
+func (s *l2VerifierBackend) FinalityStatusJSON(ctx context.Context) (json.RawMessage, error) {
+	return s.verifier.finalizer.CachedStatusJSON(), nil
+}
+
+func (s *l2VerifierBackend) FinalitySnapshot(ctx context.Context) (*finality.Snapshot, error) {
+	return s.verifier.finalizer.Snapshot(), nil
+}
+
+func (s *l2VerifierBackend) FinalityAudit(ctx context.Context) ([]finality.FinalizedHeadUpdate, error) {
+	return s.verifier.finalizer.AuditTrail(), nil
+}
+
+func (s *l2VerifierBackend) FinalityCasualties(ctx context.Context) ([]finality.PruningCasualty, error) {
+	return s.verifier.finalizer.PruningCasualties(), nil
+}
+
+func (s *l2VerifierBackend) FinalizedAtTime(ctx context.Context, timestamp uint64) (eth.L2BlockRef, error) {
+	return s.verifier.finalizer.FinalizedAtTime(ctx, timestamp)
+}
+
+func (s *l2VerifierBackend) FinalityDerivedFrom(ctx context.Context, l2Number uint64) (eth.BlockID, error) {
+	return s.verifier.finalizer.DerivedFrom(l2Number)
+}
+
+func (s *l2VerifierBackend) FinalizedAtLeast(ctx context.Context, l2Number uint64) (bool, error) {
+	return s.verifier.finalizer.FinalizedAtLeast(l2Number)
+}
+
+func (s *l2VerifierBackend) EstimateFinality(ctx context.Context, l2Number uint64) (finality.FinalityEstimate, error) {
+	return s.verifier.finalizer.EstimateFinality(l2Number)
+}
+
+func (s *l2VerifierBackend) ResumeFinality(ctx context.Context) error {
+	return s.verifier.finalizer.ResumeFinality()
+}
+
+func (s *l2VerifierBackend) ReloadFinalityTunables(ctx context.Context, update finality.FinalityTunablesUpdate) (finality.FinalityTunables, error) {
+	return s.verifier.finalizer.ReloadTunables(update, finality.TunablesTriggerRPC)
+}
+
+func (s *l2VerifierBackend) UnsafeRollbackFinalized(ctx context.Context, target eth.L2BlockRef) error {
+	return s.verifier.finalizer.UnsafeRollbackFinalized(ctx, target)
+}
+
 func (s *l2VerifierBackend) ResetDerivationPipeline(ctx context.Context) error {
 	s.verifier.derivation.Reset()
 	return nil
@@ -171,6 +217,10 @@ func (s *l2VerifierBackend) SequencerActive(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
+func (s *l2VerifierBackend) SetFakeFinalizedL1(ctx context.Context, number uint64) (eth.L1BlockRef, error) {
+	return eth.L1BlockRef{}, errors.New("fake finality signals are not supported by the L2Verifier, use ActL1FinalizedSignal")
+}
+
 func (s *l2VerifierBackend) OnUnsafeL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
 	return nil
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
@@ -42,7 +43,18 @@ type L2PlasmaDA struct {
 
 type PlasmaParam func(p *e2eutils.TestParams)
 
+// NewL2PlasmaDA sets up the plasma harness on the DA challenge contract of the devnet deployments.
 func NewL2PlasmaDA(t Testing, params ...PlasmaParam) *L2PlasmaDA {
+	return newL2PlasmaDA(t, false, params...)
+}
+
+// NewL2PlasmaDAWithChallengeContract sets up the plasma harness with a DA challenge contract in the L1 genesis,
+// so it also runs on devnets that did not deploy the plasma contracts.
+func NewL2PlasmaDAWithChallengeContract(t Testing, params ...PlasmaParam) *L2PlasmaDA {
+	return newL2PlasmaDA(t, true, params...)
+}
+
+func newL2PlasmaDA(t Testing, deployChallengeContract bool, params ...PlasmaParam) *L2PlasmaDA {
 	p := &e2eutils.TestParams{
 		MaxSequencerDrift:   40,
 		SequencerWindowSize: 120,
@@ -56,7 +68,15 @@ func NewL2PlasmaDA(t Testing, params ...PlasmaParam) *L2PlasmaDA {
 	log := testlog.Logger(t, log.LvlDebug)
 
 	dp := e2eutils.MakeDeployParams(t, p)
-	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	alloc := defaultAlloc
+	if deployChallengeContract {
+		dp.DeployConfig.DAChallengeProxy = testDAChallengeAddress
+		alloc = &e2eutils.AllocParams{
+			PrefundTestUsers: true,
+			L1Alloc:          types.GenesisAlloc{testDAChallengeAddress: daChallengeAccount(t, dp)},
+		}
+	}
+	sd := e2eutils.Setup(t, dp, alloc)
 
 	require.True(t, sd.RollupCfg.PlasmaEnabled())
 
@@ -122,6 +142,29 @@ func NewL2PlasmaDA(t Testing, params ...PlasmaParam) *L2PlasmaDA {
 	}
 }
 
+// testDAChallengeAddress is the L1 address of the DA challenge contract of NewL2PlasmaDAWithChallengeContract.
+var testDAChallengeAddress = common.HexToAddress("0xdac0000000000000000000000000000000000001")
+
+// daChallengeAccount returns the L1 genesis account of a DA challenge contract,
+// initialized with the challenge settings of the deploy config, like the deploy script initializes the proxy.
+func daChallengeAccount(t Testing, dp *e2eutils.DeployParams) types.Account {
+	code, _, _, err := runtime.Create(common.FromHex(bindings.DataAvailabilityChallengeMetaData.Bin), &runtime.Config{})
+	require.NoError(t, err)
+	dc := dp.DeployConfig
+	// storage slots of the DataAvailabilityChallenge storage layout
+	return types.Account{
+		Code: code,
+		Storage: map[common.Hash]common.Hash{
+			common.BigToHash(big.NewInt(0)):   common.BigToHash(big.NewInt(1)), // _initialized
+			common.BigToHash(big.NewInt(51)):  common.BytesToHash(dp.Addresses.Deployer.Bytes()),
+			common.BigToHash(big.NewInt(101)): common.BigToHash(new(big.Int).SetUint64(dc.DAChallengeWindow)),
+			common.BigToHash(big.NewInt(102)): common.BigToHash(new(big.Int).SetUint64(dc.DAResolveWindow)),
+			common.BigToHash(big.NewInt(103)): common.BigToHash(new(big.Int).SetUint64(dc.DABondSize)),
+			common.BigToHash(big.NewInt(104)): common.BigToHash(new(big.Int).SetUint64(dc.DAResolverRefundPercentage)),
+		},
+	}
+}
+
 func (a *L2PlasmaDA) StorageClient() *plasma.DAErrFaker {
 	return a.storage
 }
@@ -108,6 +108,12 @@ func Setup(t require.TestingT, deployParams *DeployParams, alloc *AllocParams) *
 	require.NoError(t, deployConf.Check())
 
 	l1Deployments := config.L1Deployments.Copy()
+	if deployConf.UsePlasma && deployConf.DAChallengeProxy != (common.Address{}) &&
+		deployConf.DAChallengeProxy != l1Deployments.DataAvailabilityChallengeProxy {
+		// the DA challenge contract is provided with the L1 allocs of the test, instead of the devnet deployments
+		l1Deployments.DataAvailabilityChallenge = deployConf.DAChallengeProxy
+		l1Deployments.DataAvailabilityChallengeProxy = deployConf.DAChallengeProxy
+	}
 	require.NoError(t, l1Deployments.Check(deployConf))
 
 	l1Genesis, err := genesis.BuildL1DeveloperGenesis(deployConf, config.L1Allocs, l1Deployments)
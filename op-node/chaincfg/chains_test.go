@@ -67,6 +67,7 @@ var mainnetCfg = rollup.Config{
 	EcotoneTime:             u64Ptr(1710374401),
 	FjordTime:               u64Ptr(1720627201),
 	ProtocolVersionsAddress: common.HexToAddress("0x8062AbC286f5e7D9428a0Ccb9AbD71e50d93b935"),
+	FinalityProfile:         rollup.FinalityProfileStandard,
 }
 
 var sepoliaCfg = rollup.Config{
@@ -102,6 +103,7 @@ var sepoliaCfg = rollup.Config{
 	EcotoneTime:             u64Ptr(1708534800),
 	FjordTime:               u64Ptr(1716998400),
 	ProtocolVersionsAddress: common.HexToAddress("0x79ADD5713B383DAa0a138d3C4780C7A1804a8090"),
+	FinalityProfile:         rollup.FinalityProfileStandard,
 }
 
 var sepoliaDev0Cfg = rollup.Config{
@@ -137,6 +139,7 @@ var sepoliaDev0Cfg = rollup.Config{
 	EcotoneTime:             u64Ptr(1706634000),
 	FjordTime:               u64Ptr(1715961600),
 	ProtocolVersionsAddress: common.HexToAddress("0x252CbE9517F731C618961D890D534183822dcC8d"),
+	FinalityProfile:         rollup.FinalityProfileStandard,
 }
 
 func u64Ptr(v uint64) *uint64 {
@@ -0,0 +1,171 @@
+package finality
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/api"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+var (
+	rpcFlag = &cli.StringFlag{
+		Name:     "rpc",
+		Usage:    "RPC URL of the rollup node",
+		Required: true,
+	}
+	pollIntervalFlag = &cli.DurationFlag{
+		Name:  "poll-interval",
+		Usage: "Interval to poll the finality status of the rollup node at",
+		Value: 12 * time.Second,
+	}
+	stallThresholdFlag = &cli.DurationFlag{
+		Name:  "stall-threshold",
+		Usage: "Maximum duration without progress of the finalized L1 or L2 block, before finality is considered stalled",
+		Value: time.Hour,
+	}
+	webhookFlag = &cli.StringFlag{
+		Name:  "webhook",
+		Usage: "URL to POST a JSON notification to when finality stalls or recovers. If not set, the watcher exits with an error on stall.",
+	}
+)
+
+var ErrFinalityStalled = errors.New("finality stalled")
+
+var Subcommands = []*cli.Command{
+	{
+		Name:  "watch",
+		Usage: "Watches the finality progression of a running rollup node, and alerts if it stalls",
+		Flags: []cli.Flag{rpcFlag, pollIntervalFlag, stallThresholdFlag, webhookFlag},
+		Action: func(ctx *cli.Context) error {
+			logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+			rpc, err := client.NewRPC(ctx.Context, logger, ctx.String(rpcFlag.Name))
+			if err != nil {
+				return fmt.Errorf("failed to dial rollup node RPC: %w", err)
+			}
+			defer rpc.Close()
+			w := &Watcher{
+				log:       logger,
+				source:    sources.NewRollupClient(rpc),
+				threshold: ctx.Duration(stallThresholdFlag.Name),
+				webhook:   ctx.String(webhookFlag.Name),
+			}
+			return w.Run(ctx.Context, ctx.Duration(pollIntervalFlag.Name))
+		},
+	},
+	verifyCommand,
+	simulateCommand,
+}
+
+type StatusSource interface {
+	FinalityStatus(ctx context.Context) (*api.FinalityStatus, error)
+}
+
+// Watcher tracks the finalized L1 and L2 blocks of a rollup node, to detect when finality stalls.
+type Watcher struct {
+	log       log.Logger
+	source    StatusSource
+	threshold time.Duration
+	webhook   string
+
+	lastStatus   api.FinalityStatus
+	lastL1Change time.Time
+	lastL2Change time.Time
+	stalled      bool
+}
+
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Poll(ctx, time.Now()); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches the finality status once, and checks it for progress.
+// It returns an error if finality stalled, and no webhook is configured to notify instead.
+func (w *Watcher) Poll(ctx context.Context, now time.Time) error {
+	status, err := w.source.FinalityStatus(ctx)
+	if err != nil {
+		// the node may be restarting, only stalled finality is fatal
+		w.log.Warn("failed to fetch finality status", "err", err)
+		status = &w.lastStatus
+	}
+	stallErr := w.check(*status, now)
+	if stallErr != nil && !w.stalled {
+		w.log.Error("finality stalled", "err", stallErr, "finalized_l1", status.FinalizedL1,
+			"finalized_l2", status.FinalizedL2, "last_reason", status.LastReason)
+		if w.webhook == "" {
+			return stallErr
+		}
+		w.notify(ctx, stallErr.Error(), status)
+	} else if stallErr == nil && w.stalled {
+		w.log.Info("finality recovered", "finalized_l1", status.FinalizedL1, "finalized_l2", status.FinalizedL2)
+		w.notify(ctx, "finality recovered", status)
+	}
+	w.stalled = stallErr != nil
+	return nil
+}
+
+// check updates the progress of the finalized blocks, and returns an error if either did not progress for too long.
+func (w *Watcher) check(status api.FinalityStatus, now time.Time) error {
+	if w.lastL1Change.IsZero() || status.FinalizedL1.Number > w.lastStatus.FinalizedL1.Number {
+		w.lastL1Change = now
+	}
+	if w.lastL2Change.IsZero() || status.FinalizedL2.Number > w.lastStatus.FinalizedL2.Number {
+		w.lastL2Change = now
+	}
+	w.lastStatus = status
+	if d := now.Sub(w.lastL1Change); d > w.threshold {
+		return fmt.Errorf("%w: finalized L1 block %s did not progress for %s", ErrFinalityStalled, status.FinalizedL1, d)
+	}
+	if d := now.Sub(w.lastL2Change); d > w.threshold {
+		return fmt.Errorf("%w: finalized L2 block %s did not progress for %s (last reason: %q)",
+			ErrFinalityStalled, status.FinalizedL2, d, status.LastReason)
+	}
+	return nil
+}
+
+func (w *Watcher) notify(ctx context.Context, msg string, status *api.FinalityStatus) {
+	if w.webhook == "" {
+		return
+	}
+	body, err := json.Marshal(map[string]any{"text": msg, "status": status})
+	if err != nil {
+		w.log.Error("failed to encode webhook notification", "err", err)
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.webhook, bytes.NewReader(body))
+	if err != nil {
+		w.log.Error("failed to create webhook request", "err", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		w.log.Error("failed to send webhook notification", "err", err)
+		return
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		w.log.Error("webhook notification was rejected", "status", resp.Status)
+	}
+}
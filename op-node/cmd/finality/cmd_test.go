@@ -0,0 +1,84 @@
+package finality
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/api"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeStatusSource struct {
+	status api.FinalityStatus
+	err    error
+}
+
+func (f *fakeStatusSource) FinalityStatus(ctx context.Context) (*api.FinalityStatus, error) {
+	status := f.status
+	return &status, f.err
+}
+
+func TestWatcher(t *testing.T) {
+	now := time.Unix(1000, 0)
+	advance := func(src *fakeStatusSource) {
+		src.status.FinalizedL1 = eth.L1BlockRef{Number: src.status.FinalizedL1.Number + 1}
+		src.status.FinalizedL2 = eth.L2BlockRef{Number: src.status.FinalizedL2.Number + 10}
+	}
+
+	t.Run("exit-on-stall", func(t *testing.T) {
+		src := &fakeStatusSource{}
+		w := &Watcher{log: testlog.Logger(t, log.LevelCrit), source: src, threshold: time.Minute}
+		require.NoError(t, w.Poll(context.Background(), now))
+		advance(src)
+		require.NoError(t, w.Poll(context.Background(), now.Add(50*time.Second)))
+		// the node is unreachable for a while, but finality did not stall yet
+		src.err = errors.New("offline")
+		require.NoError(t, w.Poll(context.Background(), now.Add(100*time.Second)))
+		require.ErrorIs(t, w.Poll(context.Background(), now.Add(200*time.Second)), ErrFinalityStalled)
+	})
+
+	t.Run("l2-stall", func(t *testing.T) {
+		src := &fakeStatusSource{}
+		w := &Watcher{log: testlog.Logger(t, log.LevelCrit), source: src, threshold: time.Minute}
+		require.NoError(t, w.Poll(context.Background(), now))
+		// L1 keeps finalizing, but L2 does not
+		src.status.FinalizedL1 = eth.L1BlockRef{Number: 5}
+		src.status.LastReason = api.ReasonSignalOlderThanBuffer
+		err := w.Poll(context.Background(), now.Add(2*time.Minute))
+		require.ErrorIs(t, err, ErrFinalityStalled)
+		require.ErrorContains(t, err, string(api.ReasonSignalOlderThanBuffer))
+	})
+
+	t.Run("webhook", func(t *testing.T) {
+		var msgs []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Text string `json:"text"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			msgs = append(msgs, body.Text)
+		}))
+		defer srv.Close()
+
+		src := &fakeStatusSource{}
+		w := &Watcher{log: testlog.Logger(t, log.LevelCrit), source: src, threshold: time.Minute, webhook: srv.URL}
+		require.NoError(t, w.Poll(context.Background(), now))
+		require.NoError(t, w.Poll(context.Background(), now.Add(2*time.Minute)))
+		require.NoError(t, w.Poll(context.Background(), now.Add(3*time.Minute)), "stall is only notified once")
+		advance(src)
+		require.NoError(t, w.Poll(context.Background(), now.Add(4*time.Minute)))
+		require.Len(t, msgs, 2)
+		require.Contains(t, msgs[0], "finality stalled")
+		require.Equal(t, "finality recovered", msgs[1])
+	})
+}
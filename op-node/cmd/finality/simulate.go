@@ -0,0 +1,276 @@
+package finality
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/core"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+var (
+	traceFlag = &cli.StringFlag{
+		Name:     "trace",
+		Usage:    "Path of the JSON trace of the L1 finality signals, and optionally the derivation progress, to simulate",
+		Required: true,
+	}
+	traceRPCFlag = &cli.StringFlag{
+		Name:  "rpc",
+		Usage: "RPC URL of a rollup node with the safe head database enabled, to fetch the derivation progress from, instead of the trace",
+	}
+	traceL1RPCFlag = &cli.StringFlag{
+		Name:  "l1",
+		Usage: "RPC URL of the L1 node, to time the derivation progress fetched with --rpc by",
+	}
+	l1StartFlag = &cli.Uint64Flag{
+		Name:  "l1-start",
+		Usage: "First L1 block of the derivation progress to fetch with --rpc",
+	}
+	l1EndFlag = &cli.Uint64Flag{
+		Name:  "l1-end",
+		Usage: "Last L1 block of the derivation progress to fetch with --rpc",
+	}
+	delaysFlag = &cli.Uint64SliceFlag{
+		Name:  "delay",
+		Usage: "Finality delays to simulate: the number of L1 blocks to traverse before trying to finalize again",
+		Value: cli.NewUint64Slice(64),
+	}
+	lookbacksFlag = &cli.Uint64SliceFlag{
+		Name:  "lookback",
+		Usage: "Finality lookbacks to simulate: the number of L1 blocks to track the derived L2 blocks of",
+		Value: cli.NewUint64Slice(4*32 + 1),
+	}
+	extraConfirmationsFlag = &cli.Uint64SliceFlag{
+		Name:  "extra-confirmations",
+		Usage: "Extra confirmations to simulate: the number of L1 blocks below the finalized L1 block to finalize up to",
+		Value: cli.NewUint64Slice(0),
+	}
+)
+
+var simulateCommand = &cli.Command{
+	Name:  "simulate",
+	Usage: "Projects the finality lag of historical L1 finality signals and derivation progress, under different finality parameters",
+	Flags: []cli.Flag{traceFlag, traceRPCFlag, traceL1RPCFlag, l1StartFlag, l1EndFlag, delaysFlag, lookbacksFlag, extraConfirmationsFlag},
+	Action: func(ctx *cli.Context) error {
+		trace, err := readSimTrace(ctx.String(traceFlag.Name))
+		if err != nil {
+			return err
+		}
+		if ctx.IsSet(traceRPCFlag.Name) {
+			trace.Derivation, err = fetchDerivation(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		var results []SimResult
+		for _, delay := range ctx.Uint64Slice(delaysFlag.Name) {
+			for _, lookback := range ctx.Uint64Slice(lookbacksFlag.Name) {
+				for _, extra := range ctx.Uint64Slice(extraConfirmationsFlag.Name) {
+					results = append(results, Simulate(trace, SimParams{Delay: delay, Lookback: lookback, ExtraConfirmations: extra}))
+				}
+			}
+		}
+		return writeSimResults(oplog.AppOut(ctx), results)
+	},
+}
+
+// SimTrace is a history of L1 finality signals and derivation progress, to simulate finalization with.
+// Both are in ascending order of time.
+type SimTrace struct {
+	// Signals are the L1 finality signals, by the time they were received.
+	Signals []SimSignal `json:"signals"`
+	// Derivation is the safe L2 head, by the time derivation fully derived it from an L1 block.
+	Derivation []SimDerivation `json:"derivation"`
+}
+
+// SimSignal is an L1 finality signal.
+type SimSignal struct {
+	Time uint64 `json:"time"`
+	L1   uint64 `json:"l1"`
+}
+
+// SimDerivation records that the L2 chain up to L2 was fully derived from the L1 chain up to L1.
+type SimDerivation struct {
+	Time   uint64 `json:"time"`
+	L1     uint64 `json:"l1"`
+	L2     uint64 `json:"l2"`
+	L2Time uint64 `json:"l2_time"`
+}
+
+// SimParams are the finality parameters to simulate.
+type SimParams struct {
+	Delay              uint64
+	Lookback           uint64
+	ExtraConfirmations uint64
+}
+
+// SimResult is the projected finality lag of a simulation.
+type SimResult struct {
+	SimParams
+	// Advancements is the number of times the finalized L2 head advanced.
+	Advancements int
+	// FinalizedL2 is the finalized L2 head at the end of the trace.
+	FinalizedL2 uint64
+	// MeanLag and MaxLag are the mean and maximum time between the finalized L2 head and the trace events,
+	// from the first advancement on.
+	MeanLag time.Duration
+	MaxLag  time.Duration
+}
+
+// simRefs describes the block refs of a simulation to the finality algorithm.
+type simRefs struct{}
+
+func (simRefs) SourceNumber(source uint64) uint64          { return source }
+func (simRefs) DerivedNumber(derived SimDerivation) uint64 { return derived.L2 }
+func (simRefs) SameSource(a, b uint64) bool                { return a == b }
+func (simRefs) SameDerived(a, b SimDerivation) bool        { return a.L2 == b.L2 }
+
+// Simulate replays the trace through the finality algorithm of the Finalizer, with the given parameters:
+// new finality signals are processed right away, and derivation retries finalization every Delay L1 blocks.
+func Simulate(trace SimTrace, params SimParams) SimResult {
+	result := SimResult{SimParams: params}
+	var rels core.Relations[uint64, SimDerivation, simRefs]
+	var finalized SimDerivation
+	var finalizedL1, triedAt uint64
+	tryFinalize := func() {
+		final := func(source uint64) bool { return source+params.ExtraConfirmations <= finalizedL1 }
+		accept := func(core.Relation[uint64, SimDerivation], bool) bool { return true }
+		if r, ok := rels.Finalizable(finalized, final, accept); ok {
+			finalized = r.Derived
+			result.Advancements += 1
+		}
+	}
+	var lagSum time.Duration
+	var samples int64
+	signals, derivation := trace.Signals, trace.Derivation
+	for len(signals) > 0 || len(derivation) > 0 {
+		var now uint64
+		if len(derivation) == 0 || (len(signals) > 0 && signals[0].Time < derivation[0].Time) {
+			sig := signals[0]
+			signals = signals[1:]
+			now = sig.Time
+			if sig.L1 > finalizedL1 {
+				finalizedL1 = sig.L1
+				tryFinalize()
+			}
+		} else {
+			d := derivation[0]
+			derivation = derivation[1:]
+			now = d.Time
+			rels.Track(params.Lookback, d, d.L1)
+			if finalizedL1 != 0 && (triedAt == 0 || d.L1 > triedAt+params.Delay) {
+				triedAt = d.L1
+				tryFinalize()
+			}
+		}
+		if result.Advancements == 0 || now < finalized.L2Time {
+			continue
+		}
+		lag := time.Duration(now-finalized.L2Time) * time.Second
+		lagSum += lag
+		samples += 1
+		result.MaxLag = max(result.MaxLag, lag)
+	}
+	if samples > 0 {
+		result.MeanLag = lagSum / time.Duration(samples)
+	}
+	result.FinalizedL2 = finalized.L2
+	return result
+}
+
+func readSimTrace(path string) (SimTrace, error) {
+	var trace SimTrace
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return trace, fmt.Errorf("failed to read trace: %w", err)
+	}
+	if err := json.Unmarshal(data, &trace); err != nil {
+		return trace, fmt.Errorf("failed to decode trace: %w", err)
+	}
+	return trace, nil
+}
+
+func writeSimResults(out io.Writer, results []SimResult) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DELAY\tLOOKBACK\tEXTRA_CONFIRMATIONS\tADVANCEMENTS\tFINALIZED_L2\tMEAN_LAG\tMAX_LAG")
+	for _, r := range results {
+		fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%s\t%s\n", r.Delay, r.Lookback, r.ExtraConfirmations,
+			r.Advancements, r.FinalizedL2, r.MeanLag, r.MaxLag)
+	}
+	return w.Flush()
+}
+
+func fetchDerivation(ctx *cli.Context) ([]SimDerivation, error) {
+	if !ctx.IsSet(traceL1RPCFlag.Name) || !ctx.IsSet(l1StartFlag.Name) || !ctx.IsSet(l1EndFlag.Name) {
+		return nil, errors.New("fetching the derivation progress with --rpc requires --l1, --l1-start and --l1-end")
+	}
+	logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	rpc, err := client.NewRPC(ctx.Context, logger, ctx.String(traceRPCFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial rollup node RPC: %w", err)
+	}
+	defer rpc.Close()
+	l1, err := ethclient.DialContext(ctx.Context, ctx.String(traceL1RPCFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1.Close()
+	node := sources.NewRollupClient(rpc)
+	cfg, err := node.RollupConfig(ctx.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rollup config: %w", err)
+	}
+	return FetchDerivation(ctx.Context, node, l1, cfg, ctx.Uint64(l1StartFlag.Name), ctx.Uint64(l1EndFlag.Name))
+}
+
+// SafeHeadSource serves the safe L2 head by L1 block, from the safe head database of a rollup node.
+type SafeHeadSource interface {
+	SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error)
+}
+
+// L1HeaderSource serves the L1 block headers, to time the derivation progress by.
+type L1HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// FetchDerivation fetches the derivation progress of the L1 blocks from start to end (incl.) from the safe head database.
+// The derivation of an L1 block is timed by the L1 block, as on a node that follows the L1 chain closely.
+func FetchDerivation(ctx context.Context, node SafeHeadSource, l1 L1HeaderSource, cfg *rollup.Config, start, end uint64) ([]SimDerivation, error) {
+	var out []SimDerivation
+	for n := start; n <= end; n++ {
+		resp, err := node.SafeHeadAtL1Block(ctx, n)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch safe head at L1 block %d: %w", n, err)
+		}
+		if len(out) > 0 && out[len(out)-1].L1 == resp.L1Block.Number {
+			continue // the safe head did not change at this L1 block
+		}
+		header, err := l1.HeaderByNumber(ctx, new(big.Int).SetUint64(resp.L1Block.Number))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch L1 block %d: %w", resp.L1Block.Number, err)
+		}
+		out = append(out, SimDerivation{
+			Time:   header.Time,
+			L1:     resp.L1Block.Number,
+			L2:     resp.SafeHead.Number,
+			L2Time: cfg.TimestampForBlock(resp.SafeHead.Number),
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package finality
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// simTestTrace derives 6 L2 blocks of 2s from every L1 block of 12s, and finalizes L1 blocks 5 and 15.
+func simTestTrace() SimTrace {
+	var trace SimTrace
+	for n := uint64(1); n <= 20; n++ {
+		trace.Derivation = append(trace.Derivation, SimDerivation{Time: 12 * n, L1: n, L2: 6 * n, L2Time: 12 * n})
+	}
+	trace.Signals = []SimSignal{{Time: 120, L1: 5}, {Time: 240, L1: 15}}
+	return trace
+}
+
+func TestSimulate(t *testing.T) {
+	trace := simTestTrace()
+
+	result := Simulate(trace, SimParams{Delay: 64, Lookback: 129})
+	require.Equal(t, 2, result.Advancements)
+	require.Equal(t, uint64(90), result.FinalizedL2)
+	// L2 block 30 of 60s is finalized at 120s, and lags 180s behind when L1 block 20 is derived at 240s,
+	// right before the next signal finalizes L2 block 90.
+	require.Equal(t, 115*time.Second, result.MeanLag)
+	require.Equal(t, 180*time.Second, result.MaxLag)
+
+	confirmed := Simulate(trace, SimParams{Delay: 64, Lookback: 129, ExtraConfirmations: 2})
+	require.Equal(t, uint64(78), confirmed.FinalizedL2)
+	require.Greater(t, confirmed.MaxLag, result.MaxLag)
+
+	// a lookback that is shorter than the finality lag never finalizes
+	short := Simulate(trace, SimParams{Delay: 64, Lookback: 3})
+	require.Zero(t, short.Advancements)
+	require.Zero(t, short.FinalizedL2)
+}
+
+type fakeSafeHeads map[uint64]eth.SafeHeadResponse
+
+func (f fakeSafeHeads) SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error) {
+	resp := f[blockNum]
+	return &resp, nil
+}
+
+type fakeL1Headers struct{}
+
+func (fakeL1Headers) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: number, Time: 12 * number.Uint64()}, nil
+}
+
+func TestFetchDerivation(t *testing.T) {
+	cfg := &rollup.Config{BlockTime: 2, Genesis: rollup.Genesis{L2Time: 1000}}
+	node := fakeSafeHeads{
+		1: {L1Block: eth.BlockID{Number: 1}, SafeHead: eth.BlockID{Number: 5}},
+		// the safe head did not change at L1 block 2
+		2: {L1Block: eth.BlockID{Number: 1}, SafeHead: eth.BlockID{Number: 5}},
+		3: {L1Block: eth.BlockID{Number: 3}, SafeHead: eth.BlockID{Number: 12}},
+	}
+	derivation, err := FetchDerivation(context.Background(), node, fakeL1Headers{}, cfg, 1, 3)
+	require.NoError(t, err)
+	require.Equal(t, []SimDerivation{
+		{Time: 12, L1: 1, L2: 5, L2Time: 1010},
+		{Time: 36, L1: 3, L2: 12, L2Time: 1024},
+	}, derivation)
+}
@@ -0,0 +1,293 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/api"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+var (
+	l1RPCFlag = &cli.StringFlag{
+		Name:     "l1",
+		Usage:    "RPC URL of the L1 node to read the accepted output roots from",
+		Required: true,
+	}
+	l2ooAddressFlag = &cli.StringFlag{
+		Name:  "l2oo-address",
+		Usage: "Address of the L2OutputOracle contract. Either this or --dgf-address is required.",
+	}
+	dgfAddressFlag = &cli.StringFlag{
+		Name:  "dgf-address",
+		Usage: "Address of the DisputeGameFactory contract. Either this or --l2oo-address is required.",
+	}
+	gameTypeFlag = &cli.UintFlag{
+		Name:  "game-type",
+		Usage: "Type of the dispute games to verify against",
+		Value: 0,
+	}
+	proposalsFlag = &cli.UintFlag{
+		Name:  "proposals",
+		Usage: "Number of the most recent output proposals to verify",
+		Value: 10,
+	}
+)
+
+var ErrOutputMismatch = errors.New("finalized output root does not match the output root accepted on L1")
+
+var verifyCommand = &cli.Command{
+	Name:  "verify",
+	Usage: "Verifies the finalized L2 outputs of a rollup node against the output roots accepted on L1",
+	Flags: []cli.Flag{rpcFlag, l1RPCFlag, l2ooAddressFlag, dgfAddressFlag, gameTypeFlag, proposalsFlag},
+	Action: func(ctx *cli.Context) error {
+		logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+		rpc, err := client.NewRPC(ctx.Context, logger, ctx.String(rpcFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to dial rollup node RPC: %w", err)
+		}
+		defer rpc.Close()
+		l1, err := ethclient.DialContext(ctx.Context, ctx.String(l1RPCFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to dial L1 RPC: %w", err)
+		}
+		defer l1.Close()
+		var proposals ProposalSource
+		switch {
+		case ctx.IsSet(l2ooAddressFlag.Name):
+			proposals, err = NewL2OOProposals(common.HexToAddress(ctx.String(l2ooAddressFlag.Name)), l1)
+		case ctx.IsSet(dgfAddressFlag.Name):
+			proposals, err = NewDisputeGameProposals(common.HexToAddress(ctx.String(dgfAddressFlag.Name)),
+				uint32(ctx.Uint(gameTypeFlag.Name)), l1)
+		default:
+			return errors.New("either --l2oo-address or --dgf-address is required")
+		}
+		if err != nil {
+			return err
+		}
+		v := &Verifier{log: logger, node: sources.NewRollupClient(rpc), proposals: proposals}
+		report, err := v.Verify(ctx.Context, ctx.Uint(proposalsFlag.Name))
+		if err != nil {
+			return err
+		}
+		logger.Info("verified finalized outputs", "finalized_l2", report.FinalizedL2, "checked", report.Checked,
+			"unfinalized", report.Unfinalized, "discrepancies", len(report.Discrepancies))
+		if len(report.Discrepancies) > 0 {
+			return fmt.Errorf("%w: %d of %d output roots", ErrOutputMismatch, len(report.Discrepancies), report.Checked)
+		}
+		return nil
+	},
+}
+
+// OutputProposal is an output root that was accepted on L1 for an L2 block.
+type OutputProposal struct {
+	L2BlockNumber uint64
+	OutputRoot    eth.Bytes32
+	// Ref identifies the proposal on L1, like the index of the output in the L2OutputOracle.
+	Ref string
+}
+
+// ProposalSource lists the most recent output proposals that were accepted on L1.
+type ProposalSource interface {
+	RecentProposals(ctx context.Context, n uint) ([]OutputProposal, error)
+}
+
+// NodeSource is the rollup node to verify.
+type NodeSource interface {
+	FinalityStatus(ctx context.Context) (*api.FinalityStatus, error)
+	FinalitySnapshot(ctx context.Context) (*api.Snapshot, error)
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+}
+
+// Discrepancy is an output proposal for a finalized L2 block, which does not match the output of the node.
+type Discrepancy struct {
+	Proposal       OutputProposal
+	NodeOutputRoot eth.Bytes32
+	// DerivedFrom is the L1 block the node derived the L2 block from, if it is still buffered by the node.
+	DerivedFrom eth.BlockID
+}
+
+// VerifyReport summarizes the verification of the finalized outputs of a rollup node.
+type VerifyReport struct {
+	FinalizedL2 eth.L2BlockRef
+	// Checked is the number of output proposals for finalized L2 blocks that were compared.
+	Checked int
+	// Unfinalized is the number of output proposals for L2 blocks the node did not finalize yet.
+	Unfinalized   int
+	Discrepancies []Discrepancy
+}
+
+// Verifier compares the outputs of the finalized L2 blocks of a rollup node against the output roots accepted on L1.
+type Verifier struct {
+	log       log.Logger
+	node      NodeSource
+	proposals ProposalSource
+}
+
+func (v *Verifier) Verify(ctx context.Context, n uint) (*VerifyReport, error) {
+	status, err := v.node.FinalityStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch finality status: %w", err)
+	}
+	proposals, err := v.proposals.RecentProposals(ctx, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch output proposals: %w", err)
+	}
+	report := &VerifyReport{FinalizedL2: status.FinalizedL2}
+	var snapshot *api.Snapshot
+	for _, p := range proposals {
+		if p.L2BlockNumber > status.FinalizedL2.Number {
+			report.Unfinalized += 1
+			continue
+		}
+		output, err := v.node.OutputAtBlock(ctx, p.L2BlockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch output at L2 block %d: %w", p.L2BlockNumber, err)
+		}
+		report.Checked += 1
+		if output.OutputRoot == p.OutputRoot {
+			continue
+		}
+		if snapshot == nil {
+			if snapshot, err = v.node.FinalitySnapshot(ctx); err != nil {
+				v.log.Warn("failed to fetch finality snapshot, the derived-from L1 blocks are unknown", "err", err)
+				snapshot = &api.Snapshot{}
+			}
+		}
+		d := Discrepancy{Proposal: p, NodeOutputRoot: output.OutputRoot, DerivedFrom: derivedFrom(snapshot, p.L2BlockNumber)}
+		v.log.Error("finalized output root does not match the output root accepted on L1", "proposal", p.Ref,
+			"l2_block", p.L2BlockNumber, "accepted", p.OutputRoot, "node", output.OutputRoot, "derived_from", d.DerivedFrom)
+		report.Discrepancies = append(report.Discrepancies, d)
+	}
+	return report, nil
+}
+
+// derivedFrom returns the L1 block the given L2 block was derived from, according to the finality data of the node,
+// or a zeroed ID if the L2 block is older than the buffered finality data.
+func derivedFrom(snapshot *api.Snapshot, l2Number uint64) eth.BlockID {
+	for i, fd := range snapshot.FinalityData {
+		if fd.L2Block.Number < l2Number {
+			continue
+		}
+		if i == 0 && fd.L2Block.Number != l2Number {
+			break // the L2 block may have been derived from an older, pruned, L1 block
+		}
+		return fd.L1Block
+	}
+	return eth.BlockID{}
+}
+
+// L2OOProposals reads the output proposals of the L2OutputOracle.
+type L2OOProposals struct {
+	oracle *bindings.L2OutputOracleCaller
+}
+
+func NewL2OOProposals(addr common.Address, caller bind.ContractCaller) (*L2OOProposals, error) {
+	oracle, err := bindings.NewL2OutputOracleCaller(addr, caller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind L2OutputOracle: %w", err)
+	}
+	return &L2OOProposals{oracle: oracle}, nil
+}
+
+func (s *L2OOProposals) RecentProposals(ctx context.Context, n uint) ([]OutputProposal, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	next, err := s.oracle.NextOutputIndex(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch next output index: %w", err)
+	}
+	var out []OutputProposal
+	for i := next.Uint64(); i > 0 && uint(len(out)) < n; i-- {
+		output, err := s.oracle.GetL2Output(opts, new(big.Int).SetUint64(i-1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch output %d: %w", i-1, err)
+		}
+		out = append(out, OutputProposal{
+			L2BlockNumber: output.L2BlockNumber.Uint64(),
+			OutputRoot:    output.OutputRoot,
+			Ref:           fmt.Sprintf("output %d", i-1),
+		})
+	}
+	return out, nil
+}
+
+// defenderWins is the status of a dispute game that resolved in favor of the proposed output root.
+const defenderWins = 2
+
+var disputeGameABI = `[{"type":"function","name":"status","inputs":[],"outputs":[{"type":"uint8"}],"stateMutability":"view"}]`
+
+// DisputeGameProposals reads the output proposals of the dispute games of the DisputeGameFactory,
+// that resolved in favor of the proposed output root.
+type DisputeGameProposals struct {
+	factory  *bindings.DisputeGameFactoryCaller
+	caller   bind.ContractCaller
+	gameABI  abi.ABI
+	gameType uint32
+}
+
+func NewDisputeGameProposals(addr common.Address, gameType uint32, caller bind.ContractCaller) (*DisputeGameProposals, error) {
+	factory, err := bindings.NewDisputeGameFactoryCaller(addr, caller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind DisputeGameFactory: %w", err)
+	}
+	gameABI, err := abi.JSON(strings.NewReader(disputeGameABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dispute game ABI: %w", err)
+	}
+	return &DisputeGameProposals{factory: factory, caller: caller, gameABI: gameABI, gameType: gameType}, nil
+}
+
+func (s *DisputeGameProposals) RecentProposals(ctx context.Context, n uint) ([]OutputProposal, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	count, err := s.factory.GameCount(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch game count: %w", err)
+	}
+	if count.Sign() == 0 {
+		return nil, nil
+	}
+	games, err := s.factory.FindLatestGames(opts, s.gameType, new(big.Int).Sub(count, common.Big1), new(big.Int).SetUint64(uint64(n)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find latest games: %w", err)
+	}
+	var out []OutputProposal
+	for _, game := range games {
+		if len(game.ExtraData) < 32 {
+			return nil, fmt.Errorf("game %d has no L2 block number in its extra data", game.Index)
+		}
+		var status []any
+		if err := bind.NewBoundContract(gameProxy(game.Metadata), s.gameABI, s.caller, nil, nil).Call(opts, &status, "status"); err != nil {
+			return nil, fmt.Errorf("failed to fetch status of game %d: %w", game.Index, err)
+		}
+		if status[0].(uint8) != defenderWins {
+			continue // not accepted (yet)
+		}
+		out = append(out, OutputProposal{
+			L2BlockNumber: new(big.Int).SetBytes(game.ExtraData[:32]).Uint64(),
+			OutputRoot:    game.RootClaim,
+			Ref:           fmt.Sprintf("game %d", game.Index),
+		})
+	}
+	return out, nil
+}
+
+// gameProxy returns the address of the game proxy from the packed GameId of the DisputeGameFactory,
+// which is the game type, the creation timestamp and the proxy address.
+func gameProxy(id [32]byte) common.Address {
+	return common.BytesToAddress(id[12:32])
+}
@@ -0,0 +1,91 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/api"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeNodeSource struct {
+	status   api.FinalityStatus
+	snapshot *api.Snapshot
+	outputs  map[uint64]eth.Bytes32
+}
+
+func (f *fakeNodeSource) FinalityStatus(ctx context.Context) (*api.FinalityStatus, error) {
+	return &f.status, nil
+}
+
+func (f *fakeNodeSource) FinalitySnapshot(ctx context.Context) (*api.Snapshot, error) {
+	if f.snapshot == nil {
+		return nil, errors.New("no snapshot")
+	}
+	return f.snapshot, nil
+}
+
+func (f *fakeNodeSource) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	root, ok := f.outputs[blockNum]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &eth.OutputResponse{OutputRoot: root}, nil
+}
+
+type fakeProposalSource []OutputProposal
+
+func (f fakeProposalSource) RecentProposals(ctx context.Context, n uint) ([]OutputProposal, error) {
+	return f[:min(uint(len(f)), n)], nil
+}
+
+func TestVerifier(t *testing.T) {
+	node := &fakeNodeSource{
+		status: api.FinalityStatus{FinalizedL2: eth.L2BlockRef{Number: 30}},
+		snapshot: &api.Snapshot{FinalityData: []api.FinalityData{
+			{L2Block: eth.L2BlockRef{Number: 10}, L1Block: eth.BlockID{Number: 1}},
+			{L2Block: eth.L2BlockRef{Number: 20}, L1Block: eth.BlockID{Number: 2}},
+			{L2Block: eth.L2BlockRef{Number: 30}, L1Block: eth.BlockID{Number: 3}},
+		}},
+		outputs: map[uint64]eth.Bytes32{5: {5}, 10: {10}, 15: {15}, 30: {30}},
+	}
+	proposals := fakeProposalSource{
+		{L2BlockNumber: 40, OutputRoot: eth.Bytes32{40}, Ref: "output 4"},
+		{L2BlockNumber: 30, OutputRoot: eth.Bytes32{30}, Ref: "output 3"},
+		{L2BlockNumber: 15, OutputRoot: eth.Bytes32{0xff}, Ref: "output 2"},
+		{L2BlockNumber: 10, OutputRoot: eth.Bytes32{10}, Ref: "output 1"},
+		{L2BlockNumber: 5, OutputRoot: eth.Bytes32{0xff}, Ref: "output 0"},
+	}
+	v := &Verifier{log: testlog.Logger(t, log.LevelCrit), node: node, proposals: proposals}
+
+	t.Run("discrepancies", func(t *testing.T) {
+		report, err := v.Verify(context.Background(), 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, report.Unfinalized)
+		require.Equal(t, 4, report.Checked)
+		require.Equal(t, []Discrepancy{
+			{Proposal: proposals[2], NodeOutputRoot: eth.Bytes32{15}, DerivedFrom: eth.BlockID{Number: 2}},
+			// older than the buffered finality data, so the derived-from L1 block is unknown
+			{Proposal: proposals[4], NodeOutputRoot: eth.Bytes32{5}},
+		}, report.Discrepancies)
+	})
+
+	t.Run("recent", func(t *testing.T) {
+		report, err := v.Verify(context.Background(), 2)
+		require.NoError(t, err)
+		require.Equal(t, 1, report.Checked)
+		require.Empty(t, report.Discrepancies)
+	})
+
+	t.Run("missing-output", func(t *testing.T) {
+		v := &Verifier{log: testlog.Logger(t, log.LevelCrit), node: node, proposals: fakeProposalSource{{L2BlockNumber: 20}}}
+		_, err := v.Verify(context.Background(), 1)
+		require.ErrorContains(t, err, "failed to fetch output at L2 block 20")
+	})
+}
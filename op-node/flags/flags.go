@@ -217,6 +217,20 @@ var (
 		Value:    0,
 		Category: SequencerCategory,
 	}
+	SequencerFinalityLagSlowFlag = &cli.Uint64Flag{
+		Name:     "sequencer.finality-lag-slow",
+		Usage:    "Number of unfinalized L2 blocks beyond which the sequencer slows down block production, by up to one block time at sequencer.max-finality-lag. Disabled if 0.",
+		EnvVars:  prefixEnvVars("SEQUENCER_FINALITY_LAG_SLOW"),
+		Value:    0,
+		Category: SequencerCategory,
+	}
+	SequencerMaxFinalityLagFlag = &cli.Uint64Flag{
+		Name:     "sequencer.max-finality-lag",
+		Usage:    "Number of unfinalized L2 blocks at which the sequencer pauses block production, until finalization catches up. Disabled if 0.",
+		EnvVars:  prefixEnvVars("SEQUENCER_MAX_FINALITY_LAG"),
+		Value:    0,
+		Category: SequencerCategory,
+	}
 	SequencerL1Confs = &cli.Uint64Flag{
 		Name:     "sequencer.l1-confs",
 		Usage:    "Number of L1 blocks to keep distance from the L1 head as a sequencer for picking an L1 origin.",
@@ -301,6 +315,370 @@ var (
 		EnvVars:  prefixEnvVars("SAFEDB_PATH"),
 		Category: OperationsCategory,
 	}
+	FinalityFollow = &cli.StringFlag{
+		Name:     "finality.follow",
+		Usage:    "RPC endpoint of a primary rollup node to follow the finalized L2 head of, instead of determining it from L1 finality. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_FOLLOW"),
+		Category: RollupCategory,
+	}
+	FinalityReplica = &cli.StringFlag{
+		Name:     "finality.replica",
+		Usage:    "RPC endpoint of a replica L2 execution client, to cross-validate every L2 block with before finalizing it. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_REPLICA"),
+		Category: RollupCategory,
+	}
+	FinalityReplicaPolicy = &cli.StringFlag{
+		Name: "finality.replica-policy",
+		Usage: "How to handle L2 blocks to finalize that the finality.replica does not confirm: " +
+			"'block' to not finalize them, 'warn' to finalize anyway, or 'halt' to halt finalization on a mismatch.",
+		EnvVars:  prefixEnvVars("FINALITY_REPLICA_POLICY"),
+		Value:    "block",
+		Category: RollupCategory,
+	}
+	FinalityShadowL1 = &cli.StringFlag{
+		Name:     "finality.shadow-l1",
+		Usage:    "RPC endpoint of an alternative L1 source, to run a shadow finalizer with next to the finalizer, and report where their finalized L2 heads diverge. The shadow is never applied to the engine. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_SHADOW_L1"),
+		Category: RollupCategory,
+	}
+	FinalityDisputeGameFactory = &cli.StringFlag{
+		Name:     "finality.dispute-game-factory",
+		Usage:    "Address of the DisputeGameFactory contract on L1, to read the resolved dispute games of the finality.dispute-game-type from. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_DISPUTE_GAME_FACTORY"),
+		Category: RollupCategory,
+	}
+	FinalityDisputeGameType = &cli.UintFlag{
+		Name:     "finality.dispute-game-type",
+		Usage:    "Game type of the dispute games of the finality.dispute-game-factory.",
+		EnvVars:  prefixEnvVars("FINALITY_DISPUTE_GAME_TYPE"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalityDisputeGameGate = &cli.BoolFlag{
+		Name:     "finality.dispute-game-gate",
+		Usage:    "Only finalize L2 blocks that are backed by a dispute game of the finality.dispute-game-factory, which resolved in favor of the proposed output root.",
+		EnvVars:  prefixEnvVars("FINALITY_DISPUTE_GAME_GATE"),
+		Category: RollupCategory,
+	}
+	FinalitySettlement = &cli.BoolFlag{
+		Name:     "finality.settlement",
+		Usage:    "Track a settled L2 head next to the finalized L2 head: the finalized L2 blocks that are also backed by a resolved dispute game of the finality.dispute-game-factory. Unlike finality.dispute-game-gate, it does not hold back the finalized L2 head.",
+		EnvVars:  prefixEnvVars("FINALITY_SETTLEMENT"),
+		Category: RollupCategory,
+	}
+	FinalityCommitteeRegistry = &cli.StringFlag{
+		Name:     "finality.committee-registry",
+		Usage:    "Address of the key registry contract of a committee on L1, like a security council, to gate finalization on its attestations. Requires finality.committee-attestations. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_COMMITTEE_REGISTRY"),
+		Category: RollupCategory,
+	}
+	FinalityCommitteeAttestations = &cli.StringFlag{
+		Name:     "finality.committee-attestations",
+		Usage:    "URL to fetch the latest attestation of the finality.committee-registry committee from, as JSON. The attestations are verified against the committee registered on L1.",
+		EnvVars:  prefixEnvVars("FINALITY_COMMITTEE_ATTESTATIONS"),
+		Category: RollupCategory,
+	}
+	FinalityBeaconEvents = &cli.StringFlag{
+		Name:     "finality.beacon-events",
+		Usage:    "Beacon API endpoint to subscribe to finalized checkpoint events of, to receive L1 finality signals with lower latency than polling. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_BEACON_EVENTS"),
+		Category: RollupCategory,
+	}
+	FinalityLightClient = &cli.StringFlag{
+		Name:     "finality.light-client",
+		Usage:    "RPC endpoint of a local L1 light client, like Helios, to finalize with instead of the L1 RPC: it serves the L1 finality signal, and the L1 blocks to sanity check. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_LIGHT_CLIENT"),
+		Category: RollupCategory,
+	}
+	FinalityReceipts = &cli.BoolFlag{
+		Name:     "finality.receipts",
+		Usage:    "Issue finalized-range receipts, signed with the P2P sequencer key, for every finalized L2 head advancement. Served by optimism_finalizedRangeReceipts.",
+		EnvVars:  prefixEnvVars("FINALITY_RECEIPTS"),
+		Category: RollupCategory,
+	}
+	FinalityStateStore = &cli.StringFlag{
+		Name:     "finality.state-store",
+		Usage:    "URL of the store to persist the finality state to, and restore it from on startup: a file:// URL for a local file, an s3://host/bucket/key URL for an object in S3-compatible object storage with requests signed with the AWS credentials of the environment or the shared credentials file, an http(s):// URL for an object in shared object storage that does not require signed requests, or a postgres://[user@]host/database?key=<snapshot key> URL for a row in a Postgres database, with the password from PGPASSWORD or PGPASSFILE. URLs must not contain secrets. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_STATE_STORE"),
+		Category: RollupCategory,
+	}
+	FinalityPlasmaChangeAction = &cli.StringFlag{
+		Name: "finality.plasma-change-action",
+		Usage: "How to restore a finality snapshot from the finality.state-store, if the plasma DA windows changed since it was taken: " +
+			"'migrate' to resize and re-validate its finality data, 'discard' to discard its finality data, or 'fail' to refuse to start.",
+		EnvVars:  prefixEnvVars("FINALITY_PLASMA_CHANGE_ACTION"),
+		Value:    "migrate",
+		Category: RollupCategory,
+	}
+	FinalityMode = &cli.StringFlag{
+		Name: "finality.mode",
+		Usage: "Finalizer implementation: 'l1' to finalize from L1 finality, 'plasma' to also await the DA challenges, " +
+			"'follow' to follow the finalized L2 head of the finality.follow node, or 'auto' to select one from the configuration.",
+		EnvVars:  prefixEnvVars("FINALITY_MODE"),
+		Value:    "auto",
+		Category: RollupCategory,
+	}
+	FinalityEngineAnnounce = &cli.BoolFlag{
+		Name: "finality.engine-announce",
+		Usage: "Announce the finalized L2 head, with the L1 block it was derived from, to the execution client with engine_announceFinalizedV1, " +
+			"if the execution client lists the method in engine_exchangeCapabilities.",
+		EnvVars:  prefixEnvVars("FINALITY_ENGINE_ANNOUNCE"),
+		Category: RollupCategory,
+	}
+	FinalityTunablesFile = &cli.StringFlag{
+		Name:     "finality.tunables-file",
+		Usage:    "Path of a JSON file with finality tunables (finality_delay, extra_confirmations, trust_signal, stall_threshold), applied on startup and reloaded on SIGHUP. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_TUNABLES_FILE"),
+		Category: RollupCategory,
+	}
+	FinalityIndexPath = &cli.StringFlag{
+		Name:     "finality.index-path",
+		Usage:    "File path used to persist the index of finalized L2 head advancements, served by optimism_finalizedAt. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_INDEX_PATH"),
+		Category: RollupCategory,
+	}
+	FinalityGRPCAddr = &cli.StringFlag{
+		Name:     "finality.grpc-addr",
+		Usage:    "Address of the gRPC server that streams the finalized L2 head advancements, and serves the finality status and derived-from queries, e.g. 127.0.0.1:9546. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_GRPC_ADDR"),
+		Category: RollupCategory,
+	}
+	FinalityWithdrawalRoots = &cli.BoolFlag{
+		Name:     "finality.withdrawal-roots",
+		Usage:    "Fetch the withdrawal root of every finalized L2 head from the execution client, to include it in the finalized events, and serve it by optimism_finalizedWithdrawalRoots.",
+		EnvVars:  prefixEnvVars("FINALITY_WITHDRAWAL_ROOTS"),
+		Category: RollupCategory,
+	}
+	FinalityOutbox = &cli.StringFlag{
+		Name:     "finality.outbox",
+		Usage:    "Path of the file to persist the outbox of finalized events to, for at-least-once delivery to the finality.outbox-sinks across restarts. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_OUTBOX"),
+		Category: RollupCategory,
+	}
+	FinalityOutboxSinks = &cli.StringSliceFlag{
+		Name: "finality.outbox-sinks",
+		Usage: "Sinks to deliver the finalized events of the finality.outbox to: http(s):// webhook URLs, " +
+			"nats(+tls)://host:port/subject URLs of a subject of a NATS JetStream stream, with an optional creds=<credentials file> query parameter, " +
+			"or kafka(+tls)://broker:port[,broker:port...]/topic URLs of a Kafka topic. NATS and Kafka sink URLs must not contain credentials.",
+		EnvVars:  prefixEnvVars("FINALITY_OUTBOX_SINKS"),
+		Category: RollupCategory,
+	}
+	FinalityOutboxEnriched = &cli.BoolFlag{
+		Name:     "finality.outbox-enriched",
+		Usage:    "Enrich the finalized events of the finality.outbox with the hash, timestamp and transactions of every newly finalized L2 block, fetched from the execution client.",
+		EnvVars:  prefixEnvVars("FINALITY_OUTBOX_ENRICHED"),
+		Category: RollupCategory,
+	}
+	FinalityMaxAdvance = &cli.Uint64Flag{
+		Name:     "finality.max-advance",
+		Usage:    "Maximum number of L2 blocks to advance the finalized L2 head by at a time, when catching up on finality. Disabled if 0.",
+		EnvVars:  prefixEnvVars("FINALITY_MAX_ADVANCE"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalityMaxLookback = &cli.Uint64Flag{
+		Name:     "finality.max-lookback",
+		Usage:    "Maximum number of finality data entries to grow the finality lookback to, while L1 finality stalls, to not prune entries that are not finalized yet. Disabled if 0.",
+		EnvVars:  prefixEnvVars("FINALITY_MAX_LOOKBACK"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalityPruning = &cli.StringFlag{
+		Name: "finality.pruning",
+		Usage: "Comma-separated list of policies to prune the finality data with, in addition to the finality lookback: " +
+			"'capacity=N' to retain at most N entries, 'age=N' to prune entries more than N L1 blocks behind the latest entry, " +
+			"'finalized' to prune entries below the finalized L2 head. E.g. 'capacity=2000,finalized'. Disabled if not set.",
+		EnvVars:  prefixEnvVars("FINALITY_PRUNING"),
+		Category: RollupCategory,
+	}
+	FinalityCompression = &cli.Uint64Flag{
+		Name:     "finality.compression",
+		Usage:    "Compress the finality data beyond the most recent N entries, to reduce its memory use with long finality lookbacks, e.g. the challenge windows of alt-DA chains. Disabled if 0.",
+		EnvVars:  prefixEnvVars("FINALITY_COMPRESSION"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalityMaxSignalAge = &cli.DurationFlag{
+		Name:     "finality.max-signal-age",
+		Usage:    "Maximum age of the L1 block of a finality signal, relative to the L1 head. Older signals are ignored. Disabled if 0.",
+		EnvVars:  prefixEnvVars("FINALITY_MAX_SIGNAL_AGE"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalityBlobRetention = &cli.DurationFlag{
+		Name:     "finality.blob-retention",
+		Usage:    "Blob retention window of the L1 chain, to report whether finalized L2 ranges can still be reconstructed from L1 blobs. Defaults to the 4096 epochs of Ethereum if 0.",
+		EnvVars:  prefixEnvVars("FINALITY_BLOB_RETENTION"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalityExtraConfirmations = &cli.Uint64Flag{
+		Name:     "finality.extra-confirmations",
+		Usage:    "Number of L1 blocks below the finalized L1 block, that the L2 chain has to be derived from to be finalized. If 0, the finality profile of the chain config applies.",
+		EnvVars:  prefixEnvVars("FINALITY_EXTRA_CONFIRMATIONS"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalityTraceIDs = &cli.BoolFlag{
+		Name:     "finality.trace-ids",
+		Usage:    "Assign a trace ID to every attempt to finalize, logged with its outcome, and attach it as an exemplar to the finalization latency metrics, to link a latency spike to the attempt.",
+		EnvVars:  prefixEnvVars("FINALITY_TRACE_IDS"),
+		Category: RollupCategory,
+	}
+	FinalityEngineCallTimeout = &cli.DurationFlag{
+		Name:     "finality.engine-call-timeout",
+		Usage:    "Timeout of the engine calls that apply the finalized L2 head, independent of the timeout of the finalization step.",
+		EnvVars:  prefixEnvVars("FINALITY_ENGINE_CALL_TIMEOUT"),
+		Value:    10 * time.Second,
+		Category: RollupCategory,
+	}
+	FinalitySLOObjective = &cli.Float64Flag{
+		Name:     "finality.slo-objective",
+		Usage:    "Fraction of L2 blocks that have to be finalized within the target latency of the finality SLO, e.g. 0.95. Disabled if 0.",
+		EnvVars:  prefixEnvVars("FINALITY_SLO_OBJECTIVE"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalitySLOTargetFactor = &cli.Float64Flag{
+		Name:     "finality.slo-target-factor",
+		Usage:    "Target latency of the finality SLO, from the L2 block timestamp until finalization, as a multiple of the L1 finality time.",
+		EnvVars:  prefixEnvVars("FINALITY_SLO_TARGET_FACTOR"),
+		Value:    2,
+		Category: RollupCategory,
+	}
+	FinalitySLOWindow = &cli.DurationFlag{
+		Name:     "finality.slo-window",
+		Usage:    "Sliding window to compute the compliance with the finality SLO over. The burn rate is also reported over 1/12th of it.",
+		EnvVars:  prefixEnvVars("FINALITY_SLO_WINDOW"),
+		Value:    time.Hour,
+		Category: RollupCategory,
+	}
+	FinalityMinInterval = &cli.DurationFlag{
+		Name:     "finality.min-interval",
+		Usage:    "Minimum duration between updates of the finalized L2 head of the engine, batching intermediate advancements. Disabled if 0.",
+		EnvVars:  prefixEnvVars("FINALITY_MIN_INTERVAL"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalityMinBlocks = &cli.Uint64Flag{
+		Name:     "finality.min-blocks",
+		Usage:    "Minimum number of L2 blocks to advance the finalized L2 head of the engine by at a time, batching intermediate advancements. Disabled if 0.",
+		EnvVars:  prefixEnvVars("FINALITY_MIN_BLOCKS"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalityAdaptiveDelayMin = &cli.Uint64Flag{
+		Name:     "finality.adaptive-delay-min",
+		Usage:    "Minimum number of L1 blocks to traverse between attempts to finalize, when adapting the delay to the cost of L1 fetches.",
+		EnvVars:  prefixEnvVars("FINALITY_ADAPTIVE_DELAY_MIN"),
+		Value:    8,
+		Category: RollupCategory,
+	}
+	FinalityAdaptiveDelayMax = &cli.Uint64Flag{
+		Name:     "finality.adaptive-delay-max",
+		Usage:    "Maximum number of L1 blocks to traverse between attempts to finalize, when adapting the delay to the cost of L1 fetches. Disabled if 0.",
+		EnvVars:  prefixEnvVars("FINALITY_ADAPTIVE_DELAY_MAX"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalityTrustSignal = &cli.BoolFlag{
+		Name:     "finality.trust-signal",
+		Usage:    "Skip the canonical-chain sanity checks of the L1 finality signal. Only use this if the signal source is verified, like a light client.",
+		EnvVars:  prefixEnvVars("FINALITY_TRUST_SIGNAL"),
+		Category: RollupCategory,
+	}
+	FinalityAncestryCheck = &cli.Uint64Flag{
+		Name:     "finality.ancestry-check",
+		Usage:    "Maximum number of L1 blocks to walk back, to verify every L1 finality signal builds on the previous signal. Signals that are further ahead are refused, so this must cover the L1 blocks finalized during an outage of the L1 provider. Disabled if 0.",
+		EnvVars:  prefixEnvVars("FINALITY_ANCESTRY_CHECK"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalityRepairUnjustified = &cli.BoolFlag{
+		Name:     "finality.repair-unjustified",
+		Usage:    "Reset the finalized L2 head of the engine, if it is ahead of what finalized L1 data justifies, e.g. when set manually.",
+		EnvVars:  prefixEnvVars("FINALITY_REPAIR_UNJUSTIFIED"),
+		Category: RollupCategory,
+	}
+	FinalityBackfill = &cli.BoolFlag{
+		Name:     "finality.backfill",
+		Usage:    "Backfill gaps in the buffered finality data from the safe head database. Requires --safedb.path.",
+		EnvVars:  prefixEnvVars("FINALITY_BACKFILL"),
+		Category: RollupCategory,
+	}
+	FinalitySpanBatches = &cli.BoolFlag{
+		Name:     "finality.span-batches",
+		Usage:    "Finalize whole span batches only, and include the finalized span batches in the finality events.",
+		EnvVars:  prefixEnvVars("FINALITY_SPAN_BATCHES"),
+		Category: RollupCategory,
+	}
+	FinalityL1SlotsPerEpoch = &cli.Uint64Flag{
+		Name:     "finality.l1-slots-per-epoch",
+		Usage:    "Number of slots per epoch of the L1 beacon chain, to size the finality lookback with. Fetched from the L1 beacon spec if 0.",
+		EnvVars:  prefixEnvVars("FINALITY_L1_SLOTS_PER_EPOCH"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalitySignalWeights = &cli.StringFlag{
+		Name: "finality.signal-weights",
+		Usage: "Comma-separated trust weights of the L1 finality signal sources, e.g. \"l1=1,l1_hash=1\". " +
+			"A signal is only accepted once sources with a combined weight of at least --finality.signal-threshold signaled it. Disabled if empty.",
+		EnvVars:  prefixEnvVars("FINALITY_SIGNAL_WEIGHTS"),
+		Category: RollupCategory,
+	}
+	FinalityBeaconEpochs = &cli.BoolFlag{
+		Name: "finality.beacon-epochs",
+		Usage: "Align the attempts to finalize during sync to the beacon epochs of the L1 chain, instead of a fixed L1 block delay. " +
+			"The slot clock of the L1 chain is fetched from the L1 beacon API.",
+		EnvVars:  prefixEnvVars("FINALITY_BEACON_EPOCHS"),
+		Category: RollupCategory,
+	}
+	FinalityMaxMismatches = &cli.IntFlag{
+		Name: "finality.max-mismatches",
+		Usage: "Number of consecutive canonical-chain mismatches of attempts to finalize, after which finalization is halted, " +
+			"to prevent reset storms on an inconsistent L1 provider. Resume with admin_resumeFinality. Disabled if 0.",
+		EnvVars:  prefixEnvVars("FINALITY_MAX_MISMATCHES"),
+		Value:    0,
+		Category: RollupCategory,
+	}
+	FinalityHaltOnSafeRegression = &cli.BoolFlag{
+		Name: "finality.halt-on-safe-regression",
+		Usage: "Halt finalization when the safe head moves backwards without a reset, which indicates a derivation pipeline bug. " +
+			"Safe head regressions are always logged and counted. Resume with admin_resumeFinality.",
+		EnvVars:  prefixEnvVars("FINALITY_HALT_ON_SAFE_REGRESSION"),
+		Category: RollupCategory,
+	}
+	FinalitySignalThreshold = &cli.Uint64Flag{
+		Name:     "finality.signal-threshold",
+		Usage:    "Combined trust weight of the L1 finality signal sources required to accept a signal. Requires --finality.signal-weights.",
+		EnvVars:  prefixEnvVars("FINALITY_SIGNAL_THRESHOLD"),
+		Value:    1,
+		Category: RollupCategory,
+	}
+	FinalityFakeSignals = &cli.BoolFlag{
+		Name: "finality.fake-signals",
+		Usage: "Devnet only: allow injecting synthetic L1 finality signals with admin_setFakeFinalizedL1, " +
+			"for devnets without a beacon chain. Requires --rpc.enable-admin.",
+		EnvVars:  prefixEnvVars("FINALITY_FAKE_SIGNALS"),
+		Hidden:   true,
+		Category: RollupCategory,
+	}
+	FinalityUnsafeRollback = &cli.BoolFlag{
+		Name: "finality.unsafe-rollback",
+		Usage: "Devnet only: allow rolling back the finalized L2 head with admin_unsafeRollbackFinalized, " +
+			"for chaos testing and recovery drills. This breaks the finality guarantees of the node. Requires --rpc.enable-admin.",
+		EnvVars:  prefixEnvVars("FINALITY_UNSAFE_ROLLBACK"),
+		Hidden:   true,
+		Category: RollupCategory,
+	}
+	FinalityFaultInjection = &cli.StringFlag{
+		Name: "finality.fault-injection",
+		Usage: "Devnet only: inject faults into the L1 fetches of the finalizer, to validate monitoring against finality stalls. Refused on chains of the superchain registry. " +
+			"Comma-separated rates between 0 and 1 of the error, stale and wrong-hash faults, e.g. \"error=0.1,wrong-hash=0.01\".",
+		EnvVars:  prefixEnvVars("FINALITY_FAULT_INJECTION"),
+		Hidden:   true,
+		Category: RollupCategory,
+	}
 	/* Deprecated Flags */
 	L2EngineSyncEnabled = &cli.BoolFlag{
 		Name:    "l2.engine-sync",
@@ -384,6 +762,8 @@ var optionalFlags = []cli.Flag{
 	SequencerEnabledFlag,
 	SequencerStoppedFlag,
 	SequencerMaxSafeLagFlag,
+	SequencerFinalityLagSlowFlag,
+	SequencerMaxFinalityLagFlag,
 	SequencerL1Confs,
 	L1EpochPollIntervalFlag,
 	RuntimeConfigReloadIntervalFlag,
@@ -403,6 +783,60 @@ var optionalFlags = []cli.Flag{
 	ConductorRpcFlag,
 	ConductorRpcTimeoutFlag,
 	SafeDBPath,
+	FinalityFollow,
+	FinalityReplica,
+	FinalityReplicaPolicy,
+	FinalityShadowL1,
+	FinalityDisputeGameFactory,
+	FinalityDisputeGameType,
+	FinalityDisputeGameGate,
+	FinalitySettlement,
+	FinalityCommitteeRegistry,
+	FinalityCommitteeAttestations,
+	FinalityBeaconEvents,
+	FinalityLightClient,
+	FinalityReceipts,
+	FinalityStateStore,
+	FinalityPlasmaChangeAction,
+	FinalityMode,
+	FinalityEngineAnnounce,
+	FinalityIndexPath,
+	FinalityGRPCAddr,
+	FinalityWithdrawalRoots,
+	FinalityTunablesFile,
+	FinalityOutbox,
+	FinalityOutboxSinks,
+	FinalityOutboxEnriched,
+	FinalityMaxAdvance,
+	FinalityMaxLookback,
+	FinalityPruning,
+	FinalityCompression,
+	FinalityMaxSignalAge,
+	FinalityBlobRetention,
+	FinalityExtraConfirmations,
+	FinalityMinInterval,
+	FinalityMinBlocks,
+	FinalityEngineCallTimeout,
+	FinalityTraceIDs,
+	FinalitySLOObjective,
+	FinalitySLOTargetFactor,
+	FinalitySLOWindow,
+	FinalityAdaptiveDelayMin,
+	FinalityAdaptiveDelayMax,
+	FinalityTrustSignal,
+	FinalityAncestryCheck,
+	FinalityRepairUnjustified,
+	FinalityBackfill,
+	FinalitySpanBatches,
+	FinalityL1SlotsPerEpoch,
+	FinalityBeaconEpochs,
+	FinalitySignalWeights,
+	FinalitySignalThreshold,
+	FinalityMaxMismatches,
+	FinalityHaltOnSafeRegression,
+	FinalityFakeSignals,
+	FinalityUnsafeRollback,
+	FinalityFaultInjection,
 }
 
 var DeprecatedFlags = []cli.Flag{
@@ -0,0 +1,442 @@
+package metrics
+
+import (
+	"encoding/binary"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const FinalitySubsystem = "finality"
+
+// FinalityMetricer is the set of metrics reported by the finalizer.
+type FinalityMetricer interface {
+	RecordFinalityStaleSignal()
+	RecordFinalityPanic()
+	RecordFinalityUnjustifiedHead()
+	RecordFinalityAttemptFailure(cause string)
+	RecordFinalityCatchUp(remainingL1 uint64)
+	RecordFinalityLookbackHeadroom(entries uint64)
+	RecordFinalityHalted(halted bool)
+	RecordFinalityStateDigest(digest common.Hash)
+	RecordFinalityStall(reason string)
+	RecordFinalityDelay(l1Blocks uint64)
+	RecordFinalityRelation(finalizedL2 eth.L2BlockRef, derivedFrom eth.BlockID)
+	RecordFinalityLag(l2Blocks uint64, seconds uint64)
+	RecordFinalityAttemptDuration(duration time.Duration, exemplar map[string]string)
+	RecordFinalityEngineCall(duration time.Duration, success bool)
+	RecordFinalitySLO(window string, compliance float64, burnRate float64)
+	RecordFinalityPruningCasualty(l2Blocks uint64)
+	RecordFinalitySafeRegression()
+	RecordFinalityEngineAck(status string)
+	RecordFinalityOutboxPersistFailure()
+	RecordFinalityOutboxDropped(count uint64)
+}
+
+// FinalityMetrics tracks the metrics of the finalizer.
+type FinalityMetrics struct {
+	StaleSignals *metrics.Event
+	Panics       *metrics.Event
+	// UnjustifiedHeads counts the detected engine finalized L2 heads that are not justified by finalized L1 data.
+	UnjustifiedHeads *metrics.Event
+	// AttemptFailures counts the failed attempts to finalize, by cause, to tell reset-causing failures from transient ones.
+	AttemptFailures *prometheus.CounterVec
+	// CatchUpL1Blocks is the number of L1 blocks left to derive, for finalization to catch up with the finalized L1 block.
+	CatchUpL1Blocks prometheus.Gauge
+	// LookbackHeadroom is the number of finality data entries that can still be buffered, before unfinalized entries are pruned.
+	LookbackHeadroom prometheus.Gauge
+	// Halted is 1 while finalization is halted after repeated canonical-chain mismatches, until an operator resumes it.
+	Halted prometheus.Gauge
+	// StateDigest is the leading 53 bits of the digest of the finality state, to compare replicas with on dashboards.
+	StateDigest prometheus.Gauge
+	// Stalled is 1 for the subsystem that stalls finalization, if the finalized L2 head is not advancing.
+	Stalled *prometheus.GaugeVec
+	// DelayL1Blocks is the number of L1 blocks to traverse between attempts to finalize, as adapted to the L1 fetch cost.
+	DelayL1Blocks prometheus.Gauge
+	// FinalizedInfo is an info-style series, 1 for the labels of the finalized L2 head and the L1 block it was derived from.
+	FinalizedInfo *prometheus.GaugeVec
+	// LagL2Blocks and LagSeconds are how far the finalized L2 head lags behind the safe L2 head.
+	LagL2Blocks prometheus.Gauge
+	LagSeconds  prometheus.Gauge
+	// AttemptDurationSeconds is the duration of the attempts to finalize, with trace-ID exemplars if finality trace IDs are enabled.
+	AttemptDurationSeconds prometheus.Histogram
+	// EngineCallSeconds is the latency of the engine calls that apply the finalized L2 head, as observed by the finalizer.
+	EngineCallSeconds *prometheus.HistogramVec
+	// SLOCompliance and SLOBurnRate are the compliance with the finality latency SLO,
+	// and the burn rate of its error budget, by window.
+	SLOCompliance *prometheus.GaugeVec
+	SLOBurnRate   *prometheus.GaugeVec
+	// PruningCasualties counts the finality data entries that were pruned before they were finalized,
+	// and PruningCasualtyL2Blocks the L2 blocks that lost the opportunity to be finalized with them.
+	PruningCasualties       *metrics.Event
+	PruningCasualtyL2Blocks prometheus.Counter
+	// SafeRegressions counts the safe heads reported to the finalizer that moved backwards without a reset.
+	SafeRegressions *metrics.Event
+	// EngineAcks counts the responses of the engine to the forkchoice updates that applied a finalized L2 head, by status.
+	EngineAcks *prometheus.CounterVec
+	// OutboxPersistFailures counts the failed writes of the outbox of finalized events,
+	// and OutboxDropped the undelivered finalized events dropped from the outbox.
+	OutboxPersistFailures *metrics.Event
+	OutboxDropped         prometheus.Counter
+}
+
+func newFinalityMetrics(factory metrics.Factory, ns string) FinalityMetrics {
+	return FinalityMetrics{
+		StaleSignals: metrics.NewEvent(factory, ns, FinalitySubsystem, "stale_signals", "stale L1 finality signals"),
+		Panics:       metrics.NewEvent(factory, ns, FinalitySubsystem, "panics", "recovered panics of finalization steps"),
+		UnjustifiedHeads: metrics.NewEvent(factory, ns, FinalitySubsystem, "unjustified_heads",
+			"engine finalized L2 heads not justified by finalized L1 data"),
+		AttemptFailures: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "attempt_failures",
+			Help:      "Count of failed attempts to finalize, by cause",
+		}, []string{"cause"}),
+		CatchUpL1Blocks: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "catch_up_l1_blocks",
+			Help:      "Number of L1 blocks left to derive, for finalization to catch up with the finalized L1 block",
+		}),
+		LookbackHeadroom: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "lookback_headroom",
+			Help:      "Number of finality data entries that can still be buffered, before unfinalized entries are pruned",
+		}),
+		Halted: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "halted",
+			Help:      "1 while finalization is halted after repeated canonical-chain mismatches, 0 otherwise",
+		}),
+		StateDigest: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "state_digest",
+			Help:      "Leading 53 bits of the digest of the finality state, equal across replicas with the same finality state",
+		}),
+		Stalled: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "stalled",
+			Help:      "1 for the reason finalization is stalled: derivation not advancing either, or finality despite derivation advancing",
+		}, []string{"reason"}),
+		DelayL1Blocks: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "delay_l1_blocks",
+			Help:      "Number of L1 blocks to traverse between attempts to finalize, as adapted to the L1 fetch cost",
+		}),
+		FinalizedInfo: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "finalized_info",
+			Help:      "Pseudo-metric tracking the finalized L2 head, and the L1 block it was derived from, empty if unknown",
+		}, []string{
+			"l2_number",
+			"l2_hash",
+			"derived_from_number",
+			"derived_from_hash",
+		}),
+		LagL2Blocks: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "lag_l2_blocks",
+			Help:      "Number of L2 blocks the finalized L2 head lags behind the safe L2 head",
+		}),
+		LagSeconds: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "lag_seconds",
+			Help:      "Seconds of L2 block time the finalized L2 head lags behind the safe L2 head",
+		}),
+		AttemptDurationSeconds: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "attempt_duration_seconds",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram of the duration of attempts to finalize",
+		}),
+		EngineCallSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "engine_call_seconds",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram of the latency of engine calls that apply the finalized L2 head, by result",
+		}, []string{"result"}),
+		SLOCompliance: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "slo_compliance",
+			Help:      "Fraction of L2 blocks finalized within the target latency of the finality SLO, by window",
+		}, []string{"window"}),
+		SLOBurnRate: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "slo_burn_rate",
+			Help:      "Burn rate of the error budget of the finality SLO, by window",
+		}, []string{"window"}),
+		PruningCasualties: metrics.NewEvent(factory, ns, FinalitySubsystem, "pruning_casualties",
+			"finality data entries pruned before they were finalized"),
+		PruningCasualtyL2Blocks: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "pruning_casualty_l2_blocks",
+			Help:      "Count of L2 blocks that lost the opportunity to be finalized with pruned finality data entries",
+		}),
+		SafeRegressions: metrics.NewEvent(factory, ns, FinalitySubsystem, "safe_regressions",
+			"safe heads that moved backwards without a reset"),
+		EngineAcks: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "engine_acks",
+			Help:      "Count of engine responses to forkchoice updates that applied a finalized L2 head, by payload status",
+		}, []string{"status"}),
+		OutboxPersistFailures: metrics.NewEvent(factory, ns, FinalitySubsystem, "outbox_persist_failures",
+			"failed writes of the outbox of finalized events"),
+		OutboxDropped: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: FinalitySubsystem,
+			Name:      "outbox_dropped",
+			Help:      "Count of undelivered finalized events dropped from the outbox, while sinks are unavailable",
+		}),
+	}
+}
+
+func (m *FinalityMetrics) RecordFinalityStaleSignal() {
+	m.StaleSignals.Record()
+}
+
+func (n *noopMetricer) RecordFinalityStaleSignal() {
+}
+
+func (m *FinalityMetrics) RecordFinalityPanic() {
+	m.Panics.Record()
+}
+
+func (n *noopMetricer) RecordFinalityPanic() {
+}
+
+func (m *FinalityMetrics) RecordFinalityUnjustifiedHead() {
+	m.UnjustifiedHeads.Record()
+}
+
+func (n *noopMetricer) RecordFinalityUnjustifiedHead() {
+}
+
+func (m *FinalityMetrics) RecordFinalityAttemptFailure(cause string) {
+	m.AttemptFailures.WithLabelValues(cause).Inc()
+}
+
+func (n *noopMetricer) RecordFinalityAttemptFailure(cause string) {
+}
+
+func (m *FinalityMetrics) RecordFinalityCatchUp(remainingL1 uint64) {
+	m.CatchUpL1Blocks.Set(float64(remainingL1))
+}
+
+func (n *noopMetricer) RecordFinalityCatchUp(remainingL1 uint64) {
+}
+
+func (m *FinalityMetrics) RecordFinalityLookbackHeadroom(entries uint64) {
+	m.LookbackHeadroom.Set(float64(entries))
+}
+
+func (n *noopMetricer) RecordFinalityLookbackHeadroom(entries uint64) {
+}
+
+func (m *FinalityMetrics) RecordFinalityHalted(halted bool) {
+	if halted {
+		m.Halted.Set(1)
+	} else {
+		m.Halted.Set(0)
+	}
+}
+
+func (n *noopMetricer) RecordFinalityHalted(halted bool) {
+}
+
+func (m *FinalityMetrics) RecordFinalityStateDigest(digest common.Hash) {
+	// a float64 represents integers of up to 53 bits exactly
+	m.StateDigest.Set(float64(binary.BigEndian.Uint64(digest[:8]) >> 11))
+}
+
+func (n *noopMetricer) RecordFinalityStateDigest(digest common.Hash) {
+}
+
+func (m *FinalityMetrics) RecordFinalityStall(reason string) {
+	m.Stalled.Reset()
+	if reason != "" {
+		m.Stalled.WithLabelValues(reason).Set(1)
+	}
+}
+
+func (n *noopMetricer) RecordFinalityStall(reason string) {
+}
+
+func (m *FinalityMetrics) RecordFinalityDelay(l1Blocks uint64) {
+	m.DelayL1Blocks.Set(float64(l1Blocks))
+}
+
+func (n *noopMetricer) RecordFinalityDelay(l1Blocks uint64) {
+}
+
+func (m *FinalityMetrics) RecordFinalityRelation(finalizedL2 eth.L2BlockRef, derivedFrom eth.BlockID) {
+	var derivedFromNumber, derivedFromHash string
+	if derivedFrom != (eth.BlockID{}) {
+		derivedFromNumber = strconv.FormatUint(derivedFrom.Number, 10)
+		derivedFromHash = derivedFrom.Hash.String()
+	}
+	// only the latest relation is kept, so dashboards can join on the single series
+	m.FinalizedInfo.Reset()
+	m.FinalizedInfo.WithLabelValues(strconv.FormatUint(finalizedL2.Number, 10), finalizedL2.Hash.String(),
+		derivedFromNumber, derivedFromHash).Set(1)
+}
+
+func (n *noopMetricer) RecordFinalityRelation(finalizedL2 eth.L2BlockRef, derivedFrom eth.BlockID) {
+}
+
+func (m *FinalityMetrics) RecordFinalityLag(l2Blocks uint64, seconds uint64) {
+	m.LagL2Blocks.Set(float64(l2Blocks))
+	m.LagSeconds.Set(float64(seconds))
+}
+
+func (n *noopMetricer) RecordFinalityLag(l2Blocks uint64, seconds uint64) {
+}
+
+func (m *FinalityMetrics) RecordFinalityAttemptDuration(duration time.Duration, exemplar map[string]string) {
+	seconds := float64(duration) / float64(time.Second)
+	if obs, ok := m.AttemptDurationSeconds.(prometheus.ExemplarObserver); ok && len(exemplar) > 0 {
+		obs.ObserveWithExemplar(seconds, exemplar)
+		return
+	}
+	m.AttemptDurationSeconds.Observe(seconds)
+}
+
+func (n *noopMetricer) RecordFinalityAttemptDuration(duration time.Duration, exemplar map[string]string) {
+}
+
+func (m *FinalityMetrics) RecordFinalityEngineCall(duration time.Duration, success bool) {
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+	m.EngineCallSeconds.WithLabelValues(result).Observe(float64(duration) / float64(time.Second))
+}
+
+func (n *noopMetricer) RecordFinalityEngineCall(duration time.Duration, success bool) {
+}
+
+func (m *FinalityMetrics) RecordFinalitySLO(window string, compliance float64, burnRate float64) {
+	m.SLOCompliance.WithLabelValues(window).Set(compliance)
+	m.SLOBurnRate.WithLabelValues(window).Set(burnRate)
+}
+
+func (n *noopMetricer) RecordFinalitySLO(window string, compliance float64, burnRate float64) {
+}
+
+func (m *FinalityMetrics) RecordFinalityPruningCasualty(l2Blocks uint64) {
+	m.PruningCasualties.Record()
+	m.PruningCasualtyL2Blocks.Add(float64(l2Blocks))
+}
+
+func (n *noopMetricer) RecordFinalityPruningCasualty(l2Blocks uint64) {
+}
+
+func (m *FinalityMetrics) RecordFinalitySafeRegression() {
+	m.SafeRegressions.Record()
+}
+
+func (n *noopMetricer) RecordFinalitySafeRegression() {
+}
+
+func (m *FinalityMetrics) RecordFinalityEngineAck(status string) {
+	m.EngineAcks.WithLabelValues(status).Inc()
+}
+
+func (n *noopMetricer) RecordFinalityEngineAck(status string) {
+}
+
+func (m *FinalityMetrics) RecordFinalityOutboxPersistFailure() {
+	m.OutboxPersistFailures.Record()
+}
+
+func (n *noopMetricer) RecordFinalityOutboxPersistFailure() {
+}
+
+func (m *FinalityMetrics) RecordFinalityOutboxDropped(count uint64) {
+	m.OutboxDropped.Add(float64(count))
+}
+
+func (n *noopMetricer) RecordFinalityOutboxDropped(count uint64) {
+}
+
+// ChainFinalityMetrics are the metrics of the finalizer of one of the L2 chains of a finality.FinalizerSet,
+// with every metric labeled by the chain ID, so the finalizers of the chains do not collide.
+type ChainFinalityMetrics struct {
+	metrics.RefMetrics
+	FinalityMetrics
+}
+
+// NewChainFinalityMetrics creates the finality metrics of the given L2 chain with the factory.
+// ns is the fully qualified namespace, e.g. "op_node_default".
+func NewChainFinalityMetrics(factory metrics.Factory, ns string, chainID uint64) *ChainFinalityMetrics {
+	factory = &labeledFactory{Factory: factory, labels: prometheus.Labels{"chain": strconv.FormatUint(chainID, 10)}}
+	return &ChainFinalityMetrics{
+		RefMetrics:      metrics.MakeRefMetrics(ns, factory),
+		FinalityMetrics: newFinalityMetrics(factory, ns),
+	}
+}
+
+// labeledFactory adds constant labels to every metric created with the factory.
+type labeledFactory struct {
+	metrics.Factory
+	labels prometheus.Labels
+}
+
+func (f *labeledFactory) NewCounter(opts prometheus.CounterOpts) prometheus.Counter {
+	opts.ConstLabels = f.labels
+	return f.Factory.NewCounter(opts)
+}
+
+func (f *labeledFactory) NewCounterVec(opts prometheus.CounterOpts, labelNames []string) *prometheus.CounterVec {
+	opts.ConstLabels = f.labels
+	return f.Factory.NewCounterVec(opts, labelNames)
+}
+
+func (f *labeledFactory) NewGauge(opts prometheus.GaugeOpts) prometheus.Gauge {
+	opts.ConstLabels = f.labels
+	return f.Factory.NewGauge(opts)
+}
+
+func (f *labeledFactory) NewGaugeVec(opts prometheus.GaugeOpts, labelNames []string) *prometheus.GaugeVec {
+	opts.ConstLabels = f.labels
+	return f.Factory.NewGaugeVec(opts, labelNames)
+}
+
+func (f *labeledFactory) NewHistogram(opts prometheus.HistogramOpts) prometheus.Histogram {
+	opts.ConstLabels = f.labels
+	return f.Factory.NewHistogram(opts)
+}
+
+func (f *labeledFactory) NewHistogramVec(opts prometheus.HistogramOpts, labelNames []string) *prometheus.HistogramVec {
+	opts.ConstLabels = f.labels
+	return f.Factory.NewHistogramVec(opts, labelNames)
+}
+
+func (f *labeledFactory) NewSummary(opts prometheus.SummaryOpts) prometheus.Summary {
+	opts.ConstLabels = f.labels
+	return f.Factory.NewSummary(opts)
+}
+
+func (f *labeledFactory) NewSummaryVec(opts prometheus.SummaryOpts, labelNames []string) *prometheus.SummaryVec {
+	opts.ConstLabels = f.labels
+	return f.Factory.NewSummaryVec(opts, labelNames)
+}
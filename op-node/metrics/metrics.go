@@ -72,6 +72,7 @@ type Metricer interface {
 	RecordDial(allow bool)
 	RecordAccept(allow bool)
 	ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion)
+	FinalityMetricer
 }
 
 // Metrics tracks all the metrics for the op-node.
@@ -116,6 +117,8 @@ type Metrics struct {
 
 	metrics.RefMetrics
 
+	FinalityMetrics
+
 	L1ReorgDepth prometheus.Histogram
 
 	TransactionsSequencedTotal prometheus.Counter
@@ -213,6 +216,8 @@ func NewMetrics(procName string) *Metrics {
 
 		RefMetrics: metrics.MakeRefMetrics(ns, factory),
 
+		FinalityMetrics: newFinalityMetrics(factory, ns),
+
 		L1ReorgDepth: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: ns,
 			Name:      "l1_reorg_depth",
@@ -538,7 +543,8 @@ func (m *Metrics) RecordSequencerSealingTime(duration time.Duration) {
 func (m *Metrics) StartServer(hostname string, port int) (*ophttp.HTTPServer, error) {
 	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
 	h := promhttp.InstrumentMetricHandler(
-		m.registry, promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}),
+		// the OpenMetrics format is required to serve the exemplars of the finality metrics
+		m.registry, promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{EnableOpenMetrics: true}),
 	)
 	return ophttp.StartHTTPServer(addr, h)
 }
@@ -2,15 +2,20 @@ package node
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/api"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -27,6 +32,15 @@ type l2EthClient interface {
 
 type driverClient interface {
 	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+	FinalityStatusJSON(ctx context.Context) (json.RawMessage, error)
+	FinalitySnapshot(ctx context.Context) (*finality.Snapshot, error)
+	FinalizedAtTime(ctx context.Context, timestamp uint64) (eth.L2BlockRef, error)
+	FinalityDerivedFrom(ctx context.Context, l2Number uint64) (eth.BlockID, error)
+	FinalizedAtLeast(ctx context.Context, l2Number uint64) (bool, error)
+	EstimateFinality(ctx context.Context, l2Number uint64) (finality.FinalityEstimate, error)
+	FinalityAudit(ctx context.Context) ([]finality.FinalizedHeadUpdate, error)
+	FinalityCasualties(ctx context.Context) ([]finality.PruningCasualty, error)
+	SetFakeFinalizedL1(ctx context.Context, number uint64) (eth.L1BlockRef, error)
 	BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2BlockRef, *eth.SyncStatus, error)
 	ResetDerivationPipeline(context.Context) error
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
@@ -35,19 +49,28 @@ type driverClient interface {
 	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 }
 
+// finalityAdminClient is the operator surface of the finalizer, as served by the node.
+type finalityAdminClient interface {
+	ResumeFinality(ctx context.Context) error
+	ReloadFinalityTunables(ctx context.Context, update finality.FinalityTunablesUpdate) (finality.FinalityTunables, error)
+	UnsafeRollbackFinalized(ctx context.Context, target eth.L2BlockRef) error
+}
+
 type SafeDBReader interface {
 	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1 eth.BlockID, l2 eth.BlockID, err error)
 }
 
 type adminAPI struct {
 	*rpc.CommonAdminAPI
-	dr driverClient
+	dr       driverClient
+	finality finalityAdminClient
 }
 
-func NewAdminAPI(dr driverClient, m metrics.RPCMetricer, log log.Logger) *adminAPI {
+func NewAdminAPI(dr driverClient, finality finalityAdminClient, m metrics.RPCMetricer, log log.Logger) *adminAPI {
 	return &adminAPI{
 		CommonAdminAPI: rpc.NewCommonAdminAPI(m, log),
 		dr:             dr,
+		finality:       finality,
 	}
 }
 
@@ -75,6 +98,38 @@ func (n *adminAPI) SequencerActive(ctx context.Context) (bool, error) {
 	return n.dr.SequencerActive(ctx)
 }
 
+// ResumeFinality resumes finalization after it was halted by repeated canonical-chain mismatches,
+// once the operator resolved the inconsistent L1 provider.
+func (n *adminAPI) ResumeFinality(ctx context.Context) error {
+	recordDur := n.M.RecordRPCServerRequest("admin_resumeFinality")
+	defer recordDur()
+	return n.finality.ResumeFinality(ctx)
+}
+
+// ReloadFinalityTunables applies the update to the finality tunables, without restarting the node,
+// and returns the resulting tunables. Omitted tunables are left as they are.
+func (n *adminAPI) ReloadFinalityTunables(ctx context.Context, update finality.FinalityTunablesUpdate) (finality.FinalityTunables, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_reloadFinalityTunables")
+	defer recordDur()
+	return n.finality.ReloadFinalityTunables(ctx, update)
+}
+
+// UnsafeRollbackFinalized rewinds the finalized L2 head to the given older L2 block.
+// This is for devnet chaos testing and recovery drills only, and requires the unsafe rollback to be enabled.
+func (n *adminAPI) UnsafeRollbackFinalized(ctx context.Context, target eth.L2BlockRef) error {
+	recordDur := n.M.RecordRPCServerRequest("admin_unsafeRollbackFinalized")
+	defer recordDur()
+	return n.finality.UnsafeRollbackFinalized(ctx, target)
+}
+
+// SetFakeFinalizedL1 injects a synthetic L1 finality signal for the L1 block with the given number.
+// This is for devnets without a beacon chain only, and requires the fake finality signals to be enabled.
+func (n *adminAPI) SetFakeFinalizedL1(ctx context.Context, number hexutil.Uint64) (eth.L1BlockRef, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_setFakeFinalizedL1")
+	defer recordDur()
+	return n.dr.SetFakeFinalizedL1(ctx, uint64(number))
+}
+
 // PostUnsafePayload is a special API that allows posting an unsafe payload to the L2 derivation pipeline.
 // It should only be used by op-conductor for sequencer failover scenarios.
 // TODO(ethereum-optimism/optimism#9064): op-conductor Dencun changes.
@@ -155,6 +210,66 @@ func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	return n.dr.SyncStatus(ctx)
 }
 
+// FinalityStatus serves the finality status as published by the finalizer, pre-serialized,
+// so that monitoring scrapes do not contend with the finalizer. Its version counts the changes.
+// Only the fields of the requested schema version are served, or of the first schema version if none is requested,
+// so integrations that parse the status strictly do not break when fields are added.
+func (n *nodeAPI) FinalityStatus(ctx context.Context, schema *hexutil.Uint64) (json.RawMessage, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_finalityStatus")
+	defer recordDur()
+	status, err := n.dr.FinalityStatusJSON(ctx)
+	if err != nil {
+		return nil, err
+	}
+	version := uint64(finality.StatusSchemaV1)
+	if schema != nil {
+		version = uint64(*schema)
+	}
+	return api.ProjectStatusJSON(status, version)
+}
+
+func (n *nodeAPI) FinalitySnapshot(ctx context.Context) (*finality.Snapshot, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_finalitySnapshot")
+	defer recordDur()
+	return n.dr.FinalitySnapshot(ctx)
+}
+
+func (n *nodeAPI) FinalityAudit(ctx context.Context) ([]finality.FinalizedHeadUpdate, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_finalityAudit")
+	defer recordDur()
+	return n.dr.FinalityAudit(ctx)
+}
+
+func (n *nodeAPI) FinalityCasualties(ctx context.Context) ([]finality.PruningCasualty, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_finalityCasualties")
+	defer recordDur()
+	return n.dr.FinalityCasualties(ctx)
+}
+
+func (n *nodeAPI) FinalizedAtTime(ctx context.Context, timestamp hexutil.Uint64) (eth.L2BlockRef, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_finalizedAtTime")
+	defer recordDur()
+	return n.dr.FinalizedAtTime(ctx, uint64(timestamp))
+}
+
+func (n *nodeAPI) FinalityDerivedFrom(ctx context.Context, number hexutil.Uint64) (eth.BlockID, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_finalityDerivedFrom")
+	defer recordDur()
+	return n.dr.FinalityDerivedFrom(ctx, uint64(number))
+}
+
+func (n *nodeAPI) FinalizedAtLeast(ctx context.Context, number hexutil.Uint64) (bool, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_finalizedAtLeast")
+	defer recordDur()
+	return n.dr.FinalizedAtLeast(ctx, uint64(number))
+}
+
+func (n *nodeAPI) EstimateFinality(ctx context.Context, number hexutil.Uint64) (finality.FinalityEstimate, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_estimateFinality")
+	defer recordDur()
+	return n.dr.EstimateFinality(ctx, uint64(number))
+}
+
 func (n *nodeAPI) RollupConfig(_ context.Context) (*rollup.Config, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_rollupConfig")
 	defer recordDur()
@@ -166,3 +281,151 @@ func (n *nodeAPI) Version(ctx context.Context) (string, error) {
 	defer recordDur()
 	return version.Version + "-" + version.Meta, nil
 }
+
+type finalizedSubscriptions interface {
+	SubscribeFinalized(fn finality.FinalizedSubscriber) (unsubscribe func())
+}
+
+// finalitySubscriptionAPI serves finalized L2 head notifications over WebSocket subscriptions, in the optimism namespace.
+type finalitySubscriptionAPI struct {
+	finalized finalizedSubscriptions
+	log       log.Logger
+	m         metrics.RPCMetricer
+}
+
+func NewFinalitySubscriptionAPI(finalized finalizedSubscriptions, log log.Logger, m metrics.RPCMetricer) *finalitySubscriptionAPI {
+	return &finalitySubscriptionAPI{
+		finalized: finalized,
+		log:       log,
+		m:         m,
+	}
+}
+
+// Finalized subscribes to the advancements of the finalized L2 head, including the L1 blocks the newly finalized
+// L2 blocks were derived from. It is served as optimism_subscribe("finalized").
+// Advancements that happen while the notification of a previous one is being sent are coalesced into one notification.
+func (n *finalitySubscriptionAPI) Finalized(ctx context.Context) (*gethrpc.Subscription, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_subscribe_finalized")
+	defer recordDur()
+	notifier, supported := gethrpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+
+	var mu sync.Mutex
+	var pending *finality.FinalizedEvent
+	wake := make(chan struct{}, 1)
+	// the Finalizer calls subscribers synchronously, so only queue the advancement here
+	unsubscribe := n.finalized.SubscribeFinalized(func(ev finality.FinalizedEvent) {
+		mu.Lock()
+		if pending == nil {
+			pending = &ev
+		} else {
+			pending.Coalesce(ev)
+		}
+		mu.Unlock()
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	})
+	go func() {
+		defer unsubscribe()
+		for {
+			select {
+			case <-sub.Err():
+				return
+			case <-wake:
+			}
+			mu.Lock()
+			ev := pending
+			pending = nil
+			mu.Unlock()
+			if ev == nil {
+				continue
+			}
+			if err := notifier.Notify(sub.ID, ev); err != nil {
+				n.log.Warn("failed to notify finalized L2 head subscriber", "finalized_l2", ev.FinalizedL2, "err", err)
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+type receiptsSource interface {
+	Receipts(fromL2 uint64) []finality.FinalizedRangeReceipt
+}
+
+// finalityReceiptsAPI serves the signed finalized-range receipts, in the optimism namespace.
+type finalityReceiptsAPI struct {
+	receipts receiptsSource
+	m        metrics.RPCMetricer
+}
+
+func NewFinalityReceiptsAPI(receipts receiptsSource, m metrics.RPCMetricer) *finalityReceiptsAPI {
+	return &finalityReceiptsAPI{
+		receipts: receipts,
+		m:        m,
+	}
+}
+
+// FinalizedRangeReceipts returns the retained receipts of the ranges that finalize the given L2 block or later blocks.
+func (n *finalityReceiptsAPI) FinalizedRangeReceipts(_ context.Context, fromL2 hexutil.Uint64) ([]finality.FinalizedRangeReceipt, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_finalizedRangeReceipts")
+	defer recordDur()
+	return n.receipts.Receipts(uint64(fromL2)), nil
+}
+
+type finalityIndexReader interface {
+	FinalizedAt(ctx context.Context, l2BlockNum uint64) (finality.FinalizationRecord, error)
+}
+
+// finalityIndexAPI serves the historical finalization of L2 blocks from the finality index, in the optimism namespace.
+type finalityIndexAPI struct {
+	index finalityIndexReader
+	m     metrics.RPCMetricer
+}
+
+func NewFinalityIndexAPI(index finalityIndexReader, m metrics.RPCMetricer) *finalityIndexAPI {
+	return &finalityIndexAPI{
+		index: index,
+		m:     m,
+	}
+}
+
+// FinalizedAt returns when, and with which L1 finality signal, the given L2 block was finalized.
+func (n *finalityIndexAPI) FinalizedAt(ctx context.Context, number hexutil.Uint64) (*finality.FinalizationRecord, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_finalizedAt")
+	defer recordDur()
+	rec, err := n.index.FinalizedAt(ctx, uint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up finalization of L2 block %d: %w", uint64(number), err)
+	}
+	return &rec, nil
+}
+
+type withdrawalRootsSource interface {
+	WithdrawalRoots(fromL2 uint64) []finality.FinalizedWithdrawalRoot
+}
+
+// finalityWithdrawalRootsAPI serves the withdrawal roots of the finalized L2 heads, in the optimism namespace.
+type finalityWithdrawalRootsAPI struct {
+	roots withdrawalRootsSource
+	m     metrics.RPCMetricer
+}
+
+func NewFinalityWithdrawalRootsAPI(roots withdrawalRootsSource, m metrics.RPCMetricer) *finalityWithdrawalRootsAPI {
+	return &finalityWithdrawalRootsAPI{
+		roots: roots,
+		m:     m,
+	}
+}
+
+// FinalizedWithdrawalRoots returns the cached withdrawal roots of the finalized L2 heads at the given L2 block or later.
+func (n *finalityWithdrawalRootsAPI) FinalizedWithdrawalRoots(_ context.Context, fromL2 hexutil.Uint64) ([]finality.FinalizedWithdrawalRoot, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_finalizedWithdrawalRoots")
+	defer recordDur()
+	return n.roots.WithdrawalRoots(uint64(fromL2)), nil
+}
@@ -11,9 +11,12 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	"github.com/ethereum-optimism/superchain-registry/superchain"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -76,6 +79,71 @@ type Config struct {
 
 	// Plasma DA config
 	Plasma plasma.CLIConfig
+
+	// FinalityFollow is the RPC endpoint of a primary rollup node to follow the finalized L2 head of,
+	// instead of determining L2 finality from L1. Disabled if empty.
+	FinalityFollow string
+
+	// FinalityReplica is the RPC endpoint of a replica L2 execution client,
+	// to cross-validate the L2 blocks to finalize with. Disabled if empty.
+	FinalityReplica string
+
+	// FinalityShadowL1 is the RPC endpoint of an alternative L1 source, to run a shadow finalizer with,
+	// which is compared with, but never applied to the engine. Disabled if empty.
+	FinalityShadowL1 string
+
+	// FinalityDisputeGameFactory is the address of the DisputeGameFactory contract on L1, to read the resolved
+	// dispute games of the FinalityDisputeGameType from, for the finality rules, the dispute game gate and the settlement. Disabled if zero.
+	FinalityDisputeGameFactory common.Address
+	FinalityDisputeGameType    uint32
+
+	// FinalityCommitteeRegistry is the address of the key registry contract of a committee on L1,
+	// to gate finalization on the attestations fetched from FinalityCommitteeAttestations. Disabled if zero.
+	FinalityCommitteeRegistry     common.Address
+	FinalityCommitteeAttestations string
+
+	// FinalityBeaconEvents is the Beacon API endpoint to subscribe to finalized checkpoint events of,
+	// in addition to polling the finalized L1 block. Disabled if empty.
+	FinalityBeaconEvents string
+
+	// FinalityLightClient is the RPC endpoint of a local L1 light client to finalize with,
+	// instead of the L1 RPC, for both the L1 finality signal and the L1 blocks to sanity check. Disabled if empty.
+	FinalityLightClient string
+
+	// FinalityReceipts enables issuing signed finalized-range receipts with the P2P signer.
+	FinalityReceipts bool
+
+	// FinalityStateStore is the URL of the store to persist the finality state to, and to restore it from on startup.
+	// A file:// URL for a local file, an s3:// URL for an object in S3-compatible object storage,
+	// or an http(s):// URL for an object in shared object storage. Disabled if empty.
+	FinalityStateStore string
+
+	// FinalityEngineAnnounce announces the finalized L2 head, with its L1 origin, to the execution client,
+	// if it supports the engine_announceFinalizedV1 Engine API extension.
+	FinalityEngineAnnounce bool
+
+	// FinalityOutbox is the path of the file to persist the outbox of finalized events to,
+	// for at-least-once delivery to the FinalityOutboxSinks. Disabled if empty.
+	FinalityOutbox      string
+	FinalityOutboxSinks []string
+	// FinalityOutboxEnriched enriches the finalized events of the outbox with the metadata of every finalized L2 block.
+	FinalityOutboxEnriched bool
+
+	// FinalityIndexPath is the path of the database that indexes every advancement of the finalized L2 head,
+	// to serve when and from which L1 block historical L2 blocks were finalized. Disabled if empty.
+	FinalityIndexPath string
+
+	// FinalityGRPCAddr is the address of the gRPC server of the finality service, for consumers that are not
+	// written in Go. Disabled if empty.
+	FinalityGRPCAddr string
+
+	// FinalityWithdrawalRoots fetches and caches the withdrawal root of every finalized L2 head,
+	// and includes it in the finalized events.
+	FinalityWithdrawalRoots bool
+
+	// FinalityTunablesFile is the path of a JSON file with finality tunables, applied on startup,
+	// and reloaded on SIGHUP without restarting the node. Disabled if empty.
+	FinalityTunablesFile string
 }
 
 type RPCConfig struct {
@@ -174,6 +242,27 @@ func (cfg *Config) Check() error {
 	if err := cfg.Plasma.Check(); err != nil {
 		return fmt.Errorf("plasma config error: %w", err)
 	}
+	if cfg.Driver.Finality.Mode == finality.ModeFollow && cfg.FinalityFollow == "" {
+		return fmt.Errorf("finality mode %q requires a primary rollup node to follow", cfg.Driver.Finality.Mode)
+	}
+	if cfg.Driver.Finality.Mode == finality.ModePlasma && !cfg.Rollup.PlasmaEnabled() {
+		return fmt.Errorf("finality mode %q requires plasma to be enabled in the rollup config", cfg.Driver.Finality.Mode)
+	}
+	if cfg.Driver.Finality.DisputeGameGate && cfg.FinalityDisputeGameFactory == (common.Address{}) {
+		return errors.New("the finality dispute game gate requires the DisputeGameFactory address")
+	}
+	if cfg.Driver.Finality.Settlement && cfg.FinalityDisputeGameFactory == (common.Address{}) {
+		return errors.New("the finality settlement requires the DisputeGameFactory address")
+	}
+	if (cfg.FinalityCommitteeRegistry == (common.Address{})) != (cfg.FinalityCommitteeAttestations == "") {
+		return errors.New("the finality committee gate requires both the committee registry and the attestations URL")
+	}
+	if cfg.Driver.Finality.Faults.Enabled() && cfg.Rollup.L2ChainID != nil {
+		// faults stall finality on purpose, which must never happen on a production chain
+		if _, ok := superchain.OPChains[cfg.Rollup.L2ChainID.Uint64()]; ok {
+			return fmt.Errorf("finality fault injection is for devnets only, refusing to inject faults on chain %v of the superchain registry", cfg.Rollup.L2ChainID)
+		}
+	}
 	if cfg.Plasma.Enabled {
 		log.Warn("Alt-DA Mode is a Beta feature of the MIT licensed OP Stack.  While it has received initial review from core contributors, it is still undergoing testing, and may have bugs or other issues.")
 	}
@@ -0,0 +1,164 @@
+package finalitydb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidEntry = errors.New("invalid db entry")
+)
+
+const (
+	// Keys are prefixed with a constant byte to allow us to differentiate different "columns" within the data
+	keyPrefixFinalizedByL2BlockNum byte = 0
+)
+
+var (
+	finalizedByL2BlockNumKey = uint64Key{prefix: keyPrefixFinalizedByL2BlockNum}
+)
+
+type uint64Key struct {
+	prefix byte
+}
+
+func (c uint64Key) Of(num uint64) []byte {
+	key := make([]byte, 0, 9)
+	key = append(key, c.prefix)
+	key = binary.BigEndian.AppendUint64(key, num)
+	return key
+}
+func (c uint64Key) Max() []byte {
+	return c.Of(math.MaxUint64)
+}
+
+func (c uint64Key) IterRange() *pebble.IterOptions {
+	return &pebble.IterOptions{
+		LowerBound: c.Of(0),
+		UpperBound: c.Max(),
+	}
+}
+
+// FinalityDB indexes every advancement of the finalized L2 head, by the L2 block number it advanced to,
+// so the finalization of any historical L2 block can be looked up.
+type FinalityDB struct {
+	// m ensures all read iterators are closed before closing the database by preventing concurrent read and write
+	// operations (with close considered a write operation).
+	m   sync.RWMutex
+	log log.Logger
+	db  *pebble.DB
+
+	writeOpts *pebble.WriteOptions
+
+	closed bool
+}
+
+func finalizedByL2BlockNumValue(rec finality.FinalizationRecord) []byte {
+	val := make([]byte, 0, 88)
+	val = binary.BigEndian.AppendUint64(val, rec.FromL2)
+	val = append(val, rec.FinalizedL2.Hash.Bytes()...)
+	val = binary.BigEndian.AppendUint64(val, rec.FinalizedL1.Number)
+	val = append(val, rec.FinalizedL1.Hash.Bytes()...)
+	val = binary.BigEndian.AppendUint64(val, rec.Time)
+	return val
+}
+
+func decodeFinalizedByL2BlockNum(key []byte, val []byte) (rec finality.FinalizationRecord, err error) {
+	if len(key) != 9 || len(val) != 88 || key[0] != keyPrefixFinalizedByL2BlockNum {
+		err = ErrInvalidEntry
+		return
+	}
+	rec.FromL2 = binary.BigEndian.Uint64(val[:8])
+	rec.FinalizedL2.Number = binary.BigEndian.Uint64(key[1:])
+	copy(rec.FinalizedL2.Hash[:], val[8:40])
+	rec.FinalizedL1.Number = binary.BigEndian.Uint64(val[40:48])
+	copy(rec.FinalizedL1.Hash[:], val[48:80])
+	rec.Time = binary.BigEndian.Uint64(val[80:])
+	return
+}
+
+func NewFinalityDB(logger log.Logger, path string) (*FinalityDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return &FinalityDB{
+		log:       logger,
+		db:        db,
+		writeOpts: &pebble.WriteOptions{Sync: true},
+	}, nil
+}
+
+// FinalizedUpdated records the advancement of the finalized L2 head, finalized at the given unix time.
+// Records of L2 blocks beyond the new finalized L2 head, left by a rollback of the finalized L2 head, are removed.
+func (d *FinalityDB) FinalizedUpdated(ev finality.FinalizedEvent, time uint64) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.log.Debug("Record finalized head", "l2", ev.FinalizedL2.ID(), "l1", ev.FinalizedL1.ID())
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	if err := batch.DeleteRange(finalizedByL2BlockNumKey.Of(ev.FinalizedL2.Number+1), finalizedByL2BlockNumKey.Max(), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to delete finalized head records after %d: %w", ev.FinalizedL2.Number, err)
+	}
+	if ev.FinalizedL2.Number > ev.PrevFinalizedL2.Number {
+		rec := finality.FinalizationRecord{
+			FromL2:      ev.PrevFinalizedL2.Number + 1,
+			FinalizedL2: ev.FinalizedL2.ID(),
+			FinalizedL1: ev.FinalizedL1.ID(),
+			Time:        time,
+		}
+		if err := batch.Set(finalizedByL2BlockNumKey.Of(ev.FinalizedL2.Number), finalizedByL2BlockNumValue(rec), d.writeOpts); err != nil {
+			return fmt.Errorf("failed to record finalized head update: %w", err)
+		}
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit finalized head update: %w", err)
+	}
+	return nil
+}
+
+// FinalizedAt returns the record of the advancement that finalized the given L2 block.
+// It returns ErrNotFound if the L2 block is not finalized yet, or was finalized before the records start.
+func (d *FinalityDB) FinalizedAt(ctx context.Context, l2BlockNum uint64) (rec finality.FinalizationRecord, err error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	iter, err := d.db.NewIterWithContext(ctx, finalizedByL2BlockNumKey.IterRange())
+	if err != nil {
+		return
+	}
+	defer iter.Close()
+	if valid := iter.SeekGE(finalizedByL2BlockNumKey.Of(l2BlockNum)); !valid {
+		err = ErrNotFound
+		return
+	}
+	// Found the first advancement at or beyond the requested L2 block
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return
+	}
+	rec, err = decodeFinalizedByL2BlockNum(iter.Key(), val)
+	if err == nil && l2BlockNum < rec.FromL2 {
+		rec, err = finality.FinalizationRecord{}, ErrNotFound
+	}
+	return
+}
+
+func (d *FinalityDB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		// Already closed
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
@@ -0,0 +1,105 @@
+package finalitydb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreFinalizedHeads(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewFinalityDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	l2a := eth.L2BlockRef{Hash: common.Hash{0x02, 0xaa}, Number: 20}
+	l2b := eth.L2BlockRef{Hash: common.Hash{0x02, 0xbb}, Number: 25}
+	l2c := eth.L2BlockRef{Hash: common.Hash{0x02, 0xcc}, Number: 30}
+	l1a := eth.L1BlockRef{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	l1b := eth.L1BlockRef{Hash: common.Hash{0x01, 0xbb}, Number: 150}
+	require.NoError(t, db.FinalizedUpdated(finality.FinalizedEvent{PrevFinalizedL2: l2a, FinalizedL2: l2b, FinalizedL1: l1a}, 1000))
+	require.NoError(t, db.FinalizedUpdated(finality.FinalizedEvent{PrevFinalizedL2: l2b, FinalizedL2: l2c, FinalizedL1: l1b}, 1012))
+
+	verifyFinalizedHeads := func(db *FinalityDB) {
+		_, err = db.FinalizedAt(context.Background(), l2a.Number)
+		require.ErrorIs(t, err, ErrNotFound, "finalized before the records start")
+
+		recB := finality.FinalizationRecord{FromL2: l2a.Number + 1, FinalizedL2: l2b.ID(), FinalizedL1: l1a.ID(), Time: 1000}
+		for n := l2a.Number + 1; n <= l2b.Number; n++ {
+			rec, err := db.FinalizedAt(context.Background(), n)
+			require.NoError(t, err)
+			require.Equal(t, recB, rec)
+		}
+
+		rec, err := db.FinalizedAt(context.Background(), l2c.Number)
+		require.NoError(t, err)
+		require.Equal(t, finality.FinalizationRecord{FromL2: l2b.Number + 1, FinalizedL2: l2c.ID(), FinalizedL1: l1b.ID(), Time: 1012}, rec)
+
+		_, err = db.FinalizedAt(context.Background(), l2c.Number+1)
+		require.ErrorIs(t, err, ErrNotFound, "not finalized yet")
+	}
+	// Verify loading from the in-memory cache
+	verifyFinalizedHeads(db)
+
+	// Close the DB and open a new instance
+	require.NoError(t, db.Close())
+	newDB, err := NewFinalityDB(logger, dir)
+	require.NoError(t, err)
+	defer newDB.Close()
+	// Verify reloading from disk
+	verifyFinalizedHeads(newDB)
+}
+
+func TestFinalizedHeadRollback(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewFinalityDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	l2a := eth.L2BlockRef{Hash: common.Hash{0x02, 0xaa}, Number: 20}
+	l2b := eth.L2BlockRef{Hash: common.Hash{0x02, 0xbb}, Number: 25}
+	l2c := eth.L2BlockRef{Hash: common.Hash{0x02, 0xcc}, Number: 30}
+	l1a := eth.L1BlockRef{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	l1b := eth.L1BlockRef{Hash: common.Hash{0x01, 0xbb}, Number: 150}
+	require.NoError(t, db.FinalizedUpdated(finality.FinalizedEvent{PrevFinalizedL2: l2a, FinalizedL2: l2b, FinalizedL1: l1a}, 1000))
+	require.NoError(t, db.FinalizedUpdated(finality.FinalizedEvent{PrevFinalizedL2: l2b, FinalizedL2: l2c, FinalizedL1: l1b}, 1012))
+
+	// roll back the finalized L2 head to l2b
+	require.NoError(t, db.FinalizedUpdated(finality.FinalizedEvent{PrevFinalizedL2: l2c, FinalizedL2: l2b, FinalizedL1: l1a}, 1024))
+	_, err = db.FinalizedAt(context.Background(), l2c.Number)
+	require.ErrorIs(t, err, ErrNotFound)
+	rec, err := db.FinalizedAt(context.Background(), l2b.Number)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), rec.Time, "the records up to the rolled back head are kept")
+}
+
+func TestIndexWriter(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewFinalityDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	w := NewIndexWriter(logger, db)
+	w.Start()
+	l2a := eth.L2BlockRef{Hash: common.Hash{0x02, 0xaa}, Number: 20}
+	l2b := eth.L2BlockRef{Hash: common.Hash{0x02, 0xbb}, Number: 25}
+	l2c := eth.L2BlockRef{Hash: common.Hash{0x02, 0xcc}, Number: 30}
+	l1a := eth.L1BlockRef{Hash: common.Hash{0x01, 0xaa}, Number: 100}
+	l1b := eth.L1BlockRef{Hash: common.Hash{0x01, 0xbb}, Number: 150}
+	w.OnFinalized(finality.FinalizedEvent{PrevFinalizedL2: l2a, FinalizedL2: l2b, FinalizedL1: l1a})
+	w.OnFinalized(finality.FinalizedEvent{PrevFinalizedL2: l2b, FinalizedL2: l2c, FinalizedL1: l1b})
+	// the pending advancements are written before the writer is closed
+	w.Close()
+
+	rec, err := db.FinalizedAt(context.Background(), l2b.Number)
+	require.NoError(t, err)
+	require.Equal(t, l2b.ID(), rec.FinalizedL2)
+	rec, err = db.FinalizedAt(context.Background(), l2c.Number)
+	require.NoError(t, err)
+	require.Equal(t, l2c.ID(), rec.FinalizedL2)
+	require.Equal(t, l1b.ID(), rec.FinalizedL1)
+}
@@ -0,0 +1,92 @@
+package finalitydb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+)
+
+// indexUpdate is an advancement of the finalized L2 head, and the time it was finalized at, to record in the index.
+type indexUpdate struct {
+	ev   finality.FinalizedEvent
+	time uint64
+}
+
+// IndexWriter records every advancement of the finalized L2 head into the FinalityDB.
+// The advancements are written asynchronously, in order, so the finalizer does not wait for the database.
+type IndexWriter struct {
+	log log.Logger
+	db  *FinalityDB
+
+	mu      sync.Mutex
+	pending []indexUpdate
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewIndexWriter(log log.Logger, db *FinalityDB) *IndexWriter {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &IndexWriter{
+		log:    log,
+		db:     db,
+		wake:   make(chan struct{}, 1),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (w *IndexWriter) Start() {
+	w.wg.Add(1)
+	go w.loop()
+}
+
+// Close stops the writer, after writing the advancements that are still pending.
+func (w *IndexWriter) Close() {
+	w.cancel()
+	w.wg.Wait()
+	w.writePending()
+}
+
+// OnFinalized queues the advancement to be recorded. It is a FinalizedSubscriber, and does not block.
+func (w *IndexWriter) OnFinalized(ev finality.FinalizedEvent) {
+	w.mu.Lock()
+	w.pending = append(w.pending, indexUpdate{ev: ev, time: uint64(time.Now().Unix())})
+	w.mu.Unlock()
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *IndexWriter) loop() {
+	defer w.wg.Done()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-w.wake:
+		}
+		w.writePending()
+	}
+}
+
+// writePending records the pending advancements, in order.
+// An advancement that fails to be recorded is skipped: the index does not cover the L2 blocks it finalized.
+func (w *IndexWriter) writePending() {
+	w.mu.Lock()
+	pending := w.pending
+	w.pending = nil
+	w.mu.Unlock()
+	for _, u := range pending {
+		if err := w.db.FinalizedUpdated(u.ev, u.time); err != nil {
+			w.log.Error("Failed to record finalized head in finality index", "finalized_l2", u.ev.FinalizedL2, "err", err)
+		}
+	}
+}
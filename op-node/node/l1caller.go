@@ -0,0 +1,72 @@
+package node
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+// l1ContractCaller calls the L1 contracts through the L1 RPC, for the contract bindings of the finality options.
+type l1ContractCaller struct {
+	rpc client.RPC
+}
+
+var _ finality.L1ContractCaller = (*l1ContractCaller)(nil)
+
+func (c *l1ContractCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code hexutil.Bytes
+	err := c.rpc.CallContext(ctx, &code, "eth_getCode", contract, toBlockNumArg(blockNumber))
+	return code, err
+}
+
+func (c *l1ContractCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var out hexutil.Bytes
+	err := c.rpc.CallContext(ctx, &out, "eth_call", toCallArg(call), toBlockNumArg(blockNumber))
+	return out, err
+}
+
+func (c *l1ContractCaller) CodeAtHash(ctx context.Context, contract common.Address, blockHash common.Hash) ([]byte, error) {
+	var code hexutil.Bytes
+	err := c.rpc.CallContext(ctx, &code, "eth_getCode", contract, rpc.BlockNumberOrHashWithHash(blockHash, false))
+	return code, err
+}
+
+func (c *l1ContractCaller) CallContractAtHash(ctx context.Context, call ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	var out hexutil.Bytes
+	err := c.rpc.CallContext(ctx, &out, "eth_call", toCallArg(call), rpc.BlockNumberOrHashWithHash(blockHash, false))
+	return out, err
+}
+
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	return hexutil.EncodeBig(number)
+}
+
+func toCallArg(msg ethereum.CallMsg) any {
+	arg := map[string]any{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["input"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	return arg
+}
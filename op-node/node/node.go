@@ -1,27 +1,36 @@
 package node
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"os/signal"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/heartbeat"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/finalitydb"
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/grpcapi"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	plasma "github.com/ethereum-optimism/optimism/op-plasma"
@@ -50,7 +59,10 @@ type OpNode struct {
 	l1SafeSub      ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 	l1FinalizedSub ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 
+	l1FinalizedEventsSub ethereum.Subscription // Subscription to get L1 finalized blocks from beacon events (optional)
+
 	l1Source  *sources.L1Client     // L1 Client to fetch data from
+	l1RPC     client.RPC            // L1 RPC, to call the L1 contracts through
 	l2Driver  *driver.Driver        // L2 Engine to Sync
 	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
 	server    *rpcServer            // RPC server hosting the rollup-node API
@@ -61,6 +73,57 @@ type OpNode struct {
 
 	safeDB closableSafeDB
 
+	// RPC of the primary rollup node to follow the finalized L2 head of, nil if disabled
+	finalityFollow client.RPC
+
+	// RPC of the replica L2 execution client to cross-validate the finalized L2 head with, nil if disabled
+	finalityReplica client.RPC
+
+	// RPC of the alternative L1 source of the shadow finalizer, and its subscription to the L1 finalized block, nil if disabled
+	finalityShadowL1    client.RPC
+	finalityShadowL1Sub ethereum.Subscription
+
+	// RPC of the local L1 light client to finalize with, and its adapter, nil if disabled
+	finalityLightClientRPC client.RPC
+	finalityLightClient    *finality.LightClientL1
+
+	// issues signed finalized-range receipts, nil if disabled
+	finalityReceipts      *finality.ReceiptIssuer
+	finalityReceiptsUnsub func()
+
+	// persists the finality state, nil if disabled
+	finalityPersister      *finality.StatePersister
+	finalityPersisterUnsub func()
+
+	// announces the finalized L2 head to the execution client, nil if disabled
+	finalityAnnouncer      *finality.FinalizedAnnouncer
+	finalityAnnouncerUnsub func()
+
+	// tracks the withdrawal roots of the finalized L2 heads, nil if disabled
+	finalityWithdrawals      *finality.WithdrawalRootTracker
+	finalityWithdrawalsUnsub func()
+
+	// delivers finalized events to external sinks, nil if disabled
+	finalityOutbox      *finality.Outbox
+	finalityOutboxUnsub func()
+	finalityEnricher    *finality.FinalizedEnricher
+
+	// reloads the finality tunables file on SIGHUP, nil if disabled.
+	// finalityReloadDone is closed once the reload routine exits.
+	finalityReloadSig  chan os.Signal
+	finalityReloadDone chan struct{}
+
+	// indexes the finalized L2 head advancements, nil if disabled
+	finalityIndex       *finalitydb.FinalityDB
+	finalityIndexWriter *finalitydb.IndexWriter
+	finalityIndexUnsub  func()
+
+	// serves the finality service over gRPC, nil if disabled
+	finalityGRPC *grpcapi.Server
+
+	// allows the admin API to roll back the finalized L2 head, for devnets only
+	finalityUnsafeRollback bool
+
 	rollupHalt string // when to halt the rollup, disabled if empty
 
 	pprofService *oppprof.Service
@@ -139,6 +202,26 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initP2P(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the P2P stack: %w", err)
 	}
+	if err := n.initFinalityReceipts(cfg); err != nil {
+		return fmt.Errorf("failed to init the finality receipts: %w", err)
+	}
+	if err := n.initFinalityStateStore(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init the finality state store: %w", err)
+	}
+	n.initFinalityAnnouncer(cfg)
+	n.initFinalityWithdrawalRoots(cfg)
+	if err := n.initFinalityOutbox(cfg); err != nil {
+		return fmt.Errorf("failed to init the finality outbox: %w", err)
+	}
+	if err := n.initFinalityIndex(cfg); err != nil {
+		return fmt.Errorf("failed to init the finality index: %w", err)
+	}
+	if err := n.initFinalityTunables(cfg); err != nil {
+		return fmt.Errorf("failed to init the finality tunables: %w", err)
+	}
+	if err := n.initFinalityGRPC(cfg); err != nil {
+		return fmt.Errorf("failed to init the finality gRPC server: %w", err)
+	}
 	// Only expose the server at the end, ensuring all RPC backend components are initialized.
 	if err := n.initRPCServer(cfg); err != nil {
 		return fmt.Errorf("failed to init the RPC server: %w", err)
@@ -173,8 +256,8 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	// Set the RethDB path in the EthClientConfig, if there is one configured.
 	rpcCfg.EthClientConfig.RethDBPath = cfg.RethDBPath
 
-	n.l1Source, err = sources.NewL1Client(
-		client.NewInstrumentedRPC(l1Node, &n.metrics.RPCMetrics.RPCClientMetrics), n.log, n.metrics.L1SourceCache, rpcCfg)
+	n.l1RPC = client.NewInstrumentedRPC(l1Node, &n.metrics.RPCMetrics.RPCClientMetrics)
+	n.l1Source, err = sources.NewL1Client(n.l1RPC, n.log, n.metrics.L1SourceCache, rpcCfg)
 	if err != nil {
 		return fmt.Errorf("failed to create L1 source: %w", err)
 	}
@@ -202,8 +285,38 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	// which only change once per epoch at most and may be delayed.
 	n.l1SafeSub = eth.PollBlockChanges(n.log, n.l1Source, n.OnNewL1Safe, eth.Safe,
 		cfg.L1EpochPollInterval, time.Second*10)
-	n.l1FinalizedSub = eth.PollBlockChanges(n.log, n.l1Source, n.OnNewL1Finalized, eth.Finalized,
-		cfg.L1EpochPollInterval, time.Second*10)
+	if cfg.FinalityLightClient != "" {
+		// the light client is the source of the L1 finality signal, instead of the L1 RPC
+		if err := n.initFinalityLightClient(ctx, cfg); err != nil {
+			return err
+		}
+		n.l1FinalizedSub = n.finalityLightClient.Subscribe(n.OnNewL1Finalized, cfg.L1EpochPollInterval)
+	} else {
+		n.l1FinalizedSub = eth.PollBlockChanges(n.log, n.l1Source, n.OnNewL1Finalized, eth.Finalized,
+			cfg.L1EpochPollInterval, time.Second*10)
+	}
+	// Optionally also get finalized blocks as soon as the beacon node finalizes a checkpoint.
+	if cfg.FinalityBeaconEvents != "" {
+		n.l1FinalizedEventsSub = sources.NewBeaconFinalitySource(n.log.New("source", "beacon_events"),
+			cfg.FinalityBeaconEvents, n.l1Source).Subscribe(n.OnNewL1Finalized)
+	}
+	return nil
+}
+
+// initFinalityLightClient connects to the local L1 light client to finalize with.
+func (n *OpNode) initFinalityLightClient(ctx context.Context, cfg *Config) error {
+	n.log.Info("Finalizing with L1 light client", "rpc", cfg.FinalityLightClient)
+	rpc, err := client.NewRPC(ctx, n.log, cfg.FinalityLightClient)
+	if err != nil {
+		return fmt.Errorf("failed to dial finality light client RPC: %w", err)
+	}
+	n.finalityLightClientRPC = rpc
+	// the light client verifies the L1 blocks it serves, and may only serve the most basic RPC methods
+	l1, err := sources.NewL1Client(rpc, n.log, nil, sources.L1ClientDefaultConfig(&cfg.Rollup, true, sources.RPCKindBasic))
+	if err != nil {
+		return fmt.Errorf("failed to create finality light client source: %w", err)
+	}
+	n.finalityLightClient = finality.NewLightClientL1(n.log.New("source", "light_client"), l1)
 	return nil
 }
 
@@ -401,10 +514,372 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 	} else {
 		n.safeDB = safedb.Disabled
 	}
+	var finalityFollow finality.FollowSource
+	if cfg.FinalityFollow != "" {
+		n.log.Info("Following finality of primary rollup node", "rpc", cfg.FinalityFollow)
+		followRPC, err := client.NewRPC(ctx, n.log, cfg.FinalityFollow)
+		if err != nil {
+			return fmt.Errorf("failed to dial finality follow RPC: %w", err)
+		}
+		n.finalityFollow = followRPC
+		finalityFollow = sources.NewRollupClient(followRPC)
+	}
+	var finalityReplica finality.L2BlockSource
+	if cfg.FinalityReplica != "" {
+		n.log.Info("Cross-validating finalized L2 blocks with replica", "rpc", cfg.FinalityReplica, "policy", cfg.Driver.Finality.ReplicaPolicy)
+		replicaRPC, err := client.NewRPC(ctx, n.log, cfg.FinalityReplica)
+		if err != nil {
+			return fmt.Errorf("failed to dial finality replica RPC: %w", err)
+		}
+		n.finalityReplica = replicaRPC
+		replica, err := sources.NewL2Client(replicaRPC, n.log, nil, sources.L2ClientDefaultConfig(&cfg.Rollup, false))
+		if err != nil {
+			return fmt.Errorf("failed to create finality replica client: %w", err)
+		}
+		finalityReplica = replica
+	}
+	var finalityDisputeGames finality.DisputeGameReader
+	if cfg.FinalityDisputeGameFactory != (common.Address{}) {
+		n.log.Info("Reading resolved dispute games for finality", "factory", cfg.FinalityDisputeGameFactory,
+			"game_type", cfg.FinalityDisputeGameType, "gate", cfg.Driver.Finality.DisputeGameGate, "settlement", cfg.Driver.Finality.Settlement)
+		games, err := finality.NewDisputeGameFactoryReader(cfg.FinalityDisputeGameFactory, cfg.FinalityDisputeGameType, &l1ContractCaller{rpc: n.l1RPC})
+		if err != nil {
+			return fmt.Errorf("failed to create finality dispute game reader: %w", err)
+		}
+		finalityDisputeGames = games
+	}
+	var finalityCommittee finality.CommitteeRegistry
+	var finalityAttestations finality.AttestationSource
+	if cfg.FinalityCommitteeRegistry != (common.Address{}) {
+		n.log.Info("Gating finality on committee attestations", "registry", cfg.FinalityCommitteeRegistry,
+			"attestations", cfg.FinalityCommitteeAttestations)
+		registry, err := finality.NewContractCommitteeRegistry(cfg.FinalityCommitteeRegistry, &l1ContractCaller{rpc: n.l1RPC})
+		if err != nil {
+			return fmt.Errorf("failed to create finality committee registry: %w", err)
+		}
+		finalityCommittee = registry
+		finalityAttestations = finality.NewHTTPAttestationSource(cfg.FinalityCommitteeAttestations, nil)
+	}
+	var finalityShadowL1 finality.FinalizerL1Interface
+	var shadowL1 *sources.L1Client
+	if cfg.FinalityShadowL1 != "" {
+		n.log.Info("Running shadow finalizer with alternative L1 source", "rpc", cfg.FinalityShadowL1)
+		shadowRPC, err := client.NewRPC(ctx, n.log, cfg.FinalityShadowL1)
+		if err != nil {
+			return fmt.Errorf("failed to dial finality shadow L1 RPC: %w", err)
+		}
+		n.finalityShadowL1 = shadowRPC
+		// the alternative L1 source is under evaluation, so it is not trusted
+		shadowL1, err = sources.NewL1Client(shadowRPC, n.log, nil, sources.L1ClientDefaultConfig(&cfg.Rollup, false, sources.RPCKindStandard))
+		if err != nil {
+			return fmt.Errorf("failed to create finality shadow L1 source: %w", err)
+		}
+		finalityShadowL1 = shadowL1
+	}
+	var finalityL1 finality.FinalizerL1Interface
+	if n.finalityLightClient != nil {
+		finalityL1 = n.finalityLightClient
+	}
+	n.initFinalityL1SlotsPerEpoch(ctx, cfg)
+	n.initFinalityBeaconEpochs(ctx, cfg)
+	cfg.Driver.Finality.Sources = driver.FinalitySources{
+		Follow:       finalityFollow,
+		Replica:      finalityReplica,
+		LightClient:  finalityL1,
+		DisputeGames: finalityDisputeGames,
+		ShadowL1:     finalityShadowL1,
+		Committee:    finalityCommittee,
+		Attestations: finalityAttestations,
+	}
 	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n.beacon, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, plasmaDA)
+	n.finalityUnsafeRollback = cfg.Driver.Finality.UnsafeRollback
+	if shadowL1 != nil {
+		n.finalityShadowL1Sub = eth.PollBlockChanges(n.log, shadowL1, n.OnNewShadowL1Finalized, eth.Finalized,
+			cfg.L1EpochPollInterval, time.Second*10)
+	}
 	return nil
 }
 
+// maxL1SlotsPerEpoch bounds the L1 slots per epoch of the beacon spec, to not oversize the finality lookback.
+const maxL1SlotsPerEpoch = 1024
+
+// initFinalityL1SlotsPerEpoch sizes the finality lookback to the L1 chain, if not configured,
+// with the slots per epoch of the L1 beacon spec. The mainnet lookback is used if the beacon spec is unavailable.
+func (n *OpNode) initFinalityL1SlotsPerEpoch(ctx context.Context, cfg *Config) {
+	if cfg.Driver.Finality.L1SlotsPerEpoch != 0 || n.beacon == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	slotsPerEpoch, err := n.beacon.GetSlotsPerEpoch(ctx)
+	if err != nil {
+		n.log.Warn("Failed to fetch L1 slots per epoch from beacon spec, using mainnet finality lookback", "err", err)
+		return
+	}
+	if slotsPerEpoch > maxL1SlotsPerEpoch {
+		n.log.Warn("Ignoring L1 slots per epoch of beacon spec, using mainnet finality lookback",
+			"slots_per_epoch", slotsPerEpoch, "max", maxL1SlotsPerEpoch)
+		return
+	}
+	n.log.Info("Sizing finality lookback to L1 beacon spec", "slots_per_epoch", slotsPerEpoch)
+	cfg.Driver.Finality.L1SlotsPerEpoch = slotsPerEpoch
+}
+
+// initFinalityBeaconEpochs configures the slot clock of the L1 beacon chain, to align the attempts to finalize
+// to beacon epochs, if enabled. The finalityDelay is used if the beacon spec is unavailable.
+func (n *OpNode) initFinalityBeaconEpochs(ctx context.Context, cfg *Config) {
+	if !cfg.Driver.Finality.BeaconEpochs || cfg.Driver.Finality.BeaconSecondsPerSlot != 0 {
+		return
+	}
+	if n.beacon == nil {
+		n.log.Warn("Aligning finality to beacon epochs requires the L1 beacon API, using the finality delay")
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	genesisTime, secondsPerSlot, err := n.beacon.GetSlotClock(ctx)
+	if err != nil {
+		n.log.Warn("Failed to fetch L1 slot clock from beacon spec, using the finality delay", "err", err)
+		return
+	}
+	n.log.Info("Aligning finality to L1 beacon epochs", "genesis_time", genesisTime, "seconds_per_slot", secondsPerSlot)
+	cfg.Driver.Finality.BeaconGenesisTime = genesisTime
+	cfg.Driver.Finality.BeaconSecondsPerSlot = secondsPerSlot
+}
+
+// finalityReceiptsRetained is the number of most recent finalized-range receipts served by the RPC.
+const finalityReceiptsRetained = 1000
+
+func (n *OpNode) initFinalityReceipts(cfg *Config) error {
+	if !cfg.FinalityReceipts {
+		return nil
+	}
+	if n.p2pSigner == nil {
+		return errors.New("finality receipts are enabled, but no P2P signer is configured to sign them with")
+	}
+	n.finalityReceipts = finality.NewReceiptIssuer(n.log.New("module", "finality_receipts"),
+		cfg.Rollup.L2ChainID, n.l2Source, n.p2pSigner, finalityReceiptsRetained)
+	n.finalityReceiptsUnsub = n.l2Driver.FinalityQueries.SubscribeFinalized(n.finalityReceipts.OnFinalized)
+	n.finalityReceipts.Start()
+	n.log.Info("Finality receipts enabled")
+	return nil
+}
+
+// initFinalityStateStore restores the finality state from the store, if any was persisted,
+// and persists the finality state to the store from then on.
+// A snapshot that cannot be loaded does not prevent startup, finality only takes longer to catch up without it.
+func (n *OpNode) initFinalityStateStore(ctx context.Context, cfg *Config) error {
+	if cfg.FinalityStateStore == "" {
+		return nil
+	}
+	store, err := finality.NewFinalityStateStore(cfg.FinalityStateStore)
+	if err != nil {
+		return err
+	}
+	loadCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	snapshot, err := store.LoadSnapshot(loadCtx)
+	cancel()
+	if errors.Is(err, finality.ErrNoSnapshot) {
+		n.log.Info("No finality snapshot stored yet")
+	} else if err != nil {
+		n.log.Warn("Failed to load finality snapshot, starting without it", "err", err)
+	} else if err := n.l2Driver.FinalityAdmin.Restore(snapshot); err != nil {
+		return fmt.Errorf("failed to restore finality snapshot: %w", err)
+	}
+	n.finalityPersister = finality.NewStatePersister(n.log.New("module", "finality_store"), n.l2Driver.FinalityQueries, store)
+	n.finalityPersisterUnsub = n.l2Driver.FinalityQueries.SubscribeFinalized(n.finalityPersister.OnFinalized)
+	n.finalityPersister.Start()
+	n.log.Info("Finality state store enabled")
+	return nil
+}
+
+// initFinalityAnnouncer announces the finalized L2 head, with its L1 origin, to the execution client,
+// if the execution client supports the Engine API extension.
+func (n *OpNode) initFinalityAnnouncer(cfg *Config) {
+	if !cfg.FinalityEngineAnnounce {
+		return
+	}
+	n.finalityAnnouncer = finality.NewFinalizedAnnouncer(n.log.New("module", "finality_announcer"), n.l2Source)
+	n.finalityAnnouncerUnsub = n.l2Driver.FinalityQueries.SubscribeFinalized(n.finalityAnnouncer.OnFinalized)
+	n.finalityAnnouncer.Start()
+	n.log.Info("Finalized head announcements to the execution client enabled")
+}
+
+// finalityWithdrawalRootsRetained is the number of most recent finalized withdrawal roots served by the RPC.
+const finalityWithdrawalRootsRetained = 1000
+
+// initFinalityWithdrawalRoots tracks the withdrawal root of every finalized L2 head,
+// which is then included in the finalized events of the outbox and the finalized subscriptions.
+func (n *OpNode) initFinalityWithdrawalRoots(cfg *Config) {
+	if !cfg.FinalityWithdrawalRoots {
+		return
+	}
+	n.finalityWithdrawals = finality.NewWithdrawalRootTracker(n.log.New("module", "finality_withdrawals"),
+		n.l2Source, finalityWithdrawalRootsRetained)
+	n.finalityWithdrawalsUnsub = n.l2Driver.FinalityQueries.SubscribeFinalized(n.finalityWithdrawals.OnFinalized)
+	n.finalityWithdrawals.Start()
+	n.log.Info("Finalized withdrawal root tracking enabled")
+}
+
+// finalizedEvents returns the source of the finalized events for external consumers:
+// the withdrawal root tracker if enabled, so the events include the withdrawal root, and the Finalizer otherwise.
+func (n *OpNode) finalizedEvents() finalizedSubscriptions {
+	if n.finalityWithdrawals != nil {
+		return n.finalityWithdrawals
+	}
+	return n.l2Driver.FinalityQueries
+}
+
+// initFinalityOutbox delivers the finalized events to the configured external sinks, at least once,
+// including the events that were not delivered yet before the last shutdown.
+func (n *OpNode) initFinalityOutbox(cfg *Config) error {
+	if cfg.FinalityOutbox == "" {
+		return nil
+	}
+	var sinks []finality.Sink
+	for _, sinkURL := range cfg.FinalityOutboxSinks {
+		sink, err := finality.NewSink(sinkURL)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, sink)
+	}
+	outbox, err := finality.NewOutbox(n.log.New("module", "finality_outbox"), cfg.FinalityOutbox, sinks, n.metrics)
+	if err != nil {
+		return err
+	}
+	n.finalityOutbox = outbox
+	onFinalized := n.finalityOutbox.OnFinalized
+	if cfg.FinalityOutboxEnriched {
+		n.finalityEnricher = finality.NewFinalizedEnricher(n.log.New("module", "finality_enricher"), n.l2Source, onFinalized)
+		onFinalized = n.finalityEnricher.OnFinalized
+	}
+	n.finalityOutboxUnsub = n.finalizedEvents().SubscribeFinalized(onFinalized)
+	n.finalityOutbox.Start()
+	if n.finalityEnricher != nil {
+		n.finalityEnricher.Start()
+	}
+	n.log.Info("Finality outbox enabled", "sinks", len(sinks), "enriched", cfg.FinalityOutboxEnriched)
+	return nil
+}
+
+// initFinalityIndex records every advancement of the finalized L2 head into the finality index database,
+// to serve when and from which L1 block historical L2 blocks were finalized.
+func (n *OpNode) initFinalityIndex(cfg *Config) error {
+	if cfg.FinalityIndexPath == "" {
+		return nil
+	}
+	db, err := finalitydb.NewFinalityDB(n.log, cfg.FinalityIndexPath)
+	if err != nil {
+		return fmt.Errorf("failed to create finality index database at %v: %w", cfg.FinalityIndexPath, err)
+	}
+	n.finalityIndex = db
+	n.finalityIndexWriter = finalitydb.NewIndexWriter(n.log.New("module", "finality_index"), db)
+	n.finalityIndexUnsub = n.l2Driver.FinalityQueries.SubscribeFinalized(n.finalityIndexWriter.OnFinalized)
+	n.finalityIndexWriter.Start()
+	n.log.Info("Finality index enabled", "path", cfg.FinalityIndexPath)
+	return nil
+}
+
+// initFinalityGRPC serves the finalized events, and the finality status and derived-from queries, over gRPC,
+// for consumers that are not written in Go.
+func (n *OpNode) initFinalityGRPC(cfg *Config) error {
+	if cfg.FinalityGRPCAddr == "" {
+		return nil
+	}
+	server := grpcapi.NewServer(n.log.New("module", "finality_grpc"), n.finalizedEvents(), n.l2Driver)
+	if err := server.Start(cfg.FinalityGRPCAddr); err != nil {
+		return err
+	}
+	n.finalityGRPC = server
+	n.log.Info("Finality gRPC server started", "addr", server.Addr())
+	return nil
+}
+
+// initFinalityTunables applies the finality tunables file, and reloads it on SIGHUP,
+// so finality can be re-tuned without a restart, which would drop the buffered finality data.
+func (n *OpNode) initFinalityTunables(cfg *Config) error {
+	if cfg.FinalityTunablesFile == "" {
+		return nil
+	}
+	if err := n.reloadFinalityTunablesFile(cfg.FinalityTunablesFile, finality.TunablesTriggerStartup); err != nil {
+		return err
+	}
+	n.finalityReloadSig = make(chan os.Signal, 1)
+	n.finalityReloadDone = make(chan struct{})
+	signal.Notify(n.finalityReloadSig, syscall.SIGHUP)
+	go func() {
+		defer close(n.finalityReloadDone)
+		for range n.finalityReloadSig {
+			if err := n.reloadFinalityTunablesFile(cfg.FinalityTunablesFile, finality.TunablesTriggerSignal); err != nil {
+				n.log.Error("Failed to reload finality tunables, keeping the current tunables", "err", err)
+			}
+		}
+	}()
+	n.log.Info("Finality tunables reload on SIGHUP enabled", "path", cfg.FinalityTunablesFile)
+	return nil
+}
+
+// stopFinalityTunablesReload stops reloading the finality tunables file on SIGHUP,
+// and waits for the reload routine to exit.
+func (n *OpNode) stopFinalityTunablesReload() {
+	if n.finalityReloadSig == nil {
+		return
+	}
+	signal.Stop(n.finalityReloadSig)
+	close(n.finalityReloadSig)
+	<-n.finalityReloadDone
+	n.finalityReloadSig = nil
+}
+
+// reloadFinalityTunablesFile reads the finality tunables file, and applies it with reloadFinalityTunables.
+func (n *OpNode) reloadFinalityTunablesFile(path string, trigger string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read finality tunables file: %w", err)
+	}
+	var update finality.FinalityTunablesUpdate
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&update); err != nil {
+		return fmt.Errorf("failed to decode finality tunables file: %w", err)
+	}
+	if _, err := n.reloadFinalityTunables(update, trigger); err != nil {
+		return fmt.Errorf("failed to apply finality tunables: %w", err)
+	}
+	return nil
+}
+
+// reloadFinalityTunables applies the update of the finality tunables, which the finalizer validates first.
+// Both the admin API and the tunables file reload through it. Tunables that are omitted are left as they are.
+func (n *OpNode) reloadFinalityTunables(update finality.FinalityTunablesUpdate, trigger string) (finality.FinalityTunables, error) {
+	return n.l2Driver.FinalityAdmin.ReloadTunables(update, trigger)
+}
+
+// ResumeFinality resumes finalization after it was halted by repeated canonical-chain mismatches.
+func (n *OpNode) ResumeFinality(ctx context.Context) error {
+	return n.l2Driver.FinalityAdmin.ResumeFinality()
+}
+
+// ReloadFinalityTunables applies the update to the finality tunables without restarting, and returns the resulting tunables.
+func (n *OpNode) ReloadFinalityTunables(ctx context.Context, update finality.FinalityTunablesUpdate) (finality.FinalityTunables, error) {
+	return n.reloadFinalityTunables(update, finality.TunablesTriggerRPC)
+}
+
+// UnsafeRollbackFinalized rewinds the finalized L2 head to the given older L2 block,
+// for devnet chaos testing and recovery drills. It requires the unsafe rollback to be enabled.
+// The rollback updates the engine, so the driver event loop is blocked while it runs.
+func (n *OpNode) UnsafeRollbackFinalized(ctx context.Context, target eth.L2BlockRef) error {
+	if !n.finalityUnsafeRollback {
+		return errors.New("unsafe rollback of the finalized head is not enabled")
+	}
+	return n.l2Driver.Synchronized(ctx, func() error {
+		ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+		defer cancel()
+		return n.l2Driver.FinalityAdmin.UnsafeRollbackFinalized(ctx, target)
+	})
+}
+
 func (n *OpNode) initRPCServer(cfg *Config) error {
 	server, err := newRPCServer(&cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.safeDB, n.log, n.appVersion, n.metrics)
 	if err != nil {
@@ -413,8 +888,18 @@ func (n *OpNode) initRPCServer(cfg *Config) error {
 	if n.p2pNode != nil {
 		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
 	}
+	server.EnableFinalitySubscriptions(NewFinalitySubscriptionAPI(n.finalizedEvents(), n.log, n.metrics))
+	if n.finalityReceipts != nil {
+		server.EnableFinalityReceipts(NewFinalityReceiptsAPI(n.finalityReceipts, n.metrics))
+	}
+	if n.finalityIndex != nil {
+		server.EnableFinalityIndex(NewFinalityIndexAPI(n.finalityIndex, n.metrics))
+	}
+	if n.finalityWithdrawals != nil {
+		server.EnableFinalityWithdrawalRoots(NewFinalityWithdrawalRootsAPI(n.finalityWithdrawals, n.metrics))
+	}
 	if cfg.RPC.EnableAdmin {
-		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics, n.log))
+		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n, n.metrics, n.log))
 		n.log.Info("Admin RPC enabled")
 	}
 	n.log.Info("Starting JSON-RPC server")
@@ -475,6 +960,9 @@ func (n *OpNode) initPProf(cfg *Config) error {
 		cfg.Pprof.ProfileDir,
 		cfg.Pprof.ProfileFilename,
 	)
+	if n.l2Driver != nil {
+		n.pprofService.Handle("/debug/finality", finalityDebugHandler(n.l2Driver.FinalityQueries))
+	}
 
 	if err := n.pprofService.Start(); err != nil {
 		return fmt.Errorf("failed to start pprof service: %w", err)
@@ -558,6 +1046,32 @@ func (n *OpNode) OnNewL1Finalized(ctx context.Context, sig eth.L1BlockRef) {
 	}
 }
 
+// OnNewShadowL1Finalized passes on a new L1 finalized block of the alternative L1 source to the shadow finalizer.
+func (n *OpNode) OnNewShadowL1Finalized(ctx context.Context, sig eth.L1BlockRef) {
+	if n.l2Driver == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	if err := n.l2Driver.OnShadowL1Finalized(ctx, sig); err != nil {
+		n.log.Warn("failed to notify engine driver of shadow L1 finalized block change", "err", err)
+	}
+}
+
+// OnNewL1FinalizedHash passes on a new L1 finalized block that is only identified by its hash,
+// as some light-client sources provide.
+func (n *OpNode) OnNewL1FinalizedHash(ctx context.Context, hash common.Hash) {
+	if n.l2Driver == nil {
+		return
+	}
+	// Pass on the event to the L2 Engine
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	if err := n.l2Driver.OnL1FinalizedHash(ctx, hash); err != nil {
+		n.log.Warn("failed to notify engine driver of L1 finalized block change", "err", err)
+	}
+}
+
 func (n *OpNode) PublishL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
 	n.tracer.OnPublishL2Payload(ctx, envelope)
 
@@ -634,6 +1148,11 @@ func (n *OpNode) Stop(ctx context.Context) error {
 			result = multierror.Append(result, fmt.Errorf("failed to close RPC server: %w", err))
 		}
 	}
+	if n.finalityGRPC != nil {
+		if err := n.finalityGRPC.Stop(ctx); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close finality gRPC server: %w", err))
+		}
+	}
 	if n.p2pNode != nil {
 		if err := n.p2pNode.Close(); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close p2p node: %w", err))
@@ -661,6 +1180,41 @@ func (n *OpNode) Stop(ctx context.Context) error {
 	if n.l1FinalizedSub != nil {
 		n.l1FinalizedSub.Unsubscribe()
 	}
+	if n.l1FinalizedEventsSub != nil {
+		n.l1FinalizedEventsSub.Unsubscribe()
+	}
+	if n.finalityShadowL1Sub != nil {
+		n.finalityShadowL1Sub.Unsubscribe()
+	}
+
+	if n.finalityReceipts != nil {
+		n.finalityReceiptsUnsub()
+		n.finalityReceipts.Close()
+	}
+	if n.finalityPersister != nil {
+		n.finalityPersisterUnsub()
+		n.finalityPersister.Close()
+	}
+	if n.finalityAnnouncer != nil {
+		n.finalityAnnouncerUnsub()
+		n.finalityAnnouncer.Close()
+	}
+	if n.finalityWithdrawals != nil {
+		n.finalityWithdrawalsUnsub()
+		n.finalityWithdrawals.Close()
+	}
+	if n.finalityOutbox != nil {
+		n.finalityOutboxUnsub()
+		if n.finalityEnricher != nil {
+			n.finalityEnricher.Close()
+		}
+		n.finalityOutbox.Close()
+	}
+	if n.finalityIndex != nil {
+		n.finalityIndexUnsub()
+		n.finalityIndexWriter.Close()
+	}
+	n.stopFinalityTunablesReload()
 
 	// close L2 driver
 	if n.l2Driver != nil {
@@ -674,6 +1228,11 @@ func (n *OpNode) Stop(ctx context.Context) error {
 			result = multierror.Append(result, fmt.Errorf("failed to close safe head db: %w", err))
 		}
 	}
+	if n.finalityIndex != nil {
+		if err := n.finalityIndex.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close finality index db: %w", err))
+		}
+	}
 
 	// Wait for the runtime config loader to be done using the data sources before closing them
 	if n.runtimeConfigReloaderDone != nil {
@@ -690,6 +1249,20 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		n.l1Source.Close()
 	}
 
+	// close RPC of the primary rollup node that finality is followed from
+	if n.finalityFollow != nil {
+		n.finalityFollow.Close()
+	}
+	if n.finalityReplica != nil {
+		n.finalityReplica.Close()
+	}
+	if n.finalityShadowL1 != nil {
+		n.finalityShadowL1.Close()
+	}
+	if n.finalityLightClientRPC != nil {
+		n.finalityLightClientRPC.Close()
+	}
+
 	if result == nil { // mark as closed if we successfully fully closed
 		n.closed.Store(true)
 	}
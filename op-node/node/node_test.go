@@ -1,13 +1,71 @@
 package node
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 func TestUnixTimeStale(t *testing.T) {
 	require.True(t, unixTimeStale(1_600_000_000, 1*time.Hour))
 	require.False(t, unixTimeStale(uint64(time.Now().Unix()), 1*time.Hour))
 }
+
+type fakeFinalityAdmin struct {
+	finality.Admin
+	triggers []string
+	tunables finality.FinalityTunables
+}
+
+func (f *fakeFinalityAdmin) ReloadTunables(update finality.FinalityTunablesUpdate, trigger string) (finality.FinalityTunables, error) {
+	if err := update.Check(); err != nil {
+		return f.tunables, err
+	}
+	f.triggers = append(f.triggers, trigger)
+	f.tunables = update.Apply(f.tunables)
+	return f.tunables, nil
+}
+
+func TestFinalityTunablesReload(t *testing.T) {
+	admin := &fakeFinalityAdmin{tunables: finality.FinalityTunables{FinalityDelay: 64}}
+	n := &OpNode{log: testlog.Logger(t, log.LevelInfo), l2Driver: &driver.Driver{FinalityAdmin: admin}}
+	path := filepath.Join(t.TempDir(), "tunables.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"extra_confirmations": 2}`), 0o600))
+	require.NoError(t, n.initFinalityTunables(&Config{FinalityTunablesFile: path}))
+	require.Equal(t, uint64(2), admin.tunables.ExtraConfirmations)
+
+	delay := uint64(32)
+	tunables, err := n.ReloadFinalityTunables(context.Background(), finality.FinalityTunablesUpdate{FinalityDelay: &delay})
+	require.NoError(t, err)
+	require.Equal(t, uint64(32), tunables.FinalityDelay)
+	require.Equal(t, []string{finality.TunablesTriggerStartup, finality.TunablesTriggerRPC}, admin.triggers)
+
+	zero := uint64(0)
+	_, err = n.ReloadFinalityTunables(context.Background(), finality.FinalityTunablesUpdate{FinalityDelay: &zero})
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"finality_delay": 16, "unknown": 1}`), 0o600))
+	require.Error(t, n.reloadFinalityTunablesFile(path, finality.TunablesTriggerSignal), "unknown fields are refused")
+	require.Equal(t, uint64(32), admin.tunables.FinalityDelay)
+
+	done := n.finalityReloadDone
+	n.stopFinalityTunablesReload()
+	require.Nil(t, n.finalityReloadSig)
+	select {
+	case <-done:
+	default:
+		t.Fatal("expected the reload routine to exit")
+	}
+	n.stopFinalityTunablesReload() // stopping again is a no-op
+}
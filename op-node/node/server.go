@@ -2,10 +2,12 @@ package node
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 
 	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum/go-ethereum/log"
@@ -15,6 +17,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
@@ -53,6 +56,42 @@ func (s *rpcServer) EnableAdminAPI(api *adminAPI) {
 	})
 }
 
+func (s *rpcServer) EnableFinalityReceipts(api *finalityReceiptsAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "optimism",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
+func (s *rpcServer) EnableFinalityIndex(api *finalityIndexAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "optimism",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
+func (s *rpcServer) EnableFinalityWithdrawalRoots(api *finalityWithdrawalRootsAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "optimism",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
+func (s *rpcServer) EnableFinalitySubscriptions(api *finalitySubscriptionAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "optimism",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
 func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	s.apis = append(s.apis, rpc.API{
 		Namespace:     p2p.NamespaceRPC,
@@ -73,9 +112,17 @@ func (s *rpcServer) Start() error {
 	// defaults to localhost, which will prevent containers from
 	// calling into the opnode without an "invalid host" error.
 	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, nil)
+	// WebSocket connections are served on the same endpoint, for subscriptions.
+	wsHandler := node.NewWSHandlerStack(srv.WebsocketHandler([]string{"*"}), nil)
 
 	mux := http.NewServeMux()
-	mux.Handle("/", nodeHandler)
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebsocket(r) {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+		nodeHandler.ServeHTTP(w, r)
+	}))
 	mux.HandleFunc("/healthz", healthzHandler(s.appVersion))
 
 	hs, err := ophttp.StartHTTPServer(s.endpoint, mux)
@@ -94,6 +141,26 @@ func (r *rpcServer) Addr() net.Addr {
 	return r.httpServer.Addr()
 }
 
+// isWebsocket checks the header of an http request for a websocket upgrade request.
+func isWebsocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
+type finalityDebugSource interface {
+	DebugBundle() *finality.DebugBundle
+}
+
+// finalityDebugHandler serves the full finality debug bundle as JSON.
+func finalityDebugHandler(src finalityDebugSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(src.DebugBundle()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
 func healthzHandler(appVersion string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(appVersion))
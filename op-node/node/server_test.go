@@ -4,18 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -200,6 +206,550 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestFinalityStatus(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rng := rand.New(rand.NewSource(1234))
+	status := &finality.FinalityStatus{
+		FinalizedL1: testutils.RandomBlockRef(rng),
+		FinalizedL2: testutils.RandomL2BlockRef(rng),
+		LastReason:  finality.ReasonSignalOlderThanBuffer,
+	}
+	drClient.On("FinalityStatus").Return(status)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out *finality.FinalityStatus
+	err = client.CallContext(context.Background(), &out, "optimism_finalityStatus")
+	assert.NoError(t, err)
+	assert.Equal(t, status, out)
+
+	out = nil
+	err = client.CallContext(context.Background(), &out, "optimism_finalityStatus", hexutil.Uint64(finality.LatestStatusSchema))
+	assert.NoError(t, err)
+	assert.Equal(t, status, out)
+
+	err = client.CallContext(context.Background(), &out, "optimism_finalityStatus", hexutil.Uint64(finality.LatestStatusSchema+1))
+	assert.ErrorContains(t, err, finality.ErrUnsupportedStatusSchema.Error())
+}
+
+func TestFinalizedAtTime(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rng := rand.New(rand.NewSource(1234))
+	ref := testutils.RandomL2BlockRef(rng)
+	var noErr error
+	drClient.On("FinalizedAtTime", ref.Time).Return(ref, &noErr)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out eth.L2BlockRef
+	err = client.CallContext(context.Background(), &out, "optimism_finalizedAtTime", hexutil.Uint64(ref.Time))
+	assert.NoError(t, err)
+	assert.Equal(t, ref, out)
+}
+
+func TestFinalityDerivedFrom(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rng := rand.New(rand.NewSource(1234))
+	l1 := testutils.RandomBlockRef(rng).ID()
+	var noErr error
+	drClient.On("FinalityDerivedFrom", uint64(42)).Return(l1, &noErr)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out eth.BlockID
+	err = client.CallContext(context.Background(), &out, "optimism_finalityDerivedFrom", hexutil.Uint64(42))
+	assert.NoError(t, err)
+	assert.Equal(t, l1, out)
+}
+
+func TestFinalizedAtLeast(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	var noErr error
+	drClient.On("FinalizedAtLeast", uint64(42)).Return(true, &noErr)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out bool
+	err = client.CallContext(context.Background(), &out, "optimism_finalizedAtLeast", hexutil.Uint64(42))
+	assert.NoError(t, err)
+	assert.True(t, out)
+}
+
+func TestEstimateFinality(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rng := rand.New(rand.NewSource(1234))
+	estimate := finality.FinalityEstimate{
+		L2Block:           42,
+		DerivedFrom:       testutils.RandomBlockRef(rng).ID(),
+		RemainingL1Blocks: 64,
+		EstimatedAt:       1000 + 768,
+		RemainingSeconds:  768,
+	}
+	var noErr error
+	drClient.On("EstimateFinality", uint64(42)).Return(estimate, &noErr)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out finality.FinalityEstimate
+	err = client.CallContext(context.Background(), &out, "optimism_estimateFinality", hexutil.Uint64(42))
+	assert.NoError(t, err)
+	assert.Equal(t, estimate, out)
+}
+
+func TestFinalityAudit(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rng := rand.New(rand.NewSource(1234))
+	trail := []finality.FinalizedHeadUpdate{
+		{
+			Time:   time.Unix(1000, 0).UTC(),
+			Prev:   testutils.RandomL2BlockRef(rng),
+			Next:   testutils.RandomL2BlockRef(rng),
+			Source: finality.AuditSourceFinalize,
+		},
+		{
+			Time:    time.Unix(1010, 0).UTC(),
+			Prev:    testutils.RandomL2BlockRef(rng),
+			Next:    testutils.RandomL2BlockRef(rng),
+			Source:  finality.AuditSourceRepair,
+			Refused: "lower block number",
+		},
+	}
+	drClient.On("FinalityAudit").Return(trail)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out []finality.FinalizedHeadUpdate
+	err = client.CallContext(context.Background(), &out, "optimism_finalityAudit")
+	assert.NoError(t, err)
+	assert.Equal(t, trail, out)
+}
+
+func TestFinalityCasualties(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rng := rand.New(rand.NewSource(1234))
+	casualties := []finality.PruningCasualty{
+		{
+			Time:        time.Unix(1000, 0).UTC(),
+			L1Block:     testutils.RandomBlockID(rng),
+			L2Block:     testutils.RandomBlockID(rng),
+			L2Blocks:    6,
+			FinalizedL1: testutils.RandomBlockID(rng),
+			Lookback:    129,
+		},
+	}
+	drClient.On("FinalityCasualties").Return(casualties)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out []finality.PruningCasualty
+	err = client.CallContext(context.Background(), &out, "optimism_finalityCasualties")
+	assert.NoError(t, err)
+	assert.Equal(t, casualties, out)
+}
+
+func TestFinalitySnapshot(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rng := rand.New(rand.NewSource(1234))
+	snapshot := &finality.Snapshot{
+		FinalizedL1: testutils.RandomBlockRef(rng),
+		FinalityData: []finality.FinalityData{
+			{
+				L2Block:     testutils.RandomL2BlockRef(rng),
+				L1Block:     testutils.RandomBlockID(rng),
+				BatchTxs:    []common.Hash{testutils.RandomHash(rng)},
+				BlobIndices: []uint64{1, 2},
+			},
+			{
+				L2Block: testutils.RandomL2BlockRef(rng),
+				L1Block: testutils.RandomBlockID(rng),
+			},
+		},
+	}
+	drClient.On("FinalitySnapshot").Return(snapshot)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out *finality.Snapshot
+	err = client.CallContext(context.Background(), &out, "optimism_finalitySnapshot")
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot, out)
+}
+
+type fakeReceipts []finality.FinalizedRangeReceipt
+
+func (f fakeReceipts) Receipts(fromL2 uint64) (out []finality.FinalizedRangeReceipt) {
+	for _, r := range f {
+		if r.Range.FinalizedL2.Number >= fromL2 {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func TestFinalizedRangeReceipts(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rng := rand.New(rand.NewSource(1234))
+	receipts := fakeReceipts{
+		{
+			Range: finality.FinalizedRange{
+				PrevFinalizedL2: eth.BlockID{Hash: testutils.RandomHash(rng), Number: 10},
+				FinalizedL2:     eth.BlockID{Hash: testutils.RandomHash(rng), Number: 20},
+				OutputRoot:      eth.Bytes32(testutils.RandomHash(rng)),
+				FinalizedL1:     testutils.RandomBlockID(rng),
+			},
+			Signature: testutils.RandomData(rng, 65),
+		},
+		{
+			Range: finality.FinalizedRange{
+				PrevFinalizedL2: eth.BlockID{Hash: testutils.RandomHash(rng), Number: 20},
+				FinalizedL2:     eth.BlockID{Hash: testutils.RandomHash(rng), Number: 30},
+				OutputRoot:      eth.Bytes32(testutils.RandomHash(rng)),
+				FinalizedL1:     testutils.RandomBlockID(rng),
+			},
+			Signature: testutils.RandomData(rng, 65),
+		},
+	}
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	server.EnableFinalityReceipts(NewFinalityReceiptsAPI(receipts, metrics.NoopMetrics))
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out []finality.FinalizedRangeReceipt
+	err = client.CallContext(context.Background(), &out, "optimism_finalizedRangeReceipts", hexutil.Uint64(25))
+	assert.NoError(t, err)
+	assert.Equal(t, []finality.FinalizedRangeReceipt(receipts[1:]), out)
+
+	// the regular node API is still served next to it
+	drClient.On("FinalityStatus").Return(&finality.FinalityStatus{})
+	var status *finality.FinalityStatus
+	assert.NoError(t, client.CallContext(context.Background(), &status, "optimism_finalityStatus"))
+}
+
+type fakeWithdrawalRoots []finality.FinalizedWithdrawalRoot
+
+func (f fakeWithdrawalRoots) WithdrawalRoots(fromL2 uint64) (out []finality.FinalizedWithdrawalRoot) {
+	for _, r := range f {
+		if r.L2Block.Number >= fromL2 {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func TestFinalizedWithdrawalRoots(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rng := rand.New(rand.NewSource(1234))
+	roots := fakeWithdrawalRoots{
+		{
+			L2Block:        eth.BlockID{Hash: testutils.RandomHash(rng), Number: 20},
+			WithdrawalRoot: eth.Bytes32(testutils.RandomHash(rng)),
+			OutputRoot:     eth.Bytes32(testutils.RandomHash(rng)),
+			FinalizedL1:    testutils.RandomBlockID(rng),
+		},
+		{
+			L2Block:        eth.BlockID{Hash: testutils.RandomHash(rng), Number: 30},
+			WithdrawalRoot: eth.Bytes32(testutils.RandomHash(rng)),
+			OutputRoot:     eth.Bytes32(testutils.RandomHash(rng)),
+			FinalizedL1:    testutils.RandomBlockID(rng),
+		},
+	}
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	server.EnableFinalityWithdrawalRoots(NewFinalityWithdrawalRootsAPI(roots, metrics.NoopMetrics))
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out []finality.FinalizedWithdrawalRoot
+	err = client.CallContext(context.Background(), &out, "optimism_finalizedWithdrawalRoots", hexutil.Uint64(25))
+	assert.NoError(t, err)
+	assert.Equal(t, []finality.FinalizedWithdrawalRoot(roots[1:]), out)
+}
+
+type fakeFinalizedSubscriptions struct {
+	mu   sync.Mutex
+	subs map[int]finality.FinalizedSubscriber
+	next int
+}
+
+func (f *fakeFinalizedSubscriptions) SubscribeFinalized(fn finality.FinalizedSubscriber) func() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := f.next
+	f.next += 1
+	f.subs[id] = fn
+	return func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.subs, id)
+	}
+}
+
+func (f *fakeFinalizedSubscriptions) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subs)
+}
+
+func (f *fakeFinalizedSubscriptions) emit(ev finality.FinalizedEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, fn := range f.subs {
+		fn(ev)
+	}
+}
+
+func TestFinalizedSubscription(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	rng := rand.New(rand.NewSource(1234))
+	finalized := &fakeFinalizedSubscriptions{subs: make(map[int]finality.FinalizedSubscriber)}
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, &mockDriverClient{}, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableFinalitySubscriptions(NewFinalitySubscriptionAPI(finalized, log, metrics.NoopMetrics))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := gethrpc.DialWebsocket(context.Background(), "ws://"+server.Addr().String(), "")
+	require.NoError(t, err)
+	defer client.Close()
+
+	ch := make(chan finality.FinalizedEvent, 10)
+	sub, err := client.Subscribe(context.Background(), "optimism", ch, "finalized")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return finalized.count() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	ev := finality.FinalizedEvent{
+		PrevFinalizedL2: testutils.RandomL2BlockRef(rng),
+		FinalizedL2:     testutils.RandomL2BlockRef(rng),
+		FinalizedL1:     testutils.RandomBlockRef(rng),
+		DerivedFrom:     []eth.BlockID{testutils.RandomBlockID(rng)},
+	}
+	finalized.emit(ev)
+	select {
+	case got := <-ch:
+		require.Equal(t, ev, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for finalized notification")
+	}
+
+	// the subscription of the finalizer is released when the RPC subscription ends
+	sub.Unsubscribe()
+	require.Eventually(t, func() bool { return finalized.count() == 0 }, 5*time.Second, 10*time.Millisecond)
+
+	// subscriptions are not supported over HTTP
+	httpClient, err := gethrpc.Dial("http://" + server.Addr().String())
+	require.NoError(t, err)
+	defer httpClient.Close()
+	_, err = httpClient.Subscribe(context.Background(), "optimism", ch, "finalized")
+	require.Error(t, err)
+}
+
+type fakeFinalityDebug finality.DebugBundle
+
+func (f *fakeFinalityDebug) DebugBundle() *finality.DebugBundle {
+	return (*finality.DebugBundle)(f)
+}
+
+func TestFinalityDebugHandler(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	bundle := &finality.DebugBundle{
+		Status:           finality.FinalityStatus{FinalizedL1: testutils.RandomBlockRef(rng), LastReason: finality.ReasonFinalized},
+		Counters:         finality.FinalityCounters{Attempts: 3, EntriesPruned: 1},
+		Snapshot:         &finality.Snapshot{FinalizedL1: testutils.RandomBlockRef(rng), FinalityData: []finality.FinalityData{}},
+		FinalityLookback: 129,
+	}
+	rec := httptest.NewRecorder()
+	finalityDebugHandler((*fakeFinalityDebug)(bundle)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/finality", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var out finality.DebugBundle
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	require.Equal(t, bundle, &out)
+}
+
 func TestSafeHeadAtL1Block(t *testing.T) {
 	log := testlog.Logger(t, log.LevelError)
 	l2Client := &testutils.MockL2Client{}
@@ -246,6 +796,139 @@ func TestSafeHeadAtL1Block(t *testing.T) {
 	safeReader.Mock.AssertExpectations(t)
 }
 
+func TestSetFakeFinalizedL1(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rng := rand.New(rand.NewSource(1234))
+	ref := testutils.RandomBlockRef(rng)
+	var noErr error
+	drClient.On("SetFakeFinalizedL1", ref.Number).Return(ref, &noErr)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, drClient, metrics.NoopMetrics, log))
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out eth.L1BlockRef
+	err = client.CallContext(context.Background(), &out, "admin_setFakeFinalizedL1", hexutil.Uint64(ref.Number))
+	assert.NoError(t, err)
+	assert.Equal(t, ref, out)
+}
+
+func TestResumeFinality(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	var noErr error
+	drClient.On("ResumeFinality").Return(&noErr)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, drClient, metrics.NoopMetrics, log))
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	err = client.CallContext(context.Background(), nil, "admin_resumeFinality")
+	assert.NoError(t, err)
+	drClient.AssertExpectations(t)
+}
+
+func TestReloadFinalityTunables(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	delay := uint64(32)
+	update := finality.FinalityTunablesUpdate{FinalityDelay: &delay}
+	tunables := finality.FinalityTunables{FinalityDelay: delay, ExtraConfirmations: 2}
+	var noErr error
+	drClient.On("ReloadFinalityTunables", update).Return(tunables, &noErr)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, drClient, metrics.NoopMetrics, log))
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out finality.FinalityTunables
+	err = client.CallContext(context.Background(), &out, "admin_reloadFinalityTunables", update)
+	assert.NoError(t, err)
+	assert.Equal(t, tunables, out)
+	drClient.AssertExpectations(t)
+}
+
+func TestUnsafeRollbackFinalized(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	target := testutils.RandomL2BlockRef(rand.New(rand.NewSource(1234)))
+	var noErr error
+	drClient.On("UnsafeRollbackFinalized", target).Return(&noErr)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, drClient, metrics.NoopMetrics, log))
+	assert.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	err = client.CallContext(context.Background(), nil, "admin_unsafeRollbackFinalized", target)
+	assert.NoError(t, err)
+	drClient.AssertExpectations(t)
+}
+
 type mockDriverClient struct {
 	mock.Mock
 }
@@ -263,10 +946,66 @@ func (c *mockDriverClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, err
 	return c.Mock.MethodCalled("SyncStatus").Get(0).(*eth.SyncStatus), nil
 }
 
+func (c *mockDriverClient) FinalityStatusJSON(ctx context.Context) (json.RawMessage, error) {
+	return json.Marshal(c.Mock.MethodCalled("FinalityStatus").Get(0).(*finality.FinalityStatus))
+}
+
+func (c *mockDriverClient) FinalitySnapshot(ctx context.Context) (*finality.Snapshot, error) {
+	return c.Mock.MethodCalled("FinalitySnapshot").Get(0).(*finality.Snapshot), nil
+}
+
+func (c *mockDriverClient) FinalityAudit(ctx context.Context) ([]finality.FinalizedHeadUpdate, error) {
+	return c.Mock.MethodCalled("FinalityAudit").Get(0).([]finality.FinalizedHeadUpdate), nil
+}
+
+func (c *mockDriverClient) FinalityCasualties(ctx context.Context) ([]finality.PruningCasualty, error) {
+	return c.Mock.MethodCalled("FinalityCasualties").Get(0).([]finality.PruningCasualty), nil
+}
+
+func (c *mockDriverClient) FinalizedAtTime(ctx context.Context, timestamp uint64) (eth.L2BlockRef, error) {
+	m := c.Mock.MethodCalled("FinalizedAtTime", timestamp)
+	return m[0].(eth.L2BlockRef), *m[1].(*error)
+}
+
+func (c *mockDriverClient) FinalityDerivedFrom(ctx context.Context, l2Number uint64) (eth.BlockID, error) {
+	m := c.Mock.MethodCalled("FinalityDerivedFrom", l2Number)
+	return m[0].(eth.BlockID), *m[1].(*error)
+}
+
+func (c *mockDriverClient) FinalizedAtLeast(ctx context.Context, l2Number uint64) (bool, error) {
+	m := c.Mock.MethodCalled("FinalizedAtLeast", l2Number)
+	return m[0].(bool), *m[1].(*error)
+}
+
+func (c *mockDriverClient) EstimateFinality(ctx context.Context, l2Number uint64) (finality.FinalityEstimate, error) {
+	m := c.Mock.MethodCalled("EstimateFinality", l2Number)
+	return m[0].(finality.FinalityEstimate), *m[1].(*error)
+}
+
+func (c *mockDriverClient) SetFakeFinalizedL1(ctx context.Context, number uint64) (eth.L1BlockRef, error) {
+	m := c.Mock.MethodCalled("SetFakeFinalizedL1", number)
+	return m[0].(eth.L1BlockRef), *m[1].(*error)
+}
+
 func (c *mockDriverClient) ResetDerivationPipeline(ctx context.Context) error {
 	return c.Mock.MethodCalled("ResetDerivationPipeline").Get(0).(error)
 }
 
+func (c *mockDriverClient) ReloadFinalityTunables(ctx context.Context, update finality.FinalityTunablesUpdate) (finality.FinalityTunables, error) {
+	m := c.Mock.MethodCalled("ReloadFinalityTunables", update)
+	return m[0].(finality.FinalityTunables), *m[1].(*error)
+}
+
+func (c *mockDriverClient) ResumeFinality(ctx context.Context) error {
+	m := c.Mock.MethodCalled("ResumeFinality")
+	return *m[0].(*error)
+}
+
+func (c *mockDriverClient) UnsafeRollbackFinalized(ctx context.Context, target eth.L2BlockRef) error {
+	m := c.Mock.MethodCalled("UnsafeRollbackFinalized", target)
+	return *m[0].(*error)
+}
+
 func (c *mockDriverClient) StartSequencer(ctx context.Context, blockHash common.Hash) error {
 	return c.Mock.MethodCalled("StartSequencer").Get(0).(error)
 }
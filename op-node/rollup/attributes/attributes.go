@@ -35,6 +35,12 @@ type L2 interface {
 	PayloadByNumber(context.Context, uint64) (*eth.ExecutionPayloadEnvelope, error)
 }
 
+// ForkConflictListener is notified when the L2 chain of the engine conflicts with the L2 chain derived from L1,
+// i.e. the node followed a minority fork of the unsafe chain.
+type ForkConflictListener interface {
+	OnL2ForkConflict(conflict eth.BlockID)
+}
+
 type AttributesHandler struct {
 	log log.Logger
 	cfg *rollup.Config
@@ -43,6 +49,8 @@ type AttributesHandler struct {
 	l2 L2
 
 	attributes *derive.AttributesWithParent
+
+	forkConflicts ForkConflictListener
 }
 
 func NewAttributesHandler(log log.Logger, cfg *rollup.Config, ec Engine, l2 L2) *AttributesHandler {
@@ -55,6 +63,11 @@ func NewAttributesHandler(log log.Logger, cfg *rollup.Config, ec Engine, l2 L2)
 	}
 }
 
+// SetForkConflictListener sets the listener to notify of unsafe blocks that conflict with the derived attributes.
+func (eq *AttributesHandler) SetForkConflictListener(l ForkConflictListener) {
+	eq.forkConflicts = l
+}
+
 func (eq *AttributesHandler) HasAttributes() bool {
 	return eq.attributes != nil
 }
@@ -123,6 +136,9 @@ func (eq *AttributesHandler) consolidateNextSafeAttributes(ctx context.Context,
 	}
 	if err := AttributesMatchBlock(eq.cfg, attributes.Attributes, eq.ec.PendingSafeL2Head().Hash, envelope, eq.log); err != nil {
 		eq.log.Warn("L2 reorg: existing unsafe block does not match derived attributes from L1", "err", err, "unsafe", eq.ec.UnsafeL2Head(), "pending_safe", eq.ec.PendingSafeL2Head(), "safe", eq.ec.SafeL2Head())
+		if eq.forkConflicts != nil {
+			eq.forkConflicts.OnL2ForkConflict(envelope.ExecutionPayload.ID())
+		}
 		// geth cannot wind back a chain without reorging to a new, previously non-canonical, block
 		return eq.forceNextSafeAttributes(ctx, attributes)
 	}
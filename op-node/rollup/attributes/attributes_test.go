@@ -213,6 +213,8 @@ func TestAttributesHandler(t *testing.T) {
 			eng := &testutils.MockEngine{}
 			ec := engine.NewEngineController(eng, logger, metrics.NoopMetrics, cfg, sync.CLSync)
 			ah := NewAttributesHandler(logger, cfg, ec, eng)
+			conflicts := &forkConflicts{}
+			ah.SetForkConflictListener(conflicts)
 
 			ec.SetUnsafeHead(refA1)
 			ec.SetSafeHead(refA0)
@@ -260,6 +262,7 @@ func TestAttributesHandler(t *testing.T) {
 			t.Log("ref alt: ", refA1Alt.Hash)
 			require.Equal(t, refA1Alt, ec.UnsafeL2Head(), "unsafe head reorg complete")
 			require.Equal(t, refA1Alt, ec.SafeL2Head(), "safe head reorg complete and updated")
+			require.Equal(t, []eth.BlockID{refA1.ID()}, conflicts.blocks, "unsafe block A1 conflicts with the derived chain")
 		})
 		t.Run("consolidation passes", func(t *testing.T) {
 			fn := func(t *testing.T, lastInSpan bool) {
@@ -407,3 +410,12 @@ func TestAttributesHandler(t *testing.T) {
 	})
 
 }
+
+// forkConflicts records the L2 fork conflicts the attributes handler detects.
+type forkConflicts struct {
+	blocks []eth.BlockID
+}
+
+func (f *forkConflicts) OnL2ForkConflict(conflict eth.BlockID) {
+	f.blocks = append(f.blocks, conflict)
+}
@@ -0,0 +1,65 @@
+package derive
+
+import (
+	"context"
+	"slices"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// batchInclusionCacheSize is the number of recent L1 blocks to remember the batch inclusion of.
+// This covers the default finality lookback of the finalizer, which consumes it.
+const batchInclusionCacheSize = 1000
+
+// BatchInclusion identifies the batcher transactions of an L1 block,
+// the indices of the blobs they carry in the blob sidecar of the block, and the batchers that sent them.
+type BatchInclusion struct {
+	TxHashes    []common.Hash
+	BlobIndices []uint64
+	Batchers    []common.Address
+}
+
+// batchInclusionFromTxs returns the batch inclusion of the given L1 block transactions.
+func batchInclusionFromTxs(txs types.Transactions, config *DataSourceConfig, batcherAddr common.Address) BatchInclusion {
+	var out BatchInclusion
+	blobIndex := uint64(0) // index of each blob in the block's blob sidecar
+	for _, tx := range txs {
+		if isValidBatchTx(tx, config.l1Signer, config.batchInboxAddress, batcherAddr) {
+			out.TxHashes = append(out.TxHashes, tx.Hash())
+			if !slices.Contains(out.Batchers, batcherAddr) {
+				out.Batchers = append(out.Batchers, batcherAddr)
+			}
+			if tx.Type() == types.BlobTxType {
+				for range tx.BlobHashes() {
+					out.BlobIndices = append(out.BlobIndices, blobIndex)
+					blobIndex += 1
+				}
+				continue
+			}
+		}
+		blobIndex += uint64(len(tx.BlobHashes()))
+	}
+	return out
+}
+
+// inclusionRecorder wraps the L1 transaction fetcher of a data source,
+// to record the batch inclusion of the L1 block the data source is opened for.
+type inclusionRecorder struct {
+	L1TransactionFetcher
+	dsCfg       *DataSourceConfig
+	batcherAddr common.Address
+	inclusions  *lru.Cache[common.Hash, BatchInclusion]
+}
+
+func (r *inclusionRecorder) InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	info, txs, err := r.L1TransactionFetcher.InfoAndTxsByHash(ctx, hash)
+	if err == nil {
+		r.inclusions.Add(hash, batchInclusionFromTxs(txs, r.dsCfg, r.batcherAddr))
+	}
+	return info, txs, err
+}
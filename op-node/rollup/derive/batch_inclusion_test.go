@@ -0,0 +1,77 @@
+package derive
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestBatchInclusion(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	privateKey := testutils.InsecureRandomKey(rng)
+	publicKey, _ := privateKey.Public().(*ecdsa.PublicKey)
+	batcherAddr := crypto.PubkeyToAddress(*publicKey)
+	batchInboxAddr := testutils.RandomAddress(rng)
+	signer := types.NewCancunSigner(big.NewInt(42))
+	config := DataSourceConfig{
+		l1Signer:          signer,
+		batchInboxAddress: batchInboxAddr,
+	}
+
+	calldataTx, _ := types.SignNewTx(privateKey, signer, &types.LegacyTx{
+		Nonce:    rng.Uint64(),
+		GasPrice: new(big.Int).SetUint64(rng.Uint64()),
+		Gas:      2_000_000,
+		To:       &batchInboxAddr,
+		Data:     testutils.RandomData(rng, 100),
+	})
+	// a blob transaction of someone else, which shifts the blob indices
+	otherTx, _ := types.SignNewTx(testutils.RandomKey(), signer, &types.BlobTx{
+		Nonce:      rng.Uint64(),
+		Gas:        2_000_000,
+		To:         testutils.RandomAddress(rng),
+		BlobHashes: []common.Hash{testutils.RandomHash(rng), testutils.RandomHash(rng)},
+	})
+	blobTx, _ := types.SignNewTx(privateKey, signer, &types.BlobTx{
+		Nonce:      rng.Uint64(),
+		Gas:        2_000_000,
+		To:         batchInboxAddr,
+		BlobHashes: []common.Hash{testutils.RandomHash(rng), testutils.RandomHash(rng)},
+	})
+	txs := types.Transactions{calldataTx, otherTx, blobTx}
+
+	require.Equal(t, BatchInclusion{
+		TxHashes:    []common.Hash{calldataTx.Hash(), blobTx.Hash()},
+		BlobIndices: []uint64{2, 3},
+		Batchers:    []common.Address{batcherAddr},
+	}, batchInclusionFromTxs(txs, &config, batcherAddr))
+	require.Equal(t, BatchInclusion{}, batchInclusionFromTxs(types.Transactions{otherTx}, &config, batcherAddr))
+
+	// the data sources record the batch inclusion of the L1 blocks they open
+	ref := testutils.RandomBlockRef(rng)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	l1F.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), txs, nil)
+	factory := NewDataSourceFactory(testlog.Logger(t, log.LevelInfo), &rollup.Config{}, l1F, nil, nil)
+	factory.dsCfg = config
+	_, ok := factory.BatchInclusion(ref.ID())
+	require.False(t, ok)
+	_, err := factory.OpenData(context.Background(), ref, batcherAddr)
+	require.NoError(t, err)
+	inclusion, ok := factory.BatchInclusion(ref.ID())
+	require.True(t, ok)
+	require.Equal(t, []common.Hash{calldataTx.Hash(), blobTx.Hash()}, inclusion.TxHashes)
+}
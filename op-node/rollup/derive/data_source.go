@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	lru "github.com/hashicorp/golang-lru/v2"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -45,6 +47,8 @@ type DataSourceFactory struct {
 	blobsFetcher  L1BlobsFetcher
 	plasmaFetcher PlasmaInputFetcher
 	ecotoneTime   *uint64
+	// inclusions remembers the batch inclusion of the recently opened L1 blocks
+	inclusions *lru.Cache[common.Hash, BatchInclusion]
 }
 
 func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1Fetcher, blobsFetcher L1BlobsFetcher, plasmaFetcher PlasmaInputFetcher) *DataSourceFactory {
@@ -53,6 +57,7 @@ func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1Fetcher,
 		batchInboxAddress: cfg.BatchInboxAddress,
 		plasmaEnabled:     cfg.PlasmaEnabled(),
 	}
+	inclusions, _ := lru.New[common.Hash, BatchInclusion](batchInclusionCacheSize)
 	return &DataSourceFactory{
 		log:           log,
 		dsCfg:         config,
@@ -60,6 +65,7 @@ func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1Fetcher,
 		blobsFetcher:  blobsFetcher,
 		plasmaFetcher: plasmaFetcher,
 		ecotoneTime:   cfg.EcotoneTime,
+		inclusions:    inclusions,
 	}
 }
 
@@ -68,13 +74,19 @@ func (ds *DataSourceFactory) OpenData(ctx context.Context, ref eth.L1BlockRef, b
 	// Creates a data iterator from blob or calldata source so we can forward it to the plasma source
 	// if enabled as it still requires an L1 data source for fetching input commmitments.
 	var src DataIter
+	fetcher := &inclusionRecorder{
+		L1TransactionFetcher: ds.fetcher,
+		dsCfg:                &ds.dsCfg,
+		batcherAddr:          batcherAddr,
+		inclusions:           ds.inclusions,
+	}
 	if ds.ecotoneTime != nil && ref.Time >= *ds.ecotoneTime {
 		if ds.blobsFetcher == nil {
 			return nil, fmt.Errorf("ecotone upgrade active but beacon endpoint not configured")
 		}
-		src = NewBlobDataSource(ctx, ds.log, ds.dsCfg, ds.fetcher, ds.blobsFetcher, ref, batcherAddr)
+		src = NewBlobDataSource(ctx, ds.log, ds.dsCfg, fetcher, ds.blobsFetcher, ref, batcherAddr)
 	} else {
-		src = NewCalldataSource(ctx, ds.log, ds.dsCfg, ds.fetcher, ref, batcherAddr)
+		src = NewCalldataSource(ctx, ds.log, ds.dsCfg, fetcher, ref, batcherAddr)
 	}
 	if ds.dsCfg.plasmaEnabled {
 		// plasma([calldata | blobdata](l1Ref)) -> data
@@ -83,6 +95,11 @@ func (ds *DataSourceFactory) OpenData(ctx context.Context, ref eth.L1BlockRef, b
 	return src, nil
 }
 
+// BatchInclusion returns the batch inclusion of the given L1 block, if it was opened recently.
+func (ds *DataSourceFactory) BatchInclusion(l1 eth.BlockID) (BatchInclusion, bool) {
+	return ds.inclusions.Get(l1.Hash)
+}
+
 // DataSourceConfig regroups the mandatory rollup.Config fields needed for DataFromEVMTransactions.
 type DataSourceConfig struct {
 	l1Signer          types.Signer
@@ -62,6 +62,7 @@ type DerivationPipeline struct {
 
 	// Special stages to keep track of
 	traversal *L1Traversal
+	dataSrc   *DataSourceFactory
 
 	attrib *AttributesQueue
 
@@ -103,6 +104,7 @@ func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L
 		stages:    stages,
 		metrics:   metrics,
 		traversal: l1Traversal,
+		dataSrc:   dataSrc,
 		attrib:    attributesQueue,
 		l2:        l2Source,
 	}
@@ -127,6 +129,11 @@ func (dp *DerivationPipeline) Origin() eth.L1BlockRef {
 	return dp.origin
 }
 
+// BatchInclusion returns the batcher transactions and blobs of the given L1 block, if the pipeline recently read it.
+func (dp *DerivationPipeline) BatchInclusion(l1 eth.BlockID) (BatchInclusion, bool) {
+	return dp.dataSrc.BatchInclusion(l1)
+}
+
 // Step tries to progress the buffer.
 // An EOF is returned if the pipeline is blocked by waiting for new L1 data.
 // If ctx errors no error is returned, but the step may exit early in a state that can still be continued.
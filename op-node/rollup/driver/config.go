@@ -1,5 +1,11 @@
 package driver
 
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+)
+
 type Config struct {
 	// VerifierConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
 	VerifierConfDepth uint64 `json:"verifier_conf_depth"`
@@ -20,4 +26,152 @@ type Config struct {
 	// SequencerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
+
+	// SequencerFinalityLagSlow is the number of unfinalized L2 blocks beyond which block production slows down.
+	// Disabled if 0.
+	SequencerFinalityLagSlow uint64 `json:"sequencer_finality_lag_slow"`
+
+	// SequencerMaxFinalityLag is the number of unfinalized L2 blocks at which block production pauses,
+	// until finalization catches up. Disabled if 0.
+	SequencerMaxFinalityLag uint64 `json:"sequencer_max_finality_lag"`
+
+	// Finality configures the finalizer of the driver.
+	Finality FinalityConfig `json:"finality"`
+}
+
+// FinalityConfig configures the finalizer of the driver.
+type FinalityConfig struct {
+	// MaxAdvance is the maximum number of L2 blocks to advance the finalized L2 head by at a time,
+	// when there are known intermediate L2 blocks to finalize. Disabled if 0.
+	MaxAdvance uint64 `json:"max_advance"`
+
+	// MaxSignalAge is the maximum age of the L1 block of a finality signal, relative to the L1 head.
+	// Older finality signals are ignored. Disabled if 0.
+	MaxSignalAge time.Duration `json:"max_signal_age"`
+
+	// BlobRetention is the blob retention window of the L1 chain, after which finalized L2 ranges
+	// that were derived from blobs are reported as not reconstructable from L1. Defaults to finality.DefaultBlobRetention if 0.
+	BlobRetention time.Duration `json:"blob_retention"`
+
+	// ExtraConfirmations is the number of L1 blocks below the finalized L1 block,
+	// that the L2 chain has to be derived from to be finalized. Disabled if 0.
+	ExtraConfirmations uint64 `json:"extra_confirmations"`
+
+	// MinInterval is the minimum duration between applications of a new finalized L2 head to the engine.
+	// Disabled if 0.
+	MinInterval time.Duration `json:"min_interval"`
+
+	// MinBlocks is the minimum number of L2 blocks the finalized L2 head advances by,
+	// before it is applied to the engine. Disabled if 0.
+	MinBlocks uint64 `json:"min_blocks"`
+
+	// AdaptiveDelayMin and AdaptiveDelayMax bound the number of L1 blocks to traverse
+	// between attempts to finalize, as adapted to the cost of the L1 fetches. Disabled if the max is 0.
+	AdaptiveDelayMin uint64 `json:"adaptive_delay_min"`
+	AdaptiveDelayMax uint64 `json:"adaptive_delay_max"`
+
+	// TrustSignal skips the canonical-chain sanity checks of the L1 finality signal.
+	TrustSignal bool `json:"trust_signal"`
+
+	// AncestryCheck is the maximum number of L1 blocks to walk back, to verify every L1 finality signal
+	// builds on the previous signal. Signals that are further ahead are refused. Disabled if 0.
+	AncestryCheck uint64 `json:"ancestry_check"`
+
+	// RepairUnjustified re-asserts the justified finalized L2 head on the engine,
+	// if the engine finalized L2 blocks that cannot be justified from finalized L1 data.
+	RepairUnjustified bool `json:"repair_unjustified"`
+
+	// Backfill backfills gaps in the buffered finality data from the safe head database.
+	Backfill bool `json:"backfill"`
+
+	// SpanBatches finalizes whole span batches only, and exposes them in the finality events.
+	SpanBatches bool `json:"span_batches"`
+
+	// L1SlotsPerEpoch sizes the finality lookback to the L1 chain.
+	// If 0, it is fetched from the L1 beacon spec, or the mainnet lookback is used if unavailable.
+	L1SlotsPerEpoch uint64 `json:"l1_slots_per_epoch"`
+
+	// BeaconEpochs aligns the attempts to finalize to the beacon epochs of the L1 chain,
+	// with the slot clock of BeaconGenesisTime and BeaconSecondsPerSlot.
+	// If the slot clock is not configured, it is fetched from the L1 beacon spec.
+	BeaconEpochs         bool   `json:"beacon_epochs"`
+	BeaconGenesisTime    uint64 `json:"beacon_genesis_time"`
+	BeaconSecondsPerSlot uint64 `json:"beacon_seconds_per_slot"`
+
+	// MaxMismatches is the number of consecutive canonical-chain mismatches of attempts to finalize,
+	// after which finalization is halted until resumed through the admin API. Disabled if 0.
+	MaxMismatches int `json:"max_mismatches"`
+
+	// HaltOnSafeRegression halts finalization when the safe head moves backwards without a reset.
+	HaltOnSafeRegression bool `json:"halt_on_safe_regression"`
+
+	// SignalWeights are the trust weights of the L1 finality signal sources, by label. Disabled if nil.
+	SignalWeights map[string]uint64 `json:"signal_weights"`
+
+	// SignalThreshold is the combined trust weight of the signal sources required to accept a signal.
+	SignalThreshold uint64 `json:"signal_threshold"`
+
+	// DisputeGameGate only finalizes L2 blocks that are backed by a resolved dispute game.
+	DisputeGameGate bool `json:"dispute_game_gate"`
+
+	// Settlement tracks a settled L2 head, the finalized L2 blocks that are also backed by a resolved dispute game.
+	Settlement bool `json:"settlement"`
+
+	// FakeSignals allows injecting synthetic L1 finality signals through the admin API. This is for devnets only.
+	FakeSignals bool `json:"fake_signals"`
+
+	// UnsafeRollback allows rolling back the finalized L2 head through the admin API. This is for devnets only.
+	UnsafeRollback bool `json:"unsafe_rollback"`
+
+	// Faults injects faults into the L1 fetches of the finalizer. This is for devnets only.
+	Faults finality.FaultConfig `json:"faults"`
+
+	// ReplicaPolicy determines how L2 blocks to finalize that the finality replica does not confirm are handled.
+	ReplicaPolicy finality.CrossValidationPolicy `json:"replica_policy"`
+
+	// PlasmaChangeAction determines how a restored finality snapshot is handled,
+	// if the plasma DA windows changed since it was taken.
+	PlasmaChangeAction finality.PlasmaChangeAction `json:"plasma_change_action"`
+
+	// Mode selects the finalizer implementation. Defaults to finality.ModeAuto.
+	Mode finality.Mode `json:"mode"`
+
+	// EngineCallTimeout bounds the engine calls that apply the finalized L2 head. Defaults to 10s if 0.
+	EngineCallTimeout time.Duration `json:"engine_call_timeout"`
+
+	// TraceIDs assigns a trace ID to every attempt to finalize, as exemplar of the finalization latency metrics.
+	TraceIDs bool `json:"trace_ids"`
+
+	// MaxLookback is the number of finality data entries the finality lookback may grow to,
+	// while L1 finality stalls. Disabled if 0.
+	MaxLookback uint64 `json:"max_lookback"`
+
+	// Pruning prunes the finality data with additional policies, on top of the finality lookback.
+	Pruning finality.PruningConfig `json:"pruning"`
+
+	// Compression compresses the finality data beyond the most recent entries of this number. Disabled if 0.
+	Compression uint64 `json:"compression"`
+
+	// LatencySLO is the end-to-end finality latency SLO to report compliance with. Disabled if zero.
+	LatencySLO finality.LatencySLO `json:"latency_slo"`
+
+	// Sources are the optional external sources of the finalizer, as connected by the node.
+	Sources FinalitySources `json:"-"`
+}
+
+// FinalitySources are the optional external sources of the finalizer. Any of them may be nil.
+type FinalitySources struct {
+	// Follow is the primary rollup node to follow the finalized L2 head of.
+	Follow finality.FollowSource
+	// Replica is the L2 node to cross-validate the L2 blocks to finalize with.
+	Replica finality.L2BlockSource
+	// LightClient serves the L1 blocks to finalize with, instead of the L1 source of the driver.
+	LightClient finality.FinalizerL1Interface
+	// DisputeGames are the resolved dispute games to gate and settle finalization on.
+	DisputeGames finality.DisputeGameReader
+	// ShadowL1 is the alternative L1 source to run the shadow finalizer with.
+	ShadowL1 finality.FinalizerL1Interface
+	// Committee and Attestations gate finalization on the attestations of a committee.
+	Committee    finality.CommitteeRegistry
+	Attestations finality.AttestationSource
 }
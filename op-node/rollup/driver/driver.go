@@ -2,6 +2,8 @@ package driver
 
 import (
 	"context"
+	"encoding/json"
+	"math/rand" // nosemgrep
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -45,6 +47,7 @@ type Metrics interface {
 	EngineMetrics
 	L1FetcherMetrics
 	SequencerMetrics
+	finality.Metrics
 }
 
 type L1Chain interface {
@@ -92,10 +95,61 @@ type AttributesHandler interface {
 	Proceed(ctx context.Context) error
 }
 
+// Finalizer is the finality contract of the driver event loop: it is fed the derivation progress and the
+// L1 finality signals, and is started, stopped and reset with the driver.
 type Finalizer interface {
-	Finalize(ctx context.Context, ref eth.L1BlockRef)
+	finality.FinalityController
+	// PrepareFinalize verifies the L1 blocks a list of L1 finality signals, sorted by block number, depends on,
+	// outside of the event loop, so that the subsequent FinalizeRangeOutcome applies them without waiting for L1 requests.
+	// An error is returned if the signals could not be prepared, and are not to be applied.
+	PrepareFinalize(ctx context.Context, signals []eth.L1BlockRef) (eth.L1BlockRef, bool, error)
+	// FinalizeRangeOutcome applies a list of L1 finality signals, sorted by block number, and returns the outcome.
+	FinalizeRangeOutcome(ctx context.Context, signals []eth.L1BlockRef) finality.FinalityOutcome
+	// FinalizeHash applies a finality signal identified by L1 block hash only, and returns the resolved L1 block.
+	FinalizeHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error)
 	FinalizedL1() eth.L1BlockRef
-	engine.FinalizerHooks
+	// Start processes finality signals, including any signal received before start.
+	Start(ctx context.Context)
+	// Stop queues finality signals until started again.
+	Stop()
+	// OnEngineReady applies the finalized L2 head that was determined while the engine was syncing, if any.
+	OnEngineReady(ctx context.Context)
+	// OnResetComplete re-attempts finalization once a reset completed, if the reset was caused by finalization.
+	OnResetComplete(ctx context.Context)
+	// OnDerivationIdle attempts finalization right away when derivation went idle, if a new signal arrived.
+	OnDerivationIdle(ctx context.Context) error
+}
+
+// FinalityQueries is the read-only query surface of the finalizer, served by the driver outside of the event loop.
+type FinalityQueries interface {
+	Status() finality.FinalityStatus
+	// CachedStatusJSON returns the last published finality status, pre-serialized, without taking the finalizer lock.
+	CachedStatusJSON() json.RawMessage
+	Snapshot() *finality.Snapshot
+	// FinalizedAtTime returns the newest finalized L2 block with a timestamp at or before the given time.
+	FinalizedAtTime(ctx context.Context, timestamp uint64) (eth.L2BlockRef, error)
+	// DerivedFrom returns the L1 block the given L2 block was fully derived from, according to the finality data.
+	DerivedFrom(l2Number uint64) (eth.BlockID, error)
+	// FinalizedAtLeast returns whether the given L2 block number is finalized, without taking the finalizer lock.
+	FinalizedAtLeast(l2Number uint64) (bool, error)
+	finality.SequencerFeedback
+	// EstimateFinality estimates when the given L2 block will be finalized.
+	EstimateFinality(l2Number uint64) (finality.FinalityEstimate, error)
+	DebugBundle() *finality.DebugBundle
+	// AuditTrail returns the most recent finalized head updates of the finalizer, for incident analysis.
+	AuditTrail() []finality.FinalizedHeadUpdate
+	// PruningCasualties returns the most recent finality data entries that were pruned before they were finalized.
+	PruningCasualties() []finality.PruningCasualty
+	SubscribeFinalized(fn finality.FinalizedSubscriber) (unsubscribe func())
+}
+
+// FinalizerBackend is a finalizer implementation, as constructed for the driver:
+// the driver runs its event-loop contract and serves its queries.
+// The admin surface is handed to the node as is, the driver does not use it.
+type FinalizerBackend interface {
+	Finalizer
+	FinalityQueries
+	finality.Admin
 }
 
 type PlasmaIface interface {
@@ -180,23 +234,140 @@ func NewDriver(
 	verifConfDepth := NewConfDepth(driverCfg.VerifierConfDepth, l1State.L1Head, l1)
 	engine := engine.NewEngineController(l2, log, metrics, cfg, syncCfg.SyncMode)
 	clSync := clsync.NewCLSync(log, cfg, metrics, engine)
+	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l1Blobs, plasma, l2, metrics)
 
-	var finalizer Finalizer
-	if cfg.PlasmaEnabled() {
-		finalizer = finality.NewPlasmaFinalizer(log, cfg, l1, engine, plasma)
-	} else {
-		finalizer = finality.NewFinalizer(log, cfg, l1, engine)
+	finalityCfg := &driverCfg.Finality
+	finalitySources := finalityCfg.Sources
+	finalityOpts := []finality.FinalizerOption{
+		finality.WithMetrics(metrics),
+		finality.WithMaxAdvance(finalityCfg.MaxAdvance),
+		finality.WithMaxSignalAge(finalityCfg.MaxSignalAge, l1State.L1Head),
+		finality.WithBlobRetention(finalityCfg.BlobRetention),
+		finality.WithMinInterval(finalityCfg.MinInterval, finalityCfg.MinBlocks),
+		finality.WithInclusionSource(derivationPipeline),
+		finality.WithL2BlockSource(l2),
+		finality.WithL1SlotsPerEpoch(finalityCfg.L1SlotsPerEpoch),
+		finality.WithCircuitBreaker(finalityCfg.MaxMismatches),
+		finality.WithEngineCallTimeout(finalityCfg.EngineCallTimeout),
+		finality.WithLookbackGrowth(finalityCfg.MaxLookback),
+		finality.WithLatencySLO(finalityCfg.LatencySLO),
+		// signals are only processed once the driver starts
+		finality.WithDeferredStart(),
+	}
+	// the finality flags override the finality profile of the chain config, if set
+	if finalityCfg.ExtraConfirmations != 0 {
+		finalityOpts = append(finalityOpts, finality.WithExtraConfirmations(finalityCfg.ExtraConfirmations))
+	}
+	if finalityCfg.TrustSignal {
+		finalityOpts = append(finalityOpts, finality.WithTrustSignal())
+	}
+	if finalityCfg.AncestryCheck != 0 {
+		finalityOpts = append(finalityOpts, finality.WithAncestryCheck(finalityCfg.AncestryCheck))
+	}
+	if finalityCfg.Pruning.Enabled() {
+		finalityOpts = append(finalityOpts, finality.WithPruningPolicy(finalityCfg.Pruning.Policy()))
+	}
+	if finalityCfg.Compression != 0 {
+		finalityOpts = append(finalityOpts, finality.WithCompression(finalityCfg.Compression))
+	}
+	if finalityCfg.TraceIDs {
+		finalityOpts = append(finalityOpts, finality.WithAttemptTraceIDs())
+	}
+	if finalityCfg.RepairUnjustified {
+		finalityOpts = append(finalityOpts, finality.WithRepairUnjustified())
+	}
+	if finalityCfg.BeaconEpochs {
+		finalityOpts = append(finalityOpts, finality.WithBeaconEpochs(finalityCfg.BeaconGenesisTime, finalityCfg.BeaconSecondsPerSlot))
+	}
+	if finalityCfg.HaltOnSafeRegression {
+		finalityOpts = append(finalityOpts, finality.WithSafeRegressionHalt())
 	}
+	if finalityCfg.SpanBatches {
+		finalityOpts = append(finalityOpts, finality.WithSpanBatches())
+	}
+	if finalityCfg.SignalWeights != nil {
+		finalityOpts = append(finalityOpts, finality.WithSignalWeights(finalityCfg.SignalWeights, finalityCfg.SignalThreshold))
+	}
+	if finalityCfg.Backfill {
+		if safeHeads, ok := safeHeadListener.(finality.SafeHeadSource); ok && safeHeadListener.Enabled() {
+			finalityOpts = append(finalityOpts, finality.WithBackfill(safeHeads, l2))
+		} else {
+			log.Warn("Finality data backfill requires the safe head database, backfill is disabled")
+		}
+	}
+	if finalityCfg.AdaptiveDelayMax != 0 {
+		finalityOpts = append(finalityOpts, finality.WithAdaptiveDelay(finalityCfg.AdaptiveDelayMin, finalityCfg.AdaptiveDelayMax))
+	}
+	if finalityCfg.PlasmaChangeAction != "" {
+		finalityOpts = append(finalityOpts, finality.WithPlasmaChangeAction(finalityCfg.PlasmaChangeAction))
+	}
+	if finalitySources.Replica != nil {
+		finalityOpts = append(finalityOpts, finality.WithCrossValidation(finalitySources.Replica, finalityCfg.ReplicaPolicy))
+	}
+	if finalitySources.DisputeGames != nil {
+		// the dispute games back the finality rules of the rollup config that gate on them
+		finalityOpts = append(finalityOpts, finality.WithDisputeGameReader(finalitySources.DisputeGames))
+		if finalityCfg.DisputeGameGate {
+			finalityOpts = append(finalityOpts, finality.WithDisputeGameGate(finalitySources.DisputeGames))
+		}
+		if finalityCfg.Settlement {
+			finalityOpts = append(finalityOpts, finality.WithSettlement(finalitySources.DisputeGames))
+		}
+	}
+	if finalitySources.Committee != nil && finalitySources.Attestations != nil {
+		finalityOpts = append(finalityOpts, finality.WithCommitteeGate(finalitySources.Committee, finalitySources.Attestations, finality.BLSAttestationVerifier{}))
+	}
+	var finalityL1 finality.FinalizerL1Interface = l1
+	if finalitySources.LightClient != nil {
+		// finalize with the L1 blocks served by the light client, which the finality signal is consistent with
+		finalityL1 = finalitySources.LightClient
+	}
+	if finalityCfg.Faults.Enabled() {
+		log.Warn("Injecting faults into the L1 fetches of the finalizer, this is for testing only!", "faults", finalityCfg.Faults)
+		finalityL1 = finality.NewFaultyL1(log, finalityL1, finalityCfg.Faults, rand.New(rand.NewSource(time.Now().UnixNano())))
+	}
+	finalityMode := finalityCfg.Mode
+	if finalityMode == "" || finalityMode == finality.ModeAuto {
+		if finalitySources.Follow != nil {
+			finalityMode = finality.ModeFollow
+		} else if cfg.PlasmaEnabled() {
+			finalityMode = finality.ModePlasma
+		} else {
+			finalityMode = finality.ModeL1
+		}
+	}
+	finalityEngine := finality.NewControllerEngine(engine)
+	var finalizer FinalizerBackend
+	var shadowFinalizer *finality.ShadowFinalizer
+	switch finalityMode {
+	case finality.ModeFollow:
+		finalizer = finality.NewFollowFinalizer(log, cfg, finalityEngine, finalitySources.Follow, l2, finalityOpts...)
+	case finality.ModePlasma:
+		finalizer = finality.NewPlasmaFinalizer(log, cfg, finalityL1, finalityEngine, plasma, finalityOpts...)
+	default:
+		fi := finality.NewFinalizer(log, cfg, finalityL1, finalityEngine, finalityOpts...)
+		finalizer = fi
+		if finalitySources.ShadowL1 != nil {
+			shadowFinalizer = finality.NewShadowFinalizer(log, cfg, fi, finalitySources.ShadowL1)
+			finalizer = shadowFinalizer
+		}
+	}
+	if finalitySources.ShadowL1 != nil && shadowFinalizer == nil {
+		log.Warn("The shadow finalizer is only supported in the L1 finality mode, shadow finalization is disabled", "mode", finalityMode)
+	}
+	log.Info("Selected finality mode", "mode", finalityMode, "shadow", shadowFinalizer != nil)
 
 	attributesHandler := attributes.NewAttributesHandler(log, cfg, engine, l2)
-	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l1Blobs, plasma, l2, metrics)
+	attributesHandler.SetForkConflictListener(finalizer)
 	attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
 	meteredEngine := NewMeteredEngine(cfg, engine, metrics, log) // Only use the metered engine in the sequencer b/c it records sequencing metrics.
 	sequencer := NewSequencer(log, cfg, meteredEngine, attrBuilder, findL1Origin, metrics)
 	driverCtx, driverCancel := context.WithCancel(context.Background())
 	asyncGossiper := async.NewAsyncGossiper(driverCtx, network, log, metrics)
 	return &Driver{
-		l1State: l1State,
+		l1State:         l1State,
+		FinalityQueries: finalizer,
+		FinalityAdmin:   finalizer,
 		SyncDeriver: &SyncDeriver{
 			Derivation:        derivationPipeline,
 			Finalizer:         finalizer,
@@ -205,30 +376,36 @@ func NewDriver(
 			CLSync:            clSync,
 			Engine:            engine,
 		},
-		stateReq:           make(chan chan struct{}),
-		forceReset:         make(chan chan struct{}, 10),
-		startSequencer:     make(chan hashAndErrorChannel, 10),
-		stopSequencer:      make(chan chan hashAndError, 10),
-		sequencerActive:    make(chan chan bool, 10),
-		sequencerNotifs:    sequencerStateListener,
-		config:             cfg,
-		syncCfg:            syncCfg,
-		driverConfig:       driverCfg,
-		driverCtx:          driverCtx,
-		driverCancel:       driverCancel,
-		log:                log,
-		snapshotLog:        snapshotLog,
-		l1:                 l1,
-		l2:                 l2,
-		sequencer:          sequencer,
-		network:            network,
-		metrics:            metrics,
-		l1HeadSig:          make(chan eth.L1BlockRef, 10),
-		l1SafeSig:          make(chan eth.L1BlockRef, 10),
-		l1FinalizedSig:     make(chan eth.L1BlockRef, 10),
-		unsafeL2Payloads:   make(chan *eth.ExecutionPayloadEnvelope, 10),
-		altSync:            altSync,
-		asyncGossiper:      asyncGossiper,
-		sequencerConductor: sequencerConductor,
+		stateReq:             make(chan chan struct{}),
+		forceReset:           make(chan chan struct{}, 10),
+		startSequencer:       make(chan hashAndErrorChannel, 10),
+		stopSequencer:        make(chan chan hashAndError, 10),
+		sequencerActive:      make(chan chan bool, 10),
+		sequencerNotifs:      sequencerStateListener,
+		config:               cfg,
+		syncCfg:              syncCfg,
+		driverConfig:         driverCfg,
+		driverCtx:            driverCtx,
+		driverCancel:         driverCancel,
+		log:                  log,
+		snapshotLog:          snapshotLog,
+		l1:                   l1,
+		l2:                   l2,
+		sequencer:            sequencer,
+		network:              network,
+		metrics:              metrics,
+		l1HeadSig:            make(chan eth.L1BlockRef, 10),
+		l1SafeSig:            make(chan eth.L1BlockRef, 10),
+		l1FinalizedSig:       make(chan eth.L1BlockRef, 10),
+		l1FinalizedReady:     make(chan preparedFinality, 1),
+		l1FinalizedHashSig:   make(chan common.Hash, 10),
+		shadowL1FinalizedSig: make(chan eth.L1BlockRef, 10),
+		shadowFinalizer:      shadowFinalizer,
+		unsafeL2Payloads:     make(chan *eth.ExecutionPayloadEnvelope, 10),
+		altSync:              altSync,
+		asyncGossiper:        asyncGossiper,
+		sequencerConductor:   sequencerConductor,
+		finalityThrottle: NewFinalityThrottle(finalizer, driverCfg.SequencerFinalityLagSlow, driverCfg.SequencerMaxFinalityLag,
+			time.Duration(cfg.BlockTime)*time.Second),
 	}
 }
@@ -0,0 +1,50 @@
+package driver
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FinalityThrottle throttles block production when finalization falls behind, to limit the unfinalized exposure:
+// beyond slowLag unfinalized L2 blocks, the next block is delayed, up to one block time at maxLag,
+// and at maxLag block production pauses until finalization catches up. Either is disabled if 0.
+type FinalityThrottle struct {
+	feedback  finality.SequencerFeedback
+	slowLag   uint64
+	maxLag    uint64
+	blockTime time.Duration
+}
+
+func NewFinalityThrottle(feedback finality.SequencerFeedback, slowLag, maxLag uint64, blockTime time.Duration) *FinalityThrottle {
+	return &FinalityThrottle{
+		feedback:  feedback,
+		slowLag:   slowLag,
+		maxLag:    maxLag,
+		blockTime: blockTime,
+	}
+}
+
+// Throttle returns the additional delay of the next block on top of the given unsafe L2 head,
+// and whether block production is paused. Block production is not throttled while the finalized L2 head is unknown.
+func (t *FinalityThrottle) Throttle(unsafeL2 eth.L2BlockRef) (delay time.Duration, paused bool) {
+	if t == nil || (t.slowLag == 0 && t.maxLag == 0) {
+		return 0, false
+	}
+	lag, ok := t.feedback.FinalityLag(unsafeL2)
+	if !ok {
+		return 0, false
+	}
+	if t.maxLag != 0 && lag >= t.maxLag {
+		return 0, true
+	}
+	if t.slowLag == 0 || lag <= t.slowLag {
+		return 0, false
+	}
+	if t.maxLag <= t.slowLag {
+		return t.blockTime, false
+	}
+	// ramp up the delay linearly, from none at slowLag to one block time at maxLag
+	return t.blockTime * time.Duration(lag-t.slowLag) / time.Duration(t.maxLag-t.slowLag), false
+}
@@ -0,0 +1,70 @@
+package driver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type fakeFinalityFeedback struct {
+	finalized uint64
+	known     bool
+}
+
+func (f *fakeFinalityFeedback) FinalityLag(unsafeL2 eth.L2BlockRef) (uint64, bool) {
+	return unsafeL2.Number - f.finalized, f.known
+}
+
+func TestFinalityThrottle(t *testing.T) {
+	feedback := &fakeFinalityFeedback{finalized: 100, known: true}
+	unsafe := func(n uint64) eth.L2BlockRef { return eth.L2BlockRef{Number: n} }
+
+	t.Run("disabled", func(t *testing.T) {
+		th := NewFinalityThrottle(feedback, 0, 0, 2*time.Second)
+		delay, paused := th.Throttle(unsafe(10_000))
+		require.Zero(t, delay)
+		require.False(t, paused)
+		var nilThrottle *FinalityThrottle
+		delay, paused = nilThrottle.Throttle(unsafe(10_000))
+		require.Zero(t, delay)
+		require.False(t, paused)
+	})
+
+	t.Run("ramp and pause", func(t *testing.T) {
+		th := NewFinalityThrottle(feedback, 100, 200, 2*time.Second)
+		delay, paused := th.Throttle(unsafe(200))
+		require.Zero(t, delay, "lag at slowLag")
+		require.False(t, paused)
+		delay, paused = th.Throttle(unsafe(250))
+		require.Equal(t, time.Second, delay, "half-way to maxLag")
+		require.False(t, paused)
+		_, paused = th.Throttle(unsafe(300))
+		require.True(t, paused, "lag at maxLag")
+	})
+
+	t.Run("slow only", func(t *testing.T) {
+		th := NewFinalityThrottle(feedback, 100, 0, 2*time.Second)
+		delay, paused := th.Throttle(unsafe(10_000))
+		require.Equal(t, 2*time.Second, delay)
+		require.False(t, paused)
+	})
+
+	t.Run("pause only", func(t *testing.T) {
+		th := NewFinalityThrottle(feedback, 0, 200, 2*time.Second)
+		delay, paused := th.Throttle(unsafe(250))
+		require.Zero(t, delay)
+		require.False(t, paused)
+		_, paused = th.Throttle(unsafe(300))
+		require.True(t, paused)
+	})
+
+	t.Run("unknown finalized head", func(t *testing.T) {
+		th := NewFinalityThrottle(&fakeFinalityFeedback{}, 100, 200, 2*time.Second)
+		delay, paused := th.Throttle(unsafe(10_000))
+		require.Zero(t, delay)
+		require.False(t, paused)
+	})
+}
@@ -2,11 +2,13 @@ package driver
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	gosync "sync"
 	"time"
 
@@ -18,11 +20,16 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 )
 
+// finalitySignalInterval is the minimum interval between L1 finality signals to prepare,
+// to rate-limit the finalization attempts of a burst of signals.
+const finalitySignalInterval = 500 * time.Millisecond
+
 var (
 	ErrSequencerAlreadyStarted = errors.New("sequencer already running")
 	ErrSequencerAlreadyStopped = errors.New("sequencer not running")
@@ -39,6 +46,12 @@ type Driver struct {
 
 	*SyncDeriver
 
+	// FinalityQueries are served by the same finalizer as the SyncDeriver, outside of the event loop.
+	FinalityQueries FinalityQueries
+	// FinalityAdmin is the operator surface of the same finalizer, for the admin API of the node.
+	// The driver does not use it.
+	FinalityAdmin finality.Admin
+
 	// Requests to block the event loop for synchronous execution to avoid reading an inconsistent state
 	stateReq chan chan struct{}
 
@@ -67,6 +80,9 @@ type Driver struct {
 
 	sequencerConductor conductor.SequencerConductor
 
+	// finalityThrottle throttles block production when finalization falls behind
+	finalityThrottle *FinalityThrottle
+
 	// Driver config: verifier and sequencer settings
 	driverConfig *Config
 
@@ -82,6 +98,15 @@ type Driver struct {
 	l1HeadSig      chan eth.L1BlockRef
 	l1SafeSig      chan eth.L1BlockRef
 	l1FinalizedSig chan eth.L1BlockRef
+	// L1 finalized signals, prepared by the finality loop, to apply on the event loop
+	l1FinalizedReady chan preparedFinality
+	// L1 finalized signals, identified by L1 block hash only
+	l1FinalizedHashSig chan common.Hash
+	// L1 finalized signals of the alternative L1 source of the shadow finalizer
+	shadowL1FinalizedSig chan eth.L1BlockRef
+
+	// shadowFinalizer runs a shadow finalizer, fed by an alternative L1 source, next to the finalizer. Nil if disabled.
+	shadowFinalizer *finality.ShadowFinalizer
 
 	// Interface to signal the L2 block range to sync.
 	altSync AltSync
@@ -128,9 +153,11 @@ func (s *Driver) Start() error {
 	}
 
 	s.asyncGossiper.Start()
+	s.Finalizer.Start(s.driverCtx)
 
-	s.wg.Add(1)
+	s.wg.Add(2)
 	go s.eventLoop()
+	go s.finalityLoop()
 
 	return nil
 }
@@ -138,6 +165,7 @@ func (s *Driver) Start() error {
 func (s *Driver) Close() error {
 	s.driverCancel()
 	s.wg.Wait()
+	s.Finalizer.Stop()
 	s.asyncGossiper.Stop()
 	s.sequencerConductor.Close()
 	return nil
@@ -174,6 +202,110 @@ func (s *Driver) OnL1Finalized(ctx context.Context, finalized eth.L1BlockRef) er
 	}
 }
 
+// preparedFinality is a batch of L1 finality signals, prepared by the finality loop, to apply on the event loop.
+type preparedFinality struct {
+	signals []eth.L1BlockRef
+	// finalized is the latest L1 finality signal of the batch.
+	finalized eth.L1BlockRef
+	// ok is set if the finalizer may accept the signals.
+	ok bool
+}
+
+// finalityLoop prepares the L1 finality signals as soon as they are received, outside of the event loop,
+// and hands them to the event loop to apply, at most once per finalitySignalInterval.
+// Signals that queue up, e.g. when catching up on the L1 finality history after reconnecting,
+// are prepared and applied as a batch, rather than attempting finalization for each of them.
+func (s *Driver) finalityLoop() {
+	defer s.wg.Done()
+	var lastPrepared time.Time
+	for {
+		var first eth.L1BlockRef
+		select {
+		case <-s.driverCtx.Done():
+			return
+		case first = <-s.l1FinalizedSig:
+		}
+		if wait := finalitySignalInterval - time.Since(lastPrepared); wait > 0 {
+			select {
+			case <-s.driverCtx.Done():
+				return
+			case <-time.After(wait):
+			}
+		}
+		lastPrepared = time.Now()
+		signals := s.drainFinalizedSignals(first)
+		ctx, cancel := context.WithTimeout(s.driverCtx, time.Second*5)
+		finalized, ok, err := s.Finalizer.PrepareFinalize(ctx, signals)
+		cancel()
+		if err != nil {
+			s.log.Error("failed to prepare L1 finality signals", "l1_finalized", signals[len(signals)-1], "err", err)
+		}
+		// the signals are handed to the event loop even if they are not accepted, to track the L1 finalized block
+		select {
+		case <-s.driverCtx.Done():
+			return
+		case s.l1FinalizedReady <- preparedFinality{signals: signals, finalized: finalized, ok: ok}:
+		}
+	}
+}
+
+// applyFinality applies the prepared L1 finality signals on the event loop.
+func (s *Driver) applyFinality(prepared preparedFinality) {
+	for _, sig := range prepared.signals {
+		s.l1State.HandleNewL1FinalizedBlock(sig)
+	}
+	if !prepared.ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(s.driverCtx, time.Second*5)
+	outcome := s.Finalizer.FinalizeRangeOutcome(ctx, prepared.signals)
+	cancel()
+	// The finalizer retries with the next signal, or when derivation progresses, so no action is taken here.
+	if outcome.Action != finality.ActionNone {
+		s.log.Debug("L1 finality signals were not applied", "l1_finalized", prepared.finalized, "action", outcome.Action, "err", outcome.Err)
+	}
+}
+
+// drainFinalizedSignals returns the given L1 finality signal, and any other queued signals, sorted by block number.
+func (s *Driver) drainFinalizedSignals(first eth.L1BlockRef) []eth.L1BlockRef {
+	signals := []eth.L1BlockRef{first}
+	for {
+		select {
+		case sig := <-s.l1FinalizedSig:
+			signals = append(signals, sig)
+		default:
+			slices.SortStableFunc(signals, func(a, b eth.L1BlockRef) int {
+				return cmp.Compare(a.Number, b.Number)
+			})
+			return signals
+		}
+	}
+}
+
+// OnShadowL1Finalized signals a new L1 finalized block of the alternative L1 source of the shadow finalizer.
+func (s *Driver) OnShadowL1Finalized(ctx context.Context, finalized eth.L1BlockRef) error {
+	if s.shadowFinalizer == nil {
+		return errors.New("shadow finalizer is disabled")
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.shadowL1FinalizedSig <- finalized:
+		return nil
+	}
+}
+
+// OnL1FinalizedHash signals a new L1 finalized block that is only identified by its hash,
+// as some light-client sources provide. The driver resolves and validates the block before applying it.
+func (s *Driver) OnL1FinalizedHash(ctx context.Context, hash common.Hash) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.l1FinalizedHashSig <- hash:
+		return nil
+	}
+}
+
 func (s *Driver) OnUnsafeL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
 	select {
 	case <-ctx.Done():
@@ -235,6 +367,11 @@ func (s *Driver) eventLoop() {
 	var sequencerCh <-chan time.Time
 	planSequencerAction := func() {
 		delay := s.sequencer.PlanNextSequencerAction()
+		if throttleDelay, _ := s.finalityThrottle.Throttle(s.Engine.UnsafeL2Head()); throttleDelay > 0 {
+			s.log.Debug("Delaying next block since finality lag exceeds limit", "delay", throttleDelay,
+				"finalized_l2", s.Engine.Finalized(), "unsafe_l2", s.Engine.UnsafeL2Head())
+			delay += throttleDelay
+		}
 		sequencerCh = sequencerTimer.C
 		if len(sequencerCh) > 0 { // empty if not already drained before resetting
 			<-sequencerCh
@@ -270,6 +407,17 @@ func (s *Driver) eventLoop() {
 					)
 					sequencerCh = nil
 				}
+			} else if _, paused := s.finalityThrottle.Throttle(s.Engine.UnsafeL2Head()); paused {
+				// If the finalized head has fallen behind by a significant number of blocks, delay creating new blocks
+				// until the finality lag is below SequencerMaxFinalityLag.
+				if sequencerCh != nil {
+					s.log.Warn(
+						"Delay creating new block since finality lag exceeds limit",
+						"finalized_l2", s.Engine.Finalized(),
+						"unsafe_l2", s.Engine.UnsafeL2Head(),
+					)
+					sequencerCh = nil
+				}
 			} else if s.sequencer.BuildingOnto().ID() != s.Engine.UnsafeL2Head().ID() {
 				// If we are sequencing, and the L1 state is ready, update the trigger for the next sequencer action.
 				// This may adjust at any time based on fork-choice changes or previous errors.
@@ -336,12 +484,24 @@ func (s *Driver) eventLoop() {
 		case newL1Safe := <-s.l1SafeSig:
 			s.l1State.HandleNewL1SafeBlock(newL1Safe)
 			// no step, justified L1 information does not do anything for L2 derivation or status
-		case newL1Finalized := <-s.l1FinalizedSig:
-			s.l1State.HandleNewL1FinalizedBlock(newL1Finalized)
+		case prepared := <-s.l1FinalizedReady:
+			s.applyFinality(prepared)
+			reqStep() // we may be able to mark more L2 data as finalized now
+		case hash := <-s.l1FinalizedHashSig:
 			ctx, cancel := context.WithTimeout(s.driverCtx, time.Second*5)
-			s.Finalizer.Finalize(ctx, newL1Finalized)
+			newL1Finalized, err := s.Finalizer.FinalizeHash(ctx, hash)
 			cancel()
+			if err != nil {
+				s.log.Warn("Ignoring L1 finalized block signal by hash", "hash", hash, "err", err)
+				continue
+			}
+			s.l1State.HandleNewL1FinalizedBlock(newL1Finalized)
 			reqStep() // we may be able to mark more L2 data as finalized now
+		case sig := <-s.shadowL1FinalizedSig:
+			// the decisions of the shadow finalizer are only compared, never applied, so no step is needed
+			ctx, cancel := context.WithTimeout(s.driverCtx, time.Second*5)
+			s.shadowFinalizer.ShadowFinalize(ctx, sig)
+			cancel()
 		case <-delayedStepReq:
 			delayedStepReq = nil
 			step()
@@ -356,6 +516,9 @@ func (s *Driver) eventLoop() {
 			if err == io.EOF {
 				s.log.Debug("Derivation process went idle", "progress", s.Derivation.Origin(), "err", err)
 				stepAttempts = 0
+				if err := s.Finalizer.OnDerivationIdle(s.driverCtx); err != nil {
+					s.log.Warn("Failed to finalize while derivation is idle", "err", err)
+				}
 				continue
 			} else if err != nil && errors.Is(err, derive.EngineELSyncing) {
 				s.log.Debug("Derivation process went idle because the engine is syncing", "progress", s.Derivation.Origin(), "unsafe_head", s.Engine.UnsafeL2Head(), "err", err)
@@ -374,6 +537,9 @@ func (s *Driver) eventLoop() {
 					continue
 				}
 				s.Derivation.ConfirmEngineReset()
+				ctx, cancel := context.WithTimeout(s.driverCtx, time.Second*5)
+				s.Finalizer.OnResetComplete(ctx)
+				cancel()
 				continue
 			} else if err != nil && errors.Is(err, derive.ErrTemporary) {
 				s.log.Warn("Derivation process temporary error", "attempts", stepAttempts, "err", err)
@@ -457,6 +623,14 @@ type SyncDeriver struct {
 	// The engine controller is used by the sequencer & Derivation components.
 	// We will also use it for EL sync in a future PR.
 	Engine EngineController
+	// engineSyncing is true while the engine was EL syncing, to notify the finalizer once it is ready.
+	engineSyncing bool
+
+	// finalityOrigin is the derivation origin last reported to the finalizer, and finalitySafeHead the safe head.
+	finalityOrigin   eth.L1BlockRef
+	finalitySafeHead eth.L2BlockRef
+	// originDerived is true once any L2 block was derived from the finalityOrigin.
+	originDerived bool
 }
 
 // SyncStep performs the sequence of encapsulated syncing steps.
@@ -476,8 +650,13 @@ func (s *SyncDeriver) SyncStep(ctx context.Context) error {
 
 	if s.Engine.IsEngineSyncing() {
 		// The pipeline cannot move forwards if doing EL sync.
+		s.engineSyncing = true
 		return derive.EngineELSyncing
 	}
+	if s.engineSyncing {
+		s.engineSyncing = false
+		s.Finalizer.OnEngineReady(ctx)
+	}
 
 	// Trying unsafe payload should be done before safe attributes
 	// It allows the unsafe head to move forward while the long-range consolidation is in progress.
@@ -505,8 +684,8 @@ func (s *SyncDeriver) SyncStep(ctx context.Context) error {
 	s.Finalizer.PostProcessSafeL2(s.Engine.SafeL2Head(), derivationOrigin)
 
 	// try to finalize the L2 blocks we have synced so far (no-op if L1 finality is behind)
-	if err := s.Finalizer.OnDerivationL1End(ctx, derivationOrigin); err != nil {
-		return fmt.Errorf("finalizer OnDerivationL1End error: %w", err)
+	if err := s.finalizeDerived(ctx, derivationOrigin); err != nil {
+		return err
 	}
 
 	attr, err := s.Derivation.Step(ctx, s.Engine.PendingSafeL2Head())
@@ -518,6 +697,39 @@ func (s *SyncDeriver) SyncStep(ctx context.Context) error {
 	return nil
 }
 
+// finalizeDerived reports the progress of derivation to the finalizer: L1 blocks that L2 blocks were derived from
+// end their derivation, and L1 blocks that were traversed without deriving any L2 blocks are reported as such.
+func (s *SyncDeriver) finalizeDerived(ctx context.Context, derivationOrigin eth.L1BlockRef) error {
+	if derivationOrigin != s.finalityOrigin {
+		s.finalityOrigin = derivationOrigin
+		s.originDerived = false
+	}
+	if safe := s.Engine.SafeL2Head(); safe != s.finalitySafeHead {
+		s.finalitySafeHead = safe
+		s.originDerived = true
+	}
+	method := "OnDerivationL1End"
+	var outcome finality.FinalityOutcome
+	if s.originDerived {
+		outcome = s.Finalizer.OnDerivationL1EndOutcome(ctx, derivationOrigin)
+	} else {
+		method = "OnL1Traversed"
+		outcome = s.Finalizer.OnL1TraversedOutcome(ctx, derivationOrigin)
+	}
+	switch outcome.Action {
+	case finality.ActionNone:
+		return nil
+	case finality.ActionRetry:
+		return derive.NewTemporaryError(fmt.Errorf("finalizer %s error: %w", method, outcome.Err))
+	case finality.ActionReset:
+		return derive.NewResetError(fmt.Errorf("finalizer %s error: %w", method, outcome.Err))
+	case finality.ActionHalt:
+		return derive.NewCriticalError(fmt.Errorf("finalizer %s error: %w", method, outcome.Err))
+	default:
+		return fmt.Errorf("finalizer %s requires unknown action %s: %w", method, outcome.Action, outcome.Err)
+	}
+}
+
 // ResetDerivationPipeline forces a reset of the derivation pipeline.
 // It waits for the reset to occur. It simply unblocks the caller rather
 // than fully cancelling the reset request upon a context cancellation.
@@ -644,6 +856,84 @@ func (s *Driver) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2Bloc
 	}
 }
 
+// FinalityStatus returns the finality status of the finalizer,
+// including why the last attempt to finalize L2 blocks did or did not advance.
+func (s *Driver) FinalityStatus(ctx context.Context) (*finality.FinalityStatus, error) {
+	status := s.FinalityQueries.Status()
+	return &status, nil
+}
+
+// FinalityStatusJSON returns the JSON encoding of the last published finality status,
+// without taking the lock of the finalizer, for high-frequency monitoring.
+func (s *Driver) FinalityStatusJSON(ctx context.Context) (json.RawMessage, error) {
+	return s.FinalityQueries.CachedStatusJSON(), nil
+}
+
+// FinalitySnapshot returns the finality data buffered by the finalizer,
+// including the batch inclusion of the L1 blocks the L2 chain was derived from.
+func (s *Driver) FinalitySnapshot(ctx context.Context) (*finality.Snapshot, error) {
+	return s.FinalityQueries.Snapshot(), nil
+}
+
+// FinalityAudit returns the most recent finalized head updates of the finalizer, including refused updates.
+func (s *Driver) FinalityAudit(ctx context.Context) ([]finality.FinalizedHeadUpdate, error) {
+	return s.FinalityQueries.AuditTrail(), nil
+}
+
+// FinalityCasualties returns the most recent finality data entries the finalizer pruned before they were finalized.
+func (s *Driver) FinalityCasualties(ctx context.Context) ([]finality.PruningCasualty, error) {
+	return s.FinalityQueries.PruningCasualties(), nil
+}
+
+// FinalizedAtTime returns the newest finalized L2 block with a timestamp at or before the given time.
+func (s *Driver) FinalizedAtTime(ctx context.Context, timestamp uint64) (eth.L2BlockRef, error) {
+	return s.FinalityQueries.FinalizedAtTime(ctx, timestamp)
+}
+
+// FinalityDerivedFrom returns the L1 block the given L2 block was fully derived from, according to the finality data.
+func (s *Driver) FinalityDerivedFrom(ctx context.Context, l2Number uint64) (eth.BlockID, error) {
+	return s.FinalityQueries.DerivedFrom(l2Number)
+}
+
+// FinalizedAtLeast returns whether the given L2 block number is finalized, as of the last published finality status.
+func (s *Driver) FinalizedAtLeast(ctx context.Context, l2Number uint64) (bool, error) {
+	return s.FinalityQueries.FinalizedAtLeast(l2Number)
+}
+
+// EstimateFinality estimates when the given L2 block will be finalized.
+func (s *Driver) EstimateFinality(ctx context.Context, l2Number uint64) (finality.FinalityEstimate, error) {
+	return s.FinalityQueries.EstimateFinality(l2Number)
+}
+
+// Synchronized blocks the driver event loop while fn runs, for operations that must not run concurrently
+// with derivation, like the admin operations of the finalizer that update the engine.
+// If the event loop is too busy and the context expires, a context error is returned.
+func (s *Driver) Synchronized(ctx context.Context, fn func() error) error {
+	wait := make(chan struct{})
+	select {
+	case s.stateReq <- wait:
+		err := fn()
+		<-wait
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetFakeFinalizedL1 injects a synthetic L1 finality signal for the L1 block with the given number,
+// to exercise the finalization path on devnets without a beacon chain. It returns the signaled L1 block.
+func (s *Driver) SetFakeFinalizedL1(ctx context.Context, number uint64) (eth.L1BlockRef, error) {
+	if !s.driverConfig.Finality.FakeSignals {
+		return eth.L1BlockRef{}, errors.New("fake finality signals are not enabled")
+	}
+	ref, err := s.l1.L1BlockRefByNumber(ctx, number)
+	if err != nil {
+		return eth.L1BlockRef{}, fmt.Errorf("failed to fetch L1 block %d to signal as finalized: %w", number, err)
+	}
+	s.log.Warn("Injecting fake L1 finality signal, this is for devnets only!", "l1", ref)
+	return ref, s.OnL1Finalized(ctx, ref)
+}
+
 // deferJSONString helps avoid a JSON-encoding performance hit if the snapshot logger does not run
 type deferJSONString struct {
 	x any
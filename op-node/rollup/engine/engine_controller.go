@@ -36,6 +36,13 @@ const (
 
 var ErrNoFCUNeeded = errors.New("no FCU call was needed")
 
+// ForkchoiceAck is how the engine responded to the last forkchoice update that did not build a block:
+// the payload status, and the finalized L2 head the update applied.
+type ForkchoiceAck struct {
+	Finalized eth.BlockID
+	Status    eth.ExecutePayloadStatus
+}
+
 type ExecEngine interface {
 	GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error)
 	ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
@@ -61,6 +68,8 @@ type EngineController struct {
 	finalizedHead    eth.L2BlockRef
 	backupUnsafeHead eth.L2BlockRef
 	needFCUCall      bool
+	// lastAck is the response of the engine to the last forkchoice update that did not build a block.
+	lastAck ForkchoiceAck
 	// Track when the rollup node changes the forkchoice to restore previous
 	// known unsafe chain. e.g. Unsafe Reorg caused by Invalid span batch.
 	// This update does not retry except engine returns non-input error
@@ -353,7 +362,7 @@ func (e *EngineController) TryUpdateEngine(ctx context.Context) error {
 	}
 	logFn := e.logSyncProgressMaybe()
 	defer logFn()
-	_, err := e.engine.ForkchoiceUpdate(ctx, &fc, nil)
+	fcRes, err := e.engine.ForkchoiceUpdate(ctx, &fc, nil)
 	if err != nil {
 		var inputErr eth.InputError
 		if errors.As(err, &inputErr) {
@@ -367,10 +376,25 @@ func (e *EngineController) TryUpdateEngine(ctx context.Context) error {
 			return derive.NewTemporaryError(fmt.Errorf("failed to sync forkchoice with engine: %w", err))
 		}
 	}
+	e.recordAck(fcRes)
 	e.needFCUCall = false
 	return nil
 }
 
+// recordAck records the response of the engine to a forkchoice update of the current forkchoice state.
+func (e *EngineController) recordAck(fcRes *eth.ForkchoiceUpdatedResult) {
+	if fcRes == nil {
+		return
+	}
+	e.lastAck = ForkchoiceAck{Finalized: e.finalizedHead.ID(), Status: fcRes.PayloadStatus.Status}
+}
+
+// LastForkchoiceAck returns how the engine responded to the last forkchoice update that did not build a block,
+// for the finalizer to verify the engine accepted the finalized L2 head.
+func (e *EngineController) LastForkchoiceAck() ForkchoiceAck {
+	return e.lastAck
+}
+
 func (e *EngineController) InsertUnsafePayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope, ref eth.L2BlockRef) error {
 	// Check if there is a finalized head once when doing EL sync. If so, transition to CL sync
 	if e.syncStatus == syncStatusWillStartEL {
@@ -427,6 +451,7 @@ func (e *EngineController) InsertUnsafePayload(ctx context.Context, envelope *et
 			return derive.NewTemporaryError(fmt.Errorf("failed to update forkchoice to prepare for new unsafe payload: %w", err))
 		}
 	}
+	e.recordAck(fcRes)
 	if !e.checkForkchoiceUpdatedStatus(fcRes.PayloadStatus.Status) {
 		payload := envelope.ExecutionPayload
 		return derive.NewTemporaryError(fmt.Errorf("cannot prepare unsafe chain for new payload: new - %v; parent: %v; err: %w",
@@ -0,0 +1,60 @@
+package finality
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// AckFinalizerEngine is implemented by engines that report how the execution engine responded
+// to the last forkchoice update, so the Finalizer can verify the engine accepted the finalized L2 head.
+// Engines that do not implement it are assumed to accept every finalized L2 head they apply without error.
+type AckFinalizerEngine interface {
+	LastForkchoiceAck() engine.ForkchoiceAck
+}
+
+// ErrFinalizationNotAcked is returned when the engine applied the forkchoice update of a finalized L2 head,
+// but did not acknowledge it as VALID.
+type ErrFinalizationNotAcked struct {
+	Finalized eth.L2BlockRef
+	Status    eth.ExecutePayloadStatus
+}
+
+func (e *ErrFinalizationNotAcked) Error() string {
+	return fmt.Sprintf("engine responded %s to finalized L2 head %s", e.Status, e.Finalized)
+}
+
+// checkAck verifies the engine acknowledged the forkchoice update that applied the given finalized L2 head as VALID.
+// A SYNCING or ACCEPTED engine may not have applied the finalized head, so it is retried like a failed engine call.
+// An INVALID response means the engine does not consider the finalized head canonical, which requires a reset.
+// The response is recorded in the audit trail. The lock must be held.
+func (fi *Finalizer) checkAck(finalizedL2 eth.L2BlockRef) error {
+	ae, ok := fi.ec.(AckFinalizerEngine)
+	if !ok {
+		return nil
+	}
+	ack := ae.LastForkchoiceAck()
+	if ack.Finalized != finalizedL2.ID() {
+		// the engine did not report a response to this forkchoice update
+		return nil
+	}
+	if n := len(fi.auditTrail); n > 0 && fi.auditTrail[n-1].Next == finalizedL2 {
+		fi.auditTrail[n-1].Ack = string(ack.Status)
+	}
+	fi.metrics.RecordFinalityEngineAck(string(ack.Status))
+	err := &ErrFinalizationNotAcked{Finalized: finalizedL2, Status: ack.Status}
+	switch ack.Status {
+	case eth.ExecutionValid:
+		return nil
+	case eth.ExecutionInvalid, eth.ExecutionInvalidBlockHash:
+		fi.counters.FinalizationsRejected += 1
+		fi.log.Error("engine rejected finalized L2 head", "finalized_l2", finalizedL2, "status", ack.Status)
+		return derive.NewResetError(err)
+	default:
+		fi.counters.FinalizationsUnacked += 1
+		fi.log.Warn("engine did not acknowledge finalized L2 head", "finalized_l2", finalizedL2, "status", ack.Status)
+		return derive.NewTemporaryError(err)
+	}
+}
@@ -0,0 +1,78 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/testutil"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestFinalizerEngineAck(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	setup := func(t *testing.T) (*Finalizer, *testutil.Engine, *fakeMetrics, *clock.DeterministicClock) {
+		ec := testutil.NewEngine(chain.l2[0][1])
+		m := &fakeMetrics{}
+		clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+		fi := NewFinalizer(testlog.Logger(t, log.LevelCrit), &rollup.Config{}, testutil.NewL1(chain.l1...), ec,
+			WithMetrics(m), WithClock(clk), WithRetryStrategy(retry.Fixed(10*time.Second)))
+		fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[1]))
+		return fi, ec, m, clk
+	}
+
+	t.Run("syncing", func(t *testing.T) {
+		fi, ec, m, clk := setup(t)
+		ec.AckNext(eth.ExecutionSyncing, eth.ExecutionSyncing)
+		fi.Finalize(context.Background(), chain.l1[1])
+		require.Equal(t, eth.L2BlockRef{}, ec.Applied(), "the syncing engine did not apply the finalized head")
+		require.Equal(t, chain.l2[1][1], fi.pendingFinalized, "retry the unacknowledged finalized head")
+
+		// still syncing after the backoff
+		clk.AdvanceTime(10 * time.Second)
+		err := fi.OnDerivationL1End(context.Background(), chain.l1[2])
+		var notAcked *ErrFinalizationNotAcked
+		require.ErrorAs(t, err, &notAcked)
+		require.Equal(t, eth.ExecutionSyncing, notAcked.Status)
+		require.ErrorIs(t, err, derive.ErrTemporary)
+
+		// the engine acknowledges the finalized head once synced
+		clk.AdvanceTime(20 * time.Second)
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+		ec.RequireFinalized(t, chain.l2[1][1])
+		require.Equal(t, eth.L2BlockRef{}, fi.pendingFinalized)
+
+		bundle := fi.DebugBundle()
+		require.Equal(t, uint64(2), bundle.Counters.FinalizationsUnacked)
+		require.Zero(t, bundle.Counters.FinalizationsRejected)
+		require.Equal(t, map[string]int{"SYNCING": 2, "VALID": 1}, m.engineAcks)
+		trail := bundle.AuditTrail
+		require.Equal(t, string(eth.ExecutionValid), trail[len(trail)-1].Ack)
+		require.Equal(t, string(eth.ExecutionSyncing), trail[len(trail)-2].Ack)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		fi, ec, m, _ := setup(t)
+		ec.AckNext(eth.ExecutionInvalid)
+		out := fi.FinalizeOutcome(context.Background(), chain.l1[1])
+		var notAcked *ErrFinalizationNotAcked
+		require.ErrorAs(t, out.Err, &notAcked)
+		require.Equal(t, chain.l2[1][1], notAcked.Finalized)
+		require.ErrorIs(t, out.Err, derive.ErrReset)
+		require.Equal(t, eth.L2BlockRef{}, ec.Applied())
+		require.Equal(t, uint64(1), fi.DebugBundle().Counters.FinalizationsRejected)
+		require.Equal(t, map[string]int{"INVALID": 1}, m.engineAcks)
+	})
+}
@@ -0,0 +1,145 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// cheapL1Latency is the average L1 fetch latency below which fetches are considered cheap, e.g. served from a cache,
+	// and the finality delay is shortened.
+	cheapL1Latency = 50 * time.Millisecond
+	// slowL1Latency is the average L1 fetch latency above which the L1 provider is considered under load,
+	// and the finality delay is lengthened.
+	slowL1Latency = 500 * time.Millisecond
+	// rpcLimitExceeded is the JSON-RPC error code of providers that reject requests over their rate limit, see EIP-1474.
+	rpcLimitExceeded = -32005
+)
+
+// WithAdaptiveDelay replaces the fixed finality delay with an adaptive delay, bounded by minDelay and maxDelay L1 blocks.
+// The delay is shortened while L1 fetches are cheap, and lengthened while the L1 provider is slow or rate limits requests,
+// to reduce the average finality lag without increasing the load on a constrained L1 provider.
+// The fixed delay is the initial delay. Disabled if maxDelay is 0.
+func WithAdaptiveDelay(minDelay, maxDelay uint64) FinalizerOption {
+	return func(fi *Finalizer) {
+		if maxDelay == 0 {
+			fi.adaptive = nil
+			return
+		}
+		fi.adaptive = &delayController{min: max(minDelay, 1), max: max(maxDelay, minDelay, 1)}
+	}
+}
+
+// delayController adapts the finality delay to the observed cost of the L1 fetches.
+// Observations are made by the concurrent L1 fetches of an attempt, so it has its own lock.
+type delayController struct {
+	min, max uint64
+
+	mu sync.Mutex
+	// delay is the current finality delay.
+	delay uint64
+	// latency is the moving average of the L1 fetch latency, and observed is set if any fetch was made since the last update.
+	latency  time.Duration
+	observed bool
+	// rateLimited is set if an L1 fetch was rate limited since the last update.
+	rateLimited bool
+}
+
+// observe records the latency and error of an L1 fetch.
+func (dc *delayController) observe(latency time.Duration, err error) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	if isRateLimited(err) {
+		dc.rateLimited = true
+		return
+	}
+	if dc.latency == 0 {
+		dc.latency = latency
+	} else {
+		dc.latency = (7*dc.latency + latency) / 8
+	}
+	dc.observed = true
+}
+
+// update adapts the delay to the L1 fetches observed since the last update, and returns the new delay.
+// The delay doubles when rate limited, and otherwise moves by a quarter, to back off quickly and recover gradually.
+func (dc *delayController) update() uint64 {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	switch {
+	case dc.rateLimited:
+		dc.delay *= 2
+	case !dc.observed:
+	case dc.latency < cheapL1Latency:
+		dc.delay -= dc.delay / 4
+	case dc.latency > slowL1Latency:
+		dc.delay += dc.delay/4 + 1
+	}
+	dc.delay = min(max(dc.delay, dc.min), dc.max)
+	dc.rateLimited = false
+	dc.observed = false
+	return dc.delay
+}
+
+// isRateLimited returns true if the L1 provider rejected a request due to rate limiting.
+func isRateLimited(err error) bool {
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == rpcLimitExceeded
+}
+
+// observedL1 reports the latency and errors of the L1 fetches of the Finalizer to the delay controller.
+type observedL1 struct {
+	FinalizerL1Interface
+	clock clock.Clock
+	dc    *delayController
+}
+
+func (o *observedL1) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	start := o.clock.Now()
+	ref, err := o.FinalizerL1Interface.L1BlockRefByNumber(ctx, num)
+	o.dc.observe(o.clock.Since(start), err)
+	return ref, err
+}
+
+func (o *observedL1) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	start := o.clock.Now()
+	ref, err := o.FinalizerL1Interface.L1BlockRefByHash(ctx, hash)
+	o.dc.observe(o.clock.Since(start), err)
+	return ref, err
+}
+
+// initAdaptiveDelay starts the adaptive delay at the fixed delay, and observes the L1 fetches, if enabled.
+func (fi *Finalizer) initAdaptiveDelay() {
+	if fi.adaptive == nil {
+		return
+	}
+	fi.adaptive.delay = min(max(fi.finalityDelay, fi.adaptive.min), fi.adaptive.max)
+	fi.finalityDelay = fi.adaptive.delay
+	fi.l1Fetcher = &observedL1{FinalizerL1Interface: fi.l1Fetcher, clock: fi.clock, dc: fi.adaptive}
+	fi.metrics.RecordFinalityDelay(fi.finalityDelay)
+}
+
+// adaptDelay updates the finality delay after an attempt to finalize, if the delay is adaptive. The lock must be held.
+func (fi *Finalizer) adaptDelay() {
+	if fi.adaptive == nil {
+		return
+	}
+	if delay := fi.adaptive.update(); delay != fi.finalityDelay {
+		fi.log.Debug("adapted finality delay to L1 fetch cost", "prev_delay", fi.finalityDelay, "delay", delay)
+		fi.finalityDelay = delay
+		fi.metrics.RecordFinalityDelay(delay)
+	}
+}
@@ -0,0 +1,117 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type rpcCodeError struct{ code int }
+
+func (e rpcCodeError) Error() string  { return fmt.Sprintf("rpc error %d", e.code) }
+func (e rpcCodeError) ErrorCode() int { return e.code }
+
+func TestDelayController(t *testing.T) {
+	newController := func() *delayController {
+		return &delayController{min: 8, max: 256, delay: 64}
+	}
+
+	t.Run("unobserved", func(t *testing.T) {
+		dc := newController()
+		require.Equal(t, uint64(64), dc.update())
+	})
+
+	t.Run("cheap", func(t *testing.T) {
+		dc := newController()
+		dc.observe(time.Millisecond, nil)
+		require.Equal(t, uint64(48), dc.update())
+		for i := 0; i < 20; i++ {
+			dc.observe(time.Millisecond, nil)
+			dc.update()
+		}
+		require.Equal(t, uint64(8), dc.update(), "bounded by min")
+	})
+
+	t.Run("slow", func(t *testing.T) {
+		dc := newController()
+		dc.observe(time.Second, nil)
+		require.Equal(t, uint64(81), dc.update())
+		for i := 0; i < 20; i++ {
+			dc.observe(time.Second, nil)
+			dc.update()
+		}
+		require.Equal(t, uint64(256), dc.update(), "bounded by max")
+	})
+
+	t.Run("moderate", func(t *testing.T) {
+		dc := newController()
+		dc.observe(200*time.Millisecond, nil)
+		require.Equal(t, uint64(64), dc.update())
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		for _, err := range []error{
+			rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"},
+			fmt.Errorf("wrapped: %w", rpcCodeError{code: rpcLimitExceeded}),
+		} {
+			dc := newController()
+			dc.observe(time.Millisecond, nil)
+			dc.observe(time.Millisecond, err)
+			require.Equal(t, uint64(128), dc.update(), err.Error())
+		}
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		require.False(t, isRateLimited(errors.New("oops")))
+		require.False(t, isRateLimited(rpc.HTTPError{StatusCode: 500}))
+		require.False(t, isRateLimited(rpcCodeError{code: -32000}))
+	})
+}
+
+func TestFinalizerAdaptiveDelay(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelCrit)
+
+	t.Run("disabled", func(t *testing.T) {
+		fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{}, WithAdaptiveDelay(8, 0))
+		require.Nil(t, fi.adaptive)
+		require.Equal(t, uint64(finalityDelay), fi.finalityDelay)
+	})
+
+	t.Run("initial delay bounded", func(t *testing.T) {
+		m := &fakeMetrics{}
+		fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{}, WithMetrics(m), WithAdaptiveDelay(2, 16))
+		require.Equal(t, uint64(16), fi.finalityDelay)
+		require.Equal(t, uint64(16), m.delay)
+	})
+
+	t.Run("shortened by cheap fetches", func(t *testing.T) {
+		l1F := &testutils.MockL1Source{}
+		l1F.Mock.On("L1BlockRefByNumber", chain.l1[2].Number).Return(chain.l1[2], nil)
+		ec := &fakeEngine{}
+		ec.finalized = chain.l2[0][1]
+		m := &fakeMetrics{}
+		fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithMetrics(m), WithAdaptiveDelay(8, 256))
+		for i := 1; i < 4; i++ {
+			fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		}
+		fi.Finalize(context.Background(), chain.l1[2])
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+		require.Equal(t, chain.l2[2][1], ec.Finalized())
+		require.Equal(t, uint64(48), fi.finalityDelay)
+		require.Equal(t, uint64(48), m.delay)
+	})
+}
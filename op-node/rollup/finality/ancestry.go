@@ -0,0 +1,49 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// WithAncestryCheck enables verification that every new finality signal is a descendant of the previous signal,
+// by walking back the parent-hashes of the new signal, at most maxDepth blocks.
+// Signals that jump further ahead than maxDepth are refused, since their ancestry cannot be verified:
+// maxDepth must cover the L1 blocks that may finalize between two signals, e.g. during an outage of the L1 provider.
+// The first signal after a restart is not checked, as there is no previous signal.
+// This protects against an L1 provider serving a finalized header of a different network.
+func WithAncestryCheck(maxDepth uint64) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.ancestryCheckDepth = maxDepth
+	}
+}
+
+// checkAncestry verifies that next builds on prev, if the ancestry check is enabled.
+func (fi *Finalizer) checkAncestry(ctx context.Context, prev eth.L1BlockRef, next eth.L1BlockRef) error {
+	if fi.ancestryCheckDepth == 0 || prev == (eth.L1BlockRef{}) {
+		return nil
+	}
+	if next.Number == prev.Number {
+		return fmt.Errorf("conflicting finalized block %s at the height of previous finalized block %s", next, prev)
+	}
+	if next.Number-prev.Number > fi.ancestryCheckDepth {
+		return fmt.Errorf("cannot verify ancestry of L1 block %s, it is more than %d blocks ahead of previous finalized block %s",
+			next, fi.ancestryCheckDepth, prev)
+	}
+	cur := next
+	for cur.Number > prev.Number+1 {
+		parent, err := fi.l1Fetcher.L1BlockRefByHash(ctx, cur.ParentHash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch parent %s of L1 block %s: %w", cur.ParentHash, cur, err)
+		}
+		if parent.Number+1 != cur.Number {
+			return fmt.Errorf("parent %s of L1 block %s has unexpected block number", parent, cur)
+		}
+		cur = parent
+	}
+	if cur.ParentHash != prev.Hash {
+		return fmt.Errorf("L1 block %s does not build on previous finalized block %s", cur, prev)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerAncestryCheck(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 6)
+
+	t.Run("descendant", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		l1F := &testutils.MockL1Source{}
+		defer l1F.AssertExpectations(t)
+		l1F.ExpectL1BlockRefByHash(chain.l1[3].ParentHash, chain.l1[2], nil)
+
+		fi := NewFinalizer(logger, &rollup.Config{}, l1F, &fakeEngine{}, WithAncestryCheck(10))
+		fi.Finalize(context.Background(), chain.l1[1])
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l1[3], fi.FinalizedL1())
+	})
+
+	t.Run("other-chain", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		l1F := &testutils.MockL1Source{}
+		defer l1F.AssertExpectations(t)
+		other := testutils.NextRandomRef(rng, chain.l1[0])
+		other.ParentHash = testutils.RandomHash(rng) // does not build on chain.l1[0]
+		otherChild := testutils.NextRandomRef(rng, other)
+		l1F.ExpectL1BlockRefByHash(otherChild.ParentHash, other, nil)
+
+		fi := NewFinalizer(logger, &rollup.Config{}, l1F, &fakeEngine{}, WithAncestryCheck(10))
+		fi.Finalize(context.Background(), chain.l1[0])
+		fi.Finalize(context.Background(), otherChild)
+		require.Equal(t, chain.l1[0], fi.FinalizedL1(), "signal of other chain is rejected")
+
+		// conflicting signal at the same height is rejected without any lookups
+		conflict := chain.l1[0]
+		conflict.Hash = testutils.RandomHash(rng)
+		fi.Finalize(context.Background(), conflict)
+		require.Equal(t, chain.l1[0], fi.FinalizedL1(), "conflicting signal is rejected")
+		fi.Finalize(context.Background(), chain.l1[1])
+		require.Equal(t, chain.l1[1], fi.FinalizedL1(), "direct child is verified without lookups")
+	})
+
+	t.Run("too-far", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		l1F := &testutils.MockL1Source{}
+		defer l1F.AssertExpectations(t)
+
+		l1F.ExpectL1BlockRefByHash(chain.l1[2].ParentHash, chain.l1[1], nil)
+
+		fi := NewFinalizer(logger, &rollup.Config{}, l1F, &fakeEngine{}, WithAncestryCheck(2))
+		fi.Finalize(context.Background(), chain.l1[0])
+		fi.Finalize(context.Background(), chain.l1[5])
+		require.Equal(t, chain.l1[0], fi.FinalizedL1(), "signal beyond the check depth is refused")
+		fi.Finalize(context.Background(), chain.l1[2])
+		require.Equal(t, chain.l1[2], fi.FinalizedL1(), "signal within the check depth is verified")
+	})
+}
@@ -0,0 +1,146 @@
+package finality
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// announceTimeout bounds the Engine API calls of the FinalizedAnnouncer.
+const announceTimeout = 5 * time.Second
+
+// AnnouncerEngine is the execution client that the finalized L2 head is announced to.
+type AnnouncerEngine interface {
+	ExchangeCapabilities(ctx context.Context, methods []eth.EngineAPIMethod) ([]eth.EngineAPIMethod, error)
+	AnnounceFinalizedV1(ctx context.Context, announcement *eth.FinalizedAnnouncementV1) error
+}
+
+// FinalizedAnnouncer announces the finalized L2 head, with the L1 block it was derived from, to the execution client,
+// through the engine_announceFinalizedV1 Engine API extension, after every finalized L2 head advancement.
+// The extension is negotiated with engine_exchangeCapabilities first: if the execution client does not support it,
+// nothing is announced. Announcements are sent asynchronously, and only the latest finalized L2 head is announced,
+// if the finalized L2 head advanced again while an announcement is being sent.
+type FinalizedAnnouncer struct {
+	log    log.Logger
+	engine AnnouncerEngine
+
+	mu      sync.Mutex
+	pending *eth.FinalizedAnnouncementV1
+
+	// supported is set once the execution client confirmed that it supports the extension.
+	supported bool
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewFinalizedAnnouncer(log log.Logger, engine AnnouncerEngine) *FinalizedAnnouncer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &FinalizedAnnouncer{
+		log:    log,
+		engine: engine,
+		wake:   make(chan struct{}, 1),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (fa *FinalizedAnnouncer) Start() {
+	fa.wg.Add(1)
+	go fa.loop()
+}
+
+func (fa *FinalizedAnnouncer) Close() {
+	fa.cancel()
+	fa.wg.Wait()
+}
+
+// OnFinalized queues the new finalized L2 head to be announced. It is a FinalizedSubscriber, and does not block.
+func (fa *FinalizedAnnouncer) OnFinalized(ev FinalizedEvent) {
+	if len(ev.DerivedFrom) == 0 {
+		return // the L1 origin of the finalized L2 head is unknown, e.g. when it was re-applied
+	}
+	derivedFrom := ev.DerivedFrom[len(ev.DerivedFrom)-1]
+	fa.mu.Lock()
+	fa.pending = &eth.FinalizedAnnouncementV1{
+		FinalizedBlockHash: ev.FinalizedL2.Hash,
+		FinalizedNumber:    eth.Uint64Quantity(ev.FinalizedL2.Number),
+		DerivedFromHash:    derivedFrom.Hash,
+		DerivedFromNumber:  eth.Uint64Quantity(derivedFrom.Number),
+	}
+	fa.mu.Unlock()
+	select {
+	case fa.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (fa *FinalizedAnnouncer) loop() {
+	defer fa.wg.Done()
+	for {
+		select {
+		case <-fa.ctx.Done():
+			return
+		case <-fa.wake:
+		}
+		if !fa.announcePending() {
+			return
+		}
+	}
+}
+
+// announcePending announces the pending finalized L2 head, if any.
+// It returns false if the execution client does not support the extension, and nothing is to be announced anymore.
+func (fa *FinalizedAnnouncer) announcePending() bool {
+	if !fa.supported {
+		supported, err := fa.negotiate()
+		if err != nil {
+			// negotiated again with the next announcement
+			fa.log.Warn("failed to exchange Engine API capabilities, cannot announce finalized L2 head", "err", err)
+			return true
+		}
+		if !supported {
+			fa.log.Info("execution client does not support finalized L2 head announcements, disabling them",
+				"method", eth.AnnounceFinalizedV1)
+			return false
+		}
+		fa.supported = true
+	}
+	fa.mu.Lock()
+	announcement := fa.pending
+	fa.pending = nil
+	fa.mu.Unlock()
+	if announcement == nil {
+		return true
+	}
+	ctx, cancel := context.WithTimeout(fa.ctx, announceTimeout)
+	err := fa.engine.AnnounceFinalizedV1(ctx, announcement)
+	cancel()
+	if err != nil {
+		// the next advancement is announced again
+		fa.log.Warn("failed to announce finalized L2 head to execution client",
+			"finalized", announcement.FinalizedBlockHash, "derived_from", announcement.DerivedFromHash, "err", err)
+		return true
+	}
+	fa.log.Debug("announced finalized L2 head to execution client",
+		"finalized", announcement.FinalizedBlockHash, "derived_from", announcement.DerivedFromHash)
+	return true
+}
+
+// negotiate returns if the execution client supports the finalized L2 head announcements.
+func (fa *FinalizedAnnouncer) negotiate() (bool, error) {
+	ctx, cancel := context.WithTimeout(fa.ctx, announceTimeout)
+	defer cancel()
+	methods, err := fa.engine.ExchangeCapabilities(ctx, []eth.EngineAPIMethod{eth.AnnounceFinalizedV1})
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(methods, eth.AnnounceFinalizedV1), nil
+}
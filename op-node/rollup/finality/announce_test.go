@@ -0,0 +1,73 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeAnnouncerEngine struct {
+	capabilities  []eth.EngineAPIMethod
+	exchangeErr   error
+	exchanges     int
+	announcements []eth.FinalizedAnnouncementV1
+}
+
+func (e *fakeAnnouncerEngine) ExchangeCapabilities(ctx context.Context, methods []eth.EngineAPIMethod) ([]eth.EngineAPIMethod, error) {
+	e.exchanges += 1
+	return e.capabilities, e.exchangeErr
+}
+
+func (e *fakeAnnouncerEngine) AnnounceFinalizedV1(ctx context.Context, announcement *eth.FinalizedAnnouncementV1) error {
+	e.announcements = append(e.announcements, *announcement)
+	return nil
+}
+
+func TestFinalizedAnnouncer(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	finalized := eth.L2BlockRef{Hash: common.Hash{0x02}, Number: 20}
+	ev := FinalizedEvent{
+		FinalizedL2: finalized,
+		DerivedFrom: []eth.BlockID{{Hash: common.Hash{0x10}, Number: 9}, {Hash: common.Hash{0x11}, Number: 10}},
+	}
+
+	t.Run("supported", func(t *testing.T) {
+		engine := &fakeAnnouncerEngine{exchangeErr: errors.New("engine unavailable")}
+		fa := NewFinalizedAnnouncer(logger, engine)
+		fa.OnFinalized(ev)
+		require.True(t, fa.announcePending(), "the negotiation is retried")
+		require.Empty(t, engine.announcements)
+
+		engine.exchangeErr = nil
+		engine.capabilities = []eth.EngineAPIMethod{eth.NewPayloadV3, eth.AnnounceFinalizedV1}
+		require.True(t, fa.announcePending())
+		require.Equal(t, []eth.FinalizedAnnouncementV1{{
+			FinalizedBlockHash: finalized.Hash,
+			FinalizedNumber:    20,
+			DerivedFromHash:    common.Hash{0x11},
+			DerivedFromNumber:  10,
+		}}, engine.announcements)
+
+		// the capabilities are only negotiated once
+		fa.OnFinalized(ev)
+		require.True(t, fa.announcePending())
+		require.Len(t, engine.announcements, 2)
+		require.Equal(t, 2, engine.exchanges)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		engine := &fakeAnnouncerEngine{capabilities: []eth.EngineAPIMethod{eth.NewPayloadV3}}
+		fa := NewFinalizedAnnouncer(logger, engine)
+		fa.OnFinalized(ev)
+		require.False(t, fa.announcePending())
+		require.Empty(t, engine.announcements)
+	})
+}
@@ -0,0 +1,88 @@
+package finality
+
+import (
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/api"
+)
+
+// The public types of the Finalizer are defined in the api package, which is versioned independently.
+
+type (
+	FinalizeReason        = api.FinalizeReason
+	FinalityStatus        = api.FinalityStatus
+	SignalProvenance      = api.SignalProvenance
+	FinalizedEvent        = api.FinalizedEvent
+	SpanBatchBoundary     = api.SpanBatchBoundary
+	Snapshot              = api.Snapshot
+	FinalityData          = api.FinalityData
+	FinalizedRange        = api.FinalizedRange
+	FinalizedRangeReceipt = api.FinalizedRangeReceipt
+	FinalityCounters      = api.FinalityCounters
+	DebugBundle           = api.DebugBundle
+	FinalizedHeadUpdate   = api.FinalizedHeadUpdate
+	PruningCasualty       = api.PruningCasualty
+	FinalityEstimate      = api.FinalityEstimate
+	BatcherContribution   = api.BatcherContribution
+	DerivedRange          = api.DerivedRange
+	SupervisorUpdate      = api.SupervisorUpdate
+	StallReason           = api.StallReason
+	CommitteeAttestation  = api.CommitteeAttestation
+	FinalizedBlock        = api.FinalizedBlock
+	FinalizationRecord    = api.FinalizationRecord
+
+	FinalizedWithdrawalRoot = api.FinalizedWithdrawalRoot
+
+	LatencySLO       = api.LatencySLO
+	LatencySLOStatus = api.LatencySLOStatus
+	LatencySLOWindow = api.LatencySLOWindow
+
+	FinalityTunables       = api.FinalityTunables
+	FinalityTunablesUpdate = api.FinalityTunablesUpdate
+	FinalityTunablesChange = api.FinalityTunablesChange
+)
+
+const (
+	ReasonNone                  = api.ReasonNone
+	ReasonFinalized             = api.ReasonFinalized
+	ReasonLimited               = api.ReasonLimited
+	ReasonL1Pending             = api.ReasonL1Pending
+	ReasonNoQualifyingData      = api.ReasonNoQualifyingData
+	ReasonSignalOlderThanBuffer = api.ReasonSignalOlderThanBuffer
+	ReasonEngineAhead           = api.ReasonEngineAhead
+	ReasonDisputeGameGated      = api.ReasonDisputeGameGated
+	ReasonCommitteeGated        = api.ReasonCommitteeGated
+	ReasonThrottled             = api.ReasonThrottled
+	ReasonEngineSyncing         = api.ReasonEngineSyncing
+	ReasonError                 = api.ReasonError
+	ReasonDisabled              = api.ReasonDisabled
+	ReasonHalted                = api.ReasonHalted
+	ReasonForkConflict          = api.ReasonForkConflict
+
+	StallNone       = api.StallNone
+	StallDerivation = api.StallDerivation
+	StallFinality   = api.StallFinality
+
+	SignalSourceL1   = api.SignalSourceL1
+	SignalSourceHash = api.SignalSourceHash
+
+	AuditSourceFinalize  = api.AuditSourceFinalize
+	AuditSourceRepair    = api.AuditSourceRepair
+	AuditSourceReconcile = api.AuditSourceReconcile
+	AuditSourceRollback  = api.AuditSourceRollback
+
+	EncodingV0  = api.EncodingV0
+	ContainerV1 = api.ContainerV1
+
+	StatusSchemaV1     = api.StatusSchemaV1
+	StatusSchemaV2     = api.StatusSchemaV2
+	StatusSchemaV3     = api.StatusSchemaV3
+	LatestStatusSchema = api.LatestStatusSchema
+)
+
+var (
+	ErrUnknownEncoding                  = api.ErrUnknownEncoding
+	ErrUnknownContainer                 = api.ErrUnknownContainer
+	ErrChecksumMismatch                 = api.ErrChecksumMismatch
+	ErrUnsupportedStatusSchema          = api.ErrUnsupportedStatusSchema
+	SigningDomainFinalizedRangeV1       = api.SigningDomainFinalizedRangeV1
+	SigningDomainCommitteeAttestationV1 = api.SigningDomainCommitteeAttestationV1
+)
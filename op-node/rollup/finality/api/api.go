@@ -0,0 +1,9 @@
+// Package api holds the public types of the Finalizer: its status, events, debug state and snapshot format,
+// for external tools like op-conductor, monitoring and indexers to compile against.
+//
+// The types are versioned independently of the internals of the finality package:
+// fields are only ever added, and incompatible changes bump Version, with the previous types retained.
+package api
+
+// Version is the version of the API types of this package.
+const Version = 1
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SigningDomainCommitteeAttestationV1 is the signing domain of committee attestations,
+// to separate their signatures from those of other messages signed with the same key.
+var SigningDomainCommitteeAttestationV1 = [32]byte{31: 2}
+
+// CommitteeAttestation is the attestation of a committee that the L2 block, and the L2 chain up to it, may be finalized.
+// The committee members sign the domain, the L2 chain ID, and the L2 block, see the finality package for the message.
+type CommitteeAttestation struct {
+	L2Block eth.BlockID `json:"l2_block"`
+	// Signers is the bitfield of the committee members that signed, bit i%8 of byte i/8 for the member at index i.
+	Signers hexutil.Bytes `json:"signers"`
+	// Signature is the aggregated BLS signature of the signers, a compressed BLS12-381 G2 point.
+	Signature hexutil.Bytes `json:"signature"`
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/zstd"
+)
+
+// ContainerMagic identifies the compressed container format of snapshots.
+// It does not start with an encoding version byte, so containers are told apart from plain encoded snapshots.
+var ContainerMagic = [4]byte{'O', 'P', 'F', 'S'}
+
+// ContainerV1 is the first version of the compressed container format of snapshots:
+// the magic bytes, a version byte, the SHA-256 checksum and the length of the canonical encoding of the snapshot,
+// followed by the zstd-compressed canonical encoding.
+const ContainerV1 = 1
+
+// containerHeaderLen is the length of the magic bytes, version byte, checksum and length of a ContainerV1.
+const containerHeaderLen = len(ContainerMagic) + 1 + sha256.Size + 8
+
+// maxContainerPayload bounds the length of the decompressed snapshot, to not allocate unbounded memory
+// for corrupted or malicious containers.
+const maxContainerPayload = 1 << 30
+
+var (
+	ErrUnknownContainer = errors.New("unknown finality snapshot container version")
+	ErrChecksumMismatch = errors.New("finality snapshot checksum mismatch")
+)
+
+// MarshalCompressed returns the snapshot in the compressed container format,
+// for compact persistence and transfer of large snapshots, like those of alt-DA chains.
+func (s *Snapshot) MarshalCompressed() ([]byte, error) {
+	payload, err := s.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	compressed, err := zstd.Compress(nil, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress finality snapshot: %w", err)
+	}
+	checksum := sha256.Sum256(payload)
+	out := make([]byte, 0, containerHeaderLen+len(compressed))
+	out = append(out, ContainerMagic[:]...)
+	out = append(out, ContainerV1)
+	out = append(out, checksum[:]...)
+	out = binary.BigEndian.AppendUint64(out, uint64(len(payload)))
+	return append(out, compressed...), nil
+}
+
+// UnmarshalCompressed decodes a snapshot in the compressed container format.
+// Corruption of the container is detected by the checksum, and returned as ErrChecksumMismatch.
+func (s *Snapshot) UnmarshalCompressed(data []byte) error {
+	if !IsContainer(data) {
+		return errors.New("not a finality snapshot container")
+	}
+	if len(data) < containerHeaderLen {
+		return errors.New("finality snapshot container too short")
+	}
+	if version := data[len(ContainerMagic)]; version != ContainerV1 {
+		return fmt.Errorf("%w: %d", ErrUnknownContainer, version)
+	}
+	header := data[len(ContainerMagic)+1 : containerHeaderLen]
+	checksum, length := header[:sha256.Size], binary.BigEndian.Uint64(header[sha256.Size:])
+	if length > maxContainerPayload {
+		return fmt.Errorf("finality snapshot container payload too large: %d bytes", length)
+	}
+	payload, err := zstd.Decompress(make([]byte, 0, length), data[containerHeaderLen:])
+	if err != nil {
+		return fmt.Errorf("%w: failed to decompress: %w", ErrChecksumMismatch, err)
+	}
+	if uint64(len(payload)) != length {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrChecksumMismatch, length, len(payload))
+	}
+	if sum := sha256.Sum256(payload); !bytes.Equal(sum[:], checksum) {
+		return ErrChecksumMismatch
+	}
+	return s.UnmarshalBinary(payload)
+}
+
+// IsContainer returns true if the data starts with the magic bytes of the compressed container format.
+func IsContainer(data []byte) bool {
+	return bytes.HasPrefix(data, ContainerMagic[:])
+}
+
+// DecodeSnapshot decodes a snapshot in either the compressed container format, or the plain canonical encoding,
+// so snapshots persisted before the container format was introduced can still be loaded.
+func DecodeSnapshot(data []byte) (*Snapshot, error) {
+	var snapshot Snapshot
+	var err error
+	if IsContainer(data) {
+		err = snapshot.UnmarshalCompressed(data)
+	} else {
+		err = snapshot.UnmarshalBinary(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
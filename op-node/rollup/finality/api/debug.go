@@ -0,0 +1,119 @@
+package api
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FinalityCounters are the internal counters of the Finalizer since it was created, for debugging.
+type FinalityCounters struct {
+	// Attempts counts the attempts to finalize L2 blocks.
+	Attempts uint64 `json:"attempts"`
+	// ResetsTriggered counts the attempts that required a pipeline reset.
+	ResetsTriggered uint64 `json:"resets_triggered"`
+	// EntriesPruned counts the finality data entries that were pruned to stay within the finality lookback.
+	EntriesPruned uint64 `json:"entries_pruned"`
+	// GapsDetected counts the gaps in the finality data, of L1 blocks that no entry was buffered for.
+	GapsDetected uint64 `json:"gaps_detected"`
+	// EntriesPrunedUnfinalized counts the pruned finality data entries that were not finalized yet.
+	EntriesPrunedUnfinalized uint64 `json:"entries_pruned_unfinalized"`
+	// EntriesBackfilled counts the finality data entries that were backfilled into gaps.
+	EntriesBackfilled uint64 `json:"entries_backfilled"`
+	// EntriesUpdated counts the safe head updates that replaced the finality data entry of the same L1 block.
+	EntriesUpdated uint64 `json:"entries_updated"`
+	// SignalsRejectedOld counts the finality signals that were rejected for being older than the previous signal.
+	SignalsRejectedOld uint64 `json:"signals_rejected_old"`
+	// SignalsRejectedStale counts the finality signals that were rejected for exceeding the max signal age.
+	SignalsRejectedStale uint64 `json:"signals_rejected_stale"`
+	// SignalsRejectedWrongChain counts the finality signals that were rejected, because the L1 source serves a different L1 chain.
+	SignalsRejectedWrongChain uint64 `json:"signals_rejected_wrong_chain"`
+	// EngineRegressions counts the times the engine reported a finalized L2 head older than the one last applied to it.
+	EngineRegressions uint64 `json:"engine_regressions"`
+	// BreakerTrips counts the times finalization was halted after repeated canonical-chain mismatches.
+	BreakerTrips uint64 `json:"breaker_trips"`
+	// SignalsRejectedHalted counts the finality signals that were rejected, because finalization was halted.
+	SignalsRejectedHalted uint64 `json:"signals_rejected_halted"`
+	// Rollbacks counts the unsafe rollbacks of the finalized L2 head.
+	Rollbacks uint64 `json:"rollbacks"`
+	// ReorgsDetected counts the finality signals that conflicted with the finality data, because L1 reorged.
+	ReorgsDetected uint64 `json:"reorgs_detected"`
+	// CrossValidationFailures counts the L2 blocks to finalize that the replica did not confirm.
+	CrossValidationFailures uint64 `json:"cross_validation_failures"`
+	// AttestationsRejected counts the committee attestations that failed verification.
+	AttestationsRejected uint64 `json:"attestations_rejected"`
+	// LookbackGrowths counts the times the finality lookback grew beyond the configured lookback, while finality stalled.
+	LookbackGrowths uint64 `json:"lookback_growths"`
+	// ChecksDeferred counts the attempts to finalize that were deferred, because the L1 source did not serve
+	// the L1 blocks to sanity check yet.
+	ChecksDeferred uint64 `json:"checks_deferred"`
+	// L1BlocksTraversed counts the L1 blocks that derivation traversed without deriving any L2 blocks from them.
+	L1BlocksTraversed uint64 `json:"l1_blocks_traversed"`
+	// SafeRegressions counts the safe heads that moved backwards without a reset, indicating a derivation pipeline bug.
+	SafeRegressions uint64 `json:"safe_regressions"`
+	// FinalizationsUnacked counts the finalized L2 heads the engine did not acknowledge as VALID, e.g. while syncing.
+	FinalizationsUnacked uint64 `json:"finalizations_unacked"`
+	// FinalizationsRejected counts the finalized L2 heads the engine rejected as INVALID.
+	FinalizationsRejected uint64 `json:"finalizations_rejected"`
+	// ForkConflicts counts the detected conflicts of the L2 chain of the node with the L2 chain derived from L1.
+	ForkConflicts uint64 `json:"fork_conflicts"`
+}
+
+// DebugBundle is the full debug state of the Finalizer, for support engineers to pull with a single request.
+type DebugBundle struct {
+	Status   FinalityStatus   `json:"status"`
+	Counters FinalityCounters `json:"counters"`
+	Snapshot *Snapshot        `json:"snapshot"`
+	// FinalityLookback is the maximum number of finality data entries retained.
+	FinalityLookback uint64 `json:"finality_lookback"`
+	// AuditTrail is the most recent finalized head updates.
+	AuditTrail []FinalizedHeadUpdate `json:"audit_trail"`
+	// TunablesAudit is the most recent reloads that changed the finality tunables.
+	TunablesAudit []FinalityTunablesChange `json:"tunables_audit,omitempty"`
+	// LatencySLO is the compliance with the finality latency SLO, if configured.
+	LatencySLO *LatencySLOStatus `json:"latency_slo,omitempty"`
+	// Casualties is the most recent finality data entries that were pruned before they were finalized.
+	Casualties []PruningCasualty `json:"casualties,omitempty"`
+}
+
+// sources of finalized head updates, as recorded in the audit trail.
+const (
+	// AuditSourceFinalize is the source of finalized head advancements justified by finalized L1 data.
+	AuditSourceFinalize = "finalize"
+	// AuditSourceRepair is the source of repairs of an unjustified finalized head of the engine.
+	AuditSourceRepair = "repair"
+	// AuditSourceReconcile is the source of re-applications of the finalized head to an engine that regressed below it.
+	AuditSourceReconcile = "reconcile"
+	// AuditSourceRollback is the source of unsafe rollbacks of the finalized head, on devnets.
+	AuditSourceRollback = "rollback"
+)
+
+// FinalizedHeadUpdate is an entry of the audit trail: a finalized L2 head the Finalizer set on the engine, or refused to.
+type FinalizedHeadUpdate struct {
+	Time time.Time      `json:"time"`
+	Prev eth.L2BlockRef `json:"prev"`
+	Next eth.L2BlockRef `json:"next"`
+	// Source is what caused the update, like AuditSourceFinalize.
+	Source string `json:"source"`
+	// Refused describes the violated invariant, if the update was refused.
+	Refused string `json:"refused,omitempty"`
+	// Ack is the payload status the engine responded with to the forkchoice update that applied the update, if known.
+	Ack string `json:"ack,omitempty"`
+}
+
+// PruningCasualty is an entry of the casualty log: a finality data entry that was pruned before it was finalized.
+// The L2 blocks derived from it can only be finalized once a later L1 block finalizes, which delays finalization.
+type PruningCasualty struct {
+	Time time.Time `json:"time"`
+	// L1Block is the L1 block of the pruned entry.
+	L1Block eth.BlockID `json:"l1_block"`
+	// L2Block is the last L2 block that was fully derived from L1Block.
+	L2Block eth.BlockID `json:"l2_block"`
+	// L2Blocks is the number of L2 blocks, up to L2Block, that lost the opportunity to be finalized with L1Block,
+	// excluding the L2 blocks already accounted for by earlier casualties.
+	L2Blocks uint64 `json:"l2_blocks"`
+	// FinalizedL1 is the finalized L1 block at the time of pruning.
+	FinalizedL1 eth.BlockID `json:"finalized_l1"`
+	// Lookback is the finality lookback at the time of pruning.
+	Lookback uint64 `json:"lookback"`
+}
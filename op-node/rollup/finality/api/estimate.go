@@ -0,0 +1,33 @@
+package api
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FinalityEstimate estimates when an L2 block will be finalized, e.g. for finality countdowns of wallets and bridges.
+type FinalityEstimate struct {
+	L2Block uint64 `json:"l2_block"`
+	// Finalized is set if the L2 block is already finalized.
+	Finalized bool `json:"finalized"`
+	// DerivedFrom is the L1 block the L2 block was fully derived from, if it is not finalized yet.
+	DerivedFrom eth.BlockID `json:"derived_from"`
+	// RemainingL1Blocks is the number of L1 blocks that still have to finalize, before the L2 block can be finalized.
+	RemainingL1Blocks uint64 `json:"remaining_l1_blocks"`
+	// EstimatedAt is the estimated wall-clock time at which the L2 block will be finalized, in unix seconds.
+	EstimatedAt uint64 `json:"estimated_at"`
+	// RemainingSeconds is the estimated wall-clock time until the L2 block will be finalized, in seconds.
+	RemainingSeconds uint64 `json:"remaining_seconds"`
+}
+
+// FinalizationRecord describes when, and with which L1 finality signal, a historical range of L2 blocks was finalized,
+// e.g. as audit or dispute evidence.
+type FinalizationRecord struct {
+	// FromL2 is the first L2 block number of the finalized range.
+	FromL2 uint64 `json:"from_l2"`
+	// FinalizedL2 is the finalized L2 head that the range was finalized up to (incl.).
+	FinalizedL2 eth.BlockID `json:"finalized_l2"`
+	// FinalizedL1 is the L1 finality signal that the range was justified with.
+	FinalizedL1 eth.BlockID `json:"finalized_l1"`
+	// Time is the wall-clock time at which the node finalized the range, in unix seconds.
+	Time uint64 `json:"time"`
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FinalizedEvent describes an advancement of the finalized L2 head, as applied to the engine.
+type FinalizedEvent struct {
+	// PrevFinalizedL2 is the finalized L2 head before this advancement.
+	PrevFinalizedL2 eth.L2BlockRef `json:"prev_finalized_l2"`
+	// FinalizedL2 is the new finalized L2 head.
+	FinalizedL2 eth.L2BlockRef `json:"finalized_l2"`
+	// FinalizedL1 is the L1 finality signal that the newly finalized L2 blocks were justified with.
+	FinalizedL1 eth.L1BlockRef `json:"finalized_l1"`
+	// DerivedFrom lists the L1 blocks which the newly finalized L2 blocks were derived from, in ascending order.
+	// Batch submitters can use this to stop tracking the confirmation of data that was included in these L1 blocks.
+	DerivedFrom []eth.BlockID `json:"derived_from"`
+	// SpanBatches lists the span batches that were finalized by this advancement, in ascending order,
+	// if span batches are tracked.
+	SpanBatches []SpanBatchBoundary `json:"span_batches,omitempty"`
+	// Provenance describes which signal source produced FinalizedL1.
+	Provenance *SignalProvenance `json:"provenance,omitempty"`
+	// Batchers lists the batchers that contributed to the newly finalized L2 blocks, per derived-from L1 block,
+	// in ascending order, if the batch inclusion of the L1 blocks is known.
+	Batchers []BatcherContribution `json:"batchers,omitempty"`
+	// Blocks lists the metadata of every newly finalized L2 block, in ascending order,
+	// if the event was enriched with per-block metadata.
+	Blocks []FinalizedBlock `json:"blocks,omitempty"`
+	// WithdrawalRoot is the storage root of the L2ToL1MessagePasser at FinalizedL2, if withdrawal roots are tracked.
+	WithdrawalRoot *eth.Bytes32 `json:"withdrawal_root,omitempty"`
+	// Ranges lists the newly finalized L2 blocks per derived-from L1 block, in ascending order,
+	// with whether they can still be reconstructed from L1 data alone.
+	Ranges []DerivedRange `json:"ranges,omitempty"`
+}
+
+// Coalesce combines the next advancement into this one, so the combined advancement stays contiguous.
+func (ev *FinalizedEvent) Coalesce(next FinalizedEvent) {
+	ev.FinalizedL2 = next.FinalizedL2
+	ev.FinalizedL1 = next.FinalizedL1
+	ev.Provenance = next.Provenance
+	ev.DerivedFrom = append(ev.DerivedFrom, next.DerivedFrom...)
+	ev.SpanBatches = append(ev.SpanBatches, next.SpanBatches...)
+	ev.Batchers = append(ev.Batchers, next.Batchers...)
+	ev.Blocks = append(ev.Blocks, next.Blocks...)
+	ev.WithdrawalRoot = next.WithdrawalRoot
+	ev.Ranges = append(ev.Ranges, next.Ranges...)
+}
+
+// BatcherContribution describes the batchers whose data in an L1 block contributed to a range of finalized L2 blocks,
+// for accountability and billing on chains with multiple authorized batchers.
+type BatcherContribution struct {
+	// DerivedFrom is the L1 block the L2 blocks were derived from.
+	DerivedFrom eth.BlockID `json:"derived_from"`
+	// Start and End are the first and last L2 block number of the range, within the finalized advancement.
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+	// Batchers are the addresses of the batchers of the data in the L1 block.
+	Batchers []common.Address `json:"batchers"`
+}
+
+// DerivedRange is a range of finalized L2 blocks that were derived from the same L1 block, and whether the range
+// can still be reconstructed from L1 data alone. L1 nodes prune blobs after the blob retention window,
+// after which archival operators have to rely on blob archives to reconstruct the range.
+type DerivedRange struct {
+	// DerivedFrom is the L1 block the L2 blocks were derived from.
+	DerivedFrom eth.BlockID `json:"derived_from"`
+	// Start and End are the first and last L2 block number of the range.
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+	// Blobs is set unless the range is known to be derived from calldata only,
+	// i.e. if the batch inclusion of the L1 block is unknown, or includes blobs.
+	Blobs bool `json:"blobs"`
+	// BlobsExpireAt is when the blobs of the L1 block leave the blob retention window, if the range is derived from
+	// blobs, and the time of the L1 block is known.
+	BlobsExpireAt uint64 `json:"blobs_expire_at,omitempty"`
+	// ReconstructableFromL1 is set if the range can be reconstructed from L1 data alone:
+	// it is derived from calldata, or its blobs are within the blob retention window.
+	ReconstructableFromL1 bool `json:"reconstructable_from_l1"`
+}
+
+// SpanBatchBoundary describes the range of L2 blocks of a span batch, and the L1 block it was derived from.
+type SpanBatchBoundary struct {
+	// Start is the first L2 block of the span batch.
+	Start eth.BlockID `json:"start"`
+	// End is the last L2 block of the span batch.
+	End eth.L2BlockRef `json:"end"`
+	// DerivedFrom is the L1 block the span batch was fully derived from.
+	DerivedFrom eth.BlockID `json:"derived_from"`
+}
+
+// FinalizedBlock is the metadata of a finalized L2 block, for consumers that act per block, like deposit pipelines.
+type FinalizedBlock struct {
+	Hash       common.Hash `json:"hash"`
+	Number     uint64      `json:"number"`
+	ParentHash common.Hash `json:"parent_hash"`
+	Time       uint64      `json:"time"`
+	// TxCount is the number of transactions in the block, including the deposit transactions.
+	TxCount int `json:"tx_count"`
+	// TxHashes are the hashes of the transactions in the block, in block order.
+	TxHashes []common.Hash `json:"tx_hashes"`
+}
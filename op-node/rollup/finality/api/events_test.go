@@ -0,0 +1,38 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestFinalizedEventCoalesce(t *testing.T) {
+	ev := FinalizedEvent{
+		PrevFinalizedL2: eth.L2BlockRef{Number: 1},
+		FinalizedL2:     eth.L2BlockRef{Number: 3},
+		FinalizedL1:     eth.L1BlockRef{Number: 10},
+		DerivedFrom:     []eth.BlockID{{Number: 9}},
+		Blocks:          []FinalizedBlock{{Number: 2}, {Number: 3}},
+		WithdrawalRoot:  &eth.Bytes32{3},
+	}
+	ev.Coalesce(FinalizedEvent{
+		PrevFinalizedL2: eth.L2BlockRef{Number: 3},
+		FinalizedL2:     eth.L2BlockRef{Number: 5},
+		FinalizedL1:     eth.L1BlockRef{Number: 11},
+		DerivedFrom:     []eth.BlockID{{Number: 10}},
+		Provenance:      &SignalProvenance{Source: SignalSourceHash},
+		Blocks:          []FinalizedBlock{{Number: 4}, {Number: 5}},
+		WithdrawalRoot:  &eth.Bytes32{5},
+	})
+	require.Equal(t, FinalizedEvent{
+		PrevFinalizedL2: eth.L2BlockRef{Number: 1},
+		FinalizedL2:     eth.L2BlockRef{Number: 5},
+		FinalizedL1:     eth.L1BlockRef{Number: 11},
+		DerivedFrom:     []eth.BlockID{{Number: 9}, {Number: 10}},
+		Provenance:      &SignalProvenance{Source: SignalSourceHash},
+		Blocks:          []FinalizedBlock{{Number: 2}, {Number: 3}, {Number: 4}, {Number: 5}},
+		WithdrawalRoot:  &eth.Bytes32{5},
+	}, ev)
+}
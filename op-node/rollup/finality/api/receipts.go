@@ -0,0 +1,32 @@
+package api
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SigningDomainFinalizedRangeV1 is the signing domain of finalized-range receipts,
+// to separate their signatures from those of other messages signed with the same key, like gossiped blocks.
+var SigningDomainFinalizedRangeV1 = [32]byte{31: 1}
+
+// FinalizedRange is the statement of a finalized-range receipt:
+// the L2 blocks after PrevFinalizedL2, up to and including FinalizedL2, are finalized by FinalizedL1.
+type FinalizedRange struct {
+	PrevFinalizedL2 eth.BlockID `json:"prev_finalized_l2"`
+	FinalizedL2     eth.BlockID `json:"finalized_l2"`
+	// OutputRoot is the output root of FinalizedL2.
+	OutputRoot  eth.Bytes32 `json:"output_root"`
+	FinalizedL1 eth.BlockID `json:"finalized_l1"`
+}
+
+// MarshalBinary returns the canonical encoding of the finalized range, which is signed by the receipt.
+func (r *FinalizedRange) MarshalBinary() ([]byte, error) {
+	return encodeVersioned(r)
+}
+
+// FinalizedRangeReceipt is a finalized range, signed over the SigningDomainFinalizedRangeV1 domain and L2 chain ID.
+type FinalizedRangeReceipt struct {
+	Range     FinalizedRange `json:"range"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
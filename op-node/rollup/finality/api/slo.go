@@ -0,0 +1,40 @@
+package api
+
+import (
+	"time"
+)
+
+// LatencySLO is a service level objective of the end-to-end finality latency:
+// the time from the L2 block timestamp until the L2 block is finalized.
+type LatencySLO struct {
+	// Objective is the fraction of L2 blocks that have to be finalized within the target latency, e.g. 0.95.
+	Objective float64 `json:"objective"`
+	// TargetFactor is the target latency, as a multiple of the L1 finality time, e.g. 2.
+	TargetFactor float64 `json:"target_factor"`
+	// Window is the sliding window that compliance is computed over.
+	Window time.Duration `json:"window"`
+}
+
+// LatencySLOStatus is the compliance with the finality latency SLO, over the long and the short window.
+type LatencySLOStatus struct {
+	SLO LatencySLO `json:"slo"`
+	// Target is the target latency that L2 blocks have to be finalized within.
+	Target time.Duration `json:"target"`
+	// Long is the compliance over the SLO window.
+	Long LatencySLOWindow `json:"long"`
+	// Short is the compliance over the short window, to confirm that a high burn rate is still ongoing.
+	Short LatencySLOWindow `json:"short"`
+}
+
+// LatencySLOWindow is the compliance with the finality latency SLO over a window.
+type LatencySLOWindow struct {
+	Window time.Duration `json:"window"`
+	// Total is the number of L2 blocks that were finalized, or are overdue, in the window.
+	Total uint64 `json:"total"`
+	// Breached is the number of L2 blocks that were finalized beyond the target latency, or are overdue, in the window.
+	Breached uint64 `json:"breached"`
+	// Compliance is the fraction of L2 blocks that were finalized within the target latency. 1 if there were none.
+	Compliance float64 `json:"compliance"`
+	// BurnRate is the rate at which the error budget of the SLO is spent: 1 spends exactly the budget over the window.
+	BurnRate float64 `json:"burn_rate"`
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// EncodingV0 is the first version of the binary encoding of finality data and snapshots:
+// a version byte, followed by the RLP encoding of the data.
+// New fields may be appended as optional RLP fields, without changing the version.
+const EncodingV0 = 0
+
+var ErrUnknownEncoding = errors.New("unknown finality encoding version")
+
+// Snapshot is the state of the Finalizer, to persist it, export it, or transfer it to another node.
+type Snapshot struct {
+	FinalizedL1  eth.L1BlockRef `json:"finalized_l1"`
+	FinalityData []FinalityData `json:"finality_data"`
+	// Lookback is the number of finality data entries the Finalizer was sized for. Unknown if 0, for older snapshots.
+	Lookback uint64 `json:"lookback,omitempty" rlp:"optional"`
+	// DAChallengeWindow and DAResolveWindow are the plasma DA windows the lookback was sized for, 0 if plasma was disabled.
+	DAChallengeWindow uint64 `json:"da_challenge_window,omitempty" rlp:"optional"`
+	DAResolveWindow   uint64 `json:"da_resolve_window,omitempty" rlp:"optional"`
+}
+
+// FinalityData relates an L2 block to the L1 block it was derived from.
+type FinalityData struct {
+	// The last L2 block that was fully derived and inserted into the L2 engine while processing this L1 block.
+	L2Block eth.L2BlockRef `json:"l2_block"`
+	// The L1 block this stage was at when inserting the L2 block.
+	// When this L1 block is finalized, the L2 chain up to this block can be fully reproduced from finalized L1 data.
+	L1Block eth.BlockID `json:"l1_block"`
+	// BatchTxs are the hashes of the batcher transactions in the L1 block, if known.
+	BatchTxs []common.Hash `json:"batch_txs,omitempty" rlp:"optional"`
+	// BlobIndices are the indices of the batcher blobs in the blob sidecar of the L1 block, if known.
+	BlobIndices []uint64 `json:"blob_indices,omitempty" rlp:"optional"`
+	// Batchers are the addresses of the batchers whose data in the L1 block contributed to the L2 chain, if known.
+	Batchers []common.Address `json:"batchers,omitempty" rlp:"optional"`
+	// L1Parent is the parent-hash of the L1 block, if known, to detect L1 reorgs of the finality data.
+	L1Parent common.Hash `json:"l1_parent,omitempty" rlp:"optional"`
+	// L1Time is the timestamp of the L1 block, if known, to track the blob retention window of the L1 block.
+	L1Time uint64 `json:"l1_time,omitempty" rlp:"optional"`
+}
+
+// MarshalBinary returns the canonical encoding of the snapshot.
+func (s *Snapshot) MarshalBinary() ([]byte, error) {
+	return encodeVersioned(s)
+}
+
+// UnmarshalBinary decodes the canonical encoding of the snapshot.
+func (s *Snapshot) UnmarshalBinary(data []byte) error {
+	if s == nil {
+		return errors.New("cannot decode into nil Snapshot")
+	}
+	if err := decodeVersioned(data, s); err != nil {
+		return err
+	}
+	for i := range s.FinalityData {
+		s.FinalityData[i].normalize()
+	}
+	return nil
+}
+
+// MarshalBinary returns the canonical encoding of the finality data.
+func (fd *FinalityData) MarshalBinary() ([]byte, error) {
+	return encodeVersioned(fd)
+}
+
+// UnmarshalBinary decodes the canonical encoding of the finality data.
+func (fd *FinalityData) UnmarshalBinary(data []byte) error {
+	if fd == nil {
+		return errors.New("cannot decode into nil FinalityData")
+	}
+	if err := decodeVersioned(data, fd); err != nil {
+		return err
+	}
+	fd.normalize()
+	return nil
+}
+
+// normalize restores the omitted optional lists of decoded finality data.
+// Optional fields before a later set field, like L1Parent, are encoded as empty lists, and decoded as such.
+func (fd *FinalityData) normalize() {
+	if len(fd.BatchTxs) == 0 {
+		fd.BatchTxs = nil
+	}
+	if len(fd.BlobIndices) == 0 {
+		fd.BlobIndices = nil
+	}
+	if len(fd.Batchers) == 0 {
+		fd.Batchers = nil
+	}
+}
+
+func encodeVersioned(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(EncodingV0)
+	if err := rlp.Encode(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeVersioned(data []byte, v any) error {
+	if len(data) == 0 {
+		return errors.New("finality encoding too short")
+	}
+	if data[0] != EncodingV0 {
+		return fmt.Errorf("%w: %d", ErrUnknownEncoding, data[0])
+	}
+	return rlp.DecodeBytes(data[1:], v)
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestSnapshotStableEncoding(t *testing.T) {
+	snap := &Snapshot{
+		FinalizedL1: eth.L1BlockRef{Hash: common.Hash{1}, Number: 10, ParentHash: common.Hash{2}, Time: 120},
+		FinalityData: []FinalityData{{
+			L2Block: eth.L2BlockRef{Hash: common.Hash{3}, Number: 20, ParentHash: common.Hash{4}, Time: 122,
+				L1Origin: eth.BlockID{Hash: common.Hash{5}, Number: 9}, SequenceNumber: 1},
+			L1Block: eth.BlockID{Hash: common.Hash{1}, Number: 10},
+		}},
+	}
+	data, err := snap.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, byte(EncodingV0), data[0])
+
+	// tools persist and exchange snapshots, so the encoding must not change with internal refactors
+	require.Equal(t, goldenSnapshot, common.Bytes2Hex(data))
+
+	var out Snapshot
+	require.NoError(t, out.UnmarshalBinary(data))
+	require.Equal(t, *snap, out)
+
+	data[0] = 0xff
+	require.ErrorIs(t, out.UnmarshalBinary(data), ErrUnknownEncoding)
+}
+
+func TestSnapshotContainer(t *testing.T) {
+	snap := &Snapshot{FinalizedL1: eth.L1BlockRef{Hash: common.Hash{1}, Number: 10}}
+	for i := uint64(0); i < 1000; i++ {
+		snap.FinalityData = append(snap.FinalityData, FinalityData{
+			L2Block: eth.L2BlockRef{Hash: common.Hash{3}, Number: 20 + i, Time: 122 + 2*i},
+			L1Block: eth.BlockID{Hash: common.Hash{1}, Number: 10 + i/6},
+		})
+	}
+	plain, err := snap.MarshalBinary()
+	require.NoError(t, err)
+	data, err := snap.MarshalCompressed()
+	require.NoError(t, err)
+	require.True(t, IsContainer(data))
+	require.Less(t, len(data), len(plain)/4)
+
+	out, err := DecodeSnapshot(data)
+	require.NoError(t, err)
+	require.Equal(t, snap, out)
+	// snapshots persisted before the container format was introduced still decode
+	out, err = DecodeSnapshot(plain)
+	require.NoError(t, err)
+	require.Equal(t, snap, out)
+
+	// corruption of the header or of the compressed payload is detected
+	corrupt := append([]byte{}, data...)
+	corrupt[10] ^= 1
+	_, err = DecodeSnapshot(corrupt)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	corrupt = append([]byte{}, data...)
+	corrupt[len(corrupt)-5] ^= 1
+	_, err = DecodeSnapshot(corrupt)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	_, err = DecodeSnapshot(data[:len(data)/2])
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	corrupt = append([]byte{}, data...)
+	corrupt[len(ContainerMagic)] = 0xff
+	_, err = DecodeSnapshot(corrupt)
+	require.ErrorIs(t, err, ErrUnknownContainer)
+}
+
+const goldenSnapshot = "00f8d7f844a001000000000000000000000000000000000000000000000000000000000000000aa0020000000000000000000000000000000000000000000000000000000000000078f88ff88df868a0030000000000000000000000000000000000000000000000000000000000000014a004000000000000000000000000000000000000000000000000000000000000007ae2a005000000000000000000000000000000000000000000000000000000000000000901e2a001000000000000000000000000000000000000000000000000000000000000000a"
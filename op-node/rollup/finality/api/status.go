@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FinalizeReason describes the outcome of the last attempt to finalize L2 blocks.
+type FinalizeReason string
+
+const (
+	// ReasonNone is used before any attempt to finalize was made.
+	ReasonNone FinalizeReason = ""
+	// ReasonFinalized is used when the finalized L2 head advanced.
+	ReasonFinalized FinalizeReason = "finalized"
+	// ReasonLimited is used when the finalized L2 head advanced, but was limited by the max advance budget.
+	ReasonLimited FinalizeReason = "limited"
+	// ReasonNoQualifyingData is used when no L2 blocks were buffered to finalize.
+	ReasonNoQualifyingData FinalizeReason = "no_qualifying_data"
+	// ReasonSignalOlderThanBuffer is used when the finalized L1 block is older than any buffered L1 block,
+	// e.g. when the node just started, or the finality signal lags far behind derivation.
+	ReasonSignalOlderThanBuffer FinalizeReason = "signal_older_than_buffer"
+	// ReasonEngineAhead is used when the engine already finalized all L2 blocks derived from finalized L1 blocks.
+	ReasonEngineAhead FinalizeReason = "engine_ahead"
+	// ReasonDisputeGameGated is used when the L2 blocks to finalize are not yet backed by a resolved dispute game.
+	ReasonDisputeGameGated FinalizeReason = "dispute_game_gated"
+	// ReasonCommitteeGated is used when the L2 blocks to finalize are not yet attested by a quorum of the committee.
+	ReasonCommitteeGated FinalizeReason = "committee_gated"
+	// ReasonThrottled is used when the finalized L2 head can advance, but is batched with later advancements,
+	// to stay within the minimum finalization interval.
+	ReasonThrottled FinalizeReason = "throttled"
+	// ReasonEngineSyncing is used when the finalized L2 head can advance, but the engine is still syncing,
+	// and the advancement is applied once the engine is ready.
+	ReasonEngineSyncing FinalizeReason = "engine_syncing"
+	// ReasonL1Pending is used when the finalized L2 head can advance, but the L1 source does not serve
+	// the L1 blocks to sanity check yet, e.g. a light client that is still backfilling historical L1 blocks.
+	ReasonL1Pending FinalizeReason = "l1_pending"
+	// ReasonError is used when the attempt failed with an error.
+	ReasonError FinalizeReason = "error"
+	// ReasonDisabled is used when finalization is temporarily disabled, after repeated panics.
+	ReasonDisabled FinalizeReason = "disabled"
+	// ReasonHalted is used when finalization was halted after repeated canonical-chain mismatches,
+	// until an operator resumes it.
+	ReasonHalted FinalizeReason = "halted"
+	// ReasonForkConflict is used when finalization is frozen, because the node detected it is on an L2 fork,
+	// until the engine is reorged back to the canonical chain.
+	ReasonForkConflict FinalizeReason = "fork_conflict"
+)
+
+// StallReason classifies why the finalized L2 head is not advancing.
+type StallReason string
+
+const (
+	// StallNone is used when the finalized L2 head advanced recently.
+	StallNone StallReason = ""
+	// StallDerivation is used when finalization is stalled, because derivation is not advancing the safe L2 head either:
+	// the derivation pipeline, the L1 source or the batcher needs attention.
+	StallDerivation StallReason = "derivation"
+	// StallFinality is used when finalization is stalled, despite derivation advancing the safe L2 head:
+	// the L1 finality signal or the finalizer itself needs attention, see LastReason.
+	StallFinality StallReason = "finality"
+)
+
+// FinalityStatus is a snapshot of the finality state of the Finalizer.
+type FinalityStatus struct {
+	FinalizedL1 eth.L1BlockRef `json:"finalized_l1"`
+	FinalizedL2 eth.L2BlockRef `json:"finalized_l2"`
+	// ExtraConfirmations is the number of L1 blocks below FinalizedL1,
+	// that L2 blocks have to be derived from to be finalized.
+	ExtraConfirmations uint64 `json:"extra_confirmations"`
+	// LastReason is the outcome of the last attempt to finalize L2 blocks.
+	LastReason FinalizeReason `json:"last_reason"`
+	// LastError is the error of the last attempt to finalize L2 blocks, if it failed.
+	LastError string `json:"last_error,omitempty"`
+	// UnjustifiedFinalizedL2 is the finalized L2 head of the engine, if it cannot be justified from finalized L1 data,
+	// e.g. because it was set manually.
+	UnjustifiedFinalizedL2 *eth.L2BlockRef `json:"unjustified_finalized_l2,omitempty"`
+	// DerivedFromL1 is the latest L1 block that derivation reached.
+	DerivedFromL1 eth.L1BlockRef `json:"derived_from_l1"`
+	// CatchUpL1 is the L1 block number that derivation has to fully derive up to, for finalization to catch up
+	// with FinalizedL1, while the node is syncing. It is omitted if derivation is already past FinalizedL1.
+	CatchUpL1 uint64 `json:"catch_up_l1,omitempty"`
+	// LookbackHeadroom is the number of finality data entries that can still be buffered,
+	// before entries that are not finalized yet get pruned, and finalization lags further behind.
+	LookbackHeadroom uint64 `json:"lookback_headroom"`
+	// SignalProvenance describes which signal source produced FinalizedL1, if any signal was accepted.
+	SignalProvenance *SignalProvenance `json:"signal_provenance,omitempty"`
+	// SettledL2 is the finalized L2 head that is also backed by a resolved dispute game, if settlement is tracked.
+	SettledL2 *eth.L2BlockRef `json:"settled_l2,omitempty"`
+	// Halted is set if finalization was halted after repeated canonical-chain mismatches, and the node is unhealthy,
+	// until an operator resumes finalization.
+	Halted bool `json:"halted,omitempty"`
+	// StateDigest is a canonical hash of FinalizedL1 and the buffered finality data, to compare replicas with.
+	// Replicas with the same finality config have the same digest, when they derived up to the same DerivedFromL1.
+	StateDigest common.Hash `json:"state_digest"`
+	// Stall classifies why the finalized L2 head is not advancing, if it did not advance for the stall threshold.
+	Stall StallReason `json:"stall,omitempty"`
+	// Version counts the changes of the published finality status, for clients to detect changes without diffing.
+	Version uint64 `json:"version"`
+	// ForkConflict is the L2 block that conflicts with the L2 chain derived from L1, if the node detected
+	// it is on an L2 fork. Finalization is frozen until the engine is reorged back to the canonical chain.
+	ForkConflict *eth.BlockID `json:"fork_conflict,omitempty"`
+	// FinalizedRanges is the most recently finalized L2 ranges, oldest first, with whether they can still be
+	// reconstructed from L1 data alone, as of the status.
+	FinalizedRanges []DerivedRange `json:"finalized_ranges,omitempty"`
+}
+
+const (
+	// SignalSourceL1 is the source of the finality signals polled from the L1 RPC.
+	SignalSourceL1 = "l1"
+	// SignalSourceHash is the source of the finality signals that are only identified by their L1 block hash,
+	// as pushed by light-client sources.
+	SignalSourceHash = "l1_hash"
+)
+
+// SignalProvenance describes which signal source produced an accepted L1 finality signal.
+type SignalProvenance struct {
+	// Source is the label of the signal source.
+	Source string `json:"source"`
+	// ReceivedAt is when the signal was received from the source.
+	ReceivedAt time.Time `json:"received_at"`
+	// Weight is the combined trust weight of the sources that signaled the L1 block, or any later L1 block.
+	// It is 0 if no trust weights are configured.
+	Weight uint64 `json:"weight"`
+}
+
+// Schema versions of the finality status, as served by the RPC. Integrations that parse the status strictly
+// request the schema version they were built for, and are not served fields of later schema versions.
+const (
+	// StatusSchemaV1 is the schema of the finality status before the schema was versioned,
+	// and is served to clients that do not request a schema version.
+	StatusSchemaV1 = 1
+	// StatusSchemaV2 adds the L2 fork conflict that finalization is frozen for.
+	StatusSchemaV2 = 2
+	// StatusSchemaV3 adds the most recently finalized L2 ranges, with their blob retention.
+	StatusSchemaV3 = 3
+
+	// LatestStatusSchema is the latest schema version of the finality status.
+	LatestStatusSchema = StatusSchemaV3
+)
+
+var ErrUnsupportedStatusSchema = errors.New("unsupported finality status schema version")
+
+// statusFieldSchemas is the schema version each JSON field of the FinalityStatus was introduced in.
+// New fields are added with a new schema version, and LatestStatusSchema is bumped.
+var statusFieldSchemas = map[string]uint64{
+	"finalized_l1":             StatusSchemaV1,
+	"finalized_l2":             StatusSchemaV1,
+	"extra_confirmations":      StatusSchemaV1,
+	"last_reason":              StatusSchemaV1,
+	"last_error":               StatusSchemaV1,
+	"unjustified_finalized_l2": StatusSchemaV1,
+	"derived_from_l1":          StatusSchemaV1,
+	"catch_up_l1":              StatusSchemaV1,
+	"lookback_headroom":        StatusSchemaV1,
+	"signal_provenance":        StatusSchemaV1,
+	"settled_l2":               StatusSchemaV1,
+	"halted":                   StatusSchemaV1,
+	"state_digest":             StatusSchemaV1,
+	"stall":                    StatusSchemaV1,
+	"version":                  StatusSchemaV1,
+	"fork_conflict":            StatusSchemaV2,
+	"finalized_ranges":         StatusSchemaV3,
+}
+
+// ProjectStatusJSON returns the JSON encoding of the finality status with only the fields of the given schema version,
+// or ErrUnsupportedStatusSchema if the schema version is unknown.
+func ProjectStatusJSON(status json.RawMessage, schema uint64) (json.RawMessage, error) {
+	if schema == 0 || schema > LatestStatusSchema {
+		return nil, fmt.Errorf("%w: %d, latest is %d", ErrUnsupportedStatusSchema, schema, LatestStatusSchema)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(status, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode finality status: %w", err)
+	}
+	for name := range fields {
+		if since, ok := statusFieldSchemas[name]; !ok || since > schema {
+			delete(fields, name)
+		}
+	}
+	return json.Marshal(fields)
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestFinalityStatusJSON(t *testing.T) {
+	status := FinalityStatus{
+		LastReason:       ReasonDisputeGameGated,
+		LookbackHeadroom: 3,
+		SignalProvenance: &SignalProvenance{Source: SignalSourceL1, ReceivedAt: time.Unix(1000, 0).UTC(), Weight: 2},
+	}
+	data, err := json.Marshal(status)
+	require.NoError(t, err)
+
+	// the field names are part of the API, external tools decode them from the RPC responses
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &fields))
+	for _, name := range []string{"finalized_l1", "finalized_l2", "extra_confirmations", "last_reason",
+		"derived_from_l1", "lookback_headroom", "signal_provenance"} {
+		require.Contains(t, fields, name)
+	}
+	require.JSONEq(t, `"dispute_game_gated"`, string(fields["last_reason"]))
+	require.JSONEq(t, `{"source":"l1","received_at":"1970-01-01T00:16:40Z","weight":2}`, string(fields["signal_provenance"]))
+
+	var out FinalityStatus
+	require.NoError(t, json.Unmarshal(data, &out))
+	require.Equal(t, status, out)
+}
+
+func TestFinalityStatusSchema(t *testing.T) {
+	// every field of the status must be assigned the schema version it was introduced in
+	typ := reflect.TypeOf(FinalityStatus{})
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		require.Contains(t, statusFieldSchemas, name, "field %s has no schema version", typ.Field(i).Name)
+	}
+
+	data, err := json.Marshal(FinalityStatus{LastReason: ReasonFinalized, Halted: true, Version: 3})
+	require.NoError(t, err)
+	// fields of later schema versions are not served to clients of an earlier schema version
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &fields))
+	fields["settlement"] = json.RawMessage(`{"settled":true}`)
+	extended, err := json.Marshal(fields)
+	require.NoError(t, err)
+
+	out, err := ProjectStatusJSON(extended, StatusSchemaV1)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(out))
+
+	// the fork conflict was introduced in schema V2
+	conflict, err := json.Marshal(FinalityStatus{ForkConflict: &eth.BlockID{Number: 7}})
+	require.NoError(t, err)
+	out, err = ProjectStatusJSON(conflict, StatusSchemaV1)
+	require.NoError(t, err)
+	require.NotContains(t, string(out), "fork_conflict")
+	out, err = ProjectStatusJSON(conflict, StatusSchemaV2)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "fork_conflict")
+
+	// the finalized ranges were introduced in schema V3
+	ranges, err := json.Marshal(FinalityStatus{FinalizedRanges: []DerivedRange{{Start: 1, End: 2, ReconstructableFromL1: true}}})
+	require.NoError(t, err)
+	out, err = ProjectStatusJSON(ranges, StatusSchemaV2)
+	require.NoError(t, err)
+	require.NotContains(t, string(out), "finalized_ranges")
+	out, err = ProjectStatusJSON(ranges, StatusSchemaV3)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "reconstructable_from_l1")
+
+	_, err = ProjectStatusJSON(data, 0)
+	require.ErrorIs(t, err, ErrUnsupportedStatusSchema)
+	_, err = ProjectStatusJSON(data, LatestStatusSchema+1)
+	require.ErrorIs(t, err, ErrUnsupportedStatusSchema)
+}
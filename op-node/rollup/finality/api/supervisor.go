@@ -0,0 +1,14 @@
+package api
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SupervisorUpdate is a local-safe or finalized update of the L2 chain, in the data model of op-supervisor:
+// the L2 block, and the L1 block it was fully derived from.
+type SupervisorUpdate struct {
+	L2Block     eth.BlockID `json:"l2_block"`
+	DerivedFrom eth.BlockID `json:"derived_from"`
+	// Finalized is set if the L2 block is the new finalized L2 head, and unset if it is the new local-safe L2 head.
+	Finalized bool `json:"finalized"`
+}
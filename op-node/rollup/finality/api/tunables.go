@@ -0,0 +1,65 @@
+package api
+
+import (
+	"errors"
+	"time"
+)
+
+// FinalityTunables are the finality parameters that can be reloaded while the node runs,
+// without restarting it and losing the buffered finality data.
+type FinalityTunables struct {
+	// FinalityDelay is the number of L1 blocks to traverse before trying to finalize L2 blocks again.
+	FinalityDelay uint64 `json:"finality_delay"`
+	// ExtraConfirmations is the number of L1 blocks below the finalized L1 block,
+	// that L2 blocks have to be derived from to be finalized.
+	ExtraConfirmations uint64 `json:"extra_confirmations"`
+	// TrustSignal skips the canonical-chain sanity checks of the finality signal.
+	TrustSignal bool `json:"trust_signal"`
+	// StallThreshold is how long the finalized L2 head may not advance, before finalization is considered stalled.
+	StallThreshold time.Duration `json:"stall_threshold"`
+}
+
+// FinalityTunablesUpdate changes the finality tunables that are set, and leaves the others as they are.
+type FinalityTunablesUpdate struct {
+	FinalityDelay      *uint64        `json:"finality_delay,omitempty"`
+	ExtraConfirmations *uint64        `json:"extra_confirmations,omitempty"`
+	TrustSignal        *bool          `json:"trust_signal,omitempty"`
+	StallThreshold     *time.Duration `json:"stall_threshold,omitempty"`
+}
+
+// Check returns an error if the update sets a tunable to an invalid value.
+func (u *FinalityTunablesUpdate) Check() error {
+	if u.FinalityDelay != nil && *u.FinalityDelay == 0 {
+		return errors.New("finality delay must be at least 1 L1 block")
+	}
+	if u.StallThreshold != nil && *u.StallThreshold < 0 {
+		return errors.New("stall threshold must not be negative")
+	}
+	return nil
+}
+
+// Apply returns the tunables with the update applied.
+func (u *FinalityTunablesUpdate) Apply(t FinalityTunables) FinalityTunables {
+	if u.FinalityDelay != nil {
+		t.FinalityDelay = *u.FinalityDelay
+	}
+	if u.ExtraConfirmations != nil {
+		t.ExtraConfirmations = *u.ExtraConfirmations
+	}
+	if u.TrustSignal != nil {
+		t.TrustSignal = *u.TrustSignal
+	}
+	if u.StallThreshold != nil {
+		t.StallThreshold = *u.StallThreshold
+	}
+	return t
+}
+
+// FinalityTunablesChange is an entry of the audit trail of the finality tunables: a reload that changed them.
+type FinalityTunablesChange struct {
+	Time time.Time        `json:"time"`
+	Prev FinalityTunables `json:"prev"`
+	Next FinalityTunables `json:"next"`
+	// Trigger is what caused the reload, like an admin RPC or a SIGHUP.
+	Trigger string `json:"trigger"`
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FinalizedWithdrawalRoot is the withdrawal root of a finalized L2 head,
+// for withdrawal-proving services that prove withdrawals against finalized L2 state only.
+type FinalizedWithdrawalRoot struct {
+	L2Block eth.BlockID `json:"l2_block"`
+	// WithdrawalRoot is the storage root of the L2ToL1MessagePasser at L2Block, which withdrawals are proven against.
+	WithdrawalRoot eth.Bytes32 `json:"withdrawal_root"`
+	// OutputRoot is the output root of L2Block, which commits to the withdrawal root.
+	OutputRoot eth.Bytes32 `json:"output_root"`
+	// FinalizedL1 is the L1 finality signal that L2Block was finalized with.
+	FinalizedL1 eth.BlockID `json:"finalized_l1"`
+}
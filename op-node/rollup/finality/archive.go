@@ -0,0 +1,176 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// ArchivalJob is an archival action that runs when an L2 range becomes finalized,
+// e.g. to upload the blobs and batches of the range to cold storage, or to trim a local blob cache.
+// Delivery is at-least-once: a range is delivered again until the job succeeds, so jobs must be idempotent.
+// Ranges that were not delivered yet are coalesced, so a slow job receives one larger range.
+type ArchivalJob interface {
+	// Name identifies the job in the registry and in logs.
+	Name() string
+	// Archive archives the L2 blocks that were finalized by the event.
+	Archive(ctx context.Context, ev FinalizedEvent) error
+}
+
+// archivalState is the delivery state of a registered archival job.
+type archivalState struct {
+	job ArchivalJob
+	// pending is the finalized range that is not delivered yet, if any.
+	pending *FinalizedEvent
+	// attempts counts the failed attempts to deliver pending, and retryAt is when delivery is retried.
+	attempts int
+	retryAt  time.Time
+}
+
+// ArchivalRegistry delivers finalized L2 ranges to the registered archival jobs, outside of the Finalizer lock.
+// Operators attach custom archival jobs with Register, and Run delivers the ranges.
+// Undelivered ranges are kept in memory only: they are not redelivered after a restart.
+type ArchivalRegistry struct {
+	log           log.Logger
+	clock         clock.Clock
+	retryStrategy retry.Strategy
+
+	mu   sync.Mutex
+	jobs map[string]*archivalState
+	// notify wakes up Run when there is a new range to deliver.
+	notify chan struct{}
+}
+
+// NewArchivalRegistry creates an empty registry of archival jobs.
+func NewArchivalRegistry(log log.Logger) *ArchivalRegistry {
+	return &ArchivalRegistry{
+		log:           log,
+		clock:         clock.SystemClock,
+		retryStrategy: retry.Exponential(),
+		jobs:          make(map[string]*archivalState),
+		notify:        make(chan struct{}, 1),
+	}
+}
+
+// Register adds an archival job, which receives the ranges that are finalized from now on.
+func (r *ArchivalRegistry) Register(job ArchivalJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.jobs[job.Name()]; ok {
+		return fmt.Errorf("archival job %q is already registered", job.Name())
+	}
+	r.jobs[job.Name()] = &archivalState{job: job}
+	return nil
+}
+
+// Unregister removes an archival job, and drops its undelivered ranges.
+func (r *ArchivalRegistry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.jobs, name)
+}
+
+// Attach subscribes the registry to the finalized L2 head advancements of the Finalizer.
+// The returned function detaches it again.
+func (r *ArchivalRegistry) Attach(fi *Finalizer) (detach func()) {
+	return fi.SubscribeFinalized(r.enqueue)
+}
+
+// enqueue queues the finalized range for delivery to every registered job. It does not block.
+func (r *ArchivalRegistry) enqueue(ev FinalizedEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, st := range r.jobs {
+		if st.pending == nil {
+			pending := ev
+			st.pending = &pending
+		} else {
+			st.pending.Coalesce(ev)
+		}
+	}
+	select {
+	case r.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers the finalized ranges to the archival jobs, until the context is canceled.
+func (r *ArchivalRegistry) Run(ctx context.Context) {
+	for {
+		var timer clock.Timer
+		var retryC <-chan time.Time
+		if next, ok := r.deliver(ctx); ok {
+			timer = r.clock.NewTimer(next.Sub(r.clock.Now()))
+			retryC = timer.Ch()
+		}
+		select {
+		case <-ctx.Done():
+		case <-r.notify:
+		case <-retryC:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// deliver delivers the pending ranges to the jobs that are due, in order of their names.
+// It returns the earliest time a failed delivery is retried at, if any.
+func (r *ArchivalRegistry) deliver(ctx context.Context) (retryAt time.Time, retrying bool) {
+	r.mu.Lock()
+	due := make([]*archivalState, 0, len(r.jobs))
+	now := r.clock.Now()
+	for _, st := range r.jobs {
+		if st.pending != nil && !now.Before(st.retryAt) {
+			due = append(due, st)
+		}
+	}
+	r.mu.Unlock()
+	sort.Slice(due, func(i, j int) bool { return due[i].job.Name() < due[j].job.Name() })
+
+	for _, st := range due {
+		r.mu.Lock()
+		ev := *st.pending
+		st.pending = nil
+		r.mu.Unlock()
+
+		err := st.job.Archive(ctx, ev)
+
+		r.mu.Lock()
+		if err != nil {
+			// put the range back in front of any range that was finalized in the meantime
+			if st.pending != nil {
+				ev.Coalesce(*st.pending)
+			}
+			st.pending = &ev
+			delay := r.retryStrategy.Duration(st.attempts)
+			st.attempts += 1
+			st.retryAt = r.clock.Now().Add(delay)
+			r.log.Warn("archival job failed, retrying", "job", st.job.Name(), "prev_finalized_l2", ev.PrevFinalizedL2,
+				"finalized_l2", ev.FinalizedL2, "attempts", st.attempts, "retry_in", delay, "err", err)
+		} else {
+			st.attempts = 0
+			st.retryAt = time.Time{}
+		}
+		r.mu.Unlock()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, st := range r.jobs {
+		if st.pending != nil && (!retrying || st.retryAt.Before(retryAt)) {
+			retryAt, retrying = st.retryAt, true
+		}
+	}
+	return retryAt, retrying
+}
@@ -0,0 +1,75 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeArchivalJob struct {
+	name     string
+	err      error
+	archived []FinalizedEvent
+}
+
+func (j *fakeArchivalJob) Name() string { return j.name }
+
+func (j *fakeArchivalJob) Archive(_ context.Context, ev FinalizedEvent) error {
+	j.archived = append(j.archived, ev)
+	return j.err
+}
+
+func TestArchivalRegistry(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	reg := NewArchivalRegistry(logger)
+	reg.clock = cl
+	reg.retryStrategy = retry.Fixed(time.Minute)
+
+	ok := &fakeArchivalJob{name: "ok"}
+	failing := &fakeArchivalJob{name: "failing", err: errors.New("cold storage unavailable")}
+	require.NoError(t, reg.Register(ok))
+	require.NoError(t, reg.Register(failing))
+	require.Error(t, reg.Register(&fakeArchivalJob{name: "ok"}), "names are unique")
+
+	ref := func(n uint64) eth.L2BlockRef { return eth.L2BlockRef{Number: n} }
+	reg.enqueue(FinalizedEvent{PrevFinalizedL2: ref(0), FinalizedL2: ref(10)})
+	retryAt, retrying := reg.deliver(context.Background())
+	require.True(t, retrying)
+	require.Equal(t, cl.Now().Add(time.Minute), retryAt)
+	require.Len(t, ok.archived, 1)
+	require.Len(t, failing.archived, 1)
+
+	// the failed range is coalesced with the next range, and only retried once due
+	reg.enqueue(FinalizedEvent{PrevFinalizedL2: ref(10), FinalizedL2: ref(20)})
+	_, retrying = reg.deliver(context.Background())
+	require.True(t, retrying)
+	require.Len(t, ok.archived, 2)
+	require.Equal(t, ref(10), ok.archived[1].PrevFinalizedL2)
+	require.Len(t, failing.archived, 1)
+
+	failing.err = nil
+	cl.AdvanceTime(time.Minute)
+	_, retrying = reg.deliver(context.Background())
+	require.False(t, retrying)
+	require.Len(t, failing.archived, 2)
+	require.Equal(t, ref(0), failing.archived[1].PrevFinalizedL2)
+	require.Equal(t, ref(20), failing.archived[1].FinalizedL2)
+
+	// unregistered jobs do not receive new ranges
+	reg.Unregister("failing")
+	reg.enqueue(FinalizedEvent{PrevFinalizedL2: ref(20), FinalizedL2: ref(30)})
+	reg.deliver(context.Background())
+	require.Len(t, failing.archived, 2)
+	require.Len(t, ok.archived, 3)
+}
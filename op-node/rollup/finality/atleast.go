@@ -0,0 +1,22 @@
+package finality
+
+import (
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrFinalizedUnknown is returned when the finalized L2 head is not known yet, e.g. while the engine is syncing.
+var ErrFinalizedUnknown = errors.New("finalized L2 head is not known yet")
+
+// FinalizedAtLeast returns whether the given L2 block number is finalized, i.e. at or below the finalized L2 head.
+// It reads the last published finality status, without taking the lock of the Finalizer or fetching anything,
+// so it is cheap enough for many checks per second, like bridges validating withdrawals.
+// The answer is as stale as CachedStatus: the finalized L2 head is published whenever it changes.
+func (fi *Finalizer) FinalizedAtLeast(l2Number uint64) (bool, error) {
+	finalized := fi.loadPublished().status.FinalizedL2
+	if finalized == (eth.L2BlockRef{}) {
+		return false, ErrFinalizedUnknown
+	}
+	return l2Number <= finalized.Number, nil
+}
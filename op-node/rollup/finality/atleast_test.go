@@ -0,0 +1,71 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerFinalizedAtLeast(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	for _, ref := range chain.l1 {
+		l1F.Mock.On("L1BlockRefByNumber", ref.Number).Return(ref, nil)
+	}
+	ec := &fakeEngine{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+
+	_, err := fi.FinalizedAtLeast(0)
+	require.ErrorIs(t, err, ErrFinalizedUnknown)
+
+	ec.finalized = chain.l2[0][1]
+	for i := 1; i < 3; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[2]))
+	ok, err := fi.FinalizedAtLeast(chain.l2[0][1].Number)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = fi.FinalizedAtLeast(chain.l2[1][1].Number)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	fi.Finalize(context.Background(), chain.l1[1])
+	ok, err = fi.FinalizedAtLeast(chain.l2[1][1].Number)
+	require.NoError(t, err)
+	require.True(t, ok, "the advanced finalized L2 head is published")
+	ok, err = fi.FinalizedAtLeast(chain.l2[2][0].Number)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestFinalizerFinalityLag(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 2)
+	logger := testlog.Logger(t, log.LevelInfo)
+	ec := &fakeEngine{}
+	fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, ec)
+
+	_, ok := fi.FinalityLag(chain.l2[1][1])
+	require.False(t, ok, "finalized L2 head not known yet")
+
+	ec.finalized = chain.l2[0][1]
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[1]))
+	lag, ok := fi.FinalityLag(chain.l2[1][1])
+	require.True(t, ok)
+	require.Equal(t, chain.l2[1][1].Number-chain.l2[0][1].Number, lag)
+	lag, ok = fi.FinalityLag(chain.l2[0][0])
+	require.True(t, ok)
+	require.Zero(t, lag, "unsafe L2 head behind the finalized L2 head")
+}
@@ -0,0 +1,97 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// auditTrailSize is the number of most recent finalized head updates retained in the audit trail.
+const auditTrailSize = 64
+
+// ErrFinalizedNotMonotonic is returned when the Finalizer was about to set a finalized L2 head
+// that is lower than, or does not descend from, the finalized L2 head it previously set.
+// This is a bug in the Finalizer, and is wrapped as a critical error.
+type ErrFinalizedNotMonotonic struct {
+	Prev eth.L2BlockRef
+	Next eth.L2BlockRef
+	// Reason describes how the invariant was violated.
+	Reason string
+}
+
+func (e *ErrFinalizedNotMonotonic) Error() string {
+	return fmt.Sprintf("refusing to set finalized L2 head %s after %s: %s", e.Next, e.Prev, e.Reason)
+}
+
+// AuditTrail returns the most recent finalized head updates, oldest first, for incident analysis.
+func (fi *Finalizer) AuditTrail() []FinalizedHeadUpdate {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return append([]FinalizedHeadUpdate(nil), fi.auditTrail...)
+}
+
+// setFinalizedHead records the finalized L2 head to apply to the engine, after checking it strictly continues the finalized L2 head
+// the Finalizer previously set: it may not be lower, and must descend from it.
+// Descent is verified with the L2 block source if configured, otherwise only for direct children.
+// Every update is recorded in the audit trail, including refused updates. The lock must be held.
+func (fi *Finalizer) setFinalizedHead(ctx context.Context, next eth.L2BlockRef, source string) error {
+	if fi.forkConflict != (eth.BlockID{}) {
+		return derive.NewTemporaryError(fmt.Errorf("%w: not setting finalized L2 head %s, conflict at %s",
+			ErrForkConflict, next, fi.forkConflict))
+	}
+	prev := fi.lastSetFinalized
+	update := FinalizedHeadUpdate{Time: fi.clock.Now(), Prev: prev, Next: next, Source: source}
+	reason, err := fi.checkMonotonic(ctx, prev, next)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		update.Refused = reason
+		fi.recordAudit(update)
+		err := &ErrFinalizedNotMonotonic{Prev: prev, Next: next, Reason: reason}
+		fi.log.Error("critical finalizer invariant violation", "err", err, "source", source)
+		return derive.NewCriticalError(err)
+	}
+	fi.recordAudit(update)
+	fi.lastSetFinalized = next
+	fi.finalizedL2 = next
+	return nil
+}
+
+// checkMonotonic returns why next does not strictly continue prev, or an empty string if it does.
+func (fi *Finalizer) checkMonotonic(ctx context.Context, prev, next eth.L2BlockRef) (string, error) {
+	switch {
+	case prev == (eth.L2BlockRef{}) || prev == next:
+		return "", nil
+	case next.Number < prev.Number:
+		return "lower block number", nil
+	case next.Number == prev.Number:
+		return "conflicting block at the same height", nil
+	case next.Number == prev.Number+1:
+		if next.ParentHash != prev.Hash {
+			return "not a descendant", nil
+		}
+		return "", nil
+	case fi.l2Blocks == nil:
+		return "", nil
+	}
+	ancestor, err := fi.l2Blocks.L2BlockRefByNumber(ctx, prev.Number)
+	if err != nil {
+		return "", derive.NewTemporaryError(fmt.Errorf("failed to fetch L2 block %d to verify descent of finalized L2 head %s: %w",
+			prev.Number, next, err))
+	}
+	if ancestor.Hash != prev.Hash {
+		return "not a descendant", nil
+	}
+	return "", nil
+}
+
+// recordAudit appends the update to the audit trail, dropping the oldest update if full. The lock must be held.
+func (fi *Finalizer) recordAudit(update FinalizedHeadUpdate) {
+	if len(fi.auditTrail) >= auditTrailSize {
+		fi.auditTrail = append(fi.auditTrail[:0], fi.auditTrail[1:]...)
+	}
+	fi.auditTrail = append(fi.auditTrail, update)
+}
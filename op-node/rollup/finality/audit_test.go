@@ -0,0 +1,131 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerAuditTrail(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+	for i := 1; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	fi.Finalize(context.Background(), chain.l1[1])
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	fi.Finalize(context.Background(), chain.l1[2])
+
+	trail := fi.AuditTrail()
+	require.Len(t, trail, 2)
+	require.Equal(t, chain.l2[1][1], trail[0].Next)
+	require.Equal(t, AuditSourceFinalize, trail[0].Source)
+	require.Equal(t, chain.l2[1][1], trail[1].Prev)
+	require.Equal(t, chain.l2[2][1], trail[1].Next)
+	require.Empty(t, trail[1].Refused)
+	require.Equal(t, trail, fi.DebugBundle().AuditTrail)
+}
+
+func TestFinalizerRefusesNonMonotonic(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+
+	t.Run("lower", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		ec := &fakeEngine{}
+		ec.finalized = chain.l2[0][1]
+		m := &fakeMetrics{}
+		fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, ec, WithMetrics(m))
+		// the Finalizer previously set a higher finalized head, that the engine lost track of
+		fi.lastSetFinalized = chain.l2[3][1]
+
+		fi.mu.Lock()
+		err := fi.applyFinalized(context.Background(), chain.l2[1][1])
+		fi.mu.Unlock()
+		require.ErrorIs(t, err, derive.ErrCritical)
+		var invariantErr *ErrFinalizedNotMonotonic
+		require.True(t, errors.As(err, &invariantErr))
+		require.Equal(t, FailureInvariant, failureCause(err))
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+
+		trail := fi.AuditTrail()
+		require.Len(t, trail, 1)
+		require.Equal(t, "lower block number", trail[0].Refused)
+	})
+
+	t.Run("not a descendant", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		ec := &fakeEngine{}
+		ec.finalized = chain.l2[0][1]
+		l2 := &testutils.MockL2Client{}
+		defer l2.AssertExpectations(t)
+		fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, ec, WithL2BlockSource(l2))
+		// the finalized head that was previously set was reorged out of the L2 chain
+		reorged := testutils.NextRandomL2Ref(rng, 1, chain.l2[0][1], chain.l1[0].ID())
+		fi.lastSetFinalized = reorged
+
+		l2.ExpectL2BlockRefByNumber(reorged.Number, chain.l2[1][0], nil)
+		fi.mu.Lock()
+		err := fi.applyFinalized(context.Background(), chain.l2[2][1])
+		fi.mu.Unlock()
+		require.ErrorIs(t, err, derive.ErrCritical)
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.Equal(t, "not a descendant", fi.AuditTrail()[0].Refused)
+
+		// direct children are verified without L2 lookups
+		fi.mu.Lock()
+		err = fi.applyFinalized(context.Background(), chain.l2[1][1])
+		fi.mu.Unlock()
+		require.ErrorIs(t, err, derive.ErrCritical)
+	})
+
+	t.Run("descendant", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		ec := &fakeEngine{}
+		l2 := &testutils.MockL2Client{}
+		defer l2.AssertExpectations(t)
+		fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, ec, WithL2BlockSource(l2))
+		fi.lastSetFinalized = chain.l2[0][1]
+
+		l2.ExpectL2BlockRefByNumber(chain.l2[0][1].Number, chain.l2[0][1], nil)
+		fi.mu.Lock()
+		err := fi.applyFinalized(context.Background(), chain.l2[2][1])
+		fi.mu.Unlock()
+		require.NoError(t, err)
+		require.Equal(t, chain.l2[2][1], ec.Finalized())
+	})
+}
+
+func TestAuditTrailSize(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	logger := testlog.Logger(t, log.LevelInfo)
+	fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{})
+	var last FinalizedHeadUpdate
+	for i := 0; i < auditTrailSize+10; i++ {
+		last = FinalizedHeadUpdate{Next: testutils.RandomL2BlockRef(rng)}
+		fi.recordAudit(last)
+	}
+	trail := fi.AuditTrail()
+	require.Len(t, trail, auditTrailSize)
+	require.Equal(t, last, trail[len(trail)-1])
+}
@@ -0,0 +1,111 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/core"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SafeHeadSource provides the recorded safe L2 head of the rollup node at each L1 block, like the safe head database.
+type SafeHeadSource interface {
+	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1 eth.BlockID, safeHead eth.BlockID, err error)
+}
+
+// BackfillL2 is used to look up the full block refs of backfilled safe L2 blocks.
+type BackfillL2 interface {
+	L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error)
+}
+
+// errInconsistentSafeHead is returned when a recorded safe L2 head does not fit within the gap it is to fill.
+// Such a gap is not retried, rather than finalizing from inconsistent data.
+var errInconsistentSafeHead = errors.New("recorded safe head is inconsistent with the finality data")
+
+// finalityGap is a range of L1 blocks, between two consecutive finality data entries, that no entry was buffered for.
+type finalityGap struct {
+	// from is the last entry before the gap, and to the first entry after the gap.
+	from finalityRelation
+	to   finalityRelation
+}
+
+// WithBackfill backfills gaps in the finality data, e.g. after dropped PostProcessSafeL2 calls,
+// from the safe L2 heads recorded by the rollup node, so finalization does not depend on perfect call ordering.
+func WithBackfill(safeHeads SafeHeadSource, l2 BackfillL2) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.backfillSafeHeads = safeHeads
+		fi.backfillL2 = l2
+	}
+}
+
+// detectGap records a gap if the relation that was just appended does not directly follow the previous relation.
+// The lock must be held.
+func (fi *Finalizer) detectGap(prev finalityRelation) {
+	last := fi.finalityData[len(fi.finalityData)-1]
+	if last.Source.ID.Number <= prev.Source.ID.Number+1 {
+		return
+	}
+	fi.counters.GapsDetected += 1
+	fi.dataLog.Warn("detected gap in finality data", "from_l1", prev.Source.ID, "to_l1", last.Source.ID,
+		"missing", last.Source.ID.Number-prev.Source.ID.Number-1, "backfill", fi.backfillSafeHeads != nil)
+	if fi.backfillSafeHeads != nil {
+		fi.gaps = append(fi.gaps, finalityGap{from: prev, to: last})
+	}
+}
+
+// backfillGaps fills the detected gaps in the finality data. Gaps that fail to backfill are retried on the next attempt.
+// Backfilling is best-effort: a gap only delays finalization, so errors are logged rather than returned.
+// The lock must be held.
+func (fi *Finalizer) backfillGaps(ctx context.Context) {
+	remaining := fi.gaps[:0]
+	for _, gap := range fi.gaps {
+		n, err := fi.backfillGap(ctx, gap)
+		if err != nil {
+			fi.dataLog.Warn("failed to backfill gap in finality data",
+				"from_l1", gap.from.Source.ID, "to_l1", gap.to.Source.ID, "err", err)
+			if !errors.Is(err, errInconsistentSafeHead) {
+				remaining = append(remaining, gap)
+			}
+			continue
+		}
+		fi.counters.EntriesBackfilled += uint64(n)
+		fi.dataLog.Info("backfilled gap in finality data", "from_l1", gap.from.Source.ID, "to_l1", gap.to.Source.ID, "entries", n)
+	}
+	fi.gaps = remaining
+}
+
+// backfillGap walks back from the end of the gap through the recorded safe L2 heads,
+// and tracks any that were recorded at an L1 block within the gap. It returns the number of entries backfilled.
+// The lock must be held.
+func (fi *Finalizer) backfillGap(ctx context.Context, gap finalityGap) (int, error) {
+	count := 0
+	for num := gap.to.Source.ID.Number - 1; num > gap.from.Source.ID.Number; {
+		l1, l2, err := fi.backfillSafeHeads.SafeHeadAtL1(ctx, num)
+		if err != nil {
+			return count, fmt.Errorf("failed to fetch safe head at L1 block %d: %w", num, err)
+		}
+		if l1.Number <= gap.from.Source.ID.Number {
+			break // no more recorded safe heads within the gap
+		}
+		if l1.Number > num {
+			return count, fmt.Errorf("%w: safe head at L1 block %d was recorded at later L1 block %s", errInconsistentSafeHead, num, l1)
+		}
+		l2Ref, err := fi.backfillL2.L2BlockRefByHash(ctx, l2.Hash)
+		if err != nil {
+			return count, fmt.Errorf("failed to fetch safe L2 block %s: %w", l2, err)
+		}
+		if l2Ref.Number < gap.from.Derived.Number || l2Ref.Number > gap.to.Derived.Number {
+			return count, fmt.Errorf("%w: safe L2 block %s at L1 block %s is outside of L2 blocks %d to %d",
+				errInconsistentSafeHead, l2Ref, l1, gap.from.Derived.Number, gap.to.Derived.Number)
+		}
+		if fi.trackFinalityData(l2Ref, fi.newL1Source(eth.L1BlockRef{Hash: l1.Hash, Number: l1.Number})) == core.Ignored {
+			break // the rest of the gap is older than any retained data
+		}
+		count += 1
+		num = l1.Number - 1
+	}
+	return count, nil
+}
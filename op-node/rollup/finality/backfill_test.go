@@ -0,0 +1,135 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// fakeSafeHeads records the safe L2 head per L1 block, like the safe head database.
+type fakeSafeHeads struct {
+	entries []safeHeadEntry
+	err     error
+	calls   int
+}
+
+type safeHeadEntry struct {
+	l1 eth.L1BlockRef
+	l2 eth.L2BlockRef
+}
+
+func (f *fakeSafeHeads) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (eth.BlockID, eth.BlockID, error) {
+	f.calls += 1
+	if f.err != nil {
+		return eth.BlockID{}, eth.BlockID{}, f.err
+	}
+	for i := len(f.entries) - 1; i >= 0; i-- {
+		if e := f.entries[i]; e.l1.Number <= l1BlockNum {
+			return e.l1.ID(), e.l2.ID(), nil
+		}
+	}
+	return eth.BlockID{}, eth.BlockID{}, errors.New("not found")
+}
+
+func (f *fakeSafeHeads) L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error) {
+	for _, e := range f.entries {
+		if e.l2.Hash == l2Hash {
+			return e.l2, nil
+		}
+	}
+	return eth.L2BlockRef{}, errors.New("not found")
+}
+
+func TestFinalizerBackfill(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 6)
+	safeHeads := &fakeSafeHeads{}
+	for i := 0; i < 6; i++ {
+		safeHeads.entries = append(safeHeads.entries, safeHeadEntry{l1: chain.l1[i], l2: chain.l2[i][1]})
+	}
+
+	setup := func(t *testing.T, opts ...FinalizerOption) (*Finalizer, *testutils.MockL1Source, *fakeEngine) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		l1F := &testutils.MockL1Source{}
+		t.Cleanup(func() { l1F.AssertExpectations(t) })
+		ec := &fakeEngine{}
+		ec.finalized = chain.l2[0][1]
+		fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, opts...)
+		// the calls for L1 blocks 2 and 3 were dropped
+		fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+		fi.PostProcessSafeL2(chain.l2[4][1], chain.l1[4])
+		fi.PostProcessSafeL2(chain.l2[5][1], chain.l1[5])
+		require.Equal(t, uint64(1), fi.DebugBundle().Counters.GapsDetected)
+		return fi, l1F, ec
+	}
+
+	t.Run("without backfill", func(t *testing.T) {
+		fi, l1F, ec := setup(t)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+		fi.Finalize(context.Background(), chain.l1[3])
+		// only the L2 blocks derived before the gap are finalized
+		require.Equal(t, chain.l2[1][1], ec.Finalized())
+	})
+
+	t.Run("with backfill", func(t *testing.T) {
+		safeHeads.calls = 0
+		fi, l1F, ec := setup(t, WithBackfill(safeHeads, safeHeads))
+		l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[3][1], ec.Finalized())
+		require.Equal(t, uint64(2), fi.DebugBundle().Counters.EntriesBackfilled)
+		require.Len(t, fi.Snapshot().FinalityData, 5)
+
+		// the gap is filled, and not backfilled again
+		calls := safeHeads.calls
+		l1F.ExpectL1BlockRefByNumber(chain.l1[4].Number, chain.l1[4], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[4].Number, chain.l1[4], nil)
+		fi.Finalize(context.Background(), chain.l1[4])
+		require.Equal(t, chain.l2[4][1], ec.Finalized())
+		require.Equal(t, calls, safeHeads.calls)
+	})
+
+	t.Run("retry after failure", func(t *testing.T) {
+		failing := &fakeSafeHeads{entries: safeHeads.entries, err: errors.New("unavailable")}
+		fi, l1F, ec := setup(t, WithBackfill(failing, failing))
+		l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[1][1], ec.Finalized())
+
+		// the gap is backfilled on the next attempt, once the safe heads are available
+		// the finalized L1 block was already verified to be canonical, and is not fetched again.
+		failing.err = nil
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[5]))
+		require.Equal(t, chain.l2[3][1], ec.Finalized())
+	})
+
+	t.Run("inconsistent", func(t *testing.T) {
+		// the safe head recorded at L1 block 3 is beyond the L2 block of the entry after the gap
+		inconsistent := &fakeSafeHeads{entries: []safeHeadEntry{
+			{l1: chain.l1[1], l2: chain.l2[1][1]},
+			{l1: chain.l1[3], l2: chain.l2[5][1]},
+		}}
+		fi, l1F, ec := setup(t, WithBackfill(inconsistent, inconsistent))
+		l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[1][1], ec.Finalized())
+		require.Len(t, fi.Snapshot().FinalityData, 3)
+		// the gap is not retried
+		require.Empty(t, fi.gaps)
+	})
+}
@@ -0,0 +1,122 @@
+package finality
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FinalizeRange applies a list of historical L1 finality signals, sorted by block number,
+// e.g. as replayed from the L1 finality history when reconnecting after downtime.
+// Each signal is verified to build on the previous one, but the finality data is only traversed,
+// and the engine only updated, once for the latest accepted signal.
+// Only the latest signal is subject to the max signal age, the older signals are superseded by it.
+func (fi *Finalizer) FinalizeRange(ctx context.Context, signals []eth.L1BlockRef) {
+	fi.FinalizeRangeOutcome(ctx, signals)
+}
+
+// FinalizeRangeOutcome applies a list of L1 finality signals, like FinalizeRange, and returns the outcome.
+func (fi *Finalizer) FinalizeRangeOutcome(ctx context.Context, signals []eth.L1BlockRef) FinalityOutcome {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	defer fi.publishStatus()
+	defer fi.reportStall()
+	defer fi.reportSLO()
+	defer fi.reportRelations()
+	if !fi.acceptSignals(ctx, signals) {
+		return FinalityOutcome{}
+	}
+	outcome := OutcomeOf(fi.guard(func() error { return fi.tryFinalize(ctx) }))
+	if outcome.Err != nil {
+		fi.log.Warn("received L1 finalization signals, but was unable to determine and apply L2 finality",
+			"action", outcome.Action, "err", outcome.Err)
+	}
+	return outcome
+}
+
+// acceptSignals accepts a list of L1 finality signals, sorted by block number, like FinalizeRange.
+// It returns false if none of the signals were accepted. The lock must be held.
+func (fi *Finalizer) acceptSignals(ctx context.Context, signals []eth.L1BlockRef) bool {
+	if len(signals) == 0 {
+		return false
+	}
+	if !slices.IsSortedFunc(signals, compareSignals) {
+		fi.log.Error("ignoring L1 finalized block signals that are not sorted by block number",
+			"first", signals[0], "last", signals[len(signals)-1], "count", len(signals))
+		return false
+	}
+	last := signals[len(signals)-1]
+	if fi.deferSignal(last) {
+		return false
+	}
+	if last != fi.finalizedL1 && fi.isStale(last) {
+		fi.counters.SignalsRejectedStale += 1
+		fi.metrics.RecordFinalityStaleSignal()
+		return false
+	}
+	accepted := false
+	for _, sig := range signals {
+		if sig.Number < fi.finalizedL1.Number {
+			continue // replayed signals that are superseded by an earlier signal
+		}
+		if fi.acceptSignal(ctx, sig, SignalSourceL1, false) {
+			accepted = true
+		}
+	}
+	if accepted && len(signals) > 1 {
+		fi.log.Info("applied batch of L1 finalized block signals", "first", signals[0], "last", last,
+			"count", len(signals), "l1_finalized", fi.finalizedL1)
+	}
+	return accepted
+}
+
+func compareSignals(a, b eth.L1BlockRef) int {
+	return cmp.Compare(a.Number, b.Number)
+}
+
+// FinalizeRange applies the latest of a list of L1 finality signals through the plasma backend,
+// which only tracks the latest signal.
+func (fi *PlasmaFinalizer) FinalizeRange(ctx context.Context, signals []eth.L1BlockRef) {
+	fi.FinalizeRangeOutcome(ctx, signals)
+}
+
+func (fi *PlasmaFinalizer) FinalizeRangeOutcome(ctx context.Context, signals []eth.L1BlockRef) FinalityOutcome {
+	if len(signals) == 0 {
+		return FinalityOutcome{}
+	}
+	return fi.FinalizeOutcome(ctx, signals[len(signals)-1])
+}
+
+// FinalizeRange uses the latest of a list of L1 finality signals as cue to follow the primary rollup node.
+func (fi *FollowFinalizer) FinalizeRange(ctx context.Context, signals []eth.L1BlockRef) {
+	fi.FinalizeRangeOutcome(ctx, signals)
+}
+
+func (fi *FollowFinalizer) FinalizeRangeOutcome(ctx context.Context, signals []eth.L1BlockRef) FinalityOutcome {
+	if len(signals) == 0 {
+		return FinalityOutcome{}
+	}
+	return fi.FinalizeOutcome(ctx, signals[len(signals)-1])
+}
+
+func (fi *ShadowFinalizer) FinalizeRange(ctx context.Context, signals []eth.L1BlockRef) {
+	fi.FinalizeRangeOutcome(ctx, signals)
+}
+
+// FinalizeRangeOutcome returns the outcome of the primary Finalizer only, the shadow never requires any action.
+func (fi *ShadowFinalizer) FinalizeRangeOutcome(ctx context.Context, signals []eth.L1BlockRef) FinalityOutcome {
+	outcome := fi.Finalizer.FinalizeRangeOutcome(ctx, signals)
+	fi.compare()
+	return outcome
+}
+
+// FinalizeRange fans out a list of historical L1 finality signals to the Finalizers of all chains.
+func (s *FinalizerSet) FinalizeRange(ctx context.Context, signals []eth.L1BlockRef) {
+	ids, finalizers := s.sorted()
+	for i, fi := range finalizers {
+		s.log.Debug("fanning out L1 finality signals", "chain", ids[i], "count", len(signals))
+		fi.FinalizeRange(ctx, signals)
+	}
+}
@@ -0,0 +1,81 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizeRange(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][0]
+	l1Head := chain.l1[2]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithAncestryCheck(10),
+		WithMaxSignalAge(time.Second, func() eth.L1BlockRef { return l1Head }))
+	for i := range chain.l1 {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[i]))
+	}
+
+	// the older signals of the batch are stale, but superseded by the latest signal,
+	// and only the latest signal is checked and finalized from
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	fi.FinalizeRange(context.Background(), []eth.L1BlockRef{chain.l1[0], chain.l1[1], chain.l1[2]})
+	require.Equal(t, chain.l1[2], fi.FinalizedL1())
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+	require.Equal(t, uint64(1), fi.counters.Attempts)
+	require.Zero(t, fi.counters.SignalsRejectedStale)
+
+	// signals that are not sorted are ignored
+	fi.FinalizeRange(context.Background(), []eth.L1BlockRef{chain.l1[3], chain.l1[1]})
+	require.Equal(t, chain.l1[2], fi.FinalizedL1())
+	require.Equal(t, uint64(1), fi.counters.Attempts)
+
+	// a batch with a stale latest signal is rejected
+	l1Head = chain.l1[3]
+	l1Head.Time += 60
+	fi.FinalizeRange(context.Background(), []eth.L1BlockRef{chain.l1[2], chain.l1[3]})
+	require.Equal(t, chain.l1[2], fi.FinalizedL1())
+	require.Equal(t, uint64(1), fi.counters.SignalsRejectedStale)
+	require.Equal(t, uint64(1), fi.counters.Attempts)
+}
+
+func TestFinalizeRangeAncestry(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][0]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithAncestryCheck(10))
+	for i := range chain.l1 {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[i]))
+	}
+
+	// a signal of the batch that does not build on the previous one is ignored, the others still apply
+	fork := testutils.NextRandomRef(rng, chain.l1[0])
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	fi.FinalizeRange(context.Background(), []eth.L1BlockRef{chain.l1[0], chain.l1[1], fork})
+	require.Equal(t, chain.l1[1], fi.FinalizedL1())
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+	require.Equal(t, uint64(1), fi.counters.Attempts)
+}
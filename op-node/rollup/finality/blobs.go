@@ -0,0 +1,69 @@
+package finality
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// DefaultBlobRetention is the blob retention window of Ethereum L1 nodes:
+// MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS epochs of 32 slots of 12 seconds, about 18 days.
+const DefaultBlobRetention = 4096 * 32 * 12 * time.Second
+
+// finalizedRangesRetained is the number of most recently finalized L2 ranges retained for the status.
+const finalizedRangesRetained = 16
+
+// WithBlobRetention configures the blob retention window of the L1 chain, after which L1 nodes prune blobs,
+// and finalized L2 ranges that were derived from blobs can no longer be reconstructed from L1 data alone.
+// Defaults to DefaultBlobRetention if 0.
+func WithBlobRetention(window time.Duration) FinalizerOption {
+	return func(fi *Finalizer) {
+		if window > 0 {
+			fi.blobRetention = window
+		}
+	}
+}
+
+// derivedRanges returns the newly finalized L2 ranges, from prev to finalizedL2, per derived-from L1 block,
+// and retains them for the status. The lock must be held.
+func (fi *Finalizer) derivedRanges(prev eth.L2BlockRef, finalizedL2 eth.L2BlockRef) []DerivedRange {
+	var ranges []DerivedRange
+	start := prev.Number + 1
+	for _, r := range fi.finalityData {
+		if r.Derived.Number > prev.Number && r.Derived.Number <= finalizedL2.Number {
+			rng := DerivedRange{DerivedFrom: r.Source.ID, Start: start, End: r.Derived.Number}
+			// without the batch inclusion of the L1 block, the range may have been derived from blobs
+			rng.Blobs = r.Source.BatchTxs == nil || len(r.Source.BlobIndices) > 0
+			if rng.Blobs && r.Source.Time != 0 {
+				rng.BlobsExpireAt = r.Source.Time + uint64(fi.blobRetention/time.Second)
+			}
+			ranges = append(ranges, fi.withReconstructable(rng))
+		}
+		start = max(start, r.Derived.Number+1)
+	}
+	fi.finalizedRanges = append(fi.finalizedRanges, ranges...)
+	if n := len(fi.finalizedRanges); n > finalizedRangesRetained {
+		fi.finalizedRanges = append(fi.finalizedRanges[:0], fi.finalizedRanges[n-finalizedRangesRetained:]...)
+	}
+	return ranges
+}
+
+// withReconstructable sets whether the range can be reconstructed from L1 data alone, as of now.
+// Ranges derived from blobs of an L1 block of unknown time are assumed to be expired.
+func (fi *Finalizer) withReconstructable(rng DerivedRange) DerivedRange {
+	rng.ReconstructableFromL1 = !rng.Blobs || uint64(fi.clock.Now().Unix()) < rng.BlobsExpireAt
+	return rng
+}
+
+// recentFinalizedRanges returns the most recently finalized L2 ranges, with whether they can still be reconstructed
+// from L1 data alone, as of now. The lock must be held.
+func (fi *Finalizer) recentFinalizedRanges() []DerivedRange {
+	if len(fi.finalizedRanges) == 0 {
+		return nil
+	}
+	out := make([]DerivedRange, 0, len(fi.finalizedRanges))
+	for _, rng := range fi.finalizedRanges {
+		out = append(out, fi.withReconstructable(rng))
+	}
+	return out
+}
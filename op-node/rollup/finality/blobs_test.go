@@ -0,0 +1,64 @@
+package finality
+
+import (
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerDerivedRanges(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	for i := range chain.l1 {
+		chain.l1[i].Time = 1000 + uint64(i)*12
+	}
+	chain.l1[3].Time = 0
+	logger := testlog.Logger(t, log.LevelInfo)
+	src := fakeInclusions{
+		// calldata only
+		chain.l1[1].ID(): derive.BatchInclusion{TxHashes: []common.Hash{testutils.RandomHash(rng)}},
+		chain.l1[2].ID(): derive.BatchInclusion{TxHashes: []common.Hash{testutils.RandomHash(rng)}, BlobIndices: []uint64{1}},
+	}
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	fi := NewFinalizer(logger, &rollup.Config{}, nil, &fakeEngine{}, WithClock(clk), WithInclusionSource(src),
+		WithBlobRetention(time.Hour))
+	for i := range chain.l1 {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	ranges := fi.derivedRanges(chain.l2[0][1], chain.l2[3][1])
+	require.Equal(t, []DerivedRange{
+		{DerivedFrom: chain.l1[1].ID(), Start: chain.l2[1][0].Number, End: chain.l2[1][1].Number, ReconstructableFromL1: true},
+		{DerivedFrom: chain.l1[2].ID(), Start: chain.l2[2][0].Number, End: chain.l2[2][1].Number, Blobs: true,
+			BlobsExpireAt: chain.l1[2].Time + 3600, ReconstructableFromL1: true},
+		// the time of the L1 block is unknown, so the blobs may have expired already
+		{DerivedFrom: chain.l1[3].ID(), Start: chain.l2[3][0].Number, End: chain.l2[3][1].Number, Blobs: true},
+	}, ranges)
+	require.Equal(t, ranges, fi.recentFinalizedRanges())
+
+	// the blobs expire after the retention window, the calldata does not
+	clk.AdvanceTime(time.Hour + 24*time.Second)
+	recent := fi.recentFinalizedRanges()
+	require.True(t, recent[0].ReconstructableFromL1)
+	require.False(t, recent[1].ReconstructableFromL1)
+	// the ranges that were emitted are not modified
+	require.True(t, ranges[1].ReconstructableFromL1)
+
+	// only the most recently finalized ranges are retained
+	for i := 0; i < finalizedRangesRetained; i++ {
+		fi.derivedRanges(chain.l2[0][1], chain.l2[1][1])
+	}
+	require.Len(t, fi.recentFinalizedRanges(), finalizedRangesRetained)
+	require.Equal(t, chain.l1[1].ID(), fi.recentFinalizedRanges()[0].DerivedFrom)
+}
@@ -0,0 +1,76 @@
+package finality
+
+import (
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrNotHalted is returned when resuming finalization that was not halted.
+var ErrNotHalted = errors.New("finalization is not halted")
+
+// WithCircuitBreaker halts finalization after maxMismatches consecutive attempts to finalize that failed,
+// because the L1 source served blocks that do not match the canonical chain, e.g. an L1 provider that serves
+// an inconsistent chain. While halted, new finality signals are rejected and no attempts to finalize are made,
+// to prevent reset storms, until an operator resumes finalization with ResumeFinality. Disabled if 0.
+func WithCircuitBreaker(maxMismatches int) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.maxMismatches = maxMismatches
+	}
+}
+
+// isMismatch returns true if the error of an attempt to finalize is caused by a canonical-chain mismatch.
+func isMismatch(err error) bool {
+	var signalErr *ErrSignalNotCanonical
+	var derivedErr *ErrDerivedFromNotCanonical
+	return errors.As(err, &signalErr) || errors.As(err, &derivedErr)
+}
+
+// checkBreaker counts the consecutive attempts to finalize that failed with a canonical-chain mismatch,
+// and halts finalization once there are too many. The lock must be held.
+func (fi *Finalizer) checkBreaker(err error) {
+	if fi.maxMismatches == 0 || fi.halted {
+		return
+	}
+	if !isMismatch(err) {
+		fi.mismatches = 0
+		return
+	}
+	fi.mismatches += 1
+	if fi.mismatches < fi.maxMismatches {
+		return
+	}
+	fi.halted = true
+	fi.counters.BreakerTrips += 1
+	fi.metrics.RecordFinalityHalted(true)
+	fi.log.Error("halting finalization after repeated canonical-chain mismatches! Is the L1 provider serving an inconsistent chain? "+
+		"Resume with admin_resumeFinality once resolved", "mismatches", fi.mismatches, "finalized_l1", fi.finalizedL1, "err", err)
+}
+
+// Halted returns true if finalization was halted by the circuit breaker.
+func (fi *Finalizer) Halted() bool {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.halted
+}
+
+// ResumeFinality resumes finalization after it was halted by the circuit breaker.
+// The finality signal that was accepted before may stem from the inconsistent chain, so it is dropped,
+// and finalization continues with the next finality signal. It returns ErrNotHalted if finalization was not halted.
+func (fi *Finalizer) ResumeFinality() error {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	defer fi.publishStatus()
+	if !fi.halted {
+		return ErrNotHalted
+	}
+	fi.log.Warn("resuming halted finalization, waiting for the next finality signal", "dropped_finalized_l1", fi.finalizedL1)
+	fi.halted = false
+	fi.mismatches = 0
+	fi.finalizedL1 = eth.L1BlockRef{}
+	fi.signalProvenance = SignalProvenance{}
+	fi.triedFinalizeAt = 0
+	clear(fi.verifiedL1)
+	fi.metrics.RecordFinalityHalted(false)
+	return nil
+}
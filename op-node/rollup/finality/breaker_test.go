@@ -0,0 +1,62 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerCircuitBreaker(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	// the L1 source serves a different L1 block than derivation saw, on every attempt
+	l1F := &testutils.MockL1Source{}
+	l1F.Mock.On("L1BlockRefByNumber", chain.l1[2].Number).Return(chain.l1[2], nil)
+	l1F.Mock.On("L1BlockRefByNumber", chain.l1[1].Number).Return(testutils.RandomBlockRef(rng), nil)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithCircuitBreaker(3), WithMetrics(m))
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, 1, fi.mismatches)
+	// an attempt without a mismatch breaks the sequence
+	fi.mu.Lock()
+	fi.checkBreaker(nil)
+	fi.mu.Unlock()
+	require.Zero(t, fi.mismatches)
+
+	for i := 0; i < 3; i++ {
+		fi.OnResetComplete(context.Background())
+	}
+	require.True(t, fi.Halted())
+	require.True(t, m.halted)
+	require.True(t, fi.Status().Halted)
+	require.Equal(t, uint64(1), fi.counters.BreakerTrips)
+	require.Equal(t, chain.l2[0][1], ec.Finalized())
+
+	// while halted, signals are rejected and no attempts to finalize are made
+	fi.Finalize(context.Background(), chain.l1[3])
+	require.Equal(t, chain.l1[2], fi.FinalizedL1())
+	require.Equal(t, uint64(1), fi.counters.SignalsRejectedHalted)
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+	require.Equal(t, ReasonHalted, fi.Status().LastReason)
+
+	// resuming drops the signal of the inconsistent chain
+	require.NoError(t, fi.ResumeFinality())
+	require.False(t, fi.Halted())
+	require.False(t, m.halted)
+	require.Equal(t, eth.L1BlockRef{}, fi.FinalizedL1())
+	require.ErrorIs(t, fi.ResumeFinality(), ErrNotHalted)
+}
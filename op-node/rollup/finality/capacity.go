@@ -0,0 +1,83 @@
+package finality
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// casualtyLogSize is the number of most recent pruning casualties retained in the casualty log.
+const casualtyLogSize = 64
+
+// finalizable returns true if L2 blocks derived from the given L1 block number can be finalized
+// with the current finality signal. The lock must be held.
+func (fi *Finalizer) finalizable(l1Number uint64) bool {
+	return fi.finalizedL1 != (eth.L1BlockRef{}) && l1Number+fi.extraConfirmations <= fi.finalizedL1.Number
+}
+
+// lookbackHeadroom returns the number of entries the finality data can still grow by,
+// before entries that are not yet finalizable with the current finality signal get pruned,
+// including the growth of the finality lookback up to the max lookback, if enabled.
+// The lock must be held.
+func (fi *Finalizer) lookbackHeadroom() uint64 {
+	lookback := fi.finalityLookback
+	if fi.lookbackGrowth() {
+		lookback = fi.maxLookback
+	}
+	pending := fi.pendingEntries()
+	if pending >= lookback {
+		return 0
+	}
+	return lookback - pending
+}
+
+// reportCapacity publishes the remaining lookback capacity, relative to the finality lag.
+// The lock must be held.
+func (fi *Finalizer) reportCapacity() {
+	fi.metrics.RecordFinalityLookbackHeadroom(fi.lookbackHeadroom())
+}
+
+// checkPruned warns if the pruned finality data entry was not finalizable yet:
+// the L2 blocks derived from it cannot be finalized anymore until newer L1 blocks are finalized,
+// and finalization lags further behind. The lock must be held.
+func (fi *Finalizer) checkPruned(pruned finalityRelation) {
+	if fi.finalizable(pruned.Source.ID.Number) {
+		return
+	}
+	fi.counters.EntriesPrunedUnfinalized += 1
+	fi.recordCasualty(pruned)
+	fi.dataLog.Warn("pruned finality data that is not finalized yet, finalization will lag further",
+		"pruned_l1", pruned.Source.ID, "pruned_l2", pruned.Derived, "finalized_l1", fi.finalizedL1,
+		"lookback", fi.finalityLookback)
+}
+
+// recordCasualty records the pruned finality data entry, that was not finalizable yet, in the casualty log,
+// with the number of L2 blocks that lost the opportunity to be finalized with it. The lock must be held.
+func (fi *Finalizer) recordCasualty(pruned finalityRelation) {
+	from := fi.finalizedL2.Number
+	if n := len(fi.casualties); n > 0 {
+		from = max(from, fi.casualties[n-1].L2Block.Number)
+	}
+	l2Blocks := uint64(0)
+	if pruned.Derived.Number > from {
+		l2Blocks = pruned.Derived.Number - from
+	}
+	if len(fi.casualties) >= casualtyLogSize {
+		fi.casualties = append(fi.casualties[:0], fi.casualties[1:]...)
+	}
+	fi.casualties = append(fi.casualties, PruningCasualty{
+		Time:        fi.clock.Now(),
+		L1Block:     pruned.Source.ID,
+		L2Block:     pruned.Derived.ID(),
+		L2Blocks:    l2Blocks,
+		FinalizedL1: fi.finalizedL1.ID(),
+		Lookback:    fi.finalityLookback,
+	})
+	fi.metrics.RecordFinalityPruningCasualty(l2Blocks)
+}
+
+// PruningCasualties returns the most recent finality data entries that were pruned before they were finalized,
+// oldest first, to quantify how much finalization was delayed by an undersized finality lookback.
+func (fi *Finalizer) PruningCasualties() []PruningCasualty {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return append([]PruningCasualty(nil), fi.casualties...)
+}
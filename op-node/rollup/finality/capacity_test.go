@@ -0,0 +1,83 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerLookbackHeadroom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 6)
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithMetrics(m))
+	fi.finalityLookback = 3
+
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+	require.Equal(t, uint64(1), fi.Status().LookbackHeadroom)
+	require.Equal(t, uint64(1), m.headroom)
+
+	// finalized entries do not count against the headroom
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+	require.Equal(t, uint64(2), fi.Status().LookbackHeadroom)
+	require.Equal(t, uint64(2), m.headroom)
+
+	// pruning a finalized entry is expected, and does not warn
+	fi.PostProcessSafeL2(chain.l2[3][1], chain.l1[3])
+	fi.PostProcessSafeL2(chain.l2[4][1], chain.l1[4])
+	require.Equal(t, uint64(0), m.headroom)
+	require.Equal(t, uint64(1), fi.DebugBundle().Counters.EntriesPruned)
+	require.Zero(t, fi.DebugBundle().Counters.EntriesPrunedUnfinalized)
+	require.Nil(t, logs.FindLog(testlog.NewMessageContainsFilter("pruned finality data")))
+
+	// pruning an entry that is not finalized yet warns
+	fi.PostProcessSafeL2(chain.l2[5][1], chain.l1[5])
+	require.Equal(t, uint64(1), fi.DebugBundle().Counters.EntriesPrunedUnfinalized)
+	require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn),
+		testlog.NewMessageContainsFilter("pruned finality data")))
+}
+
+func TestFinalizerPruningCasualties(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 5)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	m := &fakeMetrics{}
+	fi := NewFinalizer(testlog.Logger(t, log.LevelInfo), &rollup.Config{}, &testutils.MockL1Source{}, ec, WithMetrics(m))
+	fi.finalityLookback = 2
+	// the finalized L2 head of the engine is observed when derivation progresses
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[0]))
+
+	for i := 1; i <= 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+	casualties := fi.PruningCasualties()
+	require.Len(t, casualties, 2)
+	require.Equal(t, chain.l1[1].ID(), casualties[0].L1Block)
+	require.Equal(t, chain.l2[1][1].ID(), casualties[0].L2Block)
+	require.Equal(t, chain.l2[1][1].Number-chain.l2[0][1].Number, casualties[0].L2Blocks)
+	require.Equal(t, uint64(2), casualties[0].Lookback)
+	// the L2 blocks of the earlier casualty are not counted again
+	require.Equal(t, chain.l1[2].ID(), casualties[1].L1Block)
+	require.Equal(t, chain.l2[2][1].Number-chain.l2[1][1].Number, casualties[1].L2Blocks)
+	require.Equal(t, chain.l2[2][1].Number-chain.l2[0][1].Number, m.casualtyL2)
+	require.Equal(t, casualties, fi.DebugBundle().Casualties)
+	require.Equal(t, uint64(2), fi.DebugBundle().Counters.EntriesPrunedUnfinalized)
+}
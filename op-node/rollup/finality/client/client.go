@@ -0,0 +1,118 @@
+// Package client is a typed Go client of the finality RPC endpoints of the rollup node,
+// for services like op-proposer and indexers to integrate with, without hand-rolling JSON-RPC calls.
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/api"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// RPC is the JSON-RPC connection to the rollup node, like a go-ethereum rpc.Client.
+// Subscriptions require a connection that supports notifications, like a WebSocket connection.
+type RPC interface {
+	Close()
+	CallContext(ctx context.Context, result any, method string, args ...any) error
+	Subscribe(ctx context.Context, namespace string, channel any, args ...any) (*rpc.ClientSubscription, error)
+}
+
+// Client queries the finality of the L2 chain from a rollup node.
+type Client struct {
+	rpc RPC
+}
+
+// NewClient creates a Client that uses the given RPC connection to the rollup node.
+func NewClient(rpc RPC) *Client {
+	return &Client{rpc: rpc}
+}
+
+// Dial connects to the rollup node at the given RPC URL. Use a WebSocket URL to subscribe to finalized L2 heads.
+func Dial(ctx context.Context, url string) (*Client, error) {
+	c, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial rollup node %s: %w", url, err)
+	}
+	return NewClient(c), nil
+}
+
+// Close closes the RPC connection to the rollup node.
+func (c *Client) Close() {
+	c.rpc.Close()
+}
+
+// Status returns the finality status of the rollup node, with the fields of the latest schema version
+// of the api package this client is built with.
+func (c *Client) Status(ctx context.Context) (*api.FinalityStatus, error) {
+	var out *api.FinalityStatus
+	if err := c.rpc.CallContext(ctx, &out, "optimism_finalityStatus", hexutil.Uint64(api.LatestStatusSchema)); err != nil {
+		return nil, fmt.Errorf("failed to fetch finality status: %w", err)
+	}
+	return out, nil
+}
+
+// SubscribeFinalized subscribes to the advancements of the finalized L2 head of the rollup node.
+// Advancements that happen while a previous one is being sent are coalesced into one event.
+func (c *Client) SubscribeFinalized(ctx context.Context, ch chan<- api.FinalizedEvent) (ethereum.Subscription, error) {
+	sub, err := c.rpc.Subscribe(ctx, "optimism", ch, "finalized")
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to finalized L2 heads: %w", err)
+	}
+	return sub, nil
+}
+
+// DerivedFrom returns the L1 block the given L2 block was fully derived from, according to the finality data
+// of the rollup node. It fails if the L2 block is not safe yet, or older than the finality data of the node.
+func (c *Client) DerivedFrom(ctx context.Context, l2Number uint64) (eth.BlockID, error) {
+	var out eth.BlockID
+	if err := c.rpc.CallContext(ctx, &out, "optimism_finalityDerivedFrom", hexutil.Uint64(l2Number)); err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to fetch L1 block that L2 block %d was derived from: %w", l2Number, err)
+	}
+	return out, nil
+}
+
+// FinalizedAt returns when, and with which L1 finality signal, the given L2 block was finalized by the rollup node.
+// It fails if the rollup node does not index finalizations, or the L2 block is not finalized yet,
+// or was finalized before the index of the node starts.
+func (c *Client) FinalizedAt(ctx context.Context, l2Number uint64) (*api.FinalizationRecord, error) {
+	var out *api.FinalizationRecord
+	if err := c.rpc.CallContext(ctx, &out, "optimism_finalizedAt", hexutil.Uint64(l2Number)); err != nil {
+		return nil, fmt.Errorf("failed to fetch finalization of L2 block %d: %w", l2Number, err)
+	}
+	return out, nil
+}
+
+// FinalizedWithdrawalRoots returns the withdrawal roots of the finalized L2 heads at the given L2 block number or later,
+// as cached by the rollup node. It fails if the rollup node does not track withdrawal roots.
+func (c *Client) FinalizedWithdrawalRoots(ctx context.Context, fromL2 uint64) ([]api.FinalizedWithdrawalRoot, error) {
+	var out []api.FinalizedWithdrawalRoot
+	if err := c.rpc.CallContext(ctx, &out, "optimism_finalizedWithdrawalRoots", hexutil.Uint64(fromL2)); err != nil {
+		return nil, fmt.Errorf("failed to fetch finalized withdrawal roots from L2 block %d: %w", fromL2, err)
+	}
+	return out, nil
+}
+
+// FinalizedAtLeast returns whether the given L2 block number is finalized by the rollup node.
+// The node answers from its last published finality status, which makes this check cheap to repeat.
+func (c *Client) FinalizedAtLeast(ctx context.Context, l2Number uint64) (bool, error) {
+	var out bool
+	if err := c.rpc.CallContext(ctx, &out, "optimism_finalizedAtLeast", hexutil.Uint64(l2Number)); err != nil {
+		return false, fmt.Errorf("failed to check finality of L2 block %d: %w", l2Number, err)
+	}
+	return out, nil
+}
+
+// EstimateFinality estimates when the given L2 block will be finalized by the rollup node.
+// It fails if the L2 block is not safe yet, or older than the finality data of the node.
+func (c *Client) EstimateFinality(ctx context.Context, l2Number uint64) (*api.FinalityEstimate, error) {
+	var out *api.FinalityEstimate
+	if err := c.rpc.CallContext(ctx, &out, "optimism_estimateFinality", hexutil.Uint64(l2Number)); err != nil {
+		return nil, fmt.Errorf("failed to estimate finality of L2 block %d: %w", l2Number, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/api"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// fakeFinalityAPI serves the finality endpoints of the rollup node, in the optimism namespace.
+type fakeFinalityAPI struct {
+	status   api.FinalityStatus
+	derived  map[uint64]eth.BlockID
+	finalize chan api.FinalizedEvent
+}
+
+func (f *fakeFinalityAPI) EstimateFinality(ctx context.Context, number hexutil.Uint64) (*api.FinalityEstimate, error) {
+	id, ok := f.derived[uint64(number)]
+	if !ok {
+		return nil, errors.New("unknown")
+	}
+	return &api.FinalityEstimate{L2Block: uint64(number), DerivedFrom: id, Finalized: true}, nil
+}
+
+func (f *fakeFinalityAPI) FinalityStatus(ctx context.Context, schema *hexutil.Uint64) (*api.FinalityStatus, error) {
+	if schema == nil || uint64(*schema) != api.LatestStatusSchema {
+		return nil, api.ErrUnsupportedStatusSchema
+	}
+	return &f.status, nil
+}
+
+func (f *fakeFinalityAPI) FinalityDerivedFrom(ctx context.Context, number hexutil.Uint64) (eth.BlockID, error) {
+	id, ok := f.derived[uint64(number)]
+	if !ok {
+		return eth.BlockID{}, errors.New("unknown")
+	}
+	return id, nil
+}
+
+func (f *fakeFinalityAPI) FinalizedAt(ctx context.Context, number hexutil.Uint64) (*api.FinalizationRecord, error) {
+	if uint64(number) > f.status.FinalizedL2.Number {
+		return nil, errors.New("not found")
+	}
+	return &api.FinalizationRecord{FinalizedL2: f.status.FinalizedL2.ID(), FinalizedL1: f.status.FinalizedL1.ID(), Time: 1000}, nil
+}
+
+func (f *fakeFinalityAPI) FinalizedWithdrawalRoots(ctx context.Context, fromL2 hexutil.Uint64) ([]api.FinalizedWithdrawalRoot, error) {
+	if uint64(fromL2) > f.status.FinalizedL2.Number {
+		return nil, nil
+	}
+	return []api.FinalizedWithdrawalRoot{{L2Block: f.status.FinalizedL2.ID(), WithdrawalRoot: eth.Bytes32{1}, FinalizedL1: f.status.FinalizedL1.ID()}}, nil
+}
+
+func (f *fakeFinalityAPI) FinalizedAtLeast(ctx context.Context, number hexutil.Uint64) (bool, error) {
+	return uint64(number) <= f.status.FinalizedL2.Number, nil
+}
+
+func (f *fakeFinalityAPI) Finalized(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+	go func() {
+		for {
+			select {
+			case <-sub.Err():
+				return
+			case ev := <-f.finalize:
+				_ = notifier.Notify(sub.ID, ev)
+			}
+		}
+	}()
+	return sub, nil
+}
+
+func newTestClient(t *testing.T, service *fakeFinalityAPI) *Client {
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("optimism", service))
+	t.Cleanup(srv.Stop)
+	c := NewClient(rpc.DialInProc(srv))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestClient(t *testing.T) {
+	service := &fakeFinalityAPI{
+		status: api.FinalityStatus{
+			FinalizedL1: eth.L1BlockRef{Number: 10},
+			FinalizedL2: eth.L2BlockRef{Number: 100},
+			LastReason:  api.ReasonFinalized,
+		},
+		derived:  map[uint64]eth.BlockID{100: {Number: 10}},
+		finalize: make(chan api.FinalizedEvent),
+	}
+	c := newTestClient(t, service)
+	ctx := context.Background()
+
+	status, err := c.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, service.status, *status)
+
+	id, err := c.DerivedFrom(ctx, 100)
+	require.NoError(t, err)
+	require.Equal(t, eth.BlockID{Number: 10}, id)
+	_, err = c.DerivedFrom(ctx, 101)
+	require.ErrorContains(t, err, "unknown")
+
+	ok, err := c.FinalizedAtLeast(ctx, service.status.FinalizedL2.Number)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = c.FinalizedAtLeast(ctx, service.status.FinalizedL2.Number+1)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	rec, err := c.FinalizedAt(ctx, 100)
+	require.NoError(t, err)
+	require.Equal(t, api.FinalizationRecord{FinalizedL2: eth.BlockID{Number: 100}, FinalizedL1: eth.BlockID{Number: 10}, Time: 1000}, *rec)
+	_, err = c.FinalizedAt(ctx, 101)
+	require.ErrorContains(t, err, "not found")
+
+	roots, err := c.FinalizedWithdrawalRoots(ctx, 100)
+	require.NoError(t, err)
+	require.Equal(t, []api.FinalizedWithdrawalRoot{{L2Block: eth.BlockID{Number: 100}, WithdrawalRoot: eth.Bytes32{1}, FinalizedL1: eth.BlockID{Number: 10}}}, roots)
+	roots, err = c.FinalizedWithdrawalRoots(ctx, 101)
+	require.NoError(t, err)
+	require.Empty(t, roots)
+
+	estimate, err := c.EstimateFinality(ctx, 100)
+	require.NoError(t, err)
+	require.Equal(t, api.FinalityEstimate{L2Block: 100, DerivedFrom: eth.BlockID{Number: 10}, Finalized: true}, *estimate)
+
+	ch := make(chan api.FinalizedEvent, 1)
+	sub, err := c.SubscribeFinalized(ctx, ch)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	ev := api.FinalizedEvent{
+		PrevFinalizedL2: eth.L2BlockRef{Number: 100},
+		FinalizedL2:     eth.L2BlockRef{Number: 110},
+		DerivedFrom:     []eth.BlockID{{Number: 11}},
+	}
+	service.finalize <- ev
+	select {
+	case got := <-ch:
+		require.Equal(t, ev, got)
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for finalized event")
+	}
+}
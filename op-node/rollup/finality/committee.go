@@ -0,0 +1,327 @@
+package finality
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"net/http"
+	"strings"
+	"time"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Committee is the set of members whose attestations gate finalization, as registered on L1.
+type Committee struct {
+	// Keys are the BLS public keys of the members, compressed BLS12-381 G1 points, in registry order.
+	// The registry is trusted to only admit keys with a proof of possession, which rules out rogue-key attacks.
+	Keys [][]byte
+	// Quorum is the number of members that have to sign an attestation.
+	Quorum uint64
+}
+
+// CommitteeRegistry reads the committee from its key registry on L1.
+type CommitteeRegistry interface {
+	// Committee returns the committee as of the given L1 block.
+	Committee(ctx context.Context, l1 eth.BlockID) (Committee, error)
+}
+
+// AttestationSource provides the attestations of the committee.
+type AttestationSource interface {
+	// LatestAttestation returns the attestation of the highest L2 block that the committee attested.
+	LatestAttestation(ctx context.Context) (CommitteeAttestation, error)
+}
+
+// AttestationVerifier verifies the aggregated signature of an attestation against the committee.
+type AttestationVerifier interface {
+	// VerifyAttestation returns an error if the signature of the signers of the attestation over msg is invalid.
+	// The quorum is checked by the Finalizer.
+	VerifyAttestation(committee Committee, msg []byte, att CommitteeAttestation) error
+}
+
+// committeeTimeout bounds the lookup of the latest attestation and the committee.
+const committeeTimeout = 10 * time.Second
+
+// ErrInvalidAttestation is returned when an attestation of the committee fails verification.
+// It is wrapped as a temporary error: the L2 blocks stay gated until a valid attestation is available.
+type ErrInvalidAttestation struct {
+	// L2Block is the attested L2 block.
+	L2Block eth.BlockID
+	Err     error
+}
+
+func (e *ErrInvalidAttestation) Error() string {
+	return fmt.Sprintf("invalid committee attestation of L2 block %s: %v", e.L2Block, e.Err)
+}
+
+func (e *ErrInvalidAttestation) Unwrap() error {
+	return e.Err
+}
+
+var (
+	errQuorumNotMet     = errors.New("signers do not meet the quorum")
+	errUnknownSigner    = errors.New("signer is not a member of the committee")
+	errAttestedConflict = errors.New("attested L2 block is not canonical")
+	errAttestedUnknown  = errors.New("attested L2 block is not buffered, and there is no L2 block source to check it is canonical")
+)
+
+// WithCommitteeGate gates the finalization of L2 blocks on the attestations of a committee, like a security council
+// or a DA committee, in addition to the finality of L1. An L2 block is only finalized once a quorum of the committee,
+// as registered on L1 as of the finalized L1 block, attested it or a later L2 block.
+// The verifier checks the aggregated signatures, see BLSAttestationVerifier.
+// The attested L2 block must be canonical: it is checked against the L2 block source, see WithL2BlockSource,
+// or against the buffered finality data if there is no L2 block source.
+func WithCommitteeGate(registry CommitteeRegistry, attestations AttestationSource, verifier AttestationVerifier) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.committeeGate.registry = registry
+		fi.committeeGate.attestations = attestations
+		fi.committeeGate.verifier = verifier
+	}
+}
+
+// AttestationMessage returns the message that the committee members sign to attest the given L2 block.
+func AttestationMessage(chainID *big.Int, l2Block eth.BlockID) []byte {
+	msg := make([]byte, 0, 128)
+	msg = append(msg, SigningDomainCommitteeAttestationV1[:]...)
+	msg = append(msg, common.BigToHash(chainID).Bytes()...)
+	msg = append(msg, common.BigToHash(new(big.Int).SetUint64(l2Block.Number)).Bytes()...)
+	msg = append(msg, l2Block.Hash.Bytes()...)
+	return msg
+}
+
+// committeeGate holds back the L2 blocks that are not attested by the committee, see WithCommitteeGate.
+type committeeGate struct {
+	noopGate
+	fi *Finalizer
+	// registry, attestations and verifier gate finalization on the attestations of a committee. Disabled if nil.
+	registry     CommitteeRegistry
+	attestations AttestationSource
+	verifier     AttestationVerifier
+	// attestedL2 is the highest L2 block number that may be finalized in this attempt, according to the attestations.
+	attestedL2 uint64
+	// verifiedFor is the finalized L1 block that the attestation of verifiedL2 was verified as of.
+	// The verified attestation is reused until the finalized L1 block changes.
+	verifiedFor eth.BlockID
+	verifiedL2  uint64
+}
+
+// prepare fetches and verifies the latest attestation of the committee, once per finalized L1 block.
+// The committee is read as of the finalized L1 block, so the gate itself cannot reorg.
+func (g *committeeGate) prepare(ctx context.Context, a *finalizeAttempt) error {
+	fi := g.fi
+	g.attestedL2 = math.MaxUint64
+	if g.attestations == nil || !g.finalizable(a) {
+		return nil
+	}
+	if g.verifiedFor == fi.finalizedL1.ID() {
+		g.attestedL2 = g.verifiedL2
+		return nil
+	}
+	g.attestedL2 = 0
+	ctx, cancel := context.WithTimeout(ctx, committeeTimeout)
+	defer cancel()
+	att, err := g.attestations.LatestAttestation(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest committee attestation: %w", err)
+	}
+	committee, err := g.registry.Committee(ctx, fi.finalizedL1.ID())
+	if err != nil {
+		return fmt.Errorf("failed to fetch committee at L1 block %s: %w", fi.finalizedL1, err)
+	}
+	if err := g.verifyAttestation(ctx, committee, att); err != nil {
+		fi.counters.AttestationsRejected += 1
+		return &ErrInvalidAttestation{L2Block: att.L2Block, Err: err}
+	}
+	g.attestedL2 = att.L2Block.Number
+	g.verifiedFor, g.verifiedL2 = fi.finalizedL1.ID(), att.L2Block.Number
+	return nil
+}
+
+// finalizable returns true if any buffered L2 block above the finalized L2 head is derived from a finalized L1 block,
+// so the attempt may finalize something new, and the attestation is worth fetching.
+func (g *committeeGate) finalizable(a *finalizeAttempt) bool {
+	fi := g.fi
+	for _, r := range fi.finalityData {
+		if r.Derived.Number > a.prevFinalizedL2.Number && r.Source.ID.Number+fi.extraConfirmations <= fi.finalizedL1.Number {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *committeeGate) accept(a *finalizeAttempt, r finalityRelation, found bool) (bool, FinalizeReason) {
+	if r.Derived.Number > g.attestedL2 {
+		return false, ReasonCommitteeGated
+	}
+	return true, ""
+}
+
+// verifyAttestation checks the quorum and signature of the attestation,
+// and that the attested L2 block is the canonical L2 block at its height.
+func (g *committeeGate) verifyAttestation(ctx context.Context, committee Committee, att CommitteeAttestation) error {
+	fi := g.fi
+	signers := 0
+	for i, b := range att.Signers {
+		if i*8+bits.Len8(b) > len(committee.Keys) {
+			return errUnknownSigner
+		}
+		signers += bits.OnesCount8(b)
+	}
+	if committee.Quorum == 0 || uint64(signers) < committee.Quorum {
+		return fmt.Errorf("%w: %d of %d signers, quorum is %d", errQuorumNotMet, signers, len(committee.Keys), committee.Quorum)
+	}
+	canonical, err := fi.canonicalAttested(ctx, att.L2Block.Number)
+	if err != nil {
+		return err
+	}
+	if canonical.Hash != att.L2Block.Hash {
+		return fmt.Errorf("%w: canonical %s", errAttestedConflict, canonical)
+	}
+	return g.verifier.VerifyAttestation(committee, AttestationMessage(fi.cfg.L2ChainID, att.L2Block), att)
+}
+
+// canonicalAttested returns the canonical L2 block at the height of an attested L2 block,
+// from the L2 block source, or from the buffered finality data if there is no L2 block source. The lock must be held.
+func (fi *Finalizer) canonicalAttested(ctx context.Context, num uint64) (eth.BlockID, error) {
+	if fi.l2Blocks != nil {
+		ref, err := fi.l2Blocks.L2BlockRefByNumber(ctx, num)
+		if err != nil {
+			return eth.BlockID{}, fmt.Errorf("failed to fetch attested L2 block %d: %w", num, err)
+		}
+		return ref.ID(), nil
+	}
+	for _, r := range fi.expandedFinalityData() {
+		if r.Derived.Number == num {
+			return r.Derived.ID(), nil
+		}
+	}
+	return eth.BlockID{}, fmt.Errorf("%w: L2 block %d", errAttestedUnknown, num)
+}
+
+// blsSignatureDST is the domain separation tag of BLS signatures with public keys in G1 and signatures in G2,
+// of the proof-of-possession scheme, like the signatures of the L1 beacon chain.
+var blsSignatureDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+// BLSAttestationVerifier verifies the aggregated BLS12-381 signatures of attestations,
+// with the public keys of the signers in G1 and the signature in G2.
+type BLSAttestationVerifier struct{}
+
+var _ AttestationVerifier = BLSAttestationVerifier{}
+
+func (BLSAttestationVerifier) VerifyAttestation(committee Committee, msg []byte, att CommitteeAttestation) error {
+	var aggKey bls12381.G1Jac
+	for i, key := range committee.Keys {
+		if i/8 >= len(att.Signers) || att.Signers[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		var pk bls12381.G1Affine
+		if _, err := pk.SetBytes(key); err != nil {
+			return fmt.Errorf("invalid public key of committee member %d: %w", i, err)
+		}
+		if pk.IsInfinity() {
+			return fmt.Errorf("invalid public key of committee member %d: infinity", i)
+		}
+		aggKey.AddMixed(&pk)
+	}
+	var sig bls12381.G2Affine
+	if _, err := sig.SetBytes(att.Signature); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	hm, err := bls12381.HashToG2(msg, blsSignatureDST)
+	if err != nil {
+		return fmt.Errorf("failed to hash message: %w", err)
+	}
+	var pk, negG1 bls12381.G1Affine
+	pk.FromJacobian(&aggKey)
+	_, _, g1, _ := bls12381.Generators()
+	negG1.Neg(&g1)
+	// e(pk, H(m)) == e(g1, sig)
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{pk, negG1}, []bls12381.G2Affine{hm, sig})
+	if err != nil {
+		return fmt.Errorf("failed to check pairing: %w", err)
+	}
+	if !ok {
+		return errors.New("signature does not match the signers")
+	}
+	return nil
+}
+
+// HTTPAttestationSource fetches the latest attestation of the committee as JSON, with a plain HTTP GET request,
+// e.g. from the aggregator of the committee. The attestation is verified by the Finalizer, so the source is not trusted.
+type HTTPAttestationSource struct {
+	url    string
+	client *http.Client
+}
+
+var _ AttestationSource = (*HTTPAttestationSource)(nil)
+
+// NewHTTPAttestationSource fetches the attestations from url with the given client.
+// If client is nil, a client that times out after committeeTimeout is used.
+func NewHTTPAttestationSource(url string, client *http.Client) *HTTPAttestationSource {
+	if client == nil {
+		client = &http.Client{Timeout: committeeTimeout}
+	}
+	return &HTTPAttestationSource{url: url, client: client}
+}
+
+func (s *HTTPAttestationSource) LatestAttestation(ctx context.Context) (CommitteeAttestation, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return CommitteeAttestation{}, fmt.Errorf("failed to create attestation request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return CommitteeAttestation{}, fmt.Errorf("failed to fetch attestation: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return CommitteeAttestation{}, fmt.Errorf("failed to fetch attestation: unexpected status %s", resp.Status)
+	}
+	var att CommitteeAttestation
+	if err := json.NewDecoder(resp.Body).Decode(&att); err != nil {
+		return CommitteeAttestation{}, fmt.Errorf("failed to decode attestation: %w", err)
+	}
+	return att, nil
+}
+
+// committeeRegistryABI is the ABI of the committee key registry contract on L1.
+var committeeRegistryABI = `[{"type":"function","name":"committee","inputs":[],"outputs":[{"name":"keys","type":"bytes[]"},{"name":"quorum","type":"uint256"}],"stateMutability":"view"}]`
+
+// ContractCommitteeRegistry reads the committee from a key registry contract on L1.
+type ContractCommitteeRegistry struct {
+	contract *bind.BoundContract
+}
+
+var _ CommitteeRegistry = (*ContractCommitteeRegistry)(nil)
+
+// NewContractCommitteeRegistry binds the key registry contract at addr.
+// The caller has to support calls at a block hash, like an L1 RPC client.
+func NewContractCommitteeRegistry(addr common.Address, caller bind.ContractCaller) (*ContractCommitteeRegistry, error) {
+	registryABI, err := abi.JSON(strings.NewReader(committeeRegistryABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse committee registry ABI: %w", err)
+	}
+	return &ContractCommitteeRegistry{contract: bind.NewBoundContract(addr, registryABI, caller, nil, nil)}, nil
+}
+
+func (r *ContractCommitteeRegistry) Committee(ctx context.Context, l1 eth.BlockID) (Committee, error) {
+	var out []any
+	if err := r.contract.Call(&bind.CallOpts{Context: ctx, BlockHash: l1.Hash}, &out, "committee"); err != nil {
+		return Committee{}, err
+	}
+	keys, quorum := *abi.ConvertType(out[0], new([][]byte)).(*[][]byte), *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	if !quorum.IsUint64() {
+		return Committee{}, fmt.Errorf("committee quorum %v out of range", quorum)
+	}
+	return Committee{Keys: keys, Quorum: quorum.Uint64()}, nil
+}
@@ -0,0 +1,207 @@
+package finality
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"math/rand" // nosemgrep
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type fakeCommittee struct {
+	secrets   []*big.Int
+	committee Committee
+	l1        []eth.BlockID
+}
+
+func newFakeCommittee(rng *rand.Rand, size int, quorum uint64) *fakeCommittee {
+	c := &fakeCommittee{committee: Committee{Quorum: quorum}}
+	for i := 0; i < size; i++ {
+		sk := new(big.Int).Rand(rng, bls12381.ID.ScalarField())
+		var pk bls12381.G1Affine
+		pk.ScalarMultiplicationBase(sk)
+		key := pk.Bytes()
+		c.secrets = append(c.secrets, sk)
+		c.committee.Keys = append(c.committee.Keys, key[:])
+	}
+	return c
+}
+
+func (c *fakeCommittee) Committee(ctx context.Context, l1 eth.BlockID) (Committee, error) {
+	c.l1 = append(c.l1, l1)
+	return c.committee, nil
+}
+
+// attest returns an attestation of the L2 block, signed by the committee members at the given indices.
+func (c *fakeCommittee) attest(t *testing.T, chainID *big.Int, l2 eth.BlockID, signers ...int) CommitteeAttestation {
+	hm, err := bls12381.HashToG2(AttestationMessage(chainID, l2), blsSignatureDST)
+	require.NoError(t, err)
+	var agg bls12381.G2Affine
+	bitfield := make([]byte, (len(c.secrets)+7)/8)
+	for _, i := range signers {
+		var sig bls12381.G2Affine
+		sig.ScalarMultiplication(&hm, c.secrets[i])
+		agg.Add(&agg, &sig)
+		bitfield[i/8] |= 1 << (i % 8)
+	}
+	sig := agg.Bytes()
+	return CommitteeAttestation{L2Block: l2, Signers: bitfield, Signature: sig[:]}
+}
+
+type fakeAttestations struct {
+	att   CommitteeAttestation
+	calls int
+}
+
+func (f *fakeAttestations) LatestAttestation(ctx context.Context) (CommitteeAttestation, error) {
+	f.calls += 1
+	return f.att, nil
+}
+
+func TestFinalizerCommitteeGate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 5)
+	logger := testlog.Logger(t, log.LevelInfo)
+	cfg := &rollup.Config{L2ChainID: big.NewInt(10)}
+	committee := newFakeCommittee(rng, 4, 3)
+
+	setup := func(t *testing.T, att CommitteeAttestation) (*Finalizer, *fakeEngine, *fakeAttestations) {
+		l1F := &testutils.MockL1Source{}
+		for _, ref := range chain.l1 {
+			l1F.Mock.On("L1BlockRefByNumber", ref.Number).Return(ref, nil)
+		}
+		ec := &fakeEngine{}
+		ec.finalized = chain.l2[0][1]
+		l2 := &testutils.MockL2Client{}
+		for _, refs := range chain.l2 {
+			for _, ref := range refs {
+				l2.Mock.On("L2BlockRefByNumber", ref.Number).Return(ref, new(error))
+			}
+		}
+		attestations := &fakeAttestations{att: att}
+		fi := NewFinalizer(logger, cfg, l1F, ec, WithCommitteeGate(committee, attestations, BLSAttestationVerifier{}),
+			WithL2BlockSource(l2))
+		for i := 1; i < 4; i++ {
+			fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		}
+		return fi, ec, attestations
+	}
+
+	t.Run("gated", func(t *testing.T) {
+		fi, ec, attestations := setup(t, committee.attest(t, cfg.L2ChainID, chain.l2[0][1].ID(), 0, 1, 3))
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.Equal(t, ReasonCommitteeGated, fi.Status().LastReason)
+		require.Equal(t, chain.l1[3].ID(), committee.l1[len(committee.l1)-1], "committee read as of the finalized L1 block")
+
+		// the committee attests an L2 block in between buffered blocks
+		attestations.att = committee.attest(t, cfg.L2ChainID, chain.l2[3][0].ID(), 0, 1, 2)
+		// the attestation verified as of the finalized L1 block is reused until the finalized L1 block changes
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.Equal(t, 1, attestations.calls)
+		fi.Finalize(context.Background(), chain.l1[4])
+		require.Equal(t, 2, attestations.calls)
+		require.Equal(t, chain.l2[2][1], ec.Finalized(), "finalized up to the last buffered block attested by the committee")
+	})
+
+	t.Run("nothing to finalize", func(t *testing.T) {
+		fi, ec, attestations := setup(t, committee.attest(t, cfg.L2ChainID, chain.l2[3][1].ID(), 0, 1, 2))
+		// none of the buffered L2 blocks is derived from a finalized L1 block yet
+		fi.Finalize(context.Background(), chain.l1[0])
+		require.Zero(t, attestations.calls)
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[3][1], ec.Finalized())
+		require.Equal(t, 1, attestations.calls)
+		// the buffered L2 blocks are all finalized
+		fi.Finalize(context.Background(), chain.l1[4])
+		require.Equal(t, 1, attestations.calls)
+	})
+
+	t.Run("quorum not met", func(t *testing.T) {
+		fi, ec, _ := setup(t, committee.attest(t, cfg.L2ChainID, chain.l2[3][1].ID(), 0, 1))
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.Equal(t, ReasonError, fi.Status().LastReason)
+		require.Contains(t, fi.Status().LastError, errQuorumNotMet.Error())
+		require.Equal(t, uint64(1), fi.counters.AttestationsRejected)
+	})
+
+	t.Run("forged signers", func(t *testing.T) {
+		att := committee.attest(t, cfg.L2ChainID, chain.l2[3][1].ID(), 0, 1)
+		att.Signers[0] |= 1 << 2 // claims a signer that did not sign
+		fi, ec, _ := setup(t, att)
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.Contains(t, fi.Status().LastError, "signature does not match the signers")
+	})
+
+	t.Run("unknown signer", func(t *testing.T) {
+		att := committee.attest(t, cfg.L2ChainID, chain.l2[3][1].ID(), 0, 1, 2)
+		att.Signers[0] |= 1 << 4
+		fi, ec, _ := setup(t, att)
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.Contains(t, fi.Status().LastError, errUnknownSigner.Error())
+	})
+
+	t.Run("conflicting L2 block", func(t *testing.T) {
+		conflict := eth.BlockID{Number: chain.l2[2][1].Number, Hash: testutils.RandomHash(rng)}
+		fi, ec, _ := setup(t, committee.attest(t, cfg.L2ChainID, conflict, 0, 1, 2))
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.Contains(t, fi.Status().LastError, errAttestedConflict.Error())
+	})
+
+	t.Run("not canonical", func(t *testing.T) {
+		// the L2 block is not buffered, but it is not the canonical L2 block at its height either
+		other := eth.BlockID{Number: chain.l2[3][0].Number, Hash: testutils.RandomHash(rng)}
+		fi, ec, _ := setup(t, committee.attest(t, cfg.L2ChainID, other, 0, 1, 2))
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.Contains(t, fi.Status().LastError, errAttestedConflict.Error())
+	})
+
+	t.Run("other chain", func(t *testing.T) {
+		fi, ec, _ := setup(t, committee.attest(t, big.NewInt(11), chain.l2[3][1].ID(), 0, 1, 2))
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.Contains(t, fi.Status().LastError, "signature does not match the signers")
+	})
+}
+
+func TestHTTPAttestationSource(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	committee := newFakeCommittee(rng, 4, 3)
+	att := committee.attest(t, big.NewInt(10), testutils.RandomL2BlockRef(rng).ID(), 0, 1, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/attestation" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(att))
+	}))
+	defer srv.Close()
+
+	got, err := NewHTTPAttestationSource(srv.URL+"/attestation", srv.Client()).LatestAttestation(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, att, got)
+
+	_, err = NewHTTPAttestationSource(srv.URL+"/other", srv.Client()).LatestAttestation(context.Background())
+	require.ErrorContains(t, err, "unexpected status")
+
+	// without a client, the requests time out
+	require.Equal(t, committeeTimeout, NewHTTPAttestationSource(srv.URL+"/attestation", nil).client.Timeout)
+}
@@ -0,0 +1,224 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// WithCompression compresses the finality data beyond the most recent threshold entries,
+// if the finality lookback exceeds the threshold, e.g. for the long challenge windows of alt-DA chains.
+// Runs of entries of consecutive L1 blocks, that each derived the same number of L2 blocks,
+// are compressed to only their L1 and L2 block hashes, and expanded again when finalizing from them.
+// The L2 blocks of compressed entries are only finalized if an L2 block source is configured, see WithL2BlockSource,
+// to resolve their full block refs. Otherwise finalization only advances to the retained entries around them.
+func WithCompression(threshold uint64) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.compressAfter = threshold
+	}
+}
+
+// compressedRun is a run of compressed finality data entries, between two retained entries.
+type compressedRun struct {
+	// from is the L1 block number of the retained entry before the run.
+	from uint64
+	// l2Delta is the number of L2 blocks derived from each L1 block of the run.
+	l2Delta uint64
+	// entries are the compressed entries, of the L1 blocks following from.
+	entries []compressedEntry
+}
+
+// compressedEntry is a compressed finality data entry: its block numbers follow from its position in the run.
+type compressedEntry struct {
+	l1 common.Hash
+	l2 common.Hash
+}
+
+// compressing returns true if the finality data is compressed.
+// This depends on the configured lookback, not on the lookback as grown, so it does not change at runtime.
+// The lock must be held.
+func (fi *Finalizer) compressing() bool {
+	return fi.compressAfter > 0 && fi.baseLookback > fi.compressAfter
+}
+
+// runBefore returns the compressed run between the retained entry at index i and the retained entry before it, if any.
+// The lock must be held.
+func (fi *Finalizer) runBefore(i int) *compressedRun {
+	if i == 0 || len(fi.runs) == 0 {
+		return nil
+	}
+	run, ok := fi.runs[fi.finalityData[i].Source.ID.Number]
+	if !ok || run.from != fi.finalityData[i-1].Source.ID.Number {
+		return nil
+	}
+	return run
+}
+
+// compactFinalityData compresses the finality data after a new latest entry was appended,
+// and prunes it to retain at most the finality lookback, including the compressed entries. The lock must be held.
+func (fi *Finalizer) compactFinalityData() {
+	if !fi.compressing() {
+		return
+	}
+	fi.dropUnanchoredRuns()
+	fi.compressEntry()
+	for len(fi.finalityData) > 1 && uint64(len(fi.finalityData))+fi.compressedEntries > fi.finalityLookback {
+		fi.counters.EntriesPruned += 1
+		fi.checkPruned(fi.finalityData[0])
+		fi.finalityData = slices.Delete(fi.finalityData, 0, 1)
+		fi.dropUnanchoredRuns()
+	}
+}
+
+// compressEntry compresses the entry that moved beyond the threshold of the most recent entries,
+// if it is in a run with the entries around it. The lock must be held.
+func (fi *Finalizer) compressEntry() {
+	rels := fi.finalityData
+	i := len(rels) - 2 - int(fi.compressAfter)
+	if i < 1 {
+		return
+	}
+	prev, cur, next := rels[i-1], rels[i], rels[i+1]
+	if len(cur.Source.BatchTxs) > 0 || len(cur.Source.BlobIndices) > 0 || len(cur.Source.Batchers) > 0 {
+		return // the batch inclusion of the entry is retained
+	}
+	before, after := fi.runBefore(i), fi.runBefore(i+1)
+	delta, ok := runDelta(prev, cur, before)
+	if !ok {
+		return
+	}
+	if nextDelta, ok := runDelta(cur, next, after); !ok || nextDelta != delta {
+		return
+	}
+	merged := &compressedRun{from: prev.Source.ID.Number, l2Delta: delta}
+	if before != nil {
+		merged.entries = append(merged.entries, before.entries...)
+		delete(fi.runs, cur.Source.ID.Number)
+	}
+	merged.entries = append(merged.entries, compressedEntry{l1: cur.Source.ID.Hash, l2: cur.Derived.Hash})
+	if after != nil {
+		merged.entries = append(merged.entries, after.entries...)
+	}
+	if fi.runs == nil {
+		fi.runs = make(map[uint64]*compressedRun)
+	}
+	fi.runs[next.Source.ID.Number] = merged
+	fi.compressedEntries += 1
+	fi.finalityData = slices.Delete(rels, i, i+1)
+}
+
+// runDelta returns the number of L2 blocks derived from each L1 block between the retained entries a and b,
+// given the compressed run between them, if any. It returns false if the L1 blocks between them are not consecutive,
+// or did not derive the same number of L2 blocks each.
+func runDelta(a, b finalityRelation, run *compressedRun) (uint64, bool) {
+	blocks := uint64(1)
+	if run != nil {
+		blocks += uint64(len(run.entries))
+	}
+	if b.Source.ID.Number-a.Source.ID.Number != blocks || b.Derived.Number < a.Derived.Number {
+		return 0, false
+	}
+	derived := b.Derived.Number - a.Derived.Number
+	if derived%blocks != 0 || (run != nil && run.l2Delta != derived/blocks) {
+		return 0, false
+	}
+	return derived / blocks, true
+}
+
+// dropUnanchoredRuns drops the compressed runs before the oldest retained entry, after it was pruned.
+// The lock must be held.
+func (fi *Finalizer) dropUnanchoredRuns() {
+	if len(fi.finalityData) == 0 {
+		fi.dropRuns(func(*compressedRun, uint64) bool { return true })
+		return
+	}
+	oldest := fi.finalityData[0].Source.ID.Number
+	fi.dropRuns(func(run *compressedRun, _ uint64) bool { return run.from < oldest })
+}
+
+// dropRunsAt drops the compressed runs that include the given L1 block number, including the retained entries around them,
+// after the entry of the L1 block was inserted or updated. The lock must be held.
+func (fi *Finalizer) dropRunsAt(l1Number uint64) {
+	fi.dropRuns(func(run *compressedRun, end uint64) bool { return run.from <= l1Number && l1Number <= end })
+}
+
+// dropRuns drops the compressed runs that match, by the L1 block number of the retained entry they end at.
+// The lock must be held.
+func (fi *Finalizer) dropRuns(match func(run *compressedRun, end uint64) bool) {
+	for end, run := range fi.runs {
+		if match(run, end) {
+			fi.counters.EntriesPruned += uint64(len(run.entries))
+			fi.compressedEntries -= uint64(len(run.entries))
+			delete(fi.runs, end)
+		}
+	}
+}
+
+// expandedFinalityData returns the finality data, with the compressed entries expanded again.
+// The L2 block refs of the expanded entries only have their hash, number and time, and their source is marked as expanded.
+// The lock must be held.
+func (fi *Finalizer) expandedFinalityData() finalityRelations {
+	if len(fi.runs) == 0 {
+		return fi.finalityData
+	}
+	out := make(finalityRelations, 0, uint64(len(fi.finalityData))+fi.compressedEntries)
+	for i, r := range fi.finalityData {
+		if run := fi.runBefore(i); run != nil {
+			prev := fi.finalityData[i-1]
+			for j, e := range run.entries {
+				n := uint64(j) + 1
+				derived := n * run.l2Delta
+				out = append(out, finalityRelation{
+					Derived: eth.L2BlockRef{
+						Hash:   e.l2,
+						Number: prev.Derived.Number + derived,
+						Time:   prev.Derived.Time + derived*fi.cfg.BlockTime,
+					},
+					Source: l1Source{ID: eth.BlockID{Hash: e.l1, Number: prev.Source.ID.Number + n}, Expanded: true},
+				})
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// pendingCompressed returns the number of compressed entries before the retained entry at index i,
+// of L1 blocks that are not finalizable yet. The lock must be held.
+func (fi *Finalizer) pendingCompressed(i int) uint64 {
+	run := fi.runBefore(i)
+	if run == nil {
+		return 0
+	}
+	pending := uint64(0)
+	for j := range run.entries {
+		if !fi.finalizable(run.from + uint64(j) + 1) {
+			pending += 1
+		}
+	}
+	return pending
+}
+
+// resolveExpanded resolves the full L2 block ref of an expanded finality data entry.
+// Other entries are returned as-is. The lock must be held.
+func (fi *Finalizer) resolveExpanded(ctx context.Context, r finalityRelation) (finalityRelation, error) {
+	if !r.Source.Expanded {
+		return r, nil
+	}
+	if fi.l2Blocks == nil {
+		return r, fmt.Errorf("cannot resolve compressed L2 block %s without an L2 block source", r.Derived.ID())
+	}
+	ref, err := fi.l2Blocks.L2BlockRefByNumber(ctx, r.Derived.Number)
+	if err != nil {
+		return r, fmt.Errorf("failed to resolve compressed L2 block %s: %w", r.Derived.ID(), err)
+	}
+	if ref.Hash != r.Derived.Hash {
+		return r, fmt.Errorf("compressed L2 block %s does not match L2 block %s", r.Derived.ID(), ref.ID())
+	}
+	r.Derived = ref
+	return r, nil
+}
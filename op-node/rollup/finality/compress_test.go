@@ -0,0 +1,125 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerCompression(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 10)
+
+	t.Run("expand", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		l1F := &testutils.MockL1Source{}
+		defer l1F.AssertExpectations(t)
+		l2 := &testutils.MockL2Client{}
+		defer l2.AssertExpectations(t)
+		ec := &fakeEngine{}
+		ec.finalized = chain.l2[0][1]
+		fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithL2BlockSource(l2),
+			WithPruningPolicy(CapacityPruning{Capacity: 100}), WithCompression(2))
+		require.Nil(t, fi.finalityArena)
+
+		for i := range chain.l1 {
+			fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		}
+		// only the oldest entry and the most recent entries are retained
+		require.Len(t, fi.finalityData, 4)
+		require.Equal(t, uint64(6), fi.compressedEntries)
+		require.Equal(t, chain.l1[0].ID(), fi.finalityData[0].Source.ID)
+		require.Equal(t, chain.l1[7].ID(), fi.finalityData[1].Source.ID)
+		require.Zero(t, fi.counters.EntriesPruned)
+
+		expanded := fi.expandedFinalityData()
+		require.Len(t, expanded, len(chain.l1))
+		for i, r := range expanded {
+			require.Equal(t, chain.l1[i].ID(), r.Source.ID)
+			require.Equal(t, chain.l2[i][1].ID(), r.Derived.ID())
+			require.Equal(t, i > 0 && i < 7, r.Source.Expanded)
+		}
+		id, err := fi.DerivedFrom(chain.l2[3][0].Number)
+		require.NoError(t, err)
+		require.Equal(t, chain.l1[3].ID(), id)
+
+		// the full L2 block ref of a compressed entry is resolved to finalize it
+		l2.ExpectL2BlockRefByNumber(chain.l2[4][1].Number, chain.l2[4][1], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[4].Number, chain.l1[4], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[4].Number, chain.l1[4], nil)
+		fi.Finalize(context.Background(), chain.l1[4])
+		require.Equal(t, chain.l2[4][1], ec.Finalized())
+	})
+
+	t.Run("without L2 block source", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		l1F := &testutils.MockL1Source{}
+		defer l1F.AssertExpectations(t)
+		ec := &fakeEngine{}
+		ec.finalized = chain.l2[0][1]
+		fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec,
+			WithPruningPolicy(CapacityPruning{Capacity: 100}), WithCompression(2))
+		for i := range chain.l1 {
+			fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		}
+
+		// compressed entries are skipped, the retained entry before them is finalized
+		fi.Finalize(context.Background(), chain.l1[4])
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+
+		l1F.ExpectL1BlockRefByNumber(chain.l1[7].Number, chain.l1[7], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[7].Number, chain.l1[7], nil)
+		fi.Finalize(context.Background(), chain.l1[7])
+		require.Equal(t, chain.l2[7][1], ec.Finalized())
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{},
+			WithPruningPolicy(CapacityPruning{Capacity: 6}), WithCompression(2))
+		require.Equal(t, uint64(6), fi.finalityLookback)
+		for i := range chain.l1 {
+			fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+			require.LessOrEqual(t, uint64(len(fi.finalityData))+fi.compressedEntries, fi.finalityLookback)
+		}
+		expanded := fi.expandedFinalityData()
+		require.Len(t, expanded, 6)
+		require.Equal(t, chain.l1[4].ID(), expanded[0].Source.ID)
+		require.Equal(t, uint64(4), fi.counters.EntriesPruned)
+	})
+
+	t.Run("replace compressed entry", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{},
+			WithPruningPolicy(CapacityPruning{Capacity: 100}), WithCompression(2))
+		for i := range chain.l1 {
+			fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		}
+		// a replayed L1 block of a compressed entry drops the run, it no longer describes the L2 chain
+		fi.PostProcessSafeL2(chain.l2[3][0], chain.l1[3])
+		require.Zero(t, fi.compressedEntries)
+		require.Empty(t, fi.runs)
+		require.Len(t, fi.finalityData, 5)
+		require.Equal(t, chain.l1[3].ID(), fi.finalityData[1].Source.ID)
+	})
+
+	t.Run("below threshold", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{},
+			WithPruningPolicy(CapacityPruning{Capacity: 6}), WithCompression(6))
+		require.NotNil(t, fi.finalityArena)
+		for i := range chain.l1 {
+			fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		}
+		require.Len(t, fi.finalityData, 6)
+		require.Zero(t, fi.compressedEntries)
+	})
+}
@@ -0,0 +1,180 @@
+package finality
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ConformanceVector describes a sequence of safe head updates and finality signals, and the expected finalized heads.
+// Vectors are published in JSON, so alternative rollup node implementations can verify matching finalization semantics.
+//
+// Blocks are identified by number only. The L1 and L2 chains are linear,
+// with block hashes as defined by ConformanceL1Hash and ConformanceL2Hash.
+type ConformanceVector struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// FinalizedL2 is the number of the finalized L2 head of the engine, before the first step.
+	FinalizedL2 uint64 `json:"finalized_l2"`
+	// ExtraConfirmations is the number of L1 blocks below the finalized L1 block, that L2 blocks have to be derived from.
+	ExtraConfirmations uint64 `json:"extra_confirmations,omitempty"`
+	// MaxAdvance is the maximum number of L2 blocks to advance the finalized L2 head by at a time.
+	MaxAdvance uint64            `json:"max_advance,omitempty"`
+	Steps      []ConformanceStep `json:"steps"`
+}
+
+// ConformanceStep is a single step of a ConformanceVector. Exactly one of its fields is set.
+type ConformanceStep struct {
+	// Safe is a new safe L2 head, fully derived from an L1 block.
+	Safe *ConformanceSafe `json:"safe,omitempty"`
+	// DerivationEnd is the number of the L1 block that derivation exhausted.
+	DerivationEnd *uint64 `json:"derivation_end,omitempty"`
+	// Finalize is the number of the L1 block of a finality signal.
+	Finalize *uint64 `json:"finalize,omitempty"`
+	// Reset is a pipeline reset, which clears the buffered safe heads.
+	Reset bool `json:"reset,omitempty"`
+	// Expect is the expected state after the previous steps.
+	Expect *ConformanceExpect `json:"expect,omitempty"`
+}
+
+// ConformanceSafe is a safe L2 head, and the L1 block it was fully derived from.
+type ConformanceSafe struct {
+	L2          uint64 `json:"l2"`
+	DerivedFrom uint64 `json:"derived_from"`
+}
+
+// ConformanceExpect is the expected state of the finalizer.
+type ConformanceExpect struct {
+	// FinalizedL2 is the expected number of the finalized L2 head.
+	FinalizedL2 uint64 `json:"finalized_l2"`
+	// FinalizedL1 is the expected number of the finalized L1 block, if set.
+	FinalizedL1 *uint64 `json:"finalized_l1,omitempty"`
+	// Reason is the expected outcome of the last attempt to finalize, if set.
+	// This is specific to this implementation, and may be ignored by other implementations.
+	Reason FinalizeReason `json:"reason,omitempty"`
+}
+
+// LoadConformanceVector reads a ConformanceVector from a JSON file.
+func LoadConformanceVector(path string) (*ConformanceVector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conformance vector: %w", err)
+	}
+	var v ConformanceVector
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to decode conformance vector %s: %w", path, err)
+	}
+	return &v, nil
+}
+
+// ConformanceL1Hash is the hash of the L1 block with the given number in conformance vectors: keccak256("l1" ++ uint64be(num)).
+func ConformanceL1Hash(num uint64) common.Hash {
+	return conformanceHash("l1", num)
+}
+
+// ConformanceL2Hash is the hash of the L2 block with the given number in conformance vectors: keccak256("l2" ++ uint64be(num)).
+func ConformanceL2Hash(num uint64) common.Hash {
+	return conformanceHash("l2", num)
+}
+
+func conformanceHash(chain string, num uint64) common.Hash {
+	return crypto.Keccak256Hash([]byte(chain), binary.BigEndian.AppendUint64(nil, num))
+}
+
+func conformanceL1(num uint64) eth.L1BlockRef {
+	ref := eth.L1BlockRef{Hash: ConformanceL1Hash(num), Number: num, Time: 12 * num}
+	if num > 0 {
+		ref.ParentHash = ConformanceL1Hash(num - 1)
+	}
+	return ref
+}
+
+func conformanceL2(num uint64) eth.L2BlockRef {
+	ref := eth.L2BlockRef{Hash: ConformanceL2Hash(num), Number: num, Time: 2 * num}
+	if num > 0 {
+		ref.ParentHash = ConformanceL2Hash(num - 1)
+	}
+	return ref
+}
+
+// conformanceChain serves the linear L1 and L2 chains of conformance vectors, and acts as the engine.
+type conformanceChain struct {
+	finalized eth.L2BlockRef
+}
+
+func (c *conformanceChain) L1BlockRefByNumber(_ context.Context, num uint64) (eth.L1BlockRef, error) {
+	return conformanceL1(num), nil
+}
+
+func (c *conformanceChain) L1BlockRefByHash(_ context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	return eth.L1BlockRef{}, fmt.Errorf("L1 block %s: %w", hash, ethereum.NotFound)
+}
+
+func (c *conformanceChain) L2BlockRefByNumber(_ context.Context, num uint64) (eth.L2BlockRef, error) {
+	return conformanceL2(num), nil
+}
+
+func (c *conformanceChain) Finalized() eth.L2BlockRef {
+	return c.finalized
+}
+
+func (c *conformanceChain) SetFinalizedHead(_ context.Context, ref eth.L2BlockRef) error {
+	c.finalized = ref
+	return nil
+}
+
+// RunConformanceVector runs the steps of the vector against a new Finalizer,
+// and returns an error describing the first step that did not match the expectation.
+func RunConformanceVector(log log.Logger, v *ConformanceVector) error {
+	ctx := context.Background()
+	chain := &conformanceChain{finalized: conformanceL2(v.FinalizedL2)}
+	fi := NewFinalizer(log, &rollup.Config{}, chain, chain,
+		WithExtraConfirmations(v.ExtraConfirmations), WithMaxAdvance(v.MaxAdvance), WithL2BlockSource(chain))
+	for i, step := range v.Steps {
+		switch {
+		case step.Safe != nil:
+			fi.PostProcessSafeL2(conformanceL2(step.Safe.L2), conformanceL1(step.Safe.DerivedFrom))
+		case step.DerivationEnd != nil:
+			if err := fi.OnDerivationL1End(ctx, conformanceL1(*step.DerivationEnd)); err != nil {
+				return fmt.Errorf("step %d: derivation end of L1 block %d failed: %w", i, *step.DerivationEnd, err)
+			}
+		case step.Finalize != nil:
+			fi.Finalize(ctx, conformanceL1(*step.Finalize))
+		case step.Reset:
+			fi.Reset()
+		case step.Expect != nil:
+			if err := checkConformance(fi, step.Expect); err != nil {
+				return fmt.Errorf("step %d: %w", i, err)
+			}
+		default:
+			return fmt.Errorf("step %d: empty step", i)
+		}
+	}
+	return nil
+}
+
+func checkConformance(fi *Finalizer, expect *ConformanceExpect) error {
+	status := fi.Status()
+	var errs []error
+	if status.FinalizedL2.Number != expect.FinalizedL2 {
+		errs = append(errs, fmt.Errorf("expected finalized L2 block %d, got %d", expect.FinalizedL2, status.FinalizedL2.Number))
+	}
+	if expect.FinalizedL1 != nil && status.FinalizedL1.Number != *expect.FinalizedL1 {
+		errs = append(errs, fmt.Errorf("expected finalized L1 block %d, got %d", *expect.FinalizedL1, status.FinalizedL1.Number))
+	}
+	if expect.Reason != ReasonNone && status.LastReason != expect.Reason {
+		errs = append(errs, fmt.Errorf("expected reason %q, got %q", expect.Reason, status.LastReason))
+	}
+	return errors.Join(errs...)
+}
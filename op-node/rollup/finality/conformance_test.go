@@ -0,0 +1,43 @@
+package finality
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestConformanceVectors(t *testing.T) {
+	paths, err := filepath.Glob("testdata/conformance/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+	for _, path := range paths {
+		v, err := LoadConformanceVector(path)
+		require.NoError(t, err)
+		t.Run(v.Name, func(t *testing.T) {
+			require.NoError(t, RunConformanceVector(testlog.Logger(t, log.LevelInfo), v))
+		})
+	}
+}
+
+func TestConformanceVectorMismatch(t *testing.T) {
+	l1, l2 := uint64(1), uint64(1)
+	v := &ConformanceVector{
+		Name: "mismatch",
+		Steps: []ConformanceStep{
+			{Safe: &ConformanceSafe{L2: 2, DerivedFrom: l1}},
+			{Finalize: &l1},
+			{Expect: &ConformanceExpect{FinalizedL2: l2}},
+		},
+	}
+	err := RunConformanceVector(testlog.Logger(t, log.LevelInfo), v)
+	require.ErrorContains(t, err, "step 2: expected finalized L2 block 1, got 2")
+
+	v.Steps = append(v.Steps, ConformanceStep{})
+	v.Steps[2].Expect.FinalizedL2 = 2
+	require.ErrorContains(t, RunConformanceVector(testlog.Logger(t, log.LevelInfo), v), "step 3: empty step")
+}
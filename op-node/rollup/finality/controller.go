@@ -0,0 +1,214 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FinalityController is the core of a finalizer, as the rollup driver drives it:
+// it is fed the derived L2 blocks and the L1 finality signals, and reports its status.
+// The Finalizer, PlasmaFinalizer and FollowFinalizer are alternative implementations.
+type FinalityController interface {
+	// Finalize applies a L1 finality signal.
+	Finalize(ctx context.Context, l1Origin eth.L1BlockRef)
+	// FinalizeOutcome applies a L1 finality signal, like Finalize, and returns the outcome.
+	FinalizeOutcome(ctx context.Context, l1Origin eth.L1BlockRef) FinalityOutcome
+	// OnDerivationL1EndOutcome is like OnDerivationL1End, but returns the outcome instead of a leveled error.
+	OnDerivationL1EndOutcome(ctx context.Context, derivedFrom eth.L1BlockRef) FinalityOutcome
+	// OnL1TraversedOutcome is like OnDerivationL1EndOutcome, for L1 blocks that no L2 blocks were derived from.
+	OnL1TraversedOutcome(ctx context.Context, traversed eth.L1BlockRef) FinalityOutcome
+	// OnL2ForkConflict freezes finalization, as the L2 chain of the node conflicts with the L2 chain derived from L1.
+	OnL2ForkConflict(conflict eth.BlockID)
+	Status() FinalityStatus
+	engine.FinalizerHooks
+}
+
+// Admin is the operator surface of a finalizer. It is served by the admin API of the node only:
+// the rollup driver does not depend on it.
+type Admin interface {
+	// ResumeFinality resumes finalization after it was halted by the circuit breaker.
+	ResumeFinality() error
+	// ReloadTunables applies the update to the finality tunables, retaining the buffered finality data.
+	ReloadTunables(update FinalityTunablesUpdate, trigger string) (FinalityTunables, error)
+	// Restore merges a persisted snapshot into the finality state, before the finalizer is started.
+	Restore(snapshot *Snapshot) error
+	// UnsafeRollbackFinalized rewinds the finalized L2 head to the given older L2 block, for devnets only.
+	// It updates the engine, so it must not run concurrently with the driver event loop.
+	UnsafeRollbackFinalized(ctx context.Context, target eth.L2BlockRef) error
+}
+
+var (
+	_ Admin = (*Finalizer)(nil)
+	_ Admin = (*PlasmaFinalizer)(nil)
+	_ Admin = (*FollowFinalizer)(nil)
+	_ Admin = (*ShadowFinalizer)(nil)
+)
+
+var (
+	_ FinalityController = (*Finalizer)(nil)
+	_ FinalityController = (*PlasmaFinalizer)(nil)
+	_ FinalityController = (*FollowFinalizer)(nil)
+	_ FinalityController = (*ShadowFinalizer)(nil)
+	_ FinalityController = NoopController{}
+	_ FinalityController = (*RecordingController)(nil)
+)
+
+// Mode selects the FinalityController implementation of the rollup driver.
+type Mode string
+
+const (
+	// ModeAuto follows a primary rollup node if one is configured, uses plasma finality if plasma is enabled,
+	// and L1 finality otherwise.
+	ModeAuto Mode = "auto"
+	// ModeL1 finalizes L2 blocks once the L1 blocks they are derived from are finalized.
+	ModeL1 Mode = "l1"
+	// ModePlasma finalizes L2 blocks once the L1 blocks they are derived from, and their DA challenges, are finalized.
+	ModePlasma Mode = "plasma"
+	// ModeFollow follows the finalized L2 head of a primary rollup node.
+	ModeFollow Mode = "follow"
+)
+
+// ParseMode parses the Mode of a flag.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case ModeAuto, ModeL1, ModePlasma, ModeFollow:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown finality mode %q", s)
+	}
+}
+
+// NoopController is a FinalityController that never finalizes anything, e.g. for nodes that do not track finality.
+type NoopController struct{}
+
+func (NoopController) Finalize(ctx context.Context, l1Origin eth.L1BlockRef) {}
+
+func (NoopController) FinalizeOutcome(ctx context.Context, l1Origin eth.L1BlockRef) FinalityOutcome {
+	return FinalityOutcome{}
+}
+
+func (NoopController) OnDerivationL1EndOutcome(ctx context.Context, derivedFrom eth.L1BlockRef) FinalityOutcome {
+	return FinalityOutcome{}
+}
+
+func (NoopController) OnL1TraversedOutcome(ctx context.Context, traversed eth.L1BlockRef) FinalityOutcome {
+	return FinalityOutcome{}
+}
+
+func (NoopController) OnL2ForkConflict(conflict eth.BlockID) {}
+
+func (NoopController) Status() FinalityStatus { return FinalityStatus{} }
+
+func (NoopController) OnDerivationL1End(ctx context.Context, derivedFrom eth.L1BlockRef) error {
+	return nil
+}
+
+func (NoopController) PostProcessSafeL2(l2Safe eth.L2BlockRef, derivedFrom eth.L1BlockRef) {}
+
+func (NoopController) Reset() {}
+
+// ControllerCall is a call to a FinalityController, as recorded by the RecordingController.
+type ControllerCall struct {
+	// Method is the name of the called method.
+	Method string
+	// L1 is the L1 block argument of the call, if any.
+	L1 eth.L1BlockRef
+	// L2 is the L2 block argument of the call, if any.
+	L2 eth.L2BlockRef
+}
+
+// RecordingController is a FinalityController that records the calls it receives, and forwards them to the inner
+// FinalityController, if any. It can be used to test how a FinalityController is driven, or to trace a live one.
+type RecordingController struct {
+	Inner FinalityController
+
+	mu    sync.Mutex
+	calls []ControllerCall
+}
+
+// NewRecordingController returns a RecordingController that forwards to inner, which may be nil.
+func NewRecordingController(inner FinalityController) *RecordingController {
+	return &RecordingController{Inner: inner}
+}
+
+// Calls returns a copy of the calls recorded so far, in order.
+func (rc *RecordingController) Calls() []ControllerCall {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return append([]ControllerCall(nil), rc.calls...)
+}
+
+func (rc *RecordingController) record(call ControllerCall) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.calls = append(rc.calls, call)
+}
+
+func (rc *RecordingController) Finalize(ctx context.Context, l1Origin eth.L1BlockRef) {
+	rc.FinalizeOutcome(ctx, l1Origin)
+}
+
+// FinalizeOutcome is recorded as a call to Finalize.
+func (rc *RecordingController) FinalizeOutcome(ctx context.Context, l1Origin eth.L1BlockRef) FinalityOutcome {
+	rc.record(ControllerCall{Method: "Finalize", L1: l1Origin})
+	if rc.Inner == nil {
+		return FinalityOutcome{}
+	}
+	return rc.Inner.FinalizeOutcome(ctx, l1Origin)
+}
+
+// Status returns the status of the inner FinalityController. It is not recorded, as it does not change any state.
+func (rc *RecordingController) Status() FinalityStatus {
+	if rc.Inner == nil {
+		return FinalityStatus{}
+	}
+	return rc.Inner.Status()
+}
+
+func (rc *RecordingController) OnDerivationL1End(ctx context.Context, derivedFrom eth.L1BlockRef) error {
+	return rc.OnDerivationL1EndOutcome(ctx, derivedFrom).AsError()
+}
+
+// OnDerivationL1EndOutcome is recorded as a call to OnDerivationL1End.
+func (rc *RecordingController) OnDerivationL1EndOutcome(ctx context.Context, derivedFrom eth.L1BlockRef) FinalityOutcome {
+	rc.record(ControllerCall{Method: "OnDerivationL1End", L1: derivedFrom})
+	if rc.Inner == nil {
+		return FinalityOutcome{}
+	}
+	return rc.Inner.OnDerivationL1EndOutcome(ctx, derivedFrom)
+}
+
+// OnL1TraversedOutcome is recorded as a call to OnL1Traversed.
+func (rc *RecordingController) OnL1TraversedOutcome(ctx context.Context, traversed eth.L1BlockRef) FinalityOutcome {
+	rc.record(ControllerCall{Method: "OnL1Traversed", L1: traversed})
+	if rc.Inner == nil {
+		return FinalityOutcome{}
+	}
+	return rc.Inner.OnL1TraversedOutcome(ctx, traversed)
+}
+
+// OnL2ForkConflict is recorded with the conflicting L2 block as L2 argument.
+func (rc *RecordingController) OnL2ForkConflict(conflict eth.BlockID) {
+	rc.record(ControllerCall{Method: "OnL2ForkConflict", L2: eth.L2BlockRef{Hash: conflict.Hash, Number: conflict.Number}})
+	if rc.Inner != nil {
+		rc.Inner.OnL2ForkConflict(conflict)
+	}
+}
+
+func (rc *RecordingController) PostProcessSafeL2(l2Safe eth.L2BlockRef, derivedFrom eth.L1BlockRef) {
+	rc.record(ControllerCall{Method: "PostProcessSafeL2", L1: derivedFrom, L2: l2Safe})
+	if rc.Inner != nil {
+		rc.Inner.PostProcessSafeL2(l2Safe, derivedFrom)
+	}
+}
+
+func (rc *RecordingController) Reset() {
+	rc.record(ControllerCall{Method: "Reset"})
+	if rc.Inner != nil {
+		rc.Inner.Reset()
+	}
+}
@@ -0,0 +1,64 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestRecordingController(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 2)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	for _, ref := range chain.l1 {
+		l1F.Mock.On("L1BlockRefByNumber", ref.Number).Return(ref, nil)
+	}
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][0]
+
+	rc := NewRecordingController(NewFinalizer(logger, &rollup.Config{}, l1F, ec))
+	var fc FinalityController = rc
+	fc.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	require.NoError(t, fc.OnDerivationL1End(context.Background(), chain.l1[1]))
+	fc.Finalize(context.Background(), chain.l1[1])
+	fc.Reset()
+
+	require.Equal(t, chain.l2[1][1], ec.Finalized(), "calls are forwarded")
+	require.Equal(t, chain.l2[1][1], fc.Status().FinalizedL2)
+	require.Equal(t, []ControllerCall{
+		{Method: "PostProcessSafeL2", L1: chain.l1[1], L2: chain.l2[1][1]},
+		{Method: "OnDerivationL1End", L1: chain.l1[1]},
+		{Method: "Finalize", L1: chain.l1[1]},
+		{Method: "Reset"},
+	}, rc.Calls())
+}
+
+func TestNoopController(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 2)
+	rc := NewRecordingController(NoopController{})
+	rc.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	require.NoError(t, rc.OnDerivationL1End(context.Background(), chain.l1[1]))
+	rc.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, FinalityStatus{}, rc.Status())
+	require.Len(t, rc.Calls(), 3)
+}
+
+func TestParseMode(t *testing.T) {
+	for _, m := range []Mode{ModeAuto, ModeL1, ModePlasma, ModeFollow} {
+		parsed, err := ParseMode(string(m))
+		require.NoError(t, err)
+		require.Equal(t, m, parsed)
+	}
+	_, err := ParseMode("interop")
+	require.ErrorContains(t, err, "unknown finality mode")
+}
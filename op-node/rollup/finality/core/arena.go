@@ -0,0 +1,54 @@
+package core
+
+// Arena is a pre-allocated buffer that backs tracked relations, for large lookbacks.
+// Relations.Track prunes the oldest relation by moving all retained relations, on every new source block.
+// Tracking into an arena prunes by advancing the start of the relations in the buffer instead,
+// and only compacts the retained relations to the front of the buffer once the end of the buffer is reached.
+// The buffer is sized to the lookback, and gains a slack of an eighth of the lookback once the lookback is full
+// and pruning starts: this amortizes to moving eight relations per new source block,
+// and the buffer is reused, without allocations, for as long as the lookback does not grow.
+type Arena[S, D any, R Refs[S, D]] struct {
+	buf []Relation[S, D]
+	// compactions counts how often the retained relations were compacted to the front of the buffer.
+	compactions uint64
+}
+
+// NewArena allocates an arena for the given lookback.
+func NewArena[S, D any, R Refs[S, D]](lookback uint64) *Arena[S, D, R] {
+	return &Arena[S, D, R]{buf: make([]Relation[S, D], lookback)}
+}
+
+// arenaSlack returns the number of relations the arena buffer holds beyond the lookback, to prune into.
+func arenaSlack(lookback uint64) uint64 {
+	return max(lookback/8, 1)
+}
+
+// Relations returns empty relations, backed by the arena.
+func (a *Arena[S, D, R]) Relations() Relations[S, D, R] {
+	return a.buf[:0]
+}
+
+// Compactions returns how often the retained relations were compacted to the front of the buffer.
+func (a *Arena[S, D, R]) Compactions() uint64 {
+	return a.compactions
+}
+
+// Track is like Relations.Track, but prunes the oldest relation by advancing the start of rs in the arena.
+// The relations rs must be backed by the arena, or be empty.
+func (a *Arena[S, D, R]) Track(rs *Relations[S, D, R], lookback uint64, derived D, source S) TrackResult {
+	var refs R
+	rels := *rs
+	if n := uint64(len(rels)); n > 0 && n >= lookback && refs.SourceNumber(rels[n-1].Source) < refs.SourceNumber(source) {
+		rels = rels[n-lookback+1:]
+	}
+	if len(rels) == cap(rels) {
+		// the end of the buffer is reached, compact the retained relations to make room
+		if size := lookback + arenaSlack(lookback); uint64(len(a.buf)) < size {
+			a.buf = make([]Relation[S, D], size)
+		}
+		rels = a.buf[:copy(a.buf, rels)]
+		a.compactions += 1
+	}
+	*rs = rels
+	return rs.Track(lookback, derived, source)
+}
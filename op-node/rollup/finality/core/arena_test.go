@@ -0,0 +1,115 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testArena = Arena[testBlock, testBlock, testRefs]
+
+func TestArenaTrack(t *testing.T) {
+	a := NewArena[testBlock, testBlock, testRefs](8)
+	require.Len(t, a.buf, 8, "sized to the lookback")
+	rs := a.Relations()
+	require.Equal(t, Appended, a.Track(&rs, 8, testBlock{Num: 10}, testBlock{Num: 1}))
+	require.Equal(t, Updated, a.Track(&rs, 8, testBlock{Num: 11}, testBlock{Num: 1}))
+	require.Equal(t, Appended, a.Track(&rs, 8, testBlock{Num: 15}, testBlock{Num: 3}))
+	require.Equal(t, Inserted, a.Track(&rs, 8, testBlock{Num: 13}, testBlock{Num: 2}))
+	require.Equal(t, testRelations{rel(11, 1), rel(13, 2), rel(15, 3)}, rs)
+	for i := uint64(4); i < 9; i++ {
+		require.Equal(t, Appended, a.Track(&rs, 8, testBlock{Num: 10 + 5*i}, testBlock{Num: i}))
+	}
+	require.Len(t, rs, 8)
+	require.Len(t, a.buf, 8)
+	require.Zero(t, a.Compactions())
+
+	// pruning the full lookback adds the slack to the buffer, once
+	require.Equal(t, Appended, a.Track(&rs, 8, testBlock{Num: 55}, testBlock{Num: 9}))
+	require.Equal(t, rel(13, 2), rs[0])
+	require.Len(t, a.buf, 9)
+	require.Equal(t, uint64(1), a.Compactions())
+
+	// appending prunes the oldest relation by advancing the start, until the end of the buffer is reached
+	require.Equal(t, Appended, a.Track(&rs, 8, testBlock{Num: 60}, testBlock{Num: 10}))
+	require.Equal(t, rel(15, 3), rs[0])
+	require.Equal(t, uint64(1), a.Compactions())
+	require.Equal(t, Appended, a.Track(&rs, 8, testBlock{Num: 65}, testBlock{Num: 11}))
+	require.Equal(t, rel(30, 4), rs[0])
+	require.Len(t, a.buf, 9)
+	require.Equal(t, uint64(2), a.Compactions())
+	require.Equal(t, &a.buf[0], &rs[0], "compacted to the front of the buffer")
+
+	// older than anything retained in the full buffer
+	require.Equal(t, Ignored, a.Track(&rs, 8, testBlock{Num: 9}, testBlock{Num: 0}))
+	require.Len(t, rs, 8)
+
+	rs.Reset()
+	require.Empty(t, rs)
+}
+
+func TestArenaGrow(t *testing.T) {
+	a := NewArena[testBlock, testBlock, testRefs](1)
+	rs := a.Relations()
+	for i := uint64(0); i < 10; i++ {
+		a.Track(&rs, 4, testBlock{Num: i}, testBlock{Num: i})
+	}
+	require.Equal(t, testRelations{rel(6, 6), rel(7, 7), rel(8, 8), rel(9, 9)}, rs)
+	require.Len(t, a.buf, 5)
+
+	// a shrinking lookback prunes all relations beyond it at once
+	a.Track(&rs, 2, testBlock{Num: 10}, testBlock{Num: 10})
+	require.Equal(t, testRelations{rel(9, 9), rel(10, 10)}, rs)
+}
+
+// benchmarkTrack tracks a new source block per iteration, into a full lookback.
+// If prune is true, the oldest relation is pruned ahead of tracking, like the finality data pruning policies do.
+func benchmarkTrack(b *testing.B, lookback uint64, prune bool, track func(rs *testRelations, derived, source testBlock) TrackResult, rs testRelations) {
+	for i := uint64(0); i < lookback; i++ {
+		track(&rs, testBlock{Num: i}, testBlock{Num: i})
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := lookback; i < lookback+uint64(b.N); i++ {
+		if prune {
+			rs = rs[1:]
+		}
+		track(&rs, testBlock{Num: i}, testBlock{Num: i})
+	}
+}
+
+// BenchmarkRelationsTrack and BenchmarkArenaTrack compare tracking under a large alt-DA lookback,
+// during prolonged non-finality, when every new source block prunes the oldest relation.
+// Profile the allocations and pruning work with: go test -bench Track -memprofile mem.out -cpuprofile cpu.out
+func BenchmarkRelationsTrack(b *testing.B) {
+	const lookback = 50_000
+	benchmarkTrack(b, lookback, false, func(rs *testRelations, derived, source testBlock) TrackResult {
+		return rs.Track(lookback, derived, source)
+	}, make(testRelations, 0, lookback))
+}
+
+func BenchmarkArenaTrack(b *testing.B) {
+	const lookback = 50_000
+	a := NewArena[testBlock, testBlock, testRefs](lookback)
+	benchmarkTrack(b, lookback, false, func(rs *testRelations, derived, source testBlock) TrackResult {
+		return a.Track(rs, lookback, derived, source)
+	}, a.Relations())
+}
+
+// BenchmarkRelationsTrackPruned and BenchmarkArenaTrackPruned compare the allocations of tracking
+// after the oldest relation was pruned from the front: appending to the relations reallocates them
+// once their capacity runs out, while the arena compacts them into its buffer.
+func BenchmarkRelationsTrackPruned(b *testing.B) {
+	const lookback = 50_000
+	benchmarkTrack(b, lookback, true, func(rs *testRelations, derived, source testBlock) TrackResult {
+		return rs.Track(lookback, derived, source)
+	}, make(testRelations, 0, lookback))
+}
+
+func BenchmarkArenaTrackPruned(b *testing.B) {
+	const lookback = 50_000
+	a := NewArena[testBlock, testBlock, testRefs](lookback)
+	benchmarkTrack(b, lookback, true, func(rs *testRelations, derived, source testBlock) TrackResult {
+		return a.Track(rs, lookback, derived, source)
+	}, a.Relations())
+}
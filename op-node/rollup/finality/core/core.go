@@ -0,0 +1,121 @@
+// Package core implements the chain-agnostic finality algorithm of the finalizer:
+// it tracks which derived-chain blocks were fully derived from which source-chain blocks,
+// and determines how far the finalized head of the derived chain may monotonically advance,
+// once the source chain finalizes.
+//
+// The package is parameterized over the block refs of the source and derived chains,
+// so it can be reused by rollup frameworks with their own types.
+package core
+
+import (
+	"sort"
+)
+
+// Refs describes the block refs of the source and derived chains to the finality algorithm.
+// Implementations are stateless: the zero value is used.
+type Refs[S, D any] interface {
+	SourceNumber(source S) uint64
+	DerivedNumber(derived D) uint64
+	// SameSource returns true if the source refs identify the same block.
+	SameSource(a, b S) bool
+	// SameDerived returns true if the derived refs identify the same block.
+	SameDerived(a, b D) bool
+}
+
+// Relation records that the derived chain, up to and including Derived, was fully derived
+// from the source chain, up to and including Source.
+// When Source is finalized, the derived chain up to Derived can be fully reproduced from finalized source data.
+type Relation[S, D any] struct {
+	Derived D
+	Source  S
+}
+
+// TrackResult describes how a relation was merged into the tracked relations.
+type TrackResult uint8
+
+const (
+	// Unchanged is returned when the relation was already tracked.
+	Unchanged TrackResult = iota
+	// Appended is returned when the relation is of a new latest source block.
+	Appended
+	// Updated is returned when the relation replaced the relation of the same source block.
+	Updated
+	// Inserted is returned when the relation of an older, untracked, source block was inserted.
+	Inserted
+	// Ignored is returned when the relation is older than any retained relation.
+	Ignored
+)
+
+// Relations tracks the most recent relations, at most one per source block, sorted by source block number.
+type Relations[S, D any, R Refs[S, D]] []Relation[S, D]
+
+// Track records that derived was fully derived from source, and retains at most lookback relations.
+// Relations of source blocks older than the latest tracked relation, e.g. when older source blocks are replayed,
+// are merged into the history, so the relations are complete after the replay.
+func (rs *Relations[S, D, R]) Track(lookback uint64, derived D, source S) TrackResult {
+	var refs R
+	rels := *rs
+	if len(rels) == 0 || refs.SourceNumber(rels[len(rels)-1].Source) < refs.SourceNumber(source) {
+		// prune if necessary, before appending any relation.
+		if uint64(len(rels)) >= lookback {
+			rels = append(rels[:0], rels[1:lookback]...)
+		}
+		*rs = append(rels, Relation[S, D]{Derived: derived, Source: source})
+		return Appended
+	}
+	if last := &rels[len(rels)-1]; refs.SourceNumber(last.Source) == refs.SourceNumber(source) {
+		// a new derived block that was derived from the same latest source block
+		if refs.SameDerived(last.Derived, derived) {
+			return Unchanged
+		}
+		last.Derived = derived
+		return Updated
+	}
+	i := sort.Search(len(rels), func(i int) bool {
+		return refs.SourceNumber(rels[i].Source) >= refs.SourceNumber(source)
+	})
+	if r := &rels[i]; refs.SourceNumber(r.Source) == refs.SourceNumber(source) {
+		if !refs.SameSource(r.Source, source) || refs.DerivedNumber(r.Derived) < refs.DerivedNumber(derived) {
+			*r = Relation[S, D]{Derived: derived, Source: source}
+			return Updated
+		}
+		return Unchanged
+	}
+	if uint64(len(rels)) >= lookback {
+		if i == 0 {
+			return Ignored // older than anything we retain, it would be pruned right away
+		}
+		// prune the oldest relation to make room
+		rels = append(rels[:0], rels[1:]...)
+		i -= 1
+	}
+	rels = append(rels, Relation[S, D]{})
+	copy(rels[i+1:], rels[i:])
+	rels[i] = Relation[S, D]{Derived: derived, Source: source}
+	*rs = rels
+	return Inserted
+}
+
+// Reset clears all relations, e.g. when the derived chain reorgs.
+func (rs *Relations[S, D, R]) Reset() {
+	*rs = (*rs)[:0]
+}
+
+// Finalizable returns the latest relation to finalize: the derived block is above the finalized derived block,
+// and the source block is final. The finalized head only advances monotonically.
+// The relations are considered in order, and the search stops at the first qualifying relation rejected by accept,
+// e.g. to limit the advancement. It returns false if no relation qualifies.
+func (rs Relations[S, D, R]) Finalizable(finalized D, final func(source S) bool,
+	accept func(r Relation[S, D], found bool) bool) (out Relation[S, D], found bool) {
+	var refs R
+	for _, r := range rs {
+		if refs.DerivedNumber(r.Derived) > refs.DerivedNumber(finalized) && final(r.Source) {
+			if !accept(r, found) {
+				break
+			}
+			out, found = r, true
+			// keep iterating, there may be later derived blocks that can also be finalized
+		}
+	}
+	return out, found
+}
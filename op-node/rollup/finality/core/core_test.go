@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testBlock struct {
+	Num  uint64
+	Hash byte
+}
+
+type testRefs struct{}
+
+func (testRefs) SourceNumber(source testBlock) uint64   { return source.Num }
+func (testRefs) DerivedNumber(derived testBlock) uint64 { return derived.Num }
+func (testRefs) SameSource(a, b testBlock) bool         { return a == b }
+func (testRefs) SameDerived(a, b testBlock) bool        { return a == b }
+
+type testRelations = Relations[testBlock, testBlock, testRefs]
+
+func rel(derived, source uint64) Relation[testBlock, testBlock] {
+	return Relation[testBlock, testBlock]{Derived: testBlock{Num: derived}, Source: testBlock{Num: source}}
+}
+
+func TestTrack(t *testing.T) {
+	var rs testRelations
+	require.Equal(t, Appended, rs.Track(3, testBlock{Num: 10}, testBlock{Num: 1}))
+	require.Equal(t, Unchanged, rs.Track(3, testBlock{Num: 10}, testBlock{Num: 1}))
+	require.Equal(t, Updated, rs.Track(3, testBlock{Num: 11}, testBlock{Num: 1}))
+	require.Equal(t, Appended, rs.Track(3, testBlock{Num: 15}, testBlock{Num: 3}))
+	require.Equal(t, testRelations{rel(11, 1), rel(15, 3)}, rs)
+
+	// older source blocks are merged back in order
+	require.Equal(t, Inserted, rs.Track(3, testBlock{Num: 13}, testBlock{Num: 2}))
+	require.Equal(t, testRelations{rel(11, 1), rel(13, 2), rel(15, 3)}, rs)
+	require.Equal(t, Unchanged, rs.Track(3, testBlock{Num: 12}, testBlock{Num: 2}), "older derived block of a tracked source")
+	require.Equal(t, Updated, rs.Track(3, testBlock{Num: 14}, testBlock{Num: 2}))
+
+	// a reorged source block replaces the tracked one
+	require.Equal(t, Updated, rs.Track(3, testBlock{Num: 13}, testBlock{Num: 2, Hash: 1}))
+	require.Equal(t, Relation[testBlock, testBlock]{Derived: testBlock{Num: 13}, Source: testBlock{Num: 2, Hash: 1}}, rs[1])
+
+	// older than anything retained in the full buffer
+	require.Equal(t, Ignored, rs.Track(3, testBlock{Num: 9}, testBlock{Num: 0}))
+	require.Len(t, rs, 3)
+
+	// appending prunes the oldest relation
+	require.Equal(t, Appended, rs.Track(3, testBlock{Num: 20}, testBlock{Num: 5}))
+	require.Len(t, rs, 3)
+	require.Equal(t, uint64(2), rs[0].Source.Num)
+
+	// inserting into the full buffer prunes the oldest relation
+	require.Equal(t, Inserted, rs.Track(3, testBlock{Num: 18}, testBlock{Num: 4}))
+	require.Equal(t, testRelations{rel(15, 3), rel(18, 4), rel(20, 5)}, rs)
+
+	rs.Reset()
+	require.Empty(t, rs)
+}
+
+func TestFinalizable(t *testing.T) {
+	rs := testRelations{rel(11, 1), rel(13, 2), rel(15, 3), rel(20, 5)}
+	upTo := func(n uint64) func(testBlock) bool {
+		return func(source testBlock) bool { return source.Num <= n }
+	}
+	acceptAll := func(Relation[testBlock, testBlock], bool) bool { return true }
+
+	r, ok := rs.Finalizable(testBlock{Num: 0}, upTo(3), acceptAll)
+	require.True(t, ok)
+	require.Equal(t, rel(15, 3), r)
+
+	_, ok = rs.Finalizable(testBlock{Num: 15}, upTo(3), acceptAll)
+	require.False(t, ok, "finalized head does not move back")
+
+	_, ok = rs.Finalizable(testBlock{Num: 0}, upTo(0), acceptAll)
+	require.False(t, ok)
+
+	// the search stops at the first rejected relation
+	r, ok = rs.Finalizable(testBlock{Num: 0}, upTo(5), func(r Relation[testBlock, testBlock], found bool) bool {
+		return r.Derived.Num <= 13
+	})
+	require.True(t, ok)
+	require.Equal(t, rel(13, 2), r)
+}
@@ -0,0 +1,107 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// CrossValidationPolicy determines how a failed cross-validation of the finalized L2 head against a replica is handled.
+type CrossValidationPolicy string
+
+const (
+	// CrossValidationBlock does not finalize until the replica confirms the finalized L2 head; the attempt is retried.
+	CrossValidationBlock CrossValidationPolicy = "block"
+	// CrossValidationWarn logs the failed cross-validation, and finalizes anyway.
+	CrossValidationWarn CrossValidationPolicy = "warn"
+	// CrossValidationHalt halts finalization when the replica has a different block at the height of the finalized L2 head,
+	// until an operator resumes it. Replicas that are unavailable are retried, like CrossValidationBlock.
+	CrossValidationHalt CrossValidationPolicy = "halt"
+)
+
+// crossValidationTimeout bounds the lookup of the finalized L2 head at the replica.
+const crossValidationTimeout = 10 * time.Second
+
+// ErrReplicaMismatch is returned when the replica has a different L2 block at the height of the L2 block to finalize.
+// Depending on the CrossValidationPolicy, it is wrapped as a temporary error.
+type ErrReplicaMismatch struct {
+	// Finalized is the L2 block to finalize.
+	Finalized eth.L2BlockRef
+	// Replica is the L2 block of the replica at the same height.
+	Replica eth.L2BlockRef
+}
+
+func (e *ErrReplicaMismatch) Error() string {
+	return fmt.Sprintf("replica has L2 block %s at the height of the L2 block %s to finalize", e.Replica, e.Finalized)
+}
+
+// WithCrossValidation verifies that the L2 block about to be finalized exists with the same hash at a replica L2 RPC,
+// before setting it as the finalized L2 head, as defense-in-depth against sequencer-side bugs.
+// The policy determines how a failed verification is handled, see CrossValidationPolicy.
+func WithCrossValidation(replica L2BlockSource, policy CrossValidationPolicy) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.crossValidationGate.replica = replica
+		fi.crossValidationGate.policy = policy
+	}
+}
+
+// ParseCrossValidationPolicy parses the CrossValidationPolicy of a flag.
+func ParseCrossValidationPolicy(s string) (CrossValidationPolicy, error) {
+	switch p := CrossValidationPolicy(s); p {
+	case CrossValidationBlock, CrossValidationWarn, CrossValidationHalt:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown finality cross-validation policy %q", s)
+	}
+}
+
+// crossValidationGate verifies the L2 block to finalize at a replica L2 RPC, see WithCrossValidation.
+type crossValidationGate struct {
+	noopGate
+	fi *Finalizer
+	// replica is the L2 RPC to cross-validate the L2 blocks to finalize with, as handled by policy. Disabled if nil.
+	replica L2BlockSource
+	policy  CrossValidationPolicy
+}
+
+func (g *crossValidationGate) check(ctx context.Context, a *finalizeAttempt) (FinalizeReason, error) {
+	return "", g.crossValidate(ctx, a.finalizedL2)
+}
+
+// crossValidate verifies the L2 block to finalize at the replica, if configured,
+// and returns an error if it is not to be finalized.
+func (g *crossValidationGate) crossValidate(ctx context.Context, finalizedL2 eth.L2BlockRef) error {
+	if g.replica == nil {
+		return nil
+	}
+	fi := g.fi
+	ctx, cancel := context.WithTimeout(ctx, crossValidationTimeout)
+	defer cancel()
+	ref, err := g.replica.L2BlockRefByNumber(ctx, finalizedL2.Number)
+	if err != nil {
+		err = fmt.Errorf("failed to fetch L2 block %d from replica to cross-validate: %w", finalizedL2.Number, err)
+	} else if ref.Hash != finalizedL2.Hash {
+		err = &ErrReplicaMismatch{Finalized: finalizedL2, Replica: ref}
+	} else {
+		return nil
+	}
+	fi.counters.CrossValidationFailures += 1
+	switch g.policy {
+	case CrossValidationWarn:
+		fi.log.Warn("failed to cross-validate finalized L2 head with replica, finalizing anyway", "finalized_l2", finalizedL2, "err", err)
+		return nil
+	case CrossValidationHalt:
+		var mismatch *ErrReplicaMismatch
+		if errors.As(err, &mismatch) && !fi.halted {
+			fi.halted = true
+			fi.metrics.RecordFinalityHalted(true)
+			fi.log.Error("halting finalization, the replica disagrees on the L2 block to finalize! Is the sequencer faulty? "+
+				"Resume with admin_resumeFinality once resolved", "finalized_l2", finalizedL2, "err", err)
+		}
+	}
+	return derive.NewTemporaryError(err)
+}
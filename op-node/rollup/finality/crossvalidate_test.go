@@ -0,0 +1,86 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerCrossValidation(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	setup := func(t *testing.T, policy CrossValidationPolicy) (*Finalizer, *fakeEngine, *testutils.MockL2Client) {
+		logger := testlog.Logger(t, log.LevelCrit)
+		l1F := &testutils.MockL1Source{}
+		l1F.Mock.On("L1BlockRefByNumber", chain.l1[2].Number).Return(chain.l1[2], nil)
+		ec := &fakeEngine{}
+		ec.finalized = chain.l2[0][1]
+		replica := &testutils.MockL2Client{}
+		fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithCrossValidation(replica, policy))
+		for i := 1; i < 4; i++ {
+			fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		}
+		return fi, ec, replica
+	}
+
+	t.Run("confirmed", func(t *testing.T) {
+		fi, ec, replica := setup(t, CrossValidationBlock)
+		replica.ExpectL2BlockRefByNumber(chain.l2[2][1].Number, chain.l2[2][1], nil)
+		fi.Finalize(context.Background(), chain.l1[2])
+		require.Equal(t, chain.l2[2][1], ec.Finalized())
+		require.Zero(t, fi.counters.CrossValidationFailures)
+	})
+
+	t.Run("block", func(t *testing.T) {
+		fi, ec, replica := setup(t, CrossValidationBlock)
+		replica.ExpectL2BlockRefByNumber(chain.l2[2][1].Number, testutils.RandomL2BlockRef(rng), nil)
+		fi.Finalize(context.Background(), chain.l1[2])
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.Equal(t, ReasonError, fi.Status().LastReason)
+		require.Equal(t, uint64(1), fi.counters.CrossValidationFailures)
+
+		// retried once the replica confirms
+		replica.ExpectL2BlockRefByNumber(chain.l2[2][1].Number, chain.l2[2][1], nil)
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+		require.Equal(t, chain.l2[2][1], ec.Finalized())
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		fi, ec, replica := setup(t, CrossValidationWarn)
+		replica.ExpectL2BlockRefByNumber(chain.l2[2][1].Number, testutils.RandomL2BlockRef(rng), nil)
+		fi.Finalize(context.Background(), chain.l1[2])
+		require.Equal(t, chain.l2[2][1], ec.Finalized())
+		require.Equal(t, uint64(1), fi.counters.CrossValidationFailures)
+	})
+
+	t.Run("halt", func(t *testing.T) {
+		fi, ec, replica := setup(t, CrossValidationHalt)
+		// an unavailable replica is retried
+		replica.ExpectL2BlockRefByNumber(chain.l2[2][1].Number, testutils.RandomL2BlockRef(rng), errors.New("unavailable"))
+		fi.Finalize(context.Background(), chain.l1[2])
+		require.False(t, fi.Halted())
+
+		// a mismatch halts finalization
+		replica.ExpectL2BlockRefByNumber(chain.l2[2][1].Number, testutils.RandomL2BlockRef(rng), nil)
+		require.Error(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+		require.True(t, fi.Halted())
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+	})
+}
+
+func TestParseCrossValidationPolicy(t *testing.T) {
+	policy, err := ParseCrossValidationPolicy("halt")
+	require.NoError(t, err)
+	require.Equal(t, CrossValidationHalt, policy)
+	_, err = ParseCrossValidationPolicy("ignore")
+	require.Error(t, err)
+}
@@ -0,0 +1,21 @@
+package finality
+
+// DebugBundle captures the status, counters and state of the Finalizer at once.
+func (fi *Finalizer) DebugBundle() *DebugBundle {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	bundle := &DebugBundle{
+		Status:           fi.status(),
+		Counters:         fi.counters,
+		Snapshot:         fi.snapshot(),
+		FinalityLookback: fi.finalityLookback,
+		AuditTrail:       append([]FinalizedHeadUpdate(nil), fi.auditTrail...),
+		TunablesAudit:    append([]FinalityTunablesChange(nil), fi.tunablesAudit...),
+		Casualties:       append([]PruningCasualty(nil), fi.casualties...),
+	}
+	if fi.sloEnabled() {
+		slo := fi.sloStatus()
+		bundle.LatencySLO = &slo
+	}
+	return bundle
+}
@@ -0,0 +1,69 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerDebugBundle(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 5)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+	fi.finalityLookback = 3
+	for i := 1; i < 5; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	// the derived-from L1 block was reorged out, which requires a reset
+	l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, testutils.RandomBlockRef(rng), nil)
+	fi.Finalize(context.Background(), chain.l1[3])
+	// a signal older than the previous signal is rejected
+	fi.Finalize(context.Background(), chain.l1[2])
+
+	bundle := fi.DebugBundle()
+	require.Equal(t, FinalityCounters{
+		Attempts:                 1,
+		ResetsTriggered:          1,
+		EntriesPruned:            1,
+		EntriesPrunedUnfinalized: 1,
+		SignalsRejectedOld:       1,
+	}, bundle.Counters)
+	require.Equal(t, fi.Status(), bundle.Status)
+	require.Equal(t, fi.Snapshot(), bundle.Snapshot)
+	require.Equal(t, uint64(3), bundle.FinalityLookback)
+}
+
+func TestFinalizerDataLogs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger, logs := testlog.CaptureLogger(t, log.LevelDebug)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, ec)
+	for i := 0; i < 3; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][0], chain.l1[i])
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	// the safe head updates within the same L1 block are not logged individually, but summarized per L1 block
+	require.Equal(t, uint64(3), fi.DebugBundle().Counters.EntriesUpdated)
+	extended := logs.FindLogs(testlog.NewMessageFilter("extended finality-data"))
+	require.Len(t, extended, 3)
+	require.Equal(t, uint64(1), extended[2].AttrValue("prev_updates"))
+	require.Equal(t, chain.l2[1][1], extended[2].AttrValue("prev_l2"))
+}
@@ -0,0 +1,33 @@
+package finality
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrDerivedFromUnknown is returned when the L1 block an L2 block was derived from is not in the finality data.
+var ErrDerivedFromUnknown = errors.New("L1 block the L2 block was derived from is unknown")
+
+// DerivedFrom returns the L1 block the given L2 block was fully derived from, according to the finality data.
+// It returns ErrDerivedFromUnknown if the L2 block is not safe yet, or older than the buffered finality data.
+func (fi *Finalizer) DerivedFrom(l2Number uint64) (eth.BlockID, error) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.derivedFrom(l2Number)
+}
+
+// derivedFrom returns the L1 block the given L2 block was fully derived from. The lock must be held.
+func (fi *Finalizer) derivedFrom(l2Number uint64) (eth.BlockID, error) {
+	rels := fi.expandedFinalityData()
+	i := sort.Search(len(rels), func(i int) bool {
+		return rels[i].Derived.Number >= l2Number
+	})
+	// The L2 blocks up to the first buffered L2 block may have been derived from older, pruned, L1 blocks.
+	if i == len(rels) || (i == 0 && rels[0].Derived.Number != l2Number) {
+		return eth.BlockID{}, fmt.Errorf("%w: L2 block %d", ErrDerivedFromUnknown, l2Number)
+	}
+	return rels[i].Source.ID, nil
+}
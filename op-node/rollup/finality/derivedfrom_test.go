@@ -0,0 +1,42 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerDerivedFrom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	ec := &fakeEngine{}
+	fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, ec)
+	for i := range chain.l1 {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[i]))
+	}
+
+	id, err := fi.DerivedFrom(chain.l2[0][1].Number)
+	require.NoError(t, err)
+	require.Equal(t, chain.l1[0].ID(), id)
+	// an L2 block within the L2 blocks of an L1 block was derived from that L1 block
+	id, err = fi.DerivedFrom(chain.l2[2][0].Number)
+	require.NoError(t, err)
+	require.Equal(t, chain.l1[2].ID(), id)
+
+	// older than the buffered finality data
+	_, err = fi.DerivedFrom(chain.l2[0][0].Number)
+	require.ErrorIs(t, err, ErrDerivedFromUnknown)
+	// not safe yet
+	_, err = fi.DerivedFrom(chain.l2[2][1].Number + 1)
+	require.ErrorIs(t, err, ErrDerivedFromUnknown)
+}
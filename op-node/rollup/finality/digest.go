@@ -0,0 +1,46 @@
+package finality
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// digestState is the canonical finality state that is hashed by StateDigest.
+// The batch inclusion of the finality data is excluded: it is best-effort,
+// and may differ between replicas that derived the same L2 chain.
+type digestState struct {
+	FinalizedL1 eth.BlockID
+	Relations   []digestRelation
+}
+
+type digestRelation struct {
+	L1 eth.BlockID
+	L2 eth.BlockID
+}
+
+// StateDigest returns a canonical hash of the finalized L1 block and the buffered finality data,
+// so operators of multiple replicas can cheaply detect diverging finality state across their fleet.
+// Replicas with the same finality config have the same digest, when they derived up to the same L1 block.
+func (fi *Finalizer) StateDigest() common.Hash {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.stateDigest()
+}
+
+// stateDigest computes the digest of the finality state. The lock must be held.
+func (fi *Finalizer) stateDigest() common.Hash {
+	// compressed finality data is expanded, to not depend on the compression of the replica
+	rels := fi.expandedFinalityData()
+	state := digestState{FinalizedL1: fi.finalizedL1.ID(), Relations: make([]digestRelation, 0, len(rels))}
+	for _, r := range rels {
+		state.Relations = append(state.Relations, digestRelation{L1: r.Source.ID, L2: r.Derived.ID()})
+	}
+	data, err := rlp.EncodeToBytes(&state)
+	if err != nil {
+		panic(err) // the digest state only consists of fixed-size fields, and always encodes
+	}
+	return crypto.Keccak256Hash(data)
+}
@@ -0,0 +1,44 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerStateDigest(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 8)
+	logger := testlog.Logger(t, log.LevelInfo)
+	m := &fakeMetrics{}
+	// the replicas only differ in batch inclusion data, and the compression of the finality data
+	a := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{}, WithMetrics(m),
+		WithInclusionSource(fakeInclusions{chain.l1[1].ID(): derive.BatchInclusion{BlobIndices: []uint64{0}}}))
+	b := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{},
+		WithPruningPolicy(CapacityPruning{Capacity: 100}), WithCompression(2))
+	require.Equal(t, a.StateDigest(), b.StateDigest())
+
+	for i := range chain.l1 {
+		a.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		b.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+	require.NotZero(t, b.compressedEntries)
+	digest := a.StateDigest()
+	require.Equal(t, digest, b.StateDigest())
+	require.Equal(t, digest, a.Status().StateDigest)
+	require.NoError(t, a.OnDerivationL1End(context.Background(), chain.l1[7]))
+	require.Equal(t, digest, m.digest)
+
+	// a replica that derived a different L2 block diverges
+	b.PostProcessSafeL2(chain.l2[7][0], chain.l1[7])
+	require.NotEqual(t, digest, b.StateDigest())
+}
@@ -0,0 +1,155 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// DisputeGameReader reads the dispute game resolution status from the DisputeGameFactory on L1.
+type DisputeGameReader interface {
+	// ResolvedL2BlockNumber returns the highest L2 block number that is backed by a dispute game,
+	// which resolved in favor of the proposed output root, as of the given L1 block.
+	ResolvedL2BlockNumber(ctx context.Context, l1 eth.BlockID) (uint64, error)
+}
+
+// WithDisputeGameGate gates the finalization of L2 blocks on the resolution of dispute games,
+// so the finalized L2 head of the node matches what the bridge will accept for withdrawals.
+// The resolution status is read as of the finalized L1 block, so the gate itself cannot reorg.
+func WithDisputeGameGate(games DisputeGameReader) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.disputeGameGate.games = games
+	}
+}
+
+// disputeGameGate holds back the L2 blocks that are not backed by a resolved dispute game:
+// all L2 blocks if games is set, otherwise the L2 blocks under a finality rule that gates on dispute games.
+type disputeGameGate struct {
+	noopGate
+	fi *Finalizer
+	// games gates all L2 blocks on resolved dispute games. Disabled if nil.
+	games DisputeGameReader
+	// ruleGames provides the dispute games to the finality rules that gate on them, if games is not set.
+	ruleGames DisputeGameReader
+	// resolvedL2 is the highest L2 block number that may be finalized in this attempt, according to the dispute games.
+	resolvedL2 uint64
+}
+
+// prepare reads the resolved dispute games as of the finalized L1 block, so the gate itself cannot reorg.
+// The dispute games are only read if all L2 blocks are gated, or if any of the finality rules gates on them.
+func (g *disputeGameGate) prepare(ctx context.Context, a *finalizeAttempt) error {
+	fi := g.fi
+	g.resolvedL2 = math.MaxUint64
+	if len(fi.finalityData) == 0 || (g.games == nil && !fi.rulesGateOnDisputeGames()) {
+		return nil
+	}
+	games := g.games
+	if games == nil {
+		games = g.ruleGames
+	}
+	g.resolvedL2 = 0
+	if games == nil {
+		return nil // nothing can be backed by a dispute game, if the games are not available
+	}
+	resolved, err := games.ResolvedL2BlockNumber(ctx, fi.finalizedL1.ID())
+	if err != nil {
+		return fmt.Errorf("failed to fetch resolved dispute games at L1 block %s: %w", fi.finalizedL1, err)
+	}
+	g.resolvedL2 = resolved
+	return nil
+}
+
+func (g *disputeGameGate) accept(a *finalizeAttempt, r finalityRelation, found bool) (bool, FinalizeReason) {
+	rule, active := g.fi.ruleAt(r.Derived.Time)
+	if (g.games != nil || (active && rule.DisputeGameGated)) && r.Derived.Number > g.resolvedL2 {
+		return false, ReasonDisputeGameGated
+	}
+	return true, ""
+}
+
+// disputeGameDefenderWins is the status of a dispute game that resolved in favor of the proposed output root.
+const disputeGameDefenderWins = 2
+
+// disputeGameSearchDepth is the number of the latest dispute games of the game type that are searched
+// for the highest resolved L2 block number.
+const disputeGameSearchDepth = 64
+
+var disputeGameStatusABI = `[{"type":"function","name":"status","inputs":[],"outputs":[{"type":"uint8"}],"stateMutability":"view"}]`
+
+// L1ContractCaller calls the L1 contracts, as of a given L1 block hash.
+type L1ContractCaller interface {
+	bind.ContractCaller
+	bind.BlockHashContractCaller
+}
+
+// DisputeGameFactoryReader reads the dispute games of a game type from the DisputeGameFactory on L1.
+// The games are read as of the hash of the given L1 block, so the result does not change with L1 reorgs.
+type DisputeGameFactoryReader struct {
+	factory   *bindings.DisputeGameFactoryCaller
+	caller    L1ContractCaller
+	statusABI abi.ABI
+	gameType  uint32
+}
+
+var _ DisputeGameReader = (*DisputeGameFactoryReader)(nil)
+
+func NewDisputeGameFactoryReader(addr common.Address, gameType uint32, caller L1ContractCaller) (*DisputeGameFactoryReader, error) {
+	factory, err := bindings.NewDisputeGameFactoryCaller(addr, caller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind DisputeGameFactory: %w", err)
+	}
+	statusABI, err := abi.JSON(strings.NewReader(disputeGameStatusABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dispute game ABI: %w", err)
+	}
+	return &DisputeGameFactoryReader{factory: factory, caller: caller, statusABI: statusABI, gameType: gameType}, nil
+}
+
+// ResolvedL2BlockNumber returns the highest L2 block number of the latest dispute games of the game type,
+// which resolved in favor of the proposed output root, as of the given L1 block. This returns 0 if there are none.
+func (r *DisputeGameFactoryReader) ResolvedL2BlockNumber(ctx context.Context, l1 eth.BlockID) (uint64, error) {
+	if l1.Hash == (common.Hash{}) {
+		return 0, nil // no L1 block to read the games as of, nothing is resolved yet
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockHash: l1.Hash}
+	count, err := r.factory.GameCount(opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch game count: %w", err)
+	}
+	if count.Sign() == 0 {
+		return 0, nil
+	}
+	games, err := r.factory.FindLatestGames(opts, r.gameType, new(big.Int).Sub(count, common.Big1), big.NewInt(disputeGameSearchDepth))
+	if err != nil {
+		return 0, fmt.Errorf("failed to find latest games: %w", err)
+	}
+	var resolved uint64
+	for _, game := range games {
+		if len(game.ExtraData) < 32 {
+			return 0, fmt.Errorf("game %d has no L2 block number in its extra data", game.Index)
+		}
+		l2 := new(big.Int).SetBytes(game.ExtraData[:32])
+		if !l2.IsUint64() || l2.Uint64() <= resolved {
+			continue // cannot raise the resolved L2 block number, skip fetching the status
+		}
+		proxy := common.BytesToAddress(game.Metadata[12:32])
+		var status []any
+		if err := bind.NewBoundContract(proxy, r.statusABI, r.caller, nil, nil).Call(opts, &status, "status"); err != nil {
+			return 0, fmt.Errorf("failed to fetch status of game %d: %w", game.Index, err)
+		}
+		if status[0].(uint8) != disputeGameDefenderWins {
+			continue // not resolved (yet), or resolved against the proposed output root
+		}
+		resolved = l2.Uint64()
+	}
+	return resolved, nil
+}
@@ -0,0 +1,145 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand" // nosemgrep
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type fakeDisputeGames struct {
+	resolved uint64
+}
+
+func (f *fakeDisputeGames) ResolvedL2BlockNumber(ctx context.Context, l1 eth.BlockID) (uint64, error) {
+	return f.resolved, nil
+}
+
+func TestFinalizerDisputeGameGate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	games := &fakeDisputeGames{resolved: chain.l2[0][1].Number}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithDisputeGameGate(games))
+
+	for i := 1; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	// no dispute game backs the L2 blocks yet
+	fi.Finalize(context.Background(), chain.l1[3])
+	require.Equal(t, chain.l2[0][1], ec.Finalized())
+	require.Equal(t, ReasonDisputeGameGated, fi.Status().LastReason)
+
+	// a dispute game resolved, for an L2 block in between buffered blocks
+	games.resolved = chain.l2[2][1].Number + 1
+	l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+	require.Equal(t, chain.l2[2][1], ec.Finalized(), "finalized up to the last buffered block backed by a dispute game")
+}
+
+type fakeGame struct {
+	l2Number uint64
+	status   uint8
+}
+
+// fakeFactoryCaller serves the DisputeGameFactory and its games, per L1 block hash.
+type fakeFactoryCaller struct {
+	t       *testing.T
+	factory common.Address
+	games   map[common.Hash][]fakeGame
+}
+
+func (f *fakeFactoryCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, errors.New("unpinned call")
+}
+
+func (f *fakeFactoryCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, errors.New("unpinned call")
+}
+
+func (f *fakeFactoryCaller) CodeAtHash(ctx context.Context, contract common.Address, blockHash common.Hash) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (f *fakeFactoryCaller) CallContractAtHash(ctx context.Context, call ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	games := f.games[blockHash]
+	if *call.To != f.factory {
+		statusABI, err := abi.JSON(strings.NewReader(disputeGameStatusABI))
+		require.NoError(f.t, err)
+		i := int(new(big.Int).SetBytes(call.To[:]).Int64()) - 1
+		return statusABI.Methods["status"].Outputs.Pack(games[i].status)
+	}
+	factoryABI, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(f.t, err)
+	method, err := factoryABI.MethodById(call.Data[:4])
+	require.NoError(f.t, err)
+	switch method.Name {
+	case "gameCount":
+		return method.Outputs.Pack(big.NewInt(int64(len(games))))
+	case "findLatestGames":
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		require.NoError(f.t, err)
+		var out []bindings.IDisputeGameFactoryGameSearchResult
+		for i := int(args[1].(*big.Int).Int64()); i >= 0 && len(out) < int(args[2].(*big.Int).Int64()); i-- {
+			var id [32]byte
+			// the game proxy address is packed into the game id, the index identifies the game
+			new(big.Int).SetUint64(uint64(i + 1)).FillBytes(id[12:32])
+			out = append(out, bindings.IDisputeGameFactoryGameSearchResult{Index: big.NewInt(int64(i)), Metadata: id,
+				ExtraData: common.BigToHash(new(big.Int).SetUint64(games[i].l2Number)).Bytes()})
+		}
+		return method.Outputs.Pack(out)
+	}
+	f.t.Fatalf("unexpected call of %s", method.Name)
+	return nil, nil
+}
+
+func TestDisputeGameFactoryReader(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	factory := testutils.RandomAddress(rng)
+	l1 := testutils.RandomBlockRef(rng).ID()
+	caller := &fakeFactoryCaller{t: t, factory: factory, games: map[common.Hash][]fakeGame{
+		l1.Hash: {
+			{l2Number: 100, status: disputeGameDefenderWins},
+			{l2Number: 300, status: disputeGameDefenderWins},
+			{l2Number: 400, status: 1}, // challenger wins
+			{l2Number: 500, status: 0}, // in progress
+		},
+	}}
+	games, err := NewDisputeGameFactoryReader(factory, 0, caller)
+	require.NoError(t, err)
+
+	resolved, err := games.ResolvedL2BlockNumber(context.Background(), l1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(300), resolved)
+
+	// the games are read as of the L1 block hash, there are no games as of another L1 block
+	resolved, err = games.ResolvedL2BlockNumber(context.Background(), testutils.RandomBlockRef(rng).ID())
+	require.NoError(t, err)
+	require.Zero(t, resolved)
+
+	// nothing is resolved before a finality signal
+	resolved, err = games.ResolvedL2BlockNumber(context.Background(), eth.BlockID{})
+	require.NoError(t, err)
+	require.Zero(t, resolved)
+}
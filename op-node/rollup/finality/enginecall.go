@@ -0,0 +1,96 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// defaultEngineCallTimeout bounds the engine calls that apply the finalized L2 head.
+const defaultEngineCallTimeout = 10 * time.Second
+
+// PriorityFinalizerEngine is implemented by engines that can apply the finalized head through a priority lane,
+// e.g. a dedicated RPC connection, so finalization updates are not queued behind the calls that build payloads.
+type PriorityFinalizerEngine interface {
+	// SetFinalizedHeadPriority is SetFinalizedHead, served through the priority lane.
+	SetFinalizedHeadPriority(ctx context.Context, ref eth.L2BlockRef) error
+}
+
+// EngineController is the engine controller of the rollup node, which holds the forkchoice state
+// until it is applied to the engine with TryUpdateEngine.
+type EngineController interface {
+	Finalized() eth.L2BlockRef
+	SetFinalizedHead(eth.L2BlockRef)
+	TryUpdateEngine(ctx context.Context) error
+	LastForkchoiceAck() engine.ForkchoiceAck
+}
+
+// controllerEngine is the FinalizerEngine of an engine controller:
+// it applies every finalized head to the engine as it is set.
+type controllerEngine struct {
+	ec EngineController
+}
+
+// NewControllerEngine returns the FinalizerEngine of the engine controller.
+func NewControllerEngine(ec EngineController) FinalizerEngine {
+	return &controllerEngine{ec: ec}
+}
+
+func (e *controllerEngine) Finalized() eth.L2BlockRef {
+	return e.ec.Finalized()
+}
+
+func (e *controllerEngine) SetFinalizedHead(ctx context.Context, ref eth.L2BlockRef) error {
+	e.ec.SetFinalizedHead(ref)
+	if err := e.ec.TryUpdateEngine(ctx); err != nil && !errors.Is(err, engine.ErrNoFCUNeeded) {
+		return err
+	}
+	return nil
+}
+
+func (e *controllerEngine) LastForkchoiceAck() engine.ForkchoiceAck {
+	return e.ec.LastForkchoiceAck()
+}
+
+var _ AckFinalizerEngine = (*controllerEngine)(nil)
+
+// WithEngineCallTimeout bounds the engine calls that apply the finalized L2 head,
+// independently of the deadline of the finalization step. Defaults to 10 seconds if 0.
+func WithEngineCallTimeout(timeout time.Duration) FinalizerOption {
+	return func(fi *Finalizer) {
+		if timeout > 0 {
+			fi.engineCallTimeout = timeout
+		}
+	}
+}
+
+// updateEngine sets the finalized head of the engine, which applies it with a forkchoice update,
+// through the priority lane if the engine supports it.
+// The engine call gets a dedicated context: it does not inherit the deadline of the caller,
+// which may be mostly used up by L1 fetches, but is bounded by the engine call timeout.
+// It is still aborted if the caller is canceled, e.g. on shutdown. The lock must be held.
+func (fi *Finalizer) updateEngine(ctx context.Context, finalizedL2 eth.L2BlockRef) error {
+	callCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fi.engineCallTimeout)
+	defer cancel()
+	abortOnCancel := func() {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			cancel()
+		}
+	}
+	abortOnCancel()
+	stop := context.AfterFunc(ctx, abortOnCancel)
+	defer stop()
+
+	start := fi.clock.Now()
+	var err error
+	if pe, ok := fi.ec.(PriorityFinalizerEngine); ok {
+		err = pe.SetFinalizedHeadPriority(callCtx, finalizedL2)
+	} else {
+		err = fi.ec.SetFinalizedHead(callCtx, finalizedL2)
+	}
+	fi.metrics.RecordFinalityEngineCall(fi.clock.Since(start), err == nil)
+	return err
+}
@@ -0,0 +1,151 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// priorityEngine is a fakeEngine with a priority lane, which records the contexts of the engine calls.
+type priorityEngine struct {
+	fakeEngine
+	priorityCalls int
+	ctxErrs       []error
+}
+
+func (e *priorityEngine) SetFinalizedHeadPriority(ctx context.Context, ref eth.L2BlockRef) error {
+	e.priorityCalls += 1
+	e.ctxErrs = append(e.ctxErrs, ctx.Err())
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return e.SetFinalizedHead(ctx, ref)
+}
+
+var _ PriorityFinalizerEngine = (*priorityEngine)(nil)
+
+func TestFinalizerEngineCall(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	for _, ref := range chain.l1 {
+		l1F.Mock.On("L1BlockRefByNumber", ref.Number).Return(ref, nil)
+	}
+	ec := &priorityEngine{}
+	ec.finalized = chain.l2[0][0]
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithMetrics(m), WithEngineCallTimeout(time.Minute))
+
+	t.Run("deadline of the caller", func(t *testing.T) {
+		// the engine call does not inherit the deadline of the finalization step
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		fi.mu.Lock()
+		err := fi.updateEngine(ctx, chain.l2[1][1])
+		fi.mu.Unlock()
+		require.NoError(t, err)
+		require.Equal(t, chain.l2[1][1], ec.applied)
+		require.Equal(t, 1, ec.priorityCalls, "served through the priority lane")
+		require.Equal(t, []bool{true}, m.engineCalls)
+	})
+
+	t.Run("canceled caller", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		fi.mu.Lock()
+		err := fi.updateEngine(ctx, chain.l2[1][1])
+		fi.mu.Unlock()
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, []bool{true, false}, m.engineCalls)
+	})
+
+	t.Run("engine failure", func(t *testing.T) {
+		ec.fcuErr = errors.New("engine down")
+		fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+		fi.Finalize(context.Background(), chain.l1[2])
+		require.Equal(t, chain.l2[1][1], ec.applied)
+		require.Equal(t, []bool{true, false, false}, m.engineCalls)
+		require.Equal(t, chain.l2[2][1], fi.pendingFinalized)
+	})
+
+	t.Run("engine failure is temporary", func(t *testing.T) {
+		fi.mu.Lock()
+		err := fi.applyFinalized(context.Background(), chain.l2[2][1])
+		fi.mu.Unlock()
+		require.ErrorIs(t, err, derive.ErrTemporary)
+		require.ErrorIs(t, err, ec.fcuErr)
+	})
+}
+
+// fakeController is an engine controller, which holds the forkchoice state until it is applied.
+type fakeController struct {
+	finalized  eth.L2BlockRef
+	needUpdate bool
+	updateErr  error
+	applied    eth.L2BlockRef
+}
+
+func (c *fakeController) Finalized() eth.L2BlockRef {
+	return c.finalized
+}
+
+func (c *fakeController) SetFinalizedHead(ref eth.L2BlockRef) {
+	c.finalized = ref
+	c.needUpdate = true
+}
+
+func (c *fakeController) TryUpdateEngine(ctx context.Context) error {
+	if !c.needUpdate {
+		return engine.ErrNoFCUNeeded
+	}
+	if c.updateErr != nil {
+		return c.updateErr
+	}
+	c.needUpdate = false
+	c.applied = c.finalized
+	return nil
+}
+
+func (c *fakeController) LastForkchoiceAck() engine.ForkchoiceAck {
+	return engine.ForkchoiceAck{Finalized: c.applied.ID(), Status: eth.ExecutionValid}
+}
+
+func TestControllerEngine(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 2)
+	ctrl := &fakeController{}
+	ec := NewControllerEngine(ctrl)
+	ctx := context.Background()
+
+	// setting the finalized head applies it to the engine
+	require.NoError(t, ec.SetFinalizedHead(ctx, chain.l2[0][1]))
+	require.Equal(t, chain.l2[0][1], ec.Finalized())
+	require.Equal(t, chain.l2[0][1], ctrl.applied)
+	require.Equal(t, chain.l2[0][1].ID(), ec.(AckFinalizerEngine).LastForkchoiceAck().Finalized)
+
+	// a failed forkchoice update is returned
+	ctrl.updateErr = errors.New("engine down")
+	require.ErrorIs(t, ec.SetFinalizedHead(ctx, chain.l2[1][1]), ctrl.updateErr)
+	require.Equal(t, chain.l2[0][1], ctrl.applied)
+
+	// an engine that is already up to date is not an error
+	ctrl.updateErr = engine.ErrNoFCUNeeded
+	require.NoError(t, ec.SetFinalizedHead(ctx, chain.l2[1][1]))
+	ctrl.updateErr = nil
+	require.NoError(t, ec.SetFinalizedHead(ctx, chain.l2[1][1]))
+	require.Equal(t, chain.l2[1][1], ctrl.applied)
+}
@@ -0,0 +1,86 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// engineSyncing returns true if the engine does not report a finalized L2 head,
+// e.g. because it is still syncing, and does not have the L2 blocks to finalize yet.
+func (fi *Finalizer) engineSyncing(finalizedL2 eth.L2BlockRef) bool {
+	return finalizedL2 == (eth.L2BlockRef{})
+}
+
+// engineSyncGate buffers the L2 block to finalize while the engine is syncing, to apply it once the engine is ready.
+type engineSyncGate struct {
+	noopGate
+	fi *Finalizer
+}
+
+func (g *engineSyncGate) check(ctx context.Context, a *finalizeAttempt) (FinalizeReason, error) {
+	if g.fi.engineSyncing(a.prevFinalizedL2) {
+		g.fi.bufferSyncTarget(a.finalizedL2)
+		return ReasonEngineSyncing, nil
+	}
+	return "", nil
+}
+
+// bufferSyncTarget remembers the finalized L2 head to apply once the engine is ready. The lock must be held.
+func (fi *Finalizer) bufferSyncTarget(finalizedL2 eth.L2BlockRef) {
+	if finalizedL2.Number <= fi.syncTarget.Number && fi.syncTarget != (eth.L2BlockRef{}) {
+		return
+	}
+	fi.log.Info("engine is syncing, applying finalized L2 head once the engine is ready", "finalized_l2", finalizedL2)
+	fi.syncTarget = finalizedL2
+}
+
+// OnEngineReady is called by the driver once the engine finished syncing.
+// A finalized L2 head that was determined while the engine was syncing is applied now,
+// if the engine has the block, instead of waiting for the next finality signal.
+func (fi *Finalizer) OnEngineReady(ctx context.Context) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	target := fi.syncTarget
+	if target == (eth.L2BlockRef{}) {
+		return
+	}
+	defer fi.publishStatus()
+	if err := fi.guard(func() error { return fi.applySyncTarget(ctx, target) }); err != nil {
+		fi.log.Warn("failed to apply finalized L2 head after engine sync", "finalized_l2", target, "err", err)
+	}
+}
+
+// applySyncTarget applies the finalized L2 head that was buffered while the engine was syncing. The lock must be held.
+func (fi *Finalizer) applySyncTarget(ctx context.Context, target eth.L2BlockRef) (err error) {
+	reason := ReasonFinalized
+	defer func() {
+		fi.recordAttempt(reason, err)
+	}()
+	finalizedL2 := fi.engineFinalized()
+	if fi.engineSyncing(finalizedL2) {
+		reason = ReasonEngineSyncing
+		return nil // still not ready, keep the target buffered
+	}
+	fi.syncTarget = eth.L2BlockRef{}
+	if target.Number <= finalizedL2.Number {
+		// the engine finalized the synced L2 chain itself, up to or beyond the target
+		reason = ReasonEngineAhead
+		return nil
+	}
+	if fi.l2Blocks != nil {
+		ref, err := fi.l2Blocks.L2BlockRefByNumber(ctx, target.Number)
+		if err != nil {
+			fi.syncTarget = target // retry once the engine serves the block
+			return fmt.Errorf("failed to verify the engine has finalized L2 head %s: %w", target, err)
+		}
+		if ref.Hash != target.Hash {
+			fi.log.Warn("engine synced a different L2 block than the buffered finalized L2 head, dropping it",
+				"finalized_l2", target, "engine_l2", ref)
+			reason = ReasonNoQualifyingData
+			return nil
+		}
+	}
+	return fi.applyFinalized(ctx, target)
+}
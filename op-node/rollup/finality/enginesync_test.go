@@ -0,0 +1,75 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerEngineSyncing(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+
+	setup := func(t *testing.T) (*Finalizer, *fakeEngine, *testutils.MockL2Client) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		l1F := &testutils.MockL1Source{}
+		t.Cleanup(func() { l1F.AssertExpectations(t) })
+		l2 := &testutils.MockL2Client{}
+		t.Cleanup(func() { l2.AssertExpectations(t) })
+		// the engine is syncing, and does not report a finalized head yet
+		ec := &fakeEngine{}
+		fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithL2BlockSource(l2))
+		for i := 1; i < 4; i++ {
+			fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		}
+		l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+		fi.Finalize(context.Background(), chain.l1[2])
+		require.Equal(t, eth.L2BlockRef{}, ec.Finalized())
+		require.Equal(t, ReasonEngineSyncing, fi.Status().LastReason)
+		return fi, ec, l2
+	}
+
+	t.Run("applied once ready", func(t *testing.T) {
+		fi, ec, l2 := setup(t)
+		// still syncing, the target stays buffered
+		fi.OnEngineReady(context.Background())
+		require.Equal(t, ReasonEngineSyncing, fi.Status().LastReason)
+
+		ec.finalized = chain.l2[0][1]
+		l2.ExpectL2BlockRefByNumber(chain.l2[2][1].Number, chain.l2[2][1], nil)
+		fi.OnEngineReady(context.Background())
+		require.Equal(t, chain.l2[2][1], ec.Finalized())
+		require.Equal(t, ReasonFinalized, fi.Status().LastReason)
+
+		// the target is only applied once
+		fi.OnEngineReady(context.Background())
+	})
+
+	t.Run("engine ahead", func(t *testing.T) {
+		fi, ec, _ := setup(t)
+		ec.finalized = chain.l2[3][1]
+		fi.OnEngineReady(context.Background())
+		require.Equal(t, chain.l2[3][1], ec.Finalized())
+		require.Equal(t, ReasonEngineAhead, fi.Status().LastReason)
+	})
+
+	t.Run("different block", func(t *testing.T) {
+		fi, ec, l2 := setup(t)
+		ec.finalized = chain.l2[0][1]
+		l2.ExpectL2BlockRefByNumber(chain.l2[2][1].Number, testutils.RandomL2BlockRef(rng), nil)
+		fi.OnEngineReady(context.Background())
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		// the target is dropped
+		fi.OnEngineReady(context.Background())
+	})
+}
@@ -0,0 +1,207 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// L2ReceiptsFetcher fetches the receipts of L2 blocks, like the L2 execution client.
+type L2ReceiptsFetcher interface {
+	FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error)
+}
+
+// defaultMaxEnrichedBlocks bounds the number of blocks of a finalized advancement that are enriched,
+// so catching up on finality after a long sync does not hold the metadata of a huge range in memory.
+const defaultMaxEnrichedBlocks = 10_000
+
+// enrichFetchTimeout bounds the fetch of the receipts of a single L2 block.
+const enrichFetchTimeout = 10 * time.Second
+
+// FinalizedEnricher enriches finalized events with the metadata of every newly finalized L2 block,
+// fetched with one receipts lookup per block, and passes them on to the next subscriber,
+// so tx-level consumers can act per block without additional RPC round trips.
+// Events are enriched asynchronously, in order. Advancements that happen while an event is being enriched
+// are coalesced into the next event. Fetches that fail are retried, so no finalized block is skipped.
+// Advancements of more than maxBlocks L2 blocks are passed on without per-block metadata.
+type FinalizedEnricher struct {
+	log       log.Logger
+	receipts  L2ReceiptsFetcher
+	next      FinalizedSubscriber
+	maxBlocks uint64
+
+	clock         clock.Clock
+	retryStrategy retry.Strategy
+
+	mu sync.Mutex
+	// pending is the advancement that has not been enriched yet.
+	pending *FinalizedEvent
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewFinalizedEnricher(log log.Logger, receipts L2ReceiptsFetcher, next FinalizedSubscriber) *FinalizedEnricher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &FinalizedEnricher{
+		log:           log,
+		receipts:      receipts,
+		next:          next,
+		maxBlocks:     defaultMaxEnrichedBlocks,
+		clock:         clock.SystemClock,
+		retryStrategy: retry.Exponential(),
+		wake:          make(chan struct{}, 1),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+func (fe *FinalizedEnricher) Start() {
+	fe.wg.Add(1)
+	go fe.loop()
+}
+
+func (fe *FinalizedEnricher) Close() {
+	fe.cancel()
+	fe.wg.Wait()
+}
+
+// OnFinalized queues the advancement to be enriched. It is a FinalizedSubscriber, and does not block.
+func (fe *FinalizedEnricher) OnFinalized(ev FinalizedEvent) {
+	fe.mu.Lock()
+	if fe.pending == nil {
+		fe.pending = &ev
+	} else {
+		// coalesce with the advancement that is still pending
+		fe.pending.Coalesce(ev)
+	}
+	fe.mu.Unlock()
+	select {
+	case fe.wake <- struct{}{}:
+	default:
+	}
+}
+
+// OnEachFinalizedBlock returns a subscriber to enriched events, which calls fn for every finalized L2 block, in order.
+func OnEachFinalizedBlock(fn func(block FinalizedBlock)) FinalizedSubscriber {
+	return func(ev FinalizedEvent) {
+		for _, block := range ev.Blocks {
+			fn(block)
+		}
+	}
+}
+
+func (fe *FinalizedEnricher) loop() {
+	defer fe.wg.Done()
+	attempts := 0
+	for {
+		var timeout <-chan time.Time
+		var timer clock.Timer
+		if attempts > 0 {
+			timer = fe.clock.NewTimer(fe.retryStrategy.Duration(attempts - 1))
+			timeout = timer.Ch()
+		} else {
+			select {
+			case <-fe.ctx.Done():
+				return
+			case <-fe.wake:
+			}
+		}
+		if timer != nil {
+			select {
+			case <-fe.ctx.Done():
+				timer.Stop()
+				return
+			case <-timeout:
+			}
+		}
+		fe.mu.Lock()
+		ev := fe.pending
+		fe.pending = nil
+		fe.mu.Unlock()
+		if ev == nil {
+			continue
+		}
+		if err := fe.enrich(fe.ctx, ev); err != nil {
+			if fe.ctx.Err() != nil {
+				return
+			}
+			attempts += 1
+			fe.log.Warn("failed to enrich finalized event, retrying", "prev_finalized_l2", ev.PrevFinalizedL2,
+				"finalized_l2", ev.FinalizedL2, "attempts", attempts, "err", err)
+			fe.requeue(ev)
+			continue
+		}
+		attempts = 0
+		fe.next(*ev)
+	}
+}
+
+// requeue puts back an event that failed to be enriched, before any advancement that happened since.
+func (fe *FinalizedEnricher) requeue(ev *FinalizedEvent) {
+	fe.mu.Lock()
+	defer fe.mu.Unlock()
+	if fe.pending != nil {
+		ev.Coalesce(*fe.pending)
+	}
+	fe.pending = ev
+}
+
+// enrich fetches the metadata of the newly finalized L2 blocks of the event, walking back from the new finalized head,
+// and verifies that they continue the previous finalized head.
+func (fe *FinalizedEnricher) enrich(ctx context.Context, ev *FinalizedEvent) error {
+	ev.Blocks = nil
+	if ev.FinalizedL2.Number <= ev.PrevFinalizedL2.Number {
+		return nil
+	}
+	if count := ev.FinalizedL2.Number - ev.PrevFinalizedL2.Number; count > fe.maxBlocks {
+		fe.log.Warn("finalized advancement too large to enrich, passing it on without per-block metadata",
+			"prev_finalized_l2", ev.PrevFinalizedL2, "finalized_l2", ev.FinalizedL2, "blocks", count, "max_blocks", fe.maxBlocks)
+		return nil
+	}
+	blocks := make([]FinalizedBlock, 0, ev.FinalizedL2.Number-ev.PrevFinalizedL2.Number)
+	hash := ev.FinalizedL2.Hash
+	for n := ev.FinalizedL2.Number; n > ev.PrevFinalizedL2.Number; n-- {
+		fctx, cancel := context.WithTimeout(ctx, enrichFetchTimeout)
+		info, receipts, err := fe.receipts.FetchReceipts(fctx, hash)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to fetch receipts of finalized L2 block %d %s: %w", n, hash, err)
+		}
+		if info.NumberU64() != n {
+			return fmt.Errorf("fetched L2 block %s has number %d, expected %d", hash, info.NumberU64(), n)
+		}
+		block := FinalizedBlock{
+			Hash:       info.Hash(),
+			Number:     n,
+			ParentHash: info.ParentHash(),
+			Time:       info.Time(),
+			TxCount:    len(receipts),
+			TxHashes:   make([]common.Hash, 0, len(receipts)),
+		}
+		for _, r := range receipts {
+			block.TxHashes = append(block.TxHashes, r.TxHash)
+		}
+		blocks = append(blocks, block)
+		hash = info.ParentHash()
+	}
+	if ev.PrevFinalizedL2 != (eth.L2BlockRef{}) && hash != ev.PrevFinalizedL2.Hash {
+		return fmt.Errorf("finalized L2 block %s does not continue the previous finalized L2 block %s",
+			ev.FinalizedL2, ev.PrevFinalizedL2)
+	}
+	slices.Reverse(blocks)
+	ev.Blocks = blocks
+	return nil
+}
@@ -0,0 +1,125 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type fakeReceiptsFetcher struct {
+	mu     sync.Mutex
+	blocks map[common.Hash]eth.L2BlockRef
+	txs    map[common.Hash][]common.Hash
+	fail   int
+}
+
+func (f *fakeReceiptsFetcher) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail > 0 {
+		f.fail -= 1
+		return nil, nil, errors.New("unavailable")
+	}
+	ref, ok := f.blocks[blockHash]
+	if !ok {
+		return nil, nil, errors.New("not found")
+	}
+	var receipts types.Receipts
+	for _, tx := range f.txs[blockHash] {
+		receipts = append(receipts, &types.Receipt{TxHash: tx})
+	}
+	return &testutils.MockBlockInfo{InfoHash: ref.Hash, InfoParentHash: ref.ParentHash, InfoNum: ref.Number, InfoTime: ref.Time}, receipts, nil
+}
+
+func TestFinalizedEnricher(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	logger := testlog.Logger(t, log.LevelInfo)
+	fetcher := &fakeReceiptsFetcher{blocks: make(map[common.Hash]eth.L2BlockRef), txs: make(map[common.Hash][]common.Hash)}
+	refs := []eth.L2BlockRef{testutils.RandomL2BlockRef(rng)}
+	for i := 0; i < 6; i++ {
+		ref := testutils.NextRandomL2Ref(rng, 2, refs[len(refs)-1], refs[len(refs)-1].L1Origin)
+		refs = append(refs, ref)
+		fetcher.blocks[ref.Hash] = ref
+		for j := 0; j < i; j++ {
+			fetcher.txs[ref.Hash] = append(fetcher.txs[ref.Hash], testutils.RandomHash(rng))
+		}
+	}
+
+	events := make(chan FinalizedEvent, 10)
+	fe := NewFinalizedEnricher(logger, fetcher, func(ev FinalizedEvent) { events <- ev })
+	fe.retryStrategy = retry.Fixed(time.Millisecond)
+	fe.Start()
+	defer fe.Close()
+
+	requireBlocks := func(ev FinalizedEvent, from, to int) {
+		require.Len(t, ev.Blocks, to-from+1)
+		for i, block := range ev.Blocks {
+			ref := refs[from+i]
+			require.Equal(t, FinalizedBlock{
+				Hash: ref.Hash, Number: ref.Number, ParentHash: ref.ParentHash, Time: ref.Time,
+				TxCount: len(fetcher.txs[ref.Hash]), TxHashes: append([]common.Hash{}, fetcher.txs[ref.Hash]...),
+			}, block)
+		}
+	}
+
+	fe.OnFinalized(FinalizedEvent{PrevFinalizedL2: refs[0], FinalizedL2: refs[3]})
+	ev := <-events
+	require.Equal(t, refs[3], ev.FinalizedL2)
+	requireBlocks(ev, 1, 3)
+
+	// failed fetches are retried, and later advancements are coalesced into the retried event
+	fetcher.mu.Lock()
+	fetcher.fail = 2
+	fetcher.mu.Unlock()
+	fe.OnFinalized(FinalizedEvent{PrevFinalizedL2: refs[3], FinalizedL2: refs[4]})
+	fe.OnFinalized(FinalizedEvent{PrevFinalizedL2: refs[4], FinalizedL2: refs[6]})
+	var blocks []FinalizedBlock
+	for len(blocks) < 3 {
+		ev := <-events
+		blocks = append(blocks, ev.Blocks...)
+	}
+	requireBlocks(FinalizedEvent{Blocks: blocks}, 4, 6)
+
+	var perBlock []uint64
+	OnEachFinalizedBlock(func(block FinalizedBlock) { perBlock = append(perBlock, block.Number) })(FinalizedEvent{Blocks: blocks})
+	require.Equal(t, []uint64{refs[4].Number, refs[5].Number, refs[6].Number}, perBlock)
+}
+
+func TestFinalizedEnricherLimits(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	logger := testlog.Logger(t, log.LevelCrit)
+	a := testutils.RandomL2BlockRef(rng)
+	b := testutils.NextRandomL2Ref(rng, 2, a, a.L1Origin)
+	c := testutils.NextRandomL2Ref(rng, 2, b, b.L1Origin)
+	fetcher := &fakeReceiptsFetcher{blocks: map[common.Hash]eth.L2BlockRef{b.Hash: b, c.Hash: c}}
+	fe := NewFinalizedEnricher(logger, fetcher, func(ev FinalizedEvent) {})
+
+	t.Run("too large", func(t *testing.T) {
+		fe.maxBlocks = 1
+		defer func() { fe.maxBlocks = defaultMaxEnrichedBlocks }()
+		ev := &FinalizedEvent{PrevFinalizedL2: a, FinalizedL2: c}
+		require.NoError(t, fe.enrich(context.Background(), ev))
+		require.Empty(t, ev.Blocks)
+	})
+
+	t.Run("discontinuous", func(t *testing.T) {
+		other := a
+		other.Hash = testutils.RandomHash(rng)
+		ev := &FinalizedEvent{PrevFinalizedL2: other, FinalizedL2: c}
+		require.ErrorContains(t, fe.enrich(context.Background(), ev), "does not continue")
+	})
+}
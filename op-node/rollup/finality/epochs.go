@@ -0,0 +1,49 @@
+package finality
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// WithBeaconEpochs aligns the attempts to finalize during sync to the beacon epochs of the L1 chain,
+// with the slot clock of the given L1 beacon genesis time and seconds per slot, instead of the fixed finalityDelay.
+//
+// The L1 finality signal only advances at epoch boundaries, so derivation is tried to be finalized once per traversed
+// epoch: never more than one epoch of L1 is left unfinalized for the finalityDelay, and no attempts are wasted within
+// an epoch. Once an attempt was made at or beyond the finalized L1 block, no more L2 blocks can be finalized until
+// the next finality signal, so no further attempts are made until it arrives. Disabled if secondsPerSlot is 0.
+func WithBeaconEpochs(genesisTime uint64, secondsPerSlot uint64) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.beaconGenesisTime = genesisTime
+		fi.beaconSecondsPerSlot = secondsPerSlot
+	}
+}
+
+// l1Epoch returns the beacon epoch of the L1 timestamp, or false if beacon epochs are not tracked.
+// The lock must be held.
+func (fi *Finalizer) l1Epoch(timestamp uint64) (uint64, bool) {
+	if fi.beaconSecondsPerSlot == 0 || timestamp < fi.beaconGenesisTime {
+		return 0, false
+	}
+	slots := fi.l1SlotsPerEpoch
+	if slots == 0 {
+		slots = defaultL1SlotsPerEpoch
+	}
+	return (timestamp - fi.beaconGenesisTime) / fi.beaconSecondsPerSlot / slots, true
+}
+
+// finalizeDue returns true if derivation traversed far enough since the last attempt to finalize to try again:
+// into a later beacon epoch if beacon epochs are tracked, or beyond the finalityDelay otherwise. The lock must be held.
+func (fi *Finalizer) finalizeDue(derivedFrom eth.L1BlockRef) bool {
+	if fi.triedFinalizeAt == 0 {
+		return true
+	}
+	epoch, ok := fi.l1Epoch(derivedFrom.Time)
+	if !ok {
+		return derivedFrom.Number > fi.triedFinalizeAt+fi.finalityDelay
+	}
+	if fi.triedFinalizeAt >= fi.finalizedL1.Number {
+		// all L2 blocks derived from finalized L1 blocks were buffered at the last attempt already
+		return false
+	}
+	return epoch > fi.triedEpoch
+}
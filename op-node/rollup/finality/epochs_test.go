@@ -0,0 +1,62 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/testutil"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestFinalizerBeaconEpochs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 40)
+	const genesis = 1000
+	for i := range chain.l1 {
+		chain.l1[i].Time = genesis + 12*uint64(i)
+	}
+	ec := testutil.NewEngine(chain.l2[0][0])
+	fi := NewFinalizer(testlog.Logger(t, log.LevelInfo), &rollup.Config{}, testutil.NewL1(chain.l1...), ec,
+		WithL1SlotsPerEpoch(4), WithBeaconEpochs(genesis, 12))
+
+	// the node syncs with a finality signal far ahead of derivation
+	fi.Finalize(context.Background(), chain.l1[30])
+	var attemptsAt []int
+	for i := range chain.l1 {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		prev := fi.DebugBundle().Counters.Attempts
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[i]))
+		if fi.DebugBundle().Counters.Attempts > prev {
+			attemptsAt = append(attemptsAt, i)
+		}
+	}
+	// one attempt per epoch of 4 L1 blocks, and none after derivation passed the finalized L1 block
+	require.Equal(t, []int{0, 4, 8, 12, 16, 20, 24, 28, 32}, attemptsAt)
+	ec.RequireFinalized(t, chain.l2[30][1])
+
+	// a new finality signal is applied right away
+	fi.Finalize(context.Background(), chain.l1[39])
+	ec.RequireFinalized(t, chain.l2[39][1])
+}
+
+func TestFinalizerBeaconEpochsDisabled(t *testing.T) {
+	fi := NewFinalizer(testlog.Logger(t, log.LevelInfo), &rollup.Config{}, testutil.NewL1(), testutil.NewEngine(eth.L2BlockRef{}),
+		WithBeaconEpochs(1000, 0))
+	_, ok := fi.l1Epoch(2000)
+	require.False(t, ok)
+	fi = NewFinalizer(testlog.Logger(t, log.LevelInfo), &rollup.Config{}, testutil.NewL1(), testutil.NewEngine(eth.L2BlockRef{}),
+		WithBeaconEpochs(1000, 12))
+	epoch, ok := fi.l1Epoch(1000 + 12*32*3)
+	require.True(t, ok)
+	require.Equal(t, uint64(3), epoch)
+	// timestamps before genesis are not tracked
+	_, ok = fi.l1Epoch(999)
+	require.False(t, ok)
+}
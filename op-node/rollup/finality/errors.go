@@ -0,0 +1,101 @@
+package finality
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrL1Unavailable is returned when an L1 block, needed to verify the finality of L2 blocks, could not be fetched.
+// It is wrapped as a temporary error: finalization is retried later.
+type ErrL1Unavailable struct {
+	// Number is the number of the L1 block that could not be fetched.
+	Number uint64
+	Err    error
+}
+
+func (e *ErrL1Unavailable) Error() string {
+	return fmt.Sprintf("failed to check if on finalizing L1 chain, could not fetch block %d: %v", e.Number, e.Err)
+}
+
+func (e *ErrL1Unavailable) Unwrap() error {
+	return e.Err
+}
+
+// ErrFinalizerPanic is returned when a finalization step panicked, e.g. due to a misbehaving engine client.
+// It is wrapped as a temporary error: finalization is retried later, unless it panics repeatedly.
+type ErrFinalizerPanic struct {
+	// Value is the recovered panic value.
+	Value any
+	// Stack is the stack trace of the panic.
+	Stack []byte
+}
+
+func (e *ErrFinalizerPanic) Error() string {
+	return fmt.Sprintf("finalizer panicked: %v", e.Value)
+}
+
+// ErrSignalNotCanonical is returned when the L1 finality signal is not part of the canonical L1 chain.
+// It is wrapped as a reset error.
+type ErrSignalNotCanonical struct {
+	// Signal is the L1 block that was assumed to be finalized.
+	Signal eth.L1BlockRef
+	// Canonical is the canonical L1 block at the height of the signal.
+	Canonical eth.L1BlockRef
+}
+
+func (e *ErrSignalNotCanonical) Error() string {
+	return fmt.Sprintf("need to reset, we assumed %s is finalized, but canonical chain is %s", e.Signal, e.Canonical)
+}
+
+// ErrDerivedFromNotCanonical is returned when the L1 block that L2 blocks were derived from
+// is not part of the canonical L1 chain that is being finalized.
+// It is wrapped as a reset error.
+type ErrDerivedFromNotCanonical struct {
+	// DerivedFrom is the L1 block the L2 blocks to finalize were derived from.
+	DerivedFrom eth.BlockID
+	// Canonical is the canonical L1 block at the height of DerivedFrom.
+	Canonical eth.L1BlockRef
+	// Signal is the L1 finality signal.
+	Signal eth.L1BlockRef
+}
+
+func (e *ErrDerivedFromNotCanonical) Error() string {
+	return fmt.Sprintf("need to reset, we are on %s, not on the finalizing L1 chain %s (towards %s)",
+		e.DerivedFrom, e.Canonical, e.Signal)
+}
+
+// ErrL1Reorg is returned when a finality signal conflicts with the buffered finality data, because L1 reorged.
+// It is wrapped as a reset error, to derive again from the divergence point.
+type ErrL1Reorg struct {
+	// Signal is the L1 finality signal.
+	Signal eth.L1BlockRef
+	// DivergedAt is the oldest L1 block of the finality data that is not on the chain of the signal.
+	DivergedAt eth.BlockID
+}
+
+func (e *ErrL1Reorg) Error() string {
+	return fmt.Sprintf("need to reset, L1 reorged: derived from %s, which is not on the finalizing L1 chain %s",
+		e.DivergedAt, e.Signal)
+}
+
+// ErrWrongL1Chain is returned when the L1 source of the finality signals serves a different L1 chain than the rollup,
+// e.g. when the L1 endpoint is misconfigured to point at a different network. The finality signals are rejected.
+type ErrWrongL1Chain struct {
+	// ExpectedChainID is the L1 chain ID of the rollup config.
+	ExpectedChainID *big.Int
+	// ChainID is the chain ID of the L1 source.
+	ChainID *big.Int
+	// ExpectedGenesis is the L1 block of the L2 genesis, in the rollup config.
+	ExpectedGenesis eth.BlockID
+	// Genesis is the L1 block of the L1 source at the height of ExpectedGenesis, if the chain IDs match.
+	Genesis eth.BlockID
+}
+
+func (e *ErrWrongL1Chain) Error() string {
+	if e.ChainID != nil && e.ChainID.Cmp(e.ExpectedChainID) != 0 {
+		return fmt.Sprintf("L1 source is of chain %s, but the rollup is on L1 chain %s", e.ChainID, e.ExpectedChainID)
+	}
+	return fmt.Sprintf("L1 source has block %s at the height of the L1 genesis %s of the rollup", e.Genesis, e.ExpectedGenesis)
+}
@@ -0,0 +1,72 @@
+package finality
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrNoFinalitySignal is returned when finality cannot be estimated, because no finality signal was accepted yet.
+var ErrNoFinalitySignal = errors.New("no finality signal accepted yet")
+
+// defaultL1BlockTime is the L1 block time to estimate finality with, until it is observed from finality signals.
+const defaultL1BlockTime = 12 * time.Second
+
+// observeCadence updates the observed L1 block time, from the L1 blocks of consecutive finality signals.
+// The lock must be held.
+func (fi *Finalizer) observeCadence(prev, next eth.L1BlockRef) {
+	if prev == (eth.L1BlockRef{}) || next.Number <= prev.Number || next.Time <= prev.Time {
+		return
+	}
+	fi.l1BlockTime = time.Duration(next.Time-prev.Time) * time.Second / time.Duration(next.Number-prev.Number)
+}
+
+// EstimateFinality estimates when the given L2 block will be finalized, from the L1 block it was derived from,
+// and the cadence at which the finalized L1 block advances. L1 finalizes whole epochs, so the estimate is
+// rounded up to the next epoch, if the L1 epoch size is known.
+// It returns ErrDerivedFromUnknown if the L2 block is not safe yet, or older than the buffered finality data.
+func (fi *Finalizer) EstimateFinality(l2Number uint64) (FinalityEstimate, error) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	out := FinalityEstimate{L2Block: l2Number}
+	now := fi.clock.Now()
+	if l2Number <= fi.finalizedL2.Number {
+		out.Finalized = true
+		out.EstimatedAt = uint64(now.Unix())
+		return out, nil
+	}
+	derivedFrom, err := fi.derivedFrom(l2Number)
+	if err != nil {
+		return out, err
+	}
+	if fi.finalizedL1 == (eth.L1BlockRef{}) {
+		return out, ErrNoFinalitySignal
+	}
+	out.DerivedFrom = derivedFrom
+	at := now
+	// a finalizable L2 block is finalized on the next derivation step, no finality signal is needed
+	if target := derivedFrom.Number + fi.extraConfirmations; target > fi.finalizedL1.Number {
+		out.RemainingL1Blocks = target - fi.finalizedL1.Number
+		blocks := out.RemainingL1Blocks
+		if fi.l1SlotsPerEpoch > 0 {
+			blocks = (blocks + fi.l1SlotsPerEpoch - 1) / fi.l1SlotsPerEpoch * fi.l1SlotsPerEpoch
+		}
+		blockTime := fi.l1BlockTime
+		if blockTime == 0 {
+			blockTime = defaultL1BlockTime
+		}
+		signaledAt := fi.signalProvenance.ReceivedAt
+		if signaledAt.IsZero() {
+			signaledAt = now
+		}
+		at = signaledAt.Add(time.Duration(blocks) * blockTime)
+		// the next finality signal is overdue
+		if at.Before(now) {
+			at = now
+		}
+	}
+	out.EstimatedAt = uint64(at.Unix())
+	out.RemainingSeconds = uint64(at.Sub(now) / time.Second)
+	return out, nil
+}
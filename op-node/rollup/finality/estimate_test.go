@@ -0,0 +1,79 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerEstimateFinality(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithClock(clk))
+
+	for i := range chain.l1 {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+
+	// finalized L2 blocks need no estimate
+	estimate, err := fi.EstimateFinality(chain.l2[0][0].Number)
+	require.NoError(t, err)
+	require.True(t, estimate.Finalized)
+
+	_, err = fi.EstimateFinality(chain.l2[2][1].Number)
+	require.ErrorIs(t, err, ErrNoFinalitySignal)
+	_, err = fi.EstimateFinality(chain.l2[3][1].Number + 1)
+	require.ErrorIs(t, err, ErrDerivedFromUnknown)
+
+	fi.mu.Lock()
+	fi.finalizedL1 = chain.l1[0]
+	fi.signalProvenance = SignalProvenance{Source: SignalSourceL1, ReceivedAt: clk.Now()}
+	fi.mu.Unlock()
+	clk.AdvanceTime(5 * time.Second)
+
+	// without an observed L1 block time, the default L1 block time is assumed
+	estimate, err = fi.EstimateFinality(chain.l2[2][0].Number)
+	require.NoError(t, err)
+	require.False(t, estimate.Finalized)
+	require.Equal(t, chain.l1[2].ID(), estimate.DerivedFrom)
+	require.Equal(t, uint64(2), estimate.RemainingL1Blocks)
+	require.Equal(t, uint64(1000+24), estimate.EstimatedAt)
+	require.Equal(t, uint64(19), estimate.RemainingSeconds)
+
+	// the L1 block time is observed from consecutive finality signals
+	fi.mu.Lock()
+	fi.observeCadence(eth.L1BlockRef{Number: 10, Time: 100}, eth.L1BlockRef{Number: 14, Time: 108})
+	fi.mu.Unlock()
+	estimate, err = fi.EstimateFinality(chain.l2[3][1].Number)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), estimate.RemainingL1Blocks)
+	require.Equal(t, uint64(1000+5+1), estimate.EstimatedAt)
+	require.Equal(t, uint64(1), estimate.RemainingSeconds)
+
+	// a finalizable L2 block is estimated to be finalized on the next derivation step
+	fi.mu.Lock()
+	fi.finalizedL1 = chain.l1[1]
+	fi.mu.Unlock()
+	estimate, err = fi.EstimateFinality(chain.l2[1][1].Number)
+	require.NoError(t, err)
+	require.Zero(t, estimate.RemainingL1Blocks)
+	require.Equal(t, uint64(clk.Now().Unix()), estimate.EstimatedAt)
+}
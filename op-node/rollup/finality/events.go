@@ -0,0 +1,73 @@
+package finality
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FinalizedSubscriber is called synchronously on every finalized L2 head advancement.
+// Subscribers must not block, and must not call back into the Finalizer.
+type FinalizedSubscriber func(ev FinalizedEvent)
+
+type finalizedSubscription struct {
+	fn FinalizedSubscriber
+}
+
+// SubscribeFinalized registers a subscriber to finalized L2 head advancements.
+// The returned function removes the subscription again.
+func (fi *Finalizer) SubscribeFinalized(fn FinalizedSubscriber) (unsubscribe func()) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	sub := &finalizedSubscription{fn: fn}
+	fi.subscribers = append(fi.subscribers, sub)
+	return func() {
+		fi.mu.Lock()
+		defer fi.mu.Unlock()
+		for i, s := range fi.subscribers {
+			if s == sub {
+				fi.subscribers = append(fi.subscribers[:i], fi.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// emitFinalized notifies all subscribers of the advancement of the finalized L2 head from prev to finalizedL2.
+func (fi *Finalizer) emitFinalized(prev eth.L2BlockRef, finalizedL2 eth.L2BlockRef) {
+	spans := fi.popFinalizedSpans(finalizedL2)
+	ranges := fi.derivedRanges(prev, finalizedL2)
+	if len(fi.subscribers) == 0 {
+		return
+	}
+	var derivedFrom []eth.BlockID
+	var batchers []BatcherContribution
+	start := prev.Number + 1
+	for _, r := range fi.finalityData {
+		// An L1 block contributed to the finalized range if it was the last L1 block
+		// any of the newly finalized L2 blocks was derived from.
+		if r.Derived.Number > prev.Number && r.Derived.Number <= finalizedL2.Number {
+			derivedFrom = append(derivedFrom, r.Source.ID)
+			if len(r.Source.Batchers) > 0 {
+				batchers = append(batchers, BatcherContribution{
+					DerivedFrom: r.Source.ID,
+					Start:       start,
+					End:         r.Derived.Number,
+					Batchers:    r.Source.Batchers,
+				})
+			}
+		}
+		start = max(start, r.Derived.Number+1)
+	}
+	ev := FinalizedEvent{
+		PrevFinalizedL2: prev,
+		FinalizedL2:     finalizedL2,
+		FinalizedL1:     fi.finalizedL1,
+		DerivedFrom:     derivedFrom,
+		SpanBatches:     spans,
+		Provenance:      fi.provenance(),
+		Batchers:        batchers,
+		Ranges:          ranges,
+	}
+	for _, sub := range fi.subscribers {
+		sub.fn(ev)
+	}
+}
@@ -0,0 +1,73 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/testutil"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// testChain is a L1 chain, with two L2 blocks derived from each L1 block.
+type testChain struct {
+	l1 []eth.L1BlockRef
+	l2 [][2]eth.L2BlockRef
+}
+
+func newTestChain(rng *rand.Rand, n int) *testChain {
+	c := testutil.NewChain(rng, n)
+	return &testChain{l1: c.L1, l2: c.L2}
+}
+
+func TestFinalizedEvents(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithClock(clk))
+
+	var events []FinalizedEvent
+	unsubscribe := fi.SubscribeFinalized(func(ev FinalizedEvent) {
+		events = append(events, ev)
+	})
+
+	for i := 0; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+	require.Len(t, events, 1)
+	require.Equal(t, FinalizedEvent{
+		PrevFinalizedL2: chain.l2[0][1],
+		FinalizedL2:     chain.l2[2][1],
+		FinalizedL1:     chain.l1[2],
+		DerivedFrom:     []eth.BlockID{chain.l1[1].ID(), chain.l1[2].ID()},
+		Provenance:      &SignalProvenance{Source: SignalSourceL1, ReceivedAt: clk.Now()},
+		Ranges: []DerivedRange{
+			{DerivedFrom: chain.l1[1].ID(), Start: chain.l2[1][0].Number, End: chain.l2[1][1].Number, Blobs: true,
+				BlobsExpireAt: chain.l1[1].Time + uint64(DefaultBlobRetention/time.Second), ReconstructableFromL1: true},
+			{DerivedFrom: chain.l1[2].ID(), Start: chain.l2[2][0].Number, End: chain.l2[2][1].Number, Blobs: true,
+				BlobsExpireAt: chain.l1[2].Time + uint64(DefaultBlobRetention/time.Second), ReconstructableFromL1: true},
+		},
+	}, events[0])
+
+	unsubscribe()
+	require.Empty(t, fi.subscribers)
+}
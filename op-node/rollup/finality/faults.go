@@ -0,0 +1,128 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand" // nosemgrep
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrInjectedFault is returned by FaultyL1 when it injects an L1 fetch error.
+var ErrInjectedFault = errors.New("injected L1 fetch fault")
+
+// FaultConfig configures the rates, between 0 and 1, of the faults that FaultyL1 injects into L1 fetches.
+type FaultConfig struct {
+	// ErrorRate is the rate of fetches that fail with ErrInjectedFault, like an unavailable L1 provider.
+	ErrorRate float64 `json:"error_rate"`
+	// StaleRate is the rate of fetches that fail with ethereum.NotFound, like a lagging L1 provider.
+	StaleRate float64 `json:"stale_rate"`
+	// WrongHashRate is the rate of fetches that return the block with a random hash, like a corrupted L1 provider.
+	WrongHashRate float64 `json:"wrong_hash_rate"`
+}
+
+// Enabled returns true if any faults are injected.
+func (c FaultConfig) Enabled() bool {
+	return c.ErrorRate > 0 || c.StaleRate > 0 || c.WrongHashRate > 0
+}
+
+// ParseFaultConfig parses a comma-separated list of fault rates, e.g. "error=0.1,stale=0.05,wrong-hash=0.01".
+func ParseFaultConfig(spec string) (FaultConfig, error) {
+	var cfg FaultConfig
+	if spec == "" {
+		return cfg, nil
+	}
+	for _, kv := range strings.Split(spec, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(kv), "=")
+		if !ok {
+			return FaultConfig{}, fmt.Errorf("expected fault=rate, got %q", kv)
+		}
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return FaultConfig{}, fmt.Errorf("invalid rate of fault %q: %q", k, v)
+		}
+		switch k {
+		case "error":
+			cfg.ErrorRate = rate
+		case "stale":
+			cfg.StaleRate = rate
+		case "wrong-hash":
+			cfg.WrongHashRate = rate
+		default:
+			return FaultConfig{}, fmt.Errorf("unknown fault %q", k)
+		}
+	}
+	return cfg, nil
+}
+
+// FaultyL1 wraps the L1 source of the Finalizer, and injects faults into its fetches.
+// This is for devnets only: operators use it to validate their monitoring against induced finality stalls.
+type FaultyL1 struct {
+	inner FinalizerL1Interface
+	cfg   FaultConfig
+	log   log.Logger
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+var _ FinalizerL1Interface = (*FaultyL1)(nil)
+
+func NewFaultyL1(log log.Logger, inner FinalizerL1Interface, cfg FaultConfig, rng *rand.Rand) *FaultyL1 {
+	return &FaultyL1{
+		inner: inner,
+		cfg:   cfg,
+		log:   log,
+		rng:   rng,
+	}
+}
+
+// fault picks the fault to inject into the next fetch, if any.
+func (f *FaultyL1) fault() (fetchErr error, wrongHash common.Hash) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.rng.Float64()
+	switch {
+	case p < f.cfg.ErrorRate:
+		return ErrInjectedFault, common.Hash{}
+	case p < f.cfg.ErrorRate+f.cfg.StaleRate:
+		return ethereum.NotFound, common.Hash{}
+	case p < f.cfg.ErrorRate+f.cfg.StaleRate+f.cfg.WrongHashRate:
+		f.rng.Read(wrongHash[:])
+		return nil, wrongHash
+	default:
+		return nil, common.Hash{}
+	}
+}
+
+func (f *FaultyL1) inject(ref eth.L1BlockRef, err error) (eth.L1BlockRef, error) {
+	if err != nil {
+		return ref, err
+	}
+	fetchErr, wrongHash := f.fault()
+	if fetchErr != nil {
+		f.log.Warn("injecting L1 fetch fault", "block", ref, "err", fetchErr)
+		return eth.L1BlockRef{}, fetchErr
+	}
+	if wrongHash != (common.Hash{}) {
+		f.log.Warn("injecting wrong L1 block hash", "block", ref, "hash", wrongHash)
+		ref.Hash = wrongHash
+	}
+	return ref, nil
+}
+
+func (f *FaultyL1) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	return f.inject(f.inner.L1BlockRefByNumber(ctx, num))
+}
+
+func (f *FaultyL1) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	return f.inject(f.inner.L1BlockRefByHash(ctx, hash))
+}
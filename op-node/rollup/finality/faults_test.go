@@ -0,0 +1,76 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestParseFaultConfig(t *testing.T) {
+	cfg, err := ParseFaultConfig("")
+	require.NoError(t, err)
+	require.False(t, cfg.Enabled())
+
+	cfg, err = ParseFaultConfig("error=0.1, stale=0.2,wrong-hash=0.3")
+	require.NoError(t, err)
+	require.Equal(t, FaultConfig{ErrorRate: 0.1, StaleRate: 0.2, WrongHashRate: 0.3}, cfg)
+	require.True(t, cfg.Enabled())
+
+	for _, spec := range []string{"error", "error=1.5", "error=-1", "timeout=0.1", "stale=x"} {
+		_, err = ParseFaultConfig(spec)
+		require.Error(t, err, spec)
+	}
+}
+
+// TestFinalizerFaults checks the Finalizer stays consistent under each class of injected L1 fetch faults:
+// it never finalizes L2 blocks it cannot verify, and finalizes them once the faults stop.
+func TestFinalizerFaults(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		cfg   FaultConfig
+		cause string
+	}{
+		{name: "error", cfg: FaultConfig{ErrorRate: 1}, cause: FailureL1Unavailable},
+		{name: "stale", cfg: FaultConfig{StaleRate: 1}, cause: FailureL1Unavailable},
+		{name: "wrong-hash", cfg: FaultConfig{WrongHashRate: 1}, cause: FailureReset},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1234))
+			chain := newTestChain(rng, 4)
+			logger := testlog.Logger(t, log.LevelInfo)
+			l1F := &testutils.MockL1Source{}
+			defer l1F.AssertExpectations(t)
+			faulty := NewFaultyL1(logger, l1F, tc.cfg, rng)
+			ec := &fakeEngine{}
+			ec.finalized = chain.l2[0][1]
+			m := &fakeMetrics{}
+			fi := NewFinalizer(logger, &rollup.Config{}, faulty, ec, WithMetrics(m))
+			for i := 1; i < 4; i++ {
+				fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+			}
+
+			// the signal and the derived-from block are checked concurrently, so both are fetched
+			l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+			l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+			fi.Finalize(context.Background(), chain.l1[2])
+			require.Equal(t, chain.l2[0][1], ec.Finalized(), "nothing is finalized under faults")
+			require.Equal(t, ReasonError, fi.Status().LastReason)
+			require.Equal(t, map[string]int{tc.cause: 1}, m.failures)
+
+			// the faults stop, and finalization recovers
+			faulty.cfg = FaultConfig{}
+			l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+			l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+			fi.Finalize(context.Background(), chain.l1[2])
+			require.Equal(t, chain.l2[2][1], ec.Finalized())
+		})
+	}
+}
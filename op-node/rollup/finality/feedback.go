@@ -0,0 +1,29 @@
+package finality
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SequencerFeedback exposes the finality lag as an input to the throttling of the sequencer,
+// so block production can slow down or pause when finalization falls behind, to limit the unfinalized exposure.
+type SequencerFeedback interface {
+	// FinalityLag returns the number of L2 blocks between the finalized L2 head and the given unsafe L2 head,
+	// and false if the finalized L2 head is not known yet.
+	FinalityLag(unsafeL2 eth.L2BlockRef) (uint64, bool)
+}
+
+var _ SequencerFeedback = (*Finalizer)(nil)
+
+// FinalityLag returns the number of L2 blocks between the finalized L2 head and the given unsafe L2 head.
+// Like FinalizedAtLeast, it reads the last published finality status, without taking the lock of the Finalizer,
+// so the sequencer can check it before every block.
+func (fi *Finalizer) FinalityLag(unsafeL2 eth.L2BlockRef) (uint64, bool) {
+	finalized := fi.loadPublished().status.FinalizedL2
+	if finalized == (eth.L2BlockRef{}) {
+		return 0, false
+	}
+	if unsafeL2.Number <= finalized.Number {
+		return 0, true
+	}
+	return unsafeL2.Number - finalized.Number, true
+}
@@ -2,14 +2,24 @@ package finality
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/core"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 )
 
 // defaultFinalityLookback defines the amount of L1<>L2 relations to track for finalization purposes, one per L1 block.
@@ -27,78 +37,468 @@ import (
 // And then we add 1 to make pruning easier by leaving room for a new item without pruning the 32*4.
 const defaultFinalityLookback = 4*32 + 1
 
-// finalityDelay is the number of L1 blocks to traverse before trying to finalize L2 blocks again.
+// finalityDelay is the default number of L1 blocks to traverse before trying to finalize L2 blocks again.
 // We do not want to do this too often, since it requires fetching a L1 block by number, so no cache data.
 const finalityDelay = 64
 
+// finalityEpochs is the number of L1 epochs behind the L1 head that new finalization events happen at most.
+const finalityEpochs = 4
+
+// l1FinalityLookback calculates the L1 finality lookback for an L1 chain with the given number of slots per epoch,
+// like defaultFinalityLookback for mainnet. It falls back to defaultFinalityLookback if slotsPerEpoch is unknown.
+func l1FinalityLookback(slotsPerEpoch uint64) uint64 {
+	if slotsPerEpoch == 0 {
+		return defaultFinalityLookback
+	}
+	return finalityEpochs*slotsPerEpoch + 1
+}
+
 // calcFinalityLookback calculates the default finality lookback based on DA challenge window if plasma
 // mode is activated or L1 finality lookback.
 func calcFinalityLookback(cfg *rollup.Config) uint64 {
+	return calcFinalityLookbackFor(cfg, defaultFinalityLookback)
+}
+
+// calcFinalityLookbackFor calculates the finality lookback based on DA challenge window if plasma
+// mode is activated or the given L1 finality lookback.
+func calcFinalityLookbackFor(cfg *rollup.Config, l1Lookback uint64) uint64 {
 	// in alt-da mode the longest finality lookback is a commitment is challenged on the last block of
 	// the challenge window in which case it will be both challenge + resolve window.
 	if cfg.PlasmaEnabled() {
 		lkb := cfg.PlasmaConfig.DAChallengeWindow + cfg.PlasmaConfig.DAResolveWindow + 1
-		// in the case only if the plasma windows are longer than the default finality lookback
-		if lkb > defaultFinalityLookback {
+		// in the case only if the plasma windows are longer than the L1 finality lookback
+		if lkb > l1Lookback {
 			return lkb
 		}
 	}
-	return defaultFinalityLookback
+	return l1Lookback
 }
 
-type FinalityData struct {
-	// The last L2 block that was fully derived and inserted into the L2 engine while processing this L1 block.
-	L2Block eth.L2BlockRef
-	// The L1 block this stage was at when inserting the L2 block.
-	// When this L1 block is finalized, the L2 chain up to this block can be fully reproduced from finalized L1 data.
-	L1Block eth.BlockID
+// l1Source is the L1 block that L2 blocks were derived from, with its batch inclusion, if known.
+type l1Source struct {
+	ID eth.BlockID
+	// ParentHash is the parent-hash of the L1 block, if known, to detect L1 reorgs of the buffered data.
+	ParentHash common.Hash
+	// Time is the timestamp of the L1 block, if known, to track the blob retention window of the L1 block.
+	Time        uint64
+	BatchTxs    []common.Hash
+	BlobIndices []uint64
+	Batchers    []common.Address
+	// Expanded is set if the entry was expanded from compressed finality data, see WithCompression.
+	Expanded bool
+}
+
+// opRefs relates L2 blocks to the L1 blocks they were derived from, for the core finality algorithm.
+type opRefs struct{}
+
+func (opRefs) SourceNumber(source l1Source) uint64         { return source.ID.Number }
+func (opRefs) DerivedNumber(derived eth.L2BlockRef) uint64 { return derived.Number }
+func (opRefs) SameSource(a, b l1Source) bool               { return a.ID == b.ID }
+func (opRefs) SameDerived(a, b eth.L2BlockRef) bool        { return a == b }
+
+type finalityRelation = core.Relation[l1Source, eth.L2BlockRef]
+
+type finalityRelations = core.Relations[l1Source, eth.L2BlockRef, opRefs]
+
+type finalityArena = core.Arena[l1Source, eth.L2BlockRef, opRefs]
+
+// toFinalityData converts a tracked relation to its finality data.
+func toFinalityData(r finalityRelation) FinalityData {
+	return FinalityData{
+		L2Block:     r.Derived,
+		L1Block:     r.Source.ID,
+		BatchTxs:    r.Source.BatchTxs,
+		BlobIndices: r.Source.BlobIndices,
+		Batchers:    r.Source.Batchers,
+		L1Parent:    r.Source.ParentHash,
+		L1Time:      r.Source.Time,
+	}
 }
 
 type FinalizerEngine interface {
 	Finalized() eth.L2BlockRef
-	SetFinalizedHead(eth.L2BlockRef)
+	// SetFinalizedHead sets the finalized L2 head, and applies it to the engine with a forkchoice update.
+	// It returns an error if the engine did not apply it, in which case the Finalizer retries it later.
+	SetFinalizedHead(ctx context.Context, ref eth.L2BlockRef) error
 }
 
 type FinalizerL1Interface interface {
 	L1BlockRefByNumber(context.Context, uint64) (eth.L1BlockRef, error)
+	L1BlockRefByHash(context.Context, common.Hash) (eth.L1BlockRef, error)
 }
 
 type Finalizer struct {
 	mu sync.Mutex
 
 	log log.Logger
+	// dataLog logs the buffering of finality data, with a dedicated topic to filter the verbose data logs by.
+	dataLog log.Logger
+
+	cfg *rollup.Config
 
 	// finalizedL1 is the currently perceived finalized L1 block.
 	// This may be ahead of the current traversed origin when syncing.
 	finalizedL1 eth.L1BlockRef
 
+	// derivedFromL1 is the latest L1 block that derivation reached.
+	derivedFromL1 eth.L1BlockRef
+
 	// triedFinalizeAt tracks at which L1 block number we last tried to finalize during sync.
 	triedFinalizeAt uint64
+	// triedEpoch is the beacon epoch of the L1 block we last tried to finalize at, if beacon epochs are tracked.
+	triedEpoch uint64
 
 	// Tracks which L2 blocks where last derived from which L1 block. At most finalityLookback large.
-	finalityData []FinalityData
+	finalityData finalityRelations
+	// finalityArena backs finalityData, to prune it without moving all entries on every new L1 block.
+	finalityArena *finalityArena
+
+	// originUpdates counts the safe head updates of the latest L1 block in finalityData, to summarize in the logs.
+	originUpdates uint64
 
 	// Maximum amount of L2 blocks to store in finalityData.
 	finalityLookback uint64
+	// slo is the end-to-end finality latency SLO to report compliance with, see WithLatencySLO.
+	slo LatencySLO
+	// sloSamples are the latency compliance samples of the advancements of the finalized L2 head within the SLO window.
+	sloSamples []sloSample
+
+	// baseLookback is the configured finality lookback, that finalityLookback shrinks back to after growing.
+	baseLookback uint64
+	// maxLookback is the hard max that finalityLookback may grow to while finality signals stall. See WithLookbackGrowth.
+	maxLookback uint64
+	// l1SlotsPerEpoch sizes the finality lookback to the L1 chain, if known.
+	l1SlotsPerEpoch uint64
+	// beaconGenesisTime and beaconSecondsPerSlot are the slot clock of the L1 beacon chain,
+	// to align attempts to finalize to beacon epochs. Disabled if beaconSecondsPerSlot is 0.
+	beaconGenesisTime    uint64
+	beaconSecondsPerSlot uint64
+	// pruning decides which finality data entries are retained, within the finality lookback.
+	pruning PruningPolicy
+	// compressAfter is the number of most recent finality data entries that are not compressed. Disabled if 0.
+	compressAfter uint64
+	// runs are the compressed runs of finality data entries, by the L1 block number of the retained entry they end at.
+	runs map[uint64]*compressedRun
+	// compressedEntries is the number of compressed entries in runs.
+	compressedEntries uint64
 
 	l1Fetcher FinalizerL1Interface
 
 	ec FinalizerEngine
+
+	// pendingFinalized is the finalized L2 head that was set, but not yet applied to the engine.
+	// It is retried with backoff until the engine accepts the forkchoice update.
+	pendingFinalized eth.L2BlockRef
+	// pendingFrom is the finalized L2 head that was last successfully applied, before pendingFinalized.
+	pendingFrom eth.L2BlockRef
+	// pendingAttempts counts the failed attempts to apply pendingFinalized.
+	pendingAttempts int
+	// pendingRetryAt is the earliest time at which applying pendingFinalized may be retried.
+	pendingRetryAt time.Time
+	// engineCallTimeout bounds the engine calls that apply the finalized L2 head.
+	engineCallTimeout time.Duration
+	// blobRetention is the blob retention window of the L1 chain,
+	// and finalizedRanges the most recently finalized L2 ranges, at most finalizedRangesRetained.
+	blobRetention   time.Duration
+	finalizedRanges []DerivedRange
+
+	retryStrategy retry.Strategy
+	clock         clock.Clock
+	metrics       Metrics
+
+	// subscribers are notified of every finalized L2 head advancement.
+	subscribers []*finalizedSubscription
+	// safeSubscribers are notified of every new local-safe L2 block in the finality data.
+	safeSubscribers []*safeSubscription
+
+	// gates are the conditions on the advancement of the finalized L2 head, in the order they run in.
+	gates []finalityGate
+	// The gates that are configured with options. Each is disabled unless configured.
+	disputeGameGate     *disputeGameGate
+	committeeGate       *committeeGate
+	maxAdvanceGate      *maxAdvanceGate
+	throttleGate        *throttleGate
+	crossValidationGate *crossValidationGate
+
+	// maxSignalAge is the maximum age of the L1 block of a finality signal, relative to the L1 head. Disabled if 0.
+	maxSignalAge time.Duration
+	// l1Head returns the current L1 head, to determine the age of finality signals with.
+	l1Head func() eth.L1BlockRef
+
+	// ancestryCheckDepth is the maximum number of L1 blocks to walk back,
+	// to verify a new finality signal builds on the previous one. Disabled if 0.
+	ancestryCheckDepth uint64
+
+	// lastAppliedAt is the time the finalized L2 head was last applied to the engine.
+	lastAppliedAt time.Time
+
+	// stallThreshold is how long the finalized L2 head may not advance, before finalization is considered stalled.
+	stallThreshold time.Duration
+	// startedAt is when the Finalizer was created, the start of any stall before the first advancement.
+	startedAt time.Time
+	// progressSafeL2 is the latest safe L2 head, that derivation last advanced at safeAdvancedAt.
+	progressSafeL2 eth.L2BlockRef
+	safeAdvancedAt time.Time
+	// lastStall is the last classified stall of finalization.
+	lastStall StallReason
+	// reportedFinalizedL2 is the finalized L2 head that was last reported with the L1 block it was derived from.
+	reportedFinalizedL2 eth.L2BlockRef
+
+	// published is the last published finality status, served without taking the lock.
+	// statusVersion counts the changes of the published status.
+	published     atomic.Pointer[publishedStatus]
+	statusVersion uint64
+
+	// extraConfirmations is the number of L1 blocks below the finalized L1 block,
+	// that L2 blocks have to be derived from to be finalized. Disabled if 0.
+	extraConfirmations uint64
+
+	// finalityDelay is the number of L1 blocks to traverse before trying to finalize L2 blocks again.
+	finalityDelay uint64
+	// adaptive adapts the finalityDelay to the cost of the L1 fetches. Disabled if nil.
+	adaptive *delayController
+	// profileLookback is the L1 finality lookback of the finality profile of the chain. Sized to the L1 chain if 0.
+	profileLookback uint64
+
+	// tunablesAudit is the most recent reloads that changed the finality tunables.
+	tunablesAudit []FinalityTunablesChange
+
+	// trustSignal skips the canonical-chain sanity checks of the finality signal,
+	// for signal sources that are verified themselves, like a light client.
+	trustSignal bool
+	// l1ChainVerified is set once the L1 source was verified to serve the L1 chain of the rollup.
+	l1ChainVerified bool
+	// verifiedL1 caches the L1 blocks that were verified to be canonical since the last finality signal,
+	// to not refetch them on repeated attempts to finalize.
+	verifiedL1 map[uint64]common.Hash
+	// preparedL1 are the L1 blocks that PrepareFinalize verified to be canonical ahead of accepting the signal preparedFor,
+	// to seed verifiedL1 with once the signal is accepted.
+	preparedL1  map[uint64]common.Hash
+	preparedFor eth.L1BlockRef
+
+	// l2Blocks resolves the finalized L2 blocks of FinalizedAtTime that are not buffered. Disabled if nil.
+	l2Blocks L2BlockSource
+
+	// inclusions provides the batch inclusion of the derived-from L1 blocks. Disabled if nil.
+	inclusions InclusionSource
+
+	// backfillSafeHeads and backfillL2 are used to backfill the gaps in finalityData. Disabled if nil.
+	backfillSafeHeads SafeHeadSource
+	backfillL2        BackfillL2
+	// gaps are the detected gaps in finalityData that are yet to be backfilled.
+	gaps []finalityGap
+
+	// repairUnjustified re-asserts the justified finalized L2 head on the engine, if the engine is ahead of it.
+	repairUnjustified bool
+	// unjustifiedL2 is the finalized L2 head of the engine that cannot be justified from finalized L1 data, if any.
+	unjustifiedL2 eth.L2BlockRef
+
+	// plasmaChangeAction determines how a restored snapshot is handled, if the plasma DA windows changed since.
+	plasmaChangeAction PlasmaChangeAction
+
+	// traceID returns the ID of the trace of a finalization attempt, to link its metrics to. Disabled if nil.
+	traceID func(ctx context.Context) string
+	// attemptTraceIDs assigns a random trace ID to the attempts to finalize that are not traced otherwise.
+	attemptTraceIDs bool
+
+	// rules are the scheduled finality rules of the rollup config, in order of activation.
+	rules []ruleActivation
+
+	// settlement tracks settledL2, the finalized L2 head that is also backed by resolved dispute games. Disabled if nil.
+	settlement DisputeGameReader
+	settledL2  eth.L2BlockRef
+
+	// stopped queues finality signals into queuedSignal, until the Finalizer is started.
+	stopped      bool
+	queuedSignal eth.L1BlockRef
+	// signalWeights and signalThreshold weigh the signal sources before accepting a signal. Disabled if nil.
+	signalWeights   map[string]uint64
+	signalThreshold uint64
+	// signalReceipts is the latest signal of each signal source, if signals are weighed.
+	signalReceipts map[string]signalReceipt
+	// signalProvenance describes which signal source produced finalizedL1.
+	signalProvenance SignalProvenance
+	// l1BlockTime is the L1 block time, as observed between consecutive finality signals. Unknown if 0.
+	l1BlockTime time.Duration
+
+	// trackSpans finalizes whole span batches only, tracked in spans.
+	trackSpans bool
+	// spans are the span batches that are awaiting finalization, in ascending order.
+	spans []SpanBatchBoundary
+	// lastSafeL2 is the latest safe head, the end of the latest span batch.
+	lastSafeL2 eth.L2BlockRef
+
+	// replay processes the queued finality signal on start. Wrapping finalizers may override it. Defaults to Finalize.
+	replay func(ctx context.Context, l1Origin eth.L1BlockRef)
+
+	// signalSinceAttempt is set when a new finality signal arrived since the last successful attempt to finalize.
+	signalSinceAttempt bool
+
+	// resetRequested is set when an attempt to finalize required a pipeline reset,
+	// to re-attempt once the reset completed.
+	resetRequested bool
+	// reorg is set when a finality signal revealed an L1 reorg of the finality data,
+	// to request a pipeline reset with the next processed L1 block.
+	reorg *ErrL1Reorg
+
+	// panics counts the consecutive panics of finalization steps,
+	// and disabledUntil is the time until which finalization is disabled after repeated panics.
+	panics        int
+	disabledUntil time.Time
+
+	// maxMismatches is the number of consecutive canonical-chain mismatches after which finalization is halted,
+	// and mismatches counts them. Disabled if 0. halted is set until an operator resumes finalization.
+	maxMismatches int
+	mismatches    int
+	halted        bool
+	// haltOnSafeRegression halts finalization when the safe head moves backwards without a reset.
+	haltOnSafeRegression bool
+	// forkConflict is the L2 block that conflicts with the L2 chain derived from L1, if the node detected
+	// it is on an L2 fork. Finalization is frozen while it is set.
+	forkConflict eth.BlockID
+
+	// syncTarget is the finalized L2 head to apply once the engine is done syncing, if any.
+	syncTarget eth.L2BlockRef
+
+	// finalizedL2 is the finalized L2 head of the engine, as last observed or set on the event loop.
+	// The engine is owned by the event loop, so the queries served on other goroutines read this instead.
+	finalizedL2 eth.L2BlockRef
+	// lastSetFinalized is the finalized L2 head the Finalizer last set on the engine,
+	// which every later finalized L2 head must strictly continue.
+	lastSetFinalized eth.L2BlockRef
+	// auditTrail records the most recent finalized head updates, at most auditTrailSize.
+	auditTrail []FinalizedHeadUpdate
+	// casualties records the most recent finality data entries that were pruned before they were finalized,
+	// at most casualtyLogSize.
+	casualties []PruningCasualty
+
+	// counters are the internal counters of the Finalizer, for debugging.
+	counters FinalityCounters
+
+	// lastReason is the outcome of the last attempt to finalize, and lastError its error, if any.
+	lastReason FinalizeReason
+	lastError  string
 }
 
-func NewFinalizer(log log.Logger, cfg *rollup.Config, l1Fetcher FinalizerL1Interface, ec FinalizerEngine) *Finalizer {
-	lookback := calcFinalityLookback(cfg)
-	return &Finalizer{
-		log:              log,
-		finalizedL1:      eth.L1BlockRef{},
-		triedFinalizeAt:  0,
-		finalityData:     make([]FinalityData, 0, lookback),
-		finalityLookback: lookback,
-		l1Fetcher:        l1Fetcher,
-		ec:               ec,
+// FinalizerOption configures optional Finalizer behavior.
+type FinalizerOption func(fi *Finalizer)
+
+// WithClock overrides the clock used to schedule retries. This is primarily used for testing.
+func WithClock(c clock.Clock) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.clock = c
 	}
 }
 
+// WithRetryStrategy overrides the backoff strategy used when the engine fails to apply a new finalized head.
+func WithRetryStrategy(s retry.Strategy) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.retryStrategy = s
+	}
+}
+
+// WithMaxAdvance limits the finalized L2 head to advance by at most maxBlocks L2 blocks at a time,
+// to avoid a single large jump of the finalized head after a long period of non-finality.
+// The remaining blocks are finalized on subsequent derivation steps.
+// The limit is exceeded when there is no known intermediate L2 block to finalize within the limit.
+func WithMaxAdvance(maxBlocks uint64) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.maxAdvanceGate.maxBlocks = maxBlocks
+	}
+}
+
+// WithMaxSignalAge ignores finality signals with an L1 block timestamp older than maxAge, relative to the L1 head,
+// to protect against stale or replayed signals of misbehaving L1 providers.
+func WithMaxSignalAge(maxAge time.Duration, l1Head func() eth.L1BlockRef) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.maxSignalAge = maxAge
+		fi.l1Head = l1Head
+	}
+}
+
+// WithExtraConfirmations only finalizes L2 blocks derived from L1 blocks at least n blocks below the finalized L1 block,
+// for operators that want an extra safety margin on top of L1 finality.
+func WithExtraConfirmations(n uint64) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.extraConfirmations = n
+	}
+}
+
+// WithTrustSignal skips the canonical-chain sanity checks of the finality signal and of the L1 blocks
+// the finalized L2 blocks were derived from. This should only be used if the finality signal source is verified,
+// like a light client, and the L1 source is consistent with it.
+func WithTrustSignal() FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.trustSignal = true
+	}
+}
+
+// WithL1SlotsPerEpoch sizes the finality lookback to an L1 chain with the given number of slots per epoch,
+// e.g. as fetched from the beacon spec, for L1 chains with different epoch lengths than mainnet.
+// The mainnet lookback is used if slotsPerEpoch is 0.
+func WithL1SlotsPerEpoch(slotsPerEpoch uint64) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.l1SlotsPerEpoch = slotsPerEpoch
+	}
+}
+
+func NewFinalizer(log log.Logger, cfg *rollup.Config, l1Fetcher FinalizerL1Interface, ec FinalizerEngine, opts ...FinalizerOption) *Finalizer {
+	fi := &Finalizer{
+		log:             log,
+		dataLog:         log.New("topic", "finality_data"),
+		cfg:             cfg,
+		finalizedL1:     eth.L1BlockRef{},
+		triedFinalizeAt: 0,
+		l1Fetcher:       l1Fetcher,
+		ec:              ec,
+		retryStrategy:   retry.Exponential(),
+		clock:           clock.SystemClock,
+		metrics:         noopMetrics{},
+		pruning:         CapacityPruning{},
+		verifiedL1:      make(map[uint64]common.Hash),
+		preparedL1:      make(map[uint64]common.Hash),
+		finalityDelay:   finalityDelay,
+		stallThreshold:  defaultStallThreshold,
+
+		engineCallTimeout:  defaultEngineCallTimeout,
+		blobRetention:      DefaultBlobRetention,
+		plasmaChangeAction: PlasmaChangeMigrate,
+	}
+	fi.disputeGameGate = &disputeGameGate{fi: fi}
+	fi.committeeGate = &committeeGate{fi: fi}
+	fi.maxAdvanceGate = &maxAdvanceGate{fi: fi}
+	fi.throttleGate = &throttleGate{fi: fi}
+	fi.crossValidationGate = &crossValidationGate{fi: fi}
+	fi.applyProfile(cfg)
+	for _, opt := range opts {
+		opt(fi)
+	}
+	fi.gates = fi.newGates()
+	fi.initAdaptiveDelay()
+	fi.rules = finalityRules(cfg)
+	if fi.rulesGateOnDisputeGames() && fi.disputeGameGate.games == nil && fi.disputeGameGate.ruleGames == nil {
+		log.Error("finality rules gate on dispute games, but no dispute games are available: " +
+			"L2 blocks under these rules will not be finalized")
+	}
+	lookback := fi.pruning.Lookback(calcFinalityLookbackFor(cfg, fi.l1Lookback()))
+	fi.finalityLookback = lookback
+	fi.baseLookback = lookback
+	if !fi.compressing() {
+		// compressed finality data is pruned from the middle, and cannot be backed by an arena
+		fi.finalityArena = core.NewArena[l1Source, eth.L2BlockRef, opRefs](lookback)
+		fi.finalityData = fi.finalityArena.Relations()
+	}
+	fi.startedAt = fi.clock.Now()
+	return fi
+}
+
+// engineFinalized returns the finalized L2 head of the engine, and records it for the queries that are served
+// outside of the event loop. It must only be called on the event loop. The lock must be held.
+func (fi *Finalizer) engineFinalized() eth.L2BlockRef {
+	fi.finalizedL2 = fi.ec.Finalized()
+	return fi.finalizedL2
+}
+
 // FinalizedL1 identifies the L1 chain (incl.) that included and/or produced all the finalized L2 blocks.
 // This may return a zeroed ID if no finalization signals have been seen yet.
 func (fi *Finalizer) FinalizedL1() (out eth.L1BlockRef) {
@@ -108,29 +508,45 @@ func (fi *Finalizer) FinalizedL1() (out eth.L1BlockRef) {
 	return
 }
 
-// Finalize applies a L1 finality signal, without any fork-choice or L2 state changes.
+// Finalize applies a L1 finality signal: the L2 blocks fully derived from the finalized L1 chain
+// are finalized, and the finalized L2 head is applied to the engine with a fork-choice update.
 func (fi *Finalizer) Finalize(ctx context.Context, l1Origin eth.L1BlockRef) {
+	fi.FinalizeFrom(ctx, l1Origin, SignalSourceL1)
+}
+
+// FinalizeOutcome applies a L1 finality signal, like Finalize, and returns the outcome of the attempt to finalize,
+// so the caller can act on the requested action instead of the error only being logged.
+func (fi *Finalizer) FinalizeOutcome(ctx context.Context, l1Origin eth.L1BlockRef) FinalityOutcome {
+	return fi.finalizeFrom(ctx, l1Origin, SignalSourceL1)
+}
+
+// FinalizeFrom applies a L1 finality signal of the given signal source, like Finalize.
+// The source is recorded as provenance of the signal, and weighed if trust weights are configured.
+func (fi *Finalizer) FinalizeFrom(ctx context.Context, l1Origin eth.L1BlockRef, source string) {
+	fi.finalizeFrom(ctx, l1Origin, source)
+}
+
+func (fi *Finalizer) finalizeFrom(ctx context.Context, l1Origin eth.L1BlockRef, source string) FinalityOutcome {
 	fi.mu.Lock()
 	defer fi.mu.Unlock()
-	prevFinalizedL1 := fi.finalizedL1
-	if l1Origin.Number < fi.finalizedL1.Number {
-		fi.log.Error("ignoring old L1 finalized block signal! Is the L1 provider corrupted?",
-			"prev_finalized_l1", prevFinalizedL1, "signaled_finalized_l1", l1Origin)
-		return
+	if fi.deferSignal(l1Origin) {
+		return FinalityOutcome{}
 	}
-
-	if fi.finalizedL1 != l1Origin {
-		// reset triedFinalizeAt, so we give finalization a shot with the new signal
-		fi.triedFinalizeAt = 0
-
-		// remember the L1 finalization signal
-		fi.finalizedL1 = l1Origin
+	defer fi.publishStatus()
+	defer fi.reportStall()
+	defer fi.reportSLO()
+	defer fi.reportRelations()
+	if !fi.acceptSignal(ctx, l1Origin, source, true) {
+		return FinalityOutcome{}
 	}
 
 	// remnant of finality in EngineQueue: the finalization work does not inherit a context from the caller.
-	if err := fi.tryFinalize(ctx); err != nil {
-		fi.log.Warn("received L1 finalization signal, but was unable to determine and apply L2 finality", "err", err)
+	outcome := OutcomeOf(fi.guard(func() error { return fi.tryFinalize(ctx) }))
+	if outcome.Err != nil {
+		fi.log.Warn("received L1 finalization signal, but was unable to determine and apply L2 finality",
+			"action", outcome.Action, "err", outcome.Err)
 	}
+	return outcome
 }
 
 // OnDerivationL1End is called when a L1 block has been fully exhausted (i.e. no more L2 blocks to derive from).
@@ -142,58 +558,422 @@ func (fi *Finalizer) Finalize(ctx context.Context, l1Origin eth.L1BlockRef) {
 // sanity-check we are on the finalizing L1 chain,
 // and finalize any L2 blocks that were fully derived from known finalized L1 blocks.
 func (fi *Finalizer) OnDerivationL1End(ctx context.Context, derivedFrom eth.L1BlockRef) error {
+	return fi.OnDerivationL1EndOutcome(ctx, derivedFrom).AsError()
+}
+
+// OnDerivationL1EndOutcome is like OnDerivationL1End, but returns the outcome instead of a leveled error.
+func (fi *Finalizer) OnDerivationL1EndOutcome(ctx context.Context, derivedFrom eth.L1BlockRef) FinalityOutcome {
 	fi.mu.Lock()
 	defer fi.mu.Unlock()
+	return fi.onL1Progress(ctx, derivedFrom)
+}
+
+// onL1Progress processes the progress of derivation to the given L1 block, whether or not any L2 blocks
+// were derived from it, so attempts to finalize are throttled the same way for both. The lock must be held.
+func (fi *Finalizer) onL1Progress(ctx context.Context, derivedFrom eth.L1BlockRef) FinalityOutcome {
+	if derivedFrom.Number > fi.derivedFromL1.Number || fi.derivedFromL1 == (eth.L1BlockRef{}) {
+		fi.derivedFromL1 = derivedFrom
+		fi.reportProgress(false)
+	}
+	defer func() {
+		fi.metrics.RecordFinalityStateDigest(fi.stateDigest())
+		fi.reportStall()
+		fi.reportSLO()
+		fi.reportRelations()
+		fi.publishStatus()
+	}()
+	return OutcomeOf(fi.guard(func() error { return fi.onDerivationL1End(ctx, derivedFrom) }))
+}
+
+func (fi *Finalizer) onDerivationL1End(ctx context.Context, derivedFrom eth.L1BlockRef) error {
+	// the finalized L2 head of the status is refreshed with every L1 block, as the engine may also be reset
+	fi.engineFinalized()
+	if fi.reorg != nil {
+		err := derive.NewResetError(fi.reorg)
+		fi.reorg = nil
+		fi.resetRequested = true
+		fi.counters.ResetsTriggered += 1
+		return err
+	}
+	// A finalized head that the engine previously failed to apply takes priority, and is not subject to the finalityDelay.
+	if pending, err := fi.tryApplyPending(ctx); pending {
+		return err
+	}
+	if err := fi.reconcileFinalized(ctx); err != nil {
+		return err
+	}
 	if fi.finalizedL1 == (eth.L1BlockRef{}) {
 		return nil // if no L1 information is finalized yet, then skip this
 	}
 	// If we recently tried finalizing, then don't try again just yet, but traverse more of L1 first.
-	if fi.triedFinalizeAt != 0 && derivedFrom.Number <= fi.triedFinalizeAt+finalityDelay {
+	if !fi.finalizeDue(derivedFrom) {
 		return nil
 	}
 	fi.log.Info("processing L1 finality information", "l1_finalized", fi.finalizedL1, "derived_from", derivedFrom, "previous", fi.triedFinalizeAt)
 	fi.triedFinalizeAt = derivedFrom.Number
-	return fi.tryFinalize(ctx)
+	fi.triedEpoch, _ = fi.l1Epoch(derivedFrom.Time)
+	err := fi.tryFinalize(ctx)
+	fi.adaptDelay()
+	return err
 }
 
-func (fi *Finalizer) tryFinalize(ctx context.Context) error {
+func (fi *Finalizer) tryFinalize(ctx context.Context) (err error) {
+	a := &finalizeAttempt{reason: ReasonFinalized}
+	fi.counters.Attempts += 1
+	start := fi.clock.Now()
+	defer func() {
+		if err == nil {
+			fi.signalSinceAttempt = false
+		}
+		fi.recordAttempt(a.reason, err)
+		exemplar := fi.traceExemplar(ctx)
+		fi.metrics.RecordFinalityAttemptDuration(fi.clock.Since(start), exemplar)
+		if exemplar != nil {
+			fi.log.Info("attempted to finalize", "trace_id", exemplar[traceIDLabel], "reason", a.reason,
+				"duration", fi.clock.Since(start), "err", err)
+		}
+	}()
 	// default to keep the same finalized block
-	finalizedL2 := fi.ec.Finalized()
-	var finalizedDerivedFrom eth.BlockID
+	prevFinalizedL2, err := fi.checkJustified(ctx, fi.engineFinalized())
+	if err != nil {
+		return derive.NewTemporaryError(err)
+	}
+	a.prevFinalizedL2, a.finalizedL2 = prevFinalizedL2, prevFinalizedL2
+	for _, g := range fi.gates {
+		if err := g.prepare(ctx, a); err != nil {
+			return derive.NewTemporaryError(err)
+		}
+	}
 	// go through the latest inclusion data, and find the last L2 block that was derived from a finalized L1 block
-	for _, fd := range fi.finalityData {
-		if fd.L2Block.Number > finalizedL2.Number && fd.L1Block.Number <= fi.finalizedL1.Number {
-			finalizedL2 = fd.L2Block
-			finalizedDerivedFrom = fd.L1Block
-			// keep iterating, there may be later L2 blocks that can also be finalized
-		}
-	}
-	if finalizedDerivedFrom != (eth.BlockID{}) {
-		// Sanity check the finality signal of L1.
-		// Even though the signal is trusted and we do the below check also,
-		// the signal itself has to be canonical to proceed.
-		// TODO(#10724): This check could be removed if the finality signal is fully trusted, and if tests were more flexible for this case.
-		signalRef, err := fi.l1Fetcher.L1BlockRefByNumber(ctx, fi.finalizedL1.Number)
+	final := func(source l1Source) bool {
+		return source.ID.Number+fi.extraConfirmations <= fi.finalizedL1.Number
+	}
+	accept := func(r finalityRelation, found bool) bool {
+		return fi.acceptCandidate(a, r, found)
+	}
+	if len(fi.gaps) > 0 {
+		fi.backfillGaps(ctx)
+	}
+	// compressed entries are only finalized if their full L2 block refs can be resolved
+	rels := fi.finalityData
+	if fi.l2Blocks != nil {
+		rels = fi.expandedFinalityData()
+	}
+	if r, found := rels.Finalizable(prevFinalizedL2, final, accept); found {
+		r, err := fi.resolveExpanded(ctx, r)
 		if err != nil {
-			return derive.NewTemporaryError(fmt.Errorf("failed to check if on finalizing L1 chain, could not fetch block %d: %w", fi.finalizedL1.Number, err))
+			return derive.NewTemporaryError(err)
 		}
-		if signalRef.Hash != fi.finalizedL1.Hash {
-			return derive.NewResetError(fmt.Errorf("need to reset, we assumed %s is finalized, but canonical chain is %s", fi.finalizedL1, signalRef))
+		a.finalizedL2, a.derivedFrom = r.Derived, r.Source.ID
+	}
+	if pass, err := fi.checkGates(ctx, a); err != nil {
+		return err
+	} else if pass {
+		if err := fi.applyFinalized(ctx, a.finalizedL2); err != nil {
+			return err
 		}
+	}
+	fi.trySettle(ctx, fi.engineFinalized())
+	return nil
+}
 
-		// Sanity check we are indeed on the finalizing chain, and not stuck on something else.
-		// We assume that the block-by-number query is consistent with the previously received finalized chain signal
-		derivedRef, err := fi.l1Fetcher.L1BlockRefByNumber(ctx, finalizedDerivedFrom.Number)
+// checkGates runs the check phase of the gates on the selected L2 block to finalize.
+// It returns false if the finalized L2 head is not to advance, with the reason recorded in the attempt.
+// The lock must be held.
+func (fi *Finalizer) checkGates(ctx context.Context, a *finalizeAttempt) (bool, error) {
+	for _, g := range fi.gates {
+		if !a.advances() {
+			break
+		}
+		reason, err := g.check(ctx, a)
 		if err != nil {
-			return derive.NewTemporaryError(fmt.Errorf("failed to check if on finalizing L1 chain, could not fetch block %d: %w", finalizedDerivedFrom.Number, err))
+			return false, err
+		}
+		if reason != "" {
+			a.reason = reason
+			return false, nil
+		}
+	}
+	if !a.advances() {
+		if a.refused != "" {
+			a.reason = a.refused
+		} else {
+			a.reason = fi.classifyNoAdvance()
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// maxAdvanceGate limits how far the finalized L2 head advances at a time, see WithMaxAdvance.
+type maxAdvanceGate struct {
+	noopGate
+	fi *Finalizer
+	// maxBlocks is the maximum number of L2 blocks to advance the finalized L2 head by at a time,
+	// if there is a known intermediate L2 block to finalize. Disabled if 0.
+	maxBlocks uint64
+}
+
+// accept stays within the finalization budget, unless there is no known intermediate block to finalize.
+func (g *maxAdvanceGate) accept(a *finalizeAttempt, r finalityRelation, found bool) (bool, FinalizeReason) {
+	if g.maxBlocks != 0 && found && r.Derived.Number > a.prevFinalizedL2.Number+g.maxBlocks {
+		a.limited = true
+		return false, ""
+	}
+	return true, ""
+}
+
+func (g *maxAdvanceGate) check(ctx context.Context, a *finalizeAttempt) (FinalizeReason, error) {
+	if a.limited {
+		// Continue finalizing the remaining blocks on the next derivation step, without waiting for the finalityDelay.
+		g.fi.log.Info("limiting finalized L2 head advancement", "prev_finalized_l2", a.prevFinalizedL2,
+			"finalized_l2", a.finalizedL2, "max_advance", g.maxBlocks)
+		g.fi.triedFinalizeAt = 0
+		a.reason = ReasonLimited
+	}
+	return "", nil
+}
+
+// canonicalGate sanity checks the L1 blocks the L2 block to finalize depends on are canonical, see checkCanonical.
+type canonicalGate struct {
+	noopGate
+	fi *Finalizer
+}
+
+func (g *canonicalGate) check(ctx context.Context, a *finalizeAttempt) (FinalizeReason, error) {
+	fi := g.fi
+	err := fi.checkCanonical(ctx, a.derivedFrom)
+	if errors.Is(err, ErrL1HistoryPending) {
+		// The L1 source did not make the L1 blocks to check available yet, e.g. a light client that is still
+		// backfilling: check again on the next derivation step, rather than failing the attempt.
+		fi.counters.ChecksDeferred += 1
+		fi.log.Debug("deferring finalization until the L1 source serves the L1 blocks to check",
+			"finalized_l1", fi.finalizedL1, "derived_from", a.derivedFrom, "err", err)
+		fi.triedFinalizeAt = 0
+		return ReasonL1Pending, nil
+	}
+	return "", err
+}
+
+// canonicalCheckTimeout bounds the L1 fetches of the sanity checks of a finalization attempt,
+// so a slow L1 endpoint cannot stall derivation indefinitely.
+const canonicalCheckTimeout = 10 * time.Second
+
+// errSignalNotCanonical cancels the check of the derived-from L1 block, once the signal is known not to be canonical.
+var errSignalNotCanonical = errors.New("finality signal is not canonical")
+
+// checkCanonical sanity checks that the finality signal, and the L1 block the finalized L2 blocks were derived from,
+// are canonical, unless the signal is trusted. Both L1 blocks are fetched concurrently.
+func (fi *Finalizer) checkCanonical(ctx context.Context, finalizedDerivedFrom eth.BlockID) error {
+	if fi.trustSignal {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, canonicalCheckTimeout)
+	defer cancel()
+	g, gctx := errgroup.WithContext(ctx)
+	// Sanity check the finality signal of L1.
+	// Even though the signal is trusted and we do the below check also,
+	// the signal itself has to be canonical to proceed.
+	var signalRef, derivedRef eth.L1BlockRef
+	var signalOk, derivedOk bool
+	var signalErr, derivedErr error
+	var signalPanic, derivedPanic any
+	g.Go(recoverCheck(&signalPanic, func() error {
+		signalRef, signalOk, signalErr = fi.verifyCanonicalL1(gctx, fi.finalizedL1.ID())
+		if signalErr == nil && !signalOk {
+			return errSignalNotCanonical
+		}
+		return signalErr
+	}))
+	// Sanity check we are indeed on the finalizing chain, and not stuck on something else.
+	// We assume that the block-by-number query is consistent with the previously received finalized chain signal
+	g.Go(recoverCheck(&derivedPanic, func() error {
+		derivedRef, derivedOk, derivedErr = fi.verifyCanonicalL1(gctx, finalizedDerivedFrom)
+		return nil // the result of the signal check takes precedence, so it is not canceled
+	}))
+	_ = g.Wait() // the results of the individual checks are inspected below, in order of precedence
+	// Panics of the checks are raised again on this goroutine, so the panic recovery of the Finalizer applies.
+	if signalPanic != nil {
+		panic(signalPanic)
+	}
+	if derivedPanic != nil {
+		panic(derivedPanic)
+	}
+	if signalErr != nil {
+		return derive.NewTemporaryError(&ErrL1Unavailable{Number: fi.finalizedL1.Number, Err: signalErr})
+	}
+	if !signalOk {
+		return derive.NewResetError(&ErrSignalNotCanonical{Signal: fi.finalizedL1, Canonical: signalRef})
+	}
+	if derivedErr != nil {
+		return derive.NewTemporaryError(&ErrL1Unavailable{Number: finalizedDerivedFrom.Number, Err: derivedErr})
+	}
+	if !derivedOk {
+		return derive.NewResetError(&ErrDerivedFromNotCanonical{
+			DerivedFrom: finalizedDerivedFrom, Canonical: derivedRef, Signal: fi.finalizedL1})
+	}
+	fi.verifiedL1[fi.finalizedL1.Number] = fi.finalizedL1.Hash
+	fi.verifiedL1[finalizedDerivedFrom.Number] = finalizedDerivedFrom.Hash
+	return nil
+}
+
+// recoverCheck recovers a panic of a concurrent sanity check into recovered, and cancels the other check.
+func recoverCheck(recovered *any, check func() error) func() error {
+	return func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				*recovered = r
+				err = fmt.Errorf("sanity check panicked: %v", r)
+			}
+		}()
+		return check()
+	}
+}
+
+// verifyCanonicalL1 checks if the given L1 block is canonical, and returns the canonical block if it is not.
+// Blocks that were already verified by a previous attempt since the last finality signal are not fetched again.
+func (fi *Finalizer) verifyCanonicalL1(ctx context.Context, id eth.BlockID) (canonical eth.L1BlockRef, ok bool, err error) {
+	if hash, verified := fi.verifiedL1[id.Number]; verified && hash == id.Hash {
+		return eth.L1BlockRef{}, true, nil
+	}
+	ref, err := fi.l1Fetcher.L1BlockRefByNumber(ctx, id.Number)
+	if err != nil {
+		return eth.L1BlockRef{}, false, err
+	}
+	return ref, ref.Hash == id.Hash, nil
+}
+
+// acceptSignal weighs and validates a L1 finality signal, and remembers it if it is new.
+// The staleness of the signal is only checked if checkStale is set.
+// It returns false if the signal was rejected. The lock must be held.
+func (fi *Finalizer) acceptSignal(ctx context.Context, l1Origin eth.L1BlockRef, source string, checkStale bool) bool {
+	if fi.halted {
+		fi.counters.SignalsRejectedHalted += 1
+		fi.log.Warn("ignoring L1 finalized block signal, finalization is halted", "signaled_finalized_l1", l1Origin)
+		return false
+	}
+	if err := fi.verifyL1Chain(ctx); err != nil {
+		var wrongChain *ErrWrongL1Chain
+		if errors.As(err, &wrongChain) {
+			fi.counters.SignalsRejectedWrongChain += 1
+			fi.log.Error("ignoring L1 finalized block signal from the wrong L1 chain! Is the L1 endpoint misconfigured?",
+				"signaled_finalized_l1", l1Origin, "err", err)
+			return false
+		}
+		// the L1 chain is verified again with the next signal, rather than delaying finality on it
+		fi.log.Warn("failed to verify the L1 chain of the L1 source", "err", err)
+	}
+	l1Origin, prov, ok := fi.weighSignal(l1Origin, source)
+	if !ok {
+		return false
+	}
+	prevFinalizedL1 := fi.finalizedL1
+	if l1Origin.Number < fi.finalizedL1.Number {
+		fi.counters.SignalsRejectedOld += 1
+		fi.log.Error("ignoring old L1 finalized block signal! Is the L1 provider corrupted?",
+			"prev_finalized_l1", prevFinalizedL1, "signaled_finalized_l1", l1Origin)
+		return false
+	}
+
+	if fi.finalizedL1 != l1Origin {
+		if checkStale && fi.isStale(l1Origin) {
+			fi.counters.SignalsRejectedStale += 1
+			fi.metrics.RecordFinalityStaleSignal()
+			return false
+		}
+		if err := fi.checkAncestry(ctx, prevFinalizedL1, l1Origin); err != nil {
+			fi.log.Error("ignoring L1 finalized block signal that does not build on the previous signal! Is the L1 provider corrupted?",
+				"prev_finalized_l1", prevFinalizedL1, "signaled_finalized_l1", l1Origin, "err", err)
+			return false
 		}
-		if derivedRef.Hash != finalizedDerivedFrom.Hash {
-			return derive.NewResetError(fmt.Errorf("need to reset, we are on %s, not on the finalizing L1 chain %s (towards %s)",
-				finalizedDerivedFrom, derivedRef, fi.finalizedL1))
+
+		// reset triedFinalizeAt, so we give finalization a shot with the new signal
+		fi.triedFinalizeAt = 0
+		// verify the canonical chain again, against the new signal, unless it was prepared for the signal already
+		clear(fi.verifiedL1)
+		if l1Origin == fi.preparedFor {
+			maps.Copy(fi.verifiedL1, fi.preparedL1)
 		}
 
-		fi.ec.SetFinalizedHead(finalizedL2)
+		// remember the L1 finalization signal
+		fi.finalizedL1 = l1Origin
+		fi.signalSinceAttempt = true
+		fi.signalProvenance = prov
+		fi.detectL1Reorg(l1Origin)
+		fi.observeCadence(prevFinalizedL1, l1Origin)
+		fi.reportProgress(true)
+		fi.reportCapacity()
+	}
+	return true
+}
+
+// isStale checks if the L1 block of the finality signal is older than the maximum signal age, relative to the L1 head.
+func (fi *Finalizer) isStale(l1Origin eth.L1BlockRef) bool {
+	if fi.maxSignalAge == 0 {
+		return false
+	}
+	head := fi.l1Head()
+	if head == (eth.L1BlockRef{}) || head.Time <= l1Origin.Time {
+		return false
+	}
+	if age := time.Duration(head.Time-l1Origin.Time) * time.Second; age > fi.maxSignalAge {
+		fi.log.Warn("ignoring stale L1 finalized block signal! Is the L1 provider corrupted?",
+			"signaled_finalized_l1", l1Origin, "l1_head", head, "age", age, "max_age", fi.maxSignalAge)
+		return true
+	}
+	return false
+}
+
+// tryApplyPending retries applying the pending finalized head, if there is any, and if the backoff has expired.
+// It returns true if there was a pending finalized head.
+func (fi *Finalizer) tryApplyPending(ctx context.Context) (pending bool, err error) {
+	if fi.pendingFinalized == (eth.L2BlockRef{}) {
+		return false, nil
+	}
+	if fi.clock.Now().Before(fi.pendingRetryAt) {
+		return true, nil // back off, and wait for a later L1 block to retry
+	}
+	return true, fi.applyFinalized(ctx, fi.pendingFinalized)
+}
+
+// applyFinalized sets the finalized head of the engine, and applies it with a forkchoice update.
+// If the engine fails to apply it, the finalized head is kept as pending, and retried with backoff.
+func (fi *Finalizer) applyFinalized(ctx context.Context, finalizedL2 eth.L2BlockRef) error {
+	return fi.applyFinalizedFrom(ctx, finalizedL2, AuditSourceFinalize)
+}
+
+// applyFinalizedFrom is applyFinalized, recording the given source of the update in the audit trail.
+func (fi *Finalizer) applyFinalizedFrom(ctx context.Context, finalizedL2 eth.L2BlockRef, source string) error {
+	prev := fi.engineFinalized()
+	if fi.pendingFinalized != (eth.L2BlockRef{}) {
+		prev = fi.pendingFrom
+	}
+	if err := fi.setFinalizedHead(ctx, finalizedL2, source); err != nil {
+		return err
+	}
+	err := fi.updateEngine(ctx, finalizedL2)
+	if err != nil {
+		err = derive.NewTemporaryError(err)
+	} else {
+		err = fi.checkAck(finalizedL2)
 	}
+	if err != nil {
+		delay := fi.retryStrategy.Duration(fi.pendingAttempts)
+		fi.pendingFinalized = finalizedL2
+		fi.pendingFrom = prev
+		fi.pendingAttempts += 1
+		fi.pendingRetryAt = fi.clock.Now().Add(delay)
+		fi.log.Warn("failed to apply finalized L2 head to engine, retrying later",
+			"finalized_l2", finalizedL2, "attempts", fi.pendingAttempts, "retry_in", delay, "err", err)
+		return fmt.Errorf("failed to apply finalized L2 head %s: %w", finalizedL2, err)
+	}
+	fi.pendingFinalized = eth.L2BlockRef{}
+	fi.pendingFrom = eth.L2BlockRef{}
+	fi.pendingAttempts = 0
+	fi.pendingRetryAt = time.Time{}
+	fi.lastAppliedAt = fi.clock.Now()
+	fi.observeSLO(prev, finalizedL2)
+	fi.emitFinalized(prev, finalizedL2)
 	return nil
 }
 
@@ -203,26 +983,66 @@ func (fi *Finalizer) PostProcessSafeL2(l2Safe eth.L2BlockRef, derivedFrom eth.L1
 	fi.mu.Lock()
 	defer fi.mu.Unlock()
 	// remember the last L2 block that we fully derived from the given finality data
-	if len(fi.finalityData) == 0 || fi.finalityData[len(fi.finalityData)-1].L1Block.Number < derivedFrom.Number {
-		// prune finality data if necessary, before appending any data.
-		if uint64(len(fi.finalityData)) >= fi.finalityLookback {
-			fi.finalityData = append(fi.finalityData[:0], fi.finalityData[1:fi.finalityLookback]...)
-		}
-		// append entry for new L1 block
-		fi.finalityData = append(fi.finalityData, FinalityData{
-			L2Block: l2Safe,
-			L1Block: derivedFrom.ID(),
-		})
-		last := &fi.finalityData[len(fi.finalityData)-1]
-		fi.log.Debug("extended finality-data", "last_l1", last.L1Block, "last_l2", last.L2Block)
-	} else {
-		// if it's a new L2 block that was derived from the same latest L1 block, then just update the entry
-		last := &fi.finalityData[len(fi.finalityData)-1]
-		if last.L2Block != l2Safe { // avoid logging if there are no changes
-			last.L2Block = l2Safe
-			fi.log.Debug("updated finality-data", "last_l1", last.L1Block, "last_l2", last.L2Block)
+	n := len(fi.finalityData)
+	var prev, oldest finalityRelation
+	if n > 0 {
+		prev = fi.finalityData[n-1]
+		oldest = fi.finalityData[0]
+		fi.checkSafeRegression(prev, l2Safe, derivedFrom)
+	}
+	fi.resolveForkConflict(l2Safe)
+	fi.trackDerivationProgress(l2Safe)
+	fi.adjustLookback()
+	result := fi.trackFinalityData(l2Safe, fi.newL1Source(derivedFrom))
+	if result != core.Unchanged {
+		fi.trackSpan(l2Safe, derivedFrom)
+	}
+	if result != core.Unchanged && result != core.Ignored {
+		fi.emitSafe(l2Safe, derivedFrom.ID())
+	}
+	if (result == core.Appended || result == core.Inserted) && len(fi.finalityData) == n {
+		fi.counters.EntriesPruned += 1
+		fi.checkPruned(oldest)
+	}
+	if result == core.Appended {
+		fi.applyPruning()
+		fi.compactFinalityData()
+	}
+	fi.reportCapacity()
+	if result == core.Appended && n > 0 {
+		fi.detectGap(prev)
+	}
+	// Safe head updates may happen at a high L2 block rate, so they are only logged once per L1 block, as a summary.
+	switch result {
+	case core.Appended:
+		if n > 0 {
+			fi.dataLog.Debug("extended finality-data", "prev_l1", prev.Source.ID, "prev_l2", prev.Derived,
+				"prev_updates", fi.originUpdates, "last_l1", derivedFrom.ID(), "last_l2", l2Safe)
+		} else {
+			fi.dataLog.Debug("extended finality-data", "last_l1", derivedFrom.ID(), "last_l2", l2Safe)
+		}
+		fi.originUpdates = 0
+	case core.Updated:
+		fi.counters.EntriesUpdated += 1
+		fi.originUpdates += 1
+	case core.Inserted:
+		// the L1 block is older than the latest buffered L1 block, e.g. when older L1 origins are replayed.
+		fi.dataLog.Debug("inserted older finality-data", "l1", derivedFrom.ID(), "l2", l2Safe)
+	}
+}
+
+// trackFinalityData tracks that the L2 block was fully derived from the L1 source into finalityData.
+// The lock must be held.
+func (fi *Finalizer) trackFinalityData(l2 eth.L2BlockRef, source l1Source) core.TrackResult {
+	if fi.finalityArena == nil {
+		result := fi.finalityData.Track(fi.finalityLookback, l2, source)
+		if result == core.Updated || result == core.Inserted {
+			fi.dropRunsAt(source.ID.Number)
 		}
+		fi.dropUnanchoredRuns()
+		return result
 	}
+	return fi.finalityArena.Track(&fi.finalityData, fi.finalityLookback, l2, source)
 }
 
 // Reset clears the recent history of safe-L2 blocks used for finalization,
@@ -230,7 +1050,26 @@ func (fi *Finalizer) PostProcessSafeL2(l2Safe eth.L2BlockRef, derivedFrom eth.L1
 func (fi *Finalizer) Reset() {
 	fi.mu.Lock()
 	defer fi.mu.Unlock()
-	fi.finalityData = fi.finalityData[:0]
+	defer fi.publishStatus()
+	fi.finalityData.Reset()
+	clear(fi.runs)
+	fi.compressedEntries = 0
+	fi.gaps = fi.gaps[:0]
+	fi.spans = fi.spans[:0]
+	fi.lastSafeL2 = eth.L2BlockRef{}
+	fi.originUpdates = 0
 	fi.triedFinalizeAt = 0
+	clear(fi.verifiedL1)
+	// the engine is reset to a new finalized head, any pending finalized head may be reorged out
+	fi.pendingFinalized = eth.L2BlockRef{}
+	fi.pendingFrom = eth.L2BlockRef{}
+	fi.pendingAttempts = 0
+	fi.pendingRetryAt = time.Time{}
+	fi.reorg = nil
+	if fi.forkConflict != (eth.BlockID{}) {
+		// the engine is reset to the canonical chain
+		fi.log.Info("resuming finalization after a reset, frozen for an L2 fork conflict", "conflict", fi.forkConflict)
+		fi.forkConflict = eth.BlockID{}
+	}
 	// no need to reset finalizedL1, it's finalized after all
 }
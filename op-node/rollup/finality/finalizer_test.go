@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"math/rand" // nosemgrep
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -13,24 +15,40 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/testutil"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
 
 type fakeEngine struct {
 	finalized eth.L2BlockRef
+	// fcuErr is returned by SetFinalizedHead, to simulate an unavailable engine
+	fcuErr error
+	// applied is the finalized head last applied with a successful forkchoice update
+	applied eth.L2BlockRef
 }
 
 func (f *fakeEngine) Finalized() eth.L2BlockRef {
 	return f.finalized
 }
 
-func (f *fakeEngine) SetFinalizedHead(ref eth.L2BlockRef) {
+func (f *fakeEngine) SetFinalizedHead(ctx context.Context, ref eth.L2BlockRef) error {
 	f.finalized = ref
+	if f.fcuErr != nil {
+		return f.fcuErr
+	}
+	f.applied = ref
+	return nil
 }
 
-var _ FinalizerEngine = (*fakeEngine)(nil)
+var (
+	_ FinalizerEngine      = (*fakeEngine)(nil)
+	_ FinalizerEngine      = (*testutil.Engine)(nil)
+	_ FinalizerL1Interface = (*testutil.L1)(nil)
+)
 
 func TestEngineQueue_Finalize(t *testing.T) {
 	rng := rand.New(rand.NewSource(1234))
@@ -204,7 +222,7 @@ func TestEngineQueue_Finalize(t *testing.T) {
 		l1F.ExpectL1BlockRefByNumber(refD.Number, refD, nil)
 
 		ec := &fakeEngine{}
-		ec.SetFinalizedHead(refA1)
+		ec.finalized = refA1
 
 		fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
 
@@ -226,12 +244,14 @@ func TestEngineQueue_Finalize(t *testing.T) {
 		logger := testlog.Logger(t, log.LevelInfo)
 		l1F := &testutils.MockL1Source{}
 		defer l1F.AssertExpectations(t)
+		// the signal and what was derived from are checked concurrently, so both fetches fail
+		l1F.ExpectL1BlockRefByNumber(refD.Number, refD, errors.New("fake error"))
 		l1F.ExpectL1BlockRefByNumber(refD.Number, refD, errors.New("fake error"))
 		l1F.ExpectL1BlockRefByNumber(refD.Number, refD, nil) // to check finality signal
 		l1F.ExpectL1BlockRefByNumber(refD.Number, refD, nil) // to check what was derived from (same in this case)
 
 		ec := &fakeEngine{}
-		ec.SetFinalizedHead(refA1)
+		ec.finalized = refA1
 
 		fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
 
@@ -265,7 +285,7 @@ func TestEngineQueue_Finalize(t *testing.T) {
 		l1F.ExpectL1BlockRefByNumber(refH.Number, refH, nil)
 
 		ec := &fakeEngine{}
-		ec.SetFinalizedHead(refA1)
+		ec.finalized = refA1
 
 		fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
 
@@ -296,6 +316,42 @@ func TestEngineQueue_Finalize(t *testing.T) {
 		require.Equal(t, refF1, ec.Finalized(), "F1 should be finalized now")
 	})
 
+	// Test that a finalized head which the engine fails to apply is kept, and retried after backing off.
+	t.Run("engine-retry", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		l1F := &testutils.MockL1Source{}
+		defer l1F.AssertExpectations(t)
+		l1F.ExpectL1BlockRefByNumber(refD.Number, refD, nil)
+		l1F.ExpectL1BlockRefByNumber(refD.Number, refD, nil)
+
+		ec := &fakeEngine{}
+		ec.finalized = refA1
+		clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+
+		fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec,
+			WithClock(clk), WithRetryStrategy(retry.Fixed(10*time.Second)))
+
+		fi.PostProcessSafeL2(refC1, refD)
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), refD))
+
+		// the engine is unavailable when the finality signal is processed
+		ec.fcuErr = errors.New("engine unavailable")
+		fi.Finalize(context.Background(), refD)
+		require.Equal(t, eth.L2BlockRef{}, ec.applied, "engine did not apply the finalized head")
+		require.Equal(t, refC1, fi.pendingFinalized, "keep the finalized head as pending")
+
+		// the engine recovers, but we are still backing off
+		ec.fcuErr = nil
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), refE))
+		require.Equal(t, eth.L2BlockRef{}, ec.applied, "do not retry before the backoff expires")
+
+		// after the backoff the pending finalized head is applied, without new L1 lookups
+		clk.AdvanceTime(10 * time.Second)
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), refF))
+		require.Equal(t, refC1, ec.applied, "pending finalized head is applied after the backoff")
+		require.Equal(t, eth.L2BlockRef{}, fi.pendingFinalized)
+	})
+
 	// In this test the finality signal is for a block more than
 	// 1 L1 block later than what the L2 data was included in.
 	t.Run("older-data", func(t *testing.T) {
@@ -306,7 +362,7 @@ func TestEngineQueue_Finalize(t *testing.T) {
 		l1F.ExpectL1BlockRefByNumber(refC.Number, refC, nil) // check what we derived the L2 block from
 
 		ec := &fakeEngine{}
-		ec.SetFinalizedHead(refA1)
+		ec.finalized = refA1
 
 		fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
 
@@ -336,7 +392,7 @@ func TestEngineQueue_Finalize(t *testing.T) {
 		l1F.ExpectL1BlockRefByNumber(refE.Number, refE, nil) // post-reorg
 
 		ec := &fakeEngine{}
-		ec.SetFinalizedHead(refA1)
+		ec.finalized = refA1
 
 		fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
 
@@ -378,7 +434,12 @@ func TestEngineQueue_Finalize(t *testing.T) {
 		fi.Finalize(context.Background(), refF)
 		require.Equal(t, refA1, ec.Finalized(), "cannot verify refC0Alt and refC1Alt, and refB1 is older and not checked")
 		// And process DAlt, still stuck on old chain.
-		require.ErrorIs(t, derive.ErrReset, fi.OnDerivationL1End(context.Background(), refDAlt))
+		err := fi.OnDerivationL1End(context.Background(), refDAlt)
+		require.ErrorIs(t, err, derive.ErrReset)
+		var notCanonical *ErrDerivedFromNotCanonical
+		require.ErrorAs(t, err, &notCanonical)
+		require.Equal(t, refDAlt.ID(), notCanonical.DerivedFrom, "C1Alt was derived from the non-canonical DAlt")
+		require.Equal(t, refF, notCanonical.Signal)
 		require.Equal(t, refA1, ec.Finalized(), "no new finalized L2 blocks after early finality signal with stale chain")
 		require.Equal(t, refF, fi.FinalizedL1(), "remember the new finality signal for later however")
 		// Now reset, because of the reset error
@@ -398,3 +459,364 @@ func TestEngineQueue_Finalize(t *testing.T) {
 		require.Equal(t, refC0, ec.Finalized())
 	})
 }
+
+func TestFinalizerMaxAdvance(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 5)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithMaxAdvance(3))
+	for i := 0; i < 5; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	// each L1 block has 2 L2 blocks, so only one L1 block worth of L2 blocks fits in the budget
+	l1F.ExpectL1BlockRefByNumber(chain.l1[4].Number, chain.l1[4], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	fi.Finalize(context.Background(), chain.l1[4])
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+
+	// the remainder is finalized with subsequent derivation steps, without waiting for the finality delay,
+	// and without refetching the already verified signal
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[4]))
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+
+	l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[4]))
+	require.Equal(t, chain.l2[3][1], ec.Finalized())
+
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[4]))
+	require.Equal(t, chain.l2[4][1], ec.Finalized())
+
+	// caught up, no further attempts until the finality delay passed
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[4]))
+}
+
+func TestFinalizerTrustSignal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	// no L1 block refs are fetched for the sanity checks
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithTrustSignal())
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+}
+
+func TestFinalizerVerifiedL1Cache(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+
+	// a repeated attempt with the same signal does not refetch the verified blocks
+	fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+
+	// a new signal is verified again
+	fi.PostProcessSafeL2(chain.l2[3][1], chain.l1[3])
+	l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+	fi.Finalize(context.Background(), chain.l1[3])
+	require.Equal(t, chain.l2[3][1], ec.Finalized())
+}
+
+// barrierL1 serves L1 blocks by number only once two fetches are in flight concurrently.
+// Fetches of the stuck block do not return until canceled.
+type barrierL1 struct {
+	FinalizerL1Interface
+	refs   map[uint64]eth.L1BlockRef
+	stuck  uint64
+	mu     sync.Mutex
+	calls  int
+	both   chan struct{}
+	cancel error
+}
+
+func (b *barrierL1) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	b.mu.Lock()
+	b.calls += 1
+	if b.calls == 2 {
+		close(b.both)
+	}
+	b.mu.Unlock()
+	if num == b.stuck {
+		<-ctx.Done()
+		b.cancel = ctx.Err()
+		return eth.L1BlockRef{}, ctx.Err()
+	}
+	select {
+	case <-b.both:
+		return b.refs[num], nil
+	case <-time.After(time.Second):
+		return eth.L1BlockRef{}, errors.New("not fetched concurrently")
+	}
+}
+
+func TestFinalizerConcurrentChecks(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	newFinalizer := func(l1 *barrierL1) (*Finalizer, *fakeEngine) {
+		ec := &fakeEngine{}
+		ec.finalized = chain.l2[0][1]
+		fi := NewFinalizer(logger, &rollup.Config{}, l1, ec)
+		fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+		return fi, ec
+	}
+
+	t.Run("canonical", func(t *testing.T) {
+		l1 := &barrierL1{refs: map[uint64]eth.L1BlockRef{chain.l1[1].Number: chain.l1[1], chain.l1[2].Number: chain.l1[2]}, both: make(chan struct{})}
+		fi, ec := newFinalizer(l1)
+		fi.Finalize(context.Background(), chain.l1[2])
+		require.Equal(t, chain.l2[1][1], ec.Finalized())
+		require.Equal(t, 2, l1.calls)
+	})
+
+	t.Run("signal-not-canonical", func(t *testing.T) {
+		alt := chain.l1[2]
+		alt.Hash = testutils.RandomHash(rng)
+		l1 := &barrierL1{refs: map[uint64]eth.L1BlockRef{alt.Number: alt}, stuck: chain.l1[1].Number, both: make(chan struct{})}
+		fi, ec := newFinalizer(l1)
+		fi.finalizedL1 = chain.l1[2]
+		err := fi.tryFinalize(context.Background())
+		require.ErrorIs(t, err, derive.ErrReset)
+		var notCanonical *ErrSignalNotCanonical
+		require.ErrorAs(t, err, &notCanonical)
+		require.ErrorIs(t, l1.cancel, context.Canceled, "the check of the derived-from block is canceled")
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+	})
+}
+
+type fakeMetrics struct {
+	noopMetrics
+	staleSignals int
+	panics       int
+	unjustified  int
+	failures     map[string]int
+	catchUp      uint64
+	headroom     uint64
+	halted       bool
+	digest       common.Hash
+	stall        string
+	delay        uint64
+	finalized    eth.L2BlockRef
+	derivedFrom  eth.BlockID
+	lagBlocks    uint64
+	lagSeconds   uint64
+	durations    []time.Duration
+	exemplars    []map[string]string
+	engineCalls  []bool
+	sloBurnRates map[string]float64
+	casualtyL2   uint64
+	regressions  int
+	engineAcks   map[string]int
+}
+
+func (m *fakeMetrics) RecordFinalityStaleSignal() {
+	m.staleSignals += 1
+}
+
+func (m *fakeMetrics) RecordFinalityPanic() {
+	m.panics += 1
+}
+
+func (m *fakeMetrics) RecordFinalityUnjustifiedHead() {
+	m.unjustified += 1
+}
+
+func (m *fakeMetrics) RecordFinalityAttemptFailure(cause string) {
+	if m.failures == nil {
+		m.failures = make(map[string]int)
+	}
+	m.failures[cause] += 1
+}
+
+func (m *fakeMetrics) RecordFinalityCatchUp(remainingL1 uint64) {
+	m.catchUp = remainingL1
+}
+
+func (m *fakeMetrics) RecordFinalityLookbackHeadroom(entries uint64) {
+	m.headroom = entries
+}
+
+func (m *fakeMetrics) RecordFinalityHalted(halted bool) {
+	m.halted = halted
+}
+
+func (m *fakeMetrics) RecordFinalityStateDigest(digest common.Hash) {
+	m.digest = digest
+}
+
+func (m *fakeMetrics) RecordFinalityStall(reason string) {
+	m.stall = reason
+}
+
+func (m *fakeMetrics) RecordFinalityDelay(l1Blocks uint64) {
+	m.delay = l1Blocks
+}
+
+func (m *fakeMetrics) RecordFinalityRelation(finalizedL2 eth.L2BlockRef, derivedFrom eth.BlockID) {
+	m.finalized = finalizedL2
+	m.derivedFrom = derivedFrom
+}
+
+func (m *fakeMetrics) RecordFinalityLag(l2Blocks uint64, seconds uint64) {
+	m.lagBlocks = l2Blocks
+	m.lagSeconds = seconds
+}
+
+func (m *fakeMetrics) RecordFinalityAttemptDuration(duration time.Duration, exemplar map[string]string) {
+	m.durations = append(m.durations, duration)
+	m.exemplars = append(m.exemplars, exemplar)
+}
+
+func (m *fakeMetrics) RecordFinalityEngineCall(duration time.Duration, success bool) {
+	m.engineCalls = append(m.engineCalls, success)
+}
+
+func (m *fakeMetrics) RecordFinalityPruningCasualty(l2Blocks uint64) {
+	m.casualtyL2 += l2Blocks
+}
+
+func (m *fakeMetrics) RecordFinalitySafeRegression() {
+	m.regressions += 1
+}
+
+func (m *fakeMetrics) RecordFinalityEngineAck(status string) {
+	if m.engineAcks == nil {
+		m.engineAcks = make(map[string]int)
+	}
+	m.engineAcks[status] += 1
+}
+
+func (m *fakeMetrics) RecordFinalitySLO(window string, compliance float64, burnRate float64) {
+	if m.sloBurnRates == nil {
+		m.sloBurnRates = make(map[string]float64)
+	}
+	m.sloBurnRates[window] = burnRate
+}
+
+func TestFinalizerMaxSignalAge(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+
+	head := chain.l1[2]
+	head.Time = chain.l1[0].Time + 120
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, &fakeEngine{}, WithMetrics(m),
+		WithMaxSignalAge(time.Minute, func() eth.L1BlockRef { return head }))
+
+	fi.Finalize(context.Background(), chain.l1[0])
+	require.Equal(t, eth.L1BlockRef{}, fi.FinalizedL1(), "signal of two minutes ago is ignored")
+	require.Equal(t, 1, m.staleSignals)
+
+	head.Time = chain.l1[0].Time + 60
+	fi.Finalize(context.Background(), chain.l1[0])
+	require.Equal(t, chain.l1[0], fi.FinalizedL1(), "signal within the max age is accepted")
+	require.Equal(t, 1, m.staleSignals)
+}
+
+func TestFinalizerOutOfOrderSafeL2(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 5)
+	logger := testlog.Logger(t, log.LevelInfo)
+	fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{})
+	fi.finalityLookback = 3
+
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	fi.PostProcessSafeL2(chain.l2[3][1], chain.l1[3])
+	// older L1 origins are merged back into the buffer, in order
+	fi.PostProcessSafeL2(chain.l2[2][0], chain.l1[2])
+	fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+	require.Equal(t, []FinalityData{
+		{L2Block: chain.l2[1][1], L1Block: chain.l1[1].ID(), L1Parent: chain.l1[1].ParentHash, L1Time: chain.l1[1].Time},
+		{L2Block: chain.l2[2][1], L1Block: chain.l1[2].ID(), L1Parent: chain.l1[2].ParentHash, L1Time: chain.l1[2].Time},
+		{L2Block: chain.l2[3][1], L1Block: chain.l1[3].ID(), L1Parent: chain.l1[3].ParentHash, L1Time: chain.l1[3].Time},
+	}, fi.Snapshot().FinalityData)
+
+	// an older L1 origin than anything retained in the full buffer is ignored
+	fi.PostProcessSafeL2(chain.l2[0][1], chain.l1[0])
+	require.Len(t, fi.finalityData, 3)
+	require.Equal(t, chain.l1[1].ID(), fi.finalityData[0].Source.ID)
+
+	// an older L1 origin that is missing from the full buffer prunes the oldest entry
+	fi.finalityData = append(fi.finalityData[:1], fi.finalityData[2:]...)
+	fi.PostProcessSafeL2(chain.l2[4][1], chain.l1[4])
+	fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+	require.Equal(t, []FinalityData{
+		{L2Block: chain.l2[2][1], L1Block: chain.l1[2].ID(), L1Parent: chain.l1[2].ParentHash, L1Time: chain.l1[2].Time},
+		{L2Block: chain.l2[3][1], L1Block: chain.l1[3].ID(), L1Parent: chain.l1[3].ParentHash, L1Time: chain.l1[3].Time},
+		{L2Block: chain.l2[4][1], L1Block: chain.l1[4].ID(), L1Parent: chain.l1[4].ParentHash, L1Time: chain.l1[4].Time},
+	}, fi.Snapshot().FinalityData)
+}
+
+func TestFinalizerExtraConfirmations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithExtraConfirmations(2))
+	require.Equal(t, uint64(2), fi.Status().ExtraConfirmations)
+
+	for i := 1; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	// the finalized L1 block is not yet 2 blocks beyond any buffered L1 block
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[0][1], ec.Finalized())
+	require.Equal(t, ReasonSignalOlderThanBuffer, fi.Status().LastReason)
+
+	// only the L2 blocks derived from 2 blocks below the finalized L1 block are finalized
+	l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	fi.Finalize(context.Background(), chain.l1[3])
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+}
+
+func TestFinalizerL1SlotsPerEpoch(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{})
+	require.Equal(t, uint64(defaultFinalityLookback), fi.finalityLookback, "mainnet lookback by default")
+
+	fi = NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{}, WithL1SlotsPerEpoch(16))
+	require.Equal(t, uint64(4*16+1), fi.finalityLookback)
+	require.Equal(t, 4*16+1, cap(fi.finalityData))
+
+	// the plasma windows take priority, if they are longer
+	cfg := &rollup.Config{
+		PlasmaConfig: &rollup.PlasmaConfig{DAChallengeWindow: 90, DAResolveWindow: 90},
+	}
+	require.Equal(t, uint64(181), calcFinalityLookbackFor(cfg, l1FinalityLookback(16)))
+	require.Equal(t, uint64(4*64+1), calcFinalityLookbackFor(cfg, l1FinalityLookback(64)))
+}
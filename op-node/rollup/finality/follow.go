@@ -0,0 +1,130 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FollowSource provides the sync status, including the finalized L2 head, of a primary rollup node.
+type FollowSource interface {
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+// FollowL2 is used to verify the finalized L2 head of the primary rollup node against the local L2 chain.
+type FollowL2 interface {
+	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+}
+
+// FollowFinalizer is an alternative to the regular Finalizer, for read-replica nodes:
+// instead of determining the finalized L2 head from L1 finality, it follows the finalized L2 head of a primary rollup node.
+// The finalized L2 head of the primary is only applied once it is part of the local safe chain, with a matching block hash.
+type FollowFinalizer struct {
+	*Finalizer
+
+	primary FollowSource
+	l2      FollowL2
+
+	// safeL2 is the latest local safe L2 head. The finalized L2 head never moves beyond it.
+	safeL2 eth.L2BlockRef
+}
+
+func NewFollowFinalizer(log log.Logger, cfg *rollup.Config, ec FinalizerEngine,
+	primary FollowSource, l2 FollowL2, opts ...FinalizerOption) *FollowFinalizer {
+	fi := &FollowFinalizer{
+		Finalizer: NewFinalizer(log, cfg, nil, ec, opts...),
+		primary:   primary,
+		l2:        l2,
+	}
+	fi.replay = fi.Finalize
+	return fi
+}
+
+// Finalize is triggered by the local L1 finality signal, but only uses it as cue to follow the primary rollup node.
+func (fi *FollowFinalizer) Finalize(ctx context.Context, l1Origin eth.L1BlockRef) {
+	fi.FinalizeOutcome(ctx, l1Origin)
+}
+
+// FinalizeOutcome follows the primary rollup node, like Finalize, and returns the outcome.
+// A primary that cannot be reached or verified is retried with the next L1 finality signal.
+func (fi *FollowFinalizer) FinalizeOutcome(ctx context.Context, l1Origin eth.L1BlockRef) FinalityOutcome {
+	fi.mu.Lock()
+	deferred := fi.deferSignal(l1Origin)
+	fi.mu.Unlock()
+	if deferred {
+		return FinalityOutcome{}
+	}
+	status, err := fi.primary.SyncStatus(ctx)
+	if err != nil {
+		fi.log.Warn("failed to fetch finalized L2 head from primary rollup node", "err", err)
+		return FinalityOutcome{Action: ActionRetry, Err: err}
+	}
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	target := status.FinalizedL2
+	if target.Number <= fi.engineFinalized().Number {
+		return FinalityOutcome{} // nothing new to finalize
+	}
+	if target.Number > fi.safeL2.Number {
+		fi.log.Debug("finalized L2 head of primary is not locally safe yet", "primary_finalized", target, "safe", fi.safeL2)
+		return FinalityOutcome{}
+	}
+	local, err := fi.l2.L2BlockRefByNumber(ctx, target.Number)
+	if err != nil {
+		fi.log.Warn("failed to fetch local L2 block to verify finalized L2 head of primary", "primary_finalized", target, "err", err)
+		return FinalityOutcome{Action: ActionRetry, Err: err}
+	}
+	if local.Hash != target.Hash {
+		fi.log.Error("finalized L2 head of primary rollup node does not match the local chain",
+			"primary_finalized", target, "local", local)
+		return FinalityOutcome{Action: ActionRetry, Err: fmt.Errorf("finalized L2 head %s of primary does not match local block %s", target, local)}
+	}
+	fi.finalizedL1 = status.CurrentL1Finalized
+	outcome := OutcomeOf(fi.guard(func() error { return fi.applyFinalized(ctx, local) }))
+	if outcome.Err != nil {
+		fi.log.Warn("failed to apply finalized L2 head of primary rollup node", "action", outcome.Action, "err", outcome.Err)
+	}
+	return outcome
+}
+
+// OnDerivationL1End only retries a pending finalized head, since finality is not determined from L1 in follow mode.
+func (fi *FollowFinalizer) OnDerivationL1End(ctx context.Context, derivedFrom eth.L1BlockRef) error {
+	return fi.OnDerivationL1EndOutcome(ctx, derivedFrom).AsError()
+}
+
+func (fi *FollowFinalizer) OnDerivationL1EndOutcome(ctx context.Context, derivedFrom eth.L1BlockRef) FinalityOutcome {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return OutcomeOf(fi.guard(func() error {
+		_, err := fi.tryApplyPending(ctx)
+		return err
+	}))
+}
+
+// OnL1TraversedOutcome only retries a pending finalized head, like OnDerivationL1EndOutcome.
+func (fi *FollowFinalizer) OnL1TraversedOutcome(ctx context.Context, traversed eth.L1BlockRef) FinalityOutcome {
+	return fi.OnDerivationL1EndOutcome(ctx, traversed)
+}
+
+// OnDerivationIdle is a no-op, since finality signals are applied right away in follow mode.
+func (fi *FollowFinalizer) OnDerivationIdle(ctx context.Context) error {
+	return nil
+}
+
+func (fi *FollowFinalizer) PostProcessSafeL2(l2Safe eth.L2BlockRef, derivedFrom eth.L1BlockRef) {
+	fi.mu.Lock()
+	fi.safeL2 = l2Safe
+	fi.mu.Unlock()
+	fi.Finalizer.PostProcessSafeL2(l2Safe, derivedFrom)
+}
+
+func (fi *FollowFinalizer) Reset() {
+	fi.mu.Lock()
+	fi.safeL2 = eth.L2BlockRef{}
+	fi.mu.Unlock()
+	fi.Finalizer.Reset()
+}
@@ -0,0 +1,61 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type fakeFollowSource struct {
+	status eth.SyncStatus
+}
+
+func (f *fakeFollowSource) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	status := f.status
+	return &status, nil
+}
+
+func TestFollowFinalizer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+
+	l2 := &testutils.MockL2Client{}
+	defer l2.AssertExpectations(t)
+	primary := &fakeFollowSource{}
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFollowFinalizer(logger, &rollup.Config{}, ec, primary, l2)
+
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+
+	// the finalized L2 head of the primary is not locally safe yet
+	primary.status.FinalizedL2 = chain.l2[2][1]
+	primary.status.CurrentL1Finalized = chain.l1[2]
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[0][1], ec.Finalized())
+
+	// the primary is on a different chain than the local node
+	fi.PostProcessSafeL2(chain.l2[3][1], chain.l1[3])
+	l2.ExpectL2BlockRefByNumber(chain.l2[2][1].Number, testutils.RandomL2BlockRef(rng), nil)
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[0][1], ec.Finalized(), "mismatching finalized L2 head is not applied")
+
+	// the finalized L2 head of the primary matches the local chain
+	l2.ExpectL2BlockRefByNumber(chain.l2[2][1].Number, chain.l2[2][1], nil)
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+	require.Equal(t, chain.l1[2], fi.FinalizedL1())
+
+	// derivation does not cause any L1 lookups in follow mode
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+}
@@ -0,0 +1,40 @@
+package finality
+
+import (
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrForkConflict is returned when the finalized L2 head is not updated, because finalization is frozen
+// while the node is on an L2 fork.
+var ErrForkConflict = errors.New("finalization is frozen, the node is on an L2 fork")
+
+// OnL2ForkConflict freezes finalization, as the L2 chain of the node conflicts with the L2 chain derived from L1
+// at the given block: the node followed a minority fork of the unsafe chain. While frozen, the finalized L2 head
+// is not updated, so blocks of the fork are never marked finalized to downstream consumers.
+// Finalization resumes once the safe head is derived past the conflicting block, i.e. the engine was reorged
+// back to the canonical chain, or once the Finalizer is reset.
+func (fi *Finalizer) OnL2ForkConflict(conflict eth.BlockID) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	defer fi.publishStatus()
+	fi.counters.ForkConflicts += 1
+	if fi.forkConflict != (eth.BlockID{}) && fi.forkConflict.Number <= conflict.Number {
+		return // already frozen for an older conflict
+	}
+	fi.log.Error("L2 chain conflicts with the L2 chain derived from L1, freezing finalization until back on the canonical chain",
+		"conflict", conflict, "finalized_l2", fi.finalizedL2, "safe_l2", fi.lastSafeL2)
+	fi.forkConflict = conflict
+}
+
+// resolveForkConflict resumes finalization if the safe head was derived past the conflicting L2 block,
+// replacing it with the canonical L2 block. The lock must be held.
+func (fi *Finalizer) resolveForkConflict(l2Safe eth.L2BlockRef) {
+	if fi.forkConflict == (eth.BlockID{}) || l2Safe.Number < fi.forkConflict.Number {
+		return
+	}
+	fi.log.Info("safe head was derived past the L2 fork conflict, resuming finalization",
+		"conflict", fi.forkConflict, "safe_l2", l2Safe)
+	fi.forkConflict = eth.BlockID{}
+}
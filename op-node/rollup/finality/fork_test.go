@@ -0,0 +1,62 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/testutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestFinalizerForkConflict(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	setup := func(t *testing.T) (*Finalizer, *testutil.Engine) {
+		ec := testutil.NewEngine(chain.l2[0][1])
+		fi := NewFinalizer(testlog.Logger(t, log.LevelCrit), &rollup.Config{}, testutil.NewL1(chain.l1...), ec)
+		fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[1]))
+		return fi, ec
+	}
+
+	t.Run("resolved by derivation", func(t *testing.T) {
+		fi, ec := setup(t)
+		conflict := chain.l2[2][0].ID()
+		fi.OnL2ForkConflict(conflict)
+		status := fi.Status()
+		require.Equal(t, &conflict, status.ForkConflict)
+		require.Equal(t, uint64(1), fi.DebugBundle().Counters.ForkConflicts)
+
+		// the finalized head is frozen, even though the finality data is finalized
+		fi.Finalize(context.Background(), chain.l1[1])
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.Equal(t, ReasonForkConflict, fi.Status().LastReason)
+
+		// the engine is reorged back to the canonical chain, once the safe head is derived past the conflict
+		fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+		require.Nil(t, fi.Status().ForkConflict)
+		fi.Finalize(context.Background(), chain.l1[2])
+		ec.RequireFinalized(t, chain.l2[2][1])
+	})
+
+	t.Run("resolved by reset", func(t *testing.T) {
+		fi, ec := setup(t)
+		fi.OnL2ForkConflict(chain.l2[3][0].ID())
+		// a later conflict does not extend the freeze
+		fi.OnL2ForkConflict(chain.l2[3][1].ID())
+		require.Equal(t, chain.l2[3][0].ID(), *fi.Status().ForkConflict)
+		require.Equal(t, uint64(2), fi.DebugBundle().Counters.ForkConflicts)
+
+		fi.Reset()
+		require.Nil(t, fi.Status().ForkConflict)
+		fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+		fi.Finalize(context.Background(), chain.l1[1])
+		ec.RequireFinalized(t, chain.l2[1][1])
+	})
+}
@@ -0,0 +1,86 @@
+package finality
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// finalizeAttempt is an attempt to advance the finalized L2 head, as it passes through the gates of the Finalizer.
+type finalizeAttempt struct {
+	// prevFinalizedL2 is the finalized L2 head before the attempt.
+	prevFinalizedL2 eth.L2BlockRef
+	// finalizedL2 is the L2 block to finalize, derived from the L1 block derivedFrom.
+	// It is prevFinalizedL2 while there is no L2 block to advance the finalized L2 head to.
+	finalizedL2 eth.L2BlockRef
+	derivedFrom eth.BlockID
+	// limited is true if a candidate L2 block was refused only to limit how far the finalized L2 head advances.
+	limited bool
+	// reason is the outcome of the attempt.
+	reason FinalizeReason
+	// refused is the reason of the gate that refused the candidate L2 block the selection stopped at, if any.
+	refused FinalizeReason
+}
+
+// advances returns true if the attempt has an L2 block to advance the finalized L2 head to.
+func (a *finalizeAttempt) advances() bool {
+	return a.finalizedL2 != a.prevFinalizedL2
+}
+
+// finalityGate is a condition on the advancement of the finalized L2 head.
+// The gates of the Finalizer run in order on every attempt to finalize, in three phases:
+// prepare before the L2 block to finalize is selected, accept for every candidate L2 block while selecting it,
+// and check on the selected L2 block. A gate keeps the configuration and state it needs itself.
+// The lock of the Finalizer is held while the gates run.
+type finalityGate interface {
+	// prepare fetches what the gate decides on in this attempt.
+	prepare(ctx context.Context, a *finalizeAttempt) error
+	// accept returns true if the candidate L2 block may be finalized. found is true if an older candidate
+	// was already accepted. A refused candidate may come with the reason to report if no L2 block is finalized.
+	accept(a *finalizeAttempt, r finalityRelation, found bool) (bool, FinalizeReason)
+	// check inspects the selected L2 block to finalize. It may lower it,
+	// or return the reason to hold back the finalization until a later attempt.
+	check(ctx context.Context, a *finalizeAttempt) (FinalizeReason, error)
+}
+
+// noopGate implements the phases of a finalityGate that a gate does not take part in.
+type noopGate struct{}
+
+func (noopGate) prepare(ctx context.Context, a *finalizeAttempt) error {
+	return nil
+}
+
+func (noopGate) accept(a *finalizeAttempt, r finalityRelation, found bool) (bool, FinalizeReason) {
+	return true, ""
+}
+
+func (noopGate) check(ctx context.Context, a *finalizeAttempt) (FinalizeReason, error) {
+	return "", nil
+}
+
+// newGates returns the gates of the Finalizer, in the order they run in.
+func (fi *Finalizer) newGates() []finalityGate {
+	return []finalityGate{
+		fi.disputeGameGate,
+		&rulesGate{fi: fi},
+		fi.committeeGate,
+		fi.maxAdvanceGate,
+		&spanGate{fi: fi},
+		fi.throttleGate,
+		&canonicalGate{fi: fi},
+		fi.crossValidationGate,
+		&engineSyncGate{fi: fi},
+	}
+}
+
+// acceptCandidate runs the accept phase of the gates on a candidate L2 block to finalize,
+// and records the reason of the gate that refused it. The lock must be held.
+func (fi *Finalizer) acceptCandidate(a *finalizeAttempt, r finalityRelation, found bool) bool {
+	for _, g := range fi.gates {
+		if ok, reason := g.accept(a, r, found); !ok {
+			a.refused = reason
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// scriptedGate refuses the candidates above maxL2, and holds back or fails the check as scripted.
+type scriptedGate struct {
+	maxL2  uint64
+	refuse FinalizeReason
+	hold   FinalizeReason
+	err    error
+	// prepared and checked count the attempts the gate took part in.
+	prepared, checked int
+}
+
+func (g *scriptedGate) prepare(ctx context.Context, a *finalizeAttempt) error {
+	g.prepared += 1
+	return nil
+}
+
+func (g *scriptedGate) accept(a *finalizeAttempt, r finalityRelation, found bool) (bool, FinalizeReason) {
+	if r.Derived.Number > g.maxL2 {
+		return false, g.refuse
+	}
+	return true, ""
+}
+
+func (g *scriptedGate) check(ctx context.Context, a *finalizeAttempt) (FinalizeReason, error) {
+	g.checked += 1
+	return g.hold, g.err
+}
+
+var _ finalityGate = (*scriptedGate)(nil)
+
+func TestFinalizerGates(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	newFinalizer := func(gates ...finalityGate) (*Finalizer, *fakeEngine) {
+		ec := &fakeEngine{finalized: chain.l2[0][1]}
+		fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, ec, WithTrustSignal())
+		fi.gates = gates
+		for i := 1; i < 4; i++ {
+			fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		}
+		fi.finalizedL1 = chain.l1[3]
+		return fi, ec
+	}
+
+	t.Run("refused", func(t *testing.T) {
+		first := &scriptedGate{maxL2: chain.l2[2][1].Number, refuse: ReasonCommitteeGated}
+		second := &scriptedGate{maxL2: chain.l2[1][1].Number}
+		fi, ec := newFinalizer(first, second)
+		require.NoError(t, fi.tryFinalize(context.Background()))
+		require.Equal(t, chain.l2[1][1], ec.Finalized(), "the selection stops at the first refused candidate")
+		require.Equal(t, 1, first.checked)
+		require.Equal(t, 1, second.checked)
+
+		// the reason of the refusing gate is reported if nothing is finalized
+		first.maxL2 = chain.l2[1][1].Number
+		require.NoError(t, fi.tryFinalize(context.Background()))
+		require.Equal(t, ReasonCommitteeGated, fi.lastReason)
+		require.Equal(t, 2, first.prepared)
+		require.Equal(t, 1, first.checked, "nothing to check without an L2 block to finalize")
+	})
+
+	t.Run("held", func(t *testing.T) {
+		first := &scriptedGate{maxL2: chain.l2[3][1].Number, hold: ReasonThrottled}
+		second := &scriptedGate{maxL2: chain.l2[3][1].Number}
+		fi, ec := newFinalizer(first, second)
+		require.NoError(t, fi.tryFinalize(context.Background()))
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.Equal(t, ReasonThrottled, fi.lastReason)
+		require.Equal(t, 0, second.checked, "later gates do not run once the finalization is held back")
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		first := &scriptedGate{maxL2: chain.l2[3][1].Number, err: errors.New("gate failed")}
+		fi, ec := newFinalizer(first)
+		require.ErrorIs(t, fi.tryFinalize(context.Background()), first.err)
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+	})
+}
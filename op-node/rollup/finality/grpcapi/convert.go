@@ -0,0 +1,82 @@
+package grpcapi
+
+import (
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func blockIDToProto(id eth.BlockID) *BlockID {
+	return &BlockID{Hash: id.Hash.Bytes(), Number: id.Number}
+}
+
+func l1BlockRefToProto(ref eth.L1BlockRef) *L1BlockRef {
+	return &L1BlockRef{
+		Hash:       ref.Hash.Bytes(),
+		Number:     ref.Number,
+		ParentHash: ref.ParentHash.Bytes(),
+		Time:       ref.Time,
+	}
+}
+
+func l2BlockRefToProto(ref eth.L2BlockRef) *L2BlockRef {
+	return &L2BlockRef{
+		Hash:           ref.Hash.Bytes(),
+		Number:         ref.Number,
+		ParentHash:     ref.ParentHash.Bytes(),
+		Time:           ref.Time,
+		L1Origin:       blockIDToProto(ref.L1Origin),
+		SequenceNumber: ref.SequenceNumber,
+	}
+}
+
+func derivedRangesToProto(ranges []finality.DerivedRange) []*DerivedRange {
+	var out []*DerivedRange
+	for _, rng := range ranges {
+		out = append(out, &DerivedRange{
+			DerivedFrom:           blockIDToProto(rng.DerivedFrom),
+			Start:                 rng.Start,
+			End:                   rng.End,
+			Blobs:                 rng.Blobs,
+			BlobsExpireAt:         rng.BlobsExpireAt,
+			ReconstructableFromL1: rng.ReconstructableFromL1,
+		})
+	}
+	return out
+}
+
+func finalizedEventToProto(ev *finality.FinalizedEvent) *FinalizedEvent {
+	out := &FinalizedEvent{
+		PrevFinalizedL2: l2BlockRefToProto(ev.PrevFinalizedL2),
+		FinalizedL2:     l2BlockRefToProto(ev.FinalizedL2),
+		FinalizedL1:     l1BlockRefToProto(ev.FinalizedL1),
+		Ranges:          derivedRangesToProto(ev.Ranges),
+	}
+	for _, id := range ev.DerivedFrom {
+		out.DerivedFrom = append(out.DerivedFrom, blockIDToProto(id))
+	}
+	if ev.WithdrawalRoot != nil {
+		out.WithdrawalRoot = ev.WithdrawalRoot[:]
+	}
+	return out
+}
+
+func finalityStatusToProto(st *finality.FinalityStatus) *FinalityStatus {
+	out := &FinalityStatus{
+		FinalizedL1:        l1BlockRefToProto(st.FinalizedL1),
+		FinalizedL2:        l2BlockRefToProto(st.FinalizedL2),
+		ExtraConfirmations: st.ExtraConfirmations,
+		LastReason:         string(st.LastReason),
+		LastError:          st.LastError,
+		DerivedFromL1:      l1BlockRefToProto(st.DerivedFromL1),
+		LookbackHeadroom:   st.LookbackHeadroom,
+		Halted:             st.Halted,
+		StateDigest:        st.StateDigest.Bytes(),
+		Stall:              string(st.Stall),
+		Version:            st.Version,
+		FinalizedRanges:    derivedRangesToProto(st.FinalizedRanges),
+	}
+	if st.ForkConflict != nil {
+		out.ForkConflict = blockIDToProto(*st.ForkConflict)
+	}
+	return out
+}
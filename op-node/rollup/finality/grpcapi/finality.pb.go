@@ -0,0 +1,1093 @@
+// Finality service of the rollup node, for consumers that are not written in Go
+// to follow the finalized L2 head with typed clients, instead of polling the JSON-RPC API.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        (unknown)
+// source: finality.proto
+
+package grpcapi
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BlockID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash   []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Number uint64 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *BlockID) Reset() {
+	*x = BlockID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockID) ProtoMessage() {}
+
+func (x *BlockID) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockID.ProtoReflect.Descriptor instead.
+func (*BlockID) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BlockID) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *BlockID) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+type L1BlockRef struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash       []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Number     uint64 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	ParentHash []byte `protobuf:"bytes,3,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	Time       uint64 `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *L1BlockRef) Reset() {
+	*x = L1BlockRef{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *L1BlockRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*L1BlockRef) ProtoMessage() {}
+
+func (x *L1BlockRef) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use L1BlockRef.ProtoReflect.Descriptor instead.
+func (*L1BlockRef) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *L1BlockRef) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *L1BlockRef) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *L1BlockRef) GetParentHash() []byte {
+	if x != nil {
+		return x.ParentHash
+	}
+	return nil
+}
+
+func (x *L1BlockRef) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type L2BlockRef struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash           []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Number         uint64   `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	ParentHash     []byte   `protobuf:"bytes,3,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	Time           uint64   `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	L1Origin       *BlockID `protobuf:"bytes,5,opt,name=l1_origin,json=l1Origin,proto3" json:"l1_origin,omitempty"`
+	SequenceNumber uint64   `protobuf:"varint,6,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+}
+
+func (x *L2BlockRef) Reset() {
+	*x = L2BlockRef{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *L2BlockRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*L2BlockRef) ProtoMessage() {}
+
+func (x *L2BlockRef) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use L2BlockRef.ProtoReflect.Descriptor instead.
+func (*L2BlockRef) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *L2BlockRef) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *L2BlockRef) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *L2BlockRef) GetParentHash() []byte {
+	if x != nil {
+		return x.ParentHash
+	}
+	return nil
+}
+
+func (x *L2BlockRef) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *L2BlockRef) GetL1Origin() *BlockID {
+	if x != nil {
+		return x.L1Origin
+	}
+	return nil
+}
+
+func (x *L2BlockRef) GetSequenceNumber() uint64 {
+	if x != nil {
+		return x.SequenceNumber
+	}
+	return 0
+}
+
+type StreamFinalizedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StreamFinalizedRequest) Reset() {
+	*x = StreamFinalizedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamFinalizedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamFinalizedRequest) ProtoMessage() {}
+
+func (x *StreamFinalizedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamFinalizedRequest.ProtoReflect.Descriptor instead.
+func (*StreamFinalizedRequest) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{3}
+}
+
+// FinalizedEvent is an advancement of the finalized L2 head.
+type FinalizedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// prev_finalized_l2 is the finalized L2 head before this advancement.
+	PrevFinalizedL2 *L2BlockRef `protobuf:"bytes,1,opt,name=prev_finalized_l2,json=prevFinalizedL2,proto3" json:"prev_finalized_l2,omitempty"`
+	// finalized_l2 is the new finalized L2 head.
+	FinalizedL2 *L2BlockRef `protobuf:"bytes,2,opt,name=finalized_l2,json=finalizedL2,proto3" json:"finalized_l2,omitempty"`
+	// finalized_l1 is the L1 finality signal that the newly finalized L2 blocks were justified with.
+	FinalizedL1 *L1BlockRef `protobuf:"bytes,3,opt,name=finalized_l1,json=finalizedL1,proto3" json:"finalized_l1,omitempty"`
+	// derived_from lists the L1 blocks which the newly finalized L2 blocks were derived from, in ascending order.
+	DerivedFrom []*BlockID `protobuf:"bytes,4,rep,name=derived_from,json=derivedFrom,proto3" json:"derived_from,omitempty"`
+	// withdrawal_root is the storage root of the L2ToL1MessagePasser at finalized_l2, if withdrawal roots are tracked.
+	WithdrawalRoot []byte `protobuf:"bytes,5,opt,name=withdrawal_root,json=withdrawalRoot,proto3,oneof" json:"withdrawal_root,omitempty"`
+	// ranges lists the newly finalized L2 blocks per derived-from L1 block, in ascending order,
+	// with whether they can still be reconstructed from L1 data alone.
+	Ranges []*DerivedRange `protobuf:"bytes,6,rep,name=ranges,proto3" json:"ranges,omitempty"`
+}
+
+func (x *FinalizedEvent) Reset() {
+	*x = FinalizedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FinalizedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizedEvent) ProtoMessage() {}
+
+func (x *FinalizedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalizedEvent.ProtoReflect.Descriptor instead.
+func (*FinalizedEvent) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FinalizedEvent) GetPrevFinalizedL2() *L2BlockRef {
+	if x != nil {
+		return x.PrevFinalizedL2
+	}
+	return nil
+}
+
+func (x *FinalizedEvent) GetFinalizedL2() *L2BlockRef {
+	if x != nil {
+		return x.FinalizedL2
+	}
+	return nil
+}
+
+func (x *FinalizedEvent) GetFinalizedL1() *L1BlockRef {
+	if x != nil {
+		return x.FinalizedL1
+	}
+	return nil
+}
+
+func (x *FinalizedEvent) GetDerivedFrom() []*BlockID {
+	if x != nil {
+		return x.DerivedFrom
+	}
+	return nil
+}
+
+func (x *FinalizedEvent) GetWithdrawalRoot() []byte {
+	if x != nil {
+		return x.WithdrawalRoot
+	}
+	return nil
+}
+
+func (x *FinalizedEvent) GetRanges() []*DerivedRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+// DerivedRange is a range of finalized L2 blocks that were derived from the same L1 block.
+type DerivedRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DerivedFrom *BlockID `protobuf:"bytes,1,opt,name=derived_from,json=derivedFrom,proto3" json:"derived_from,omitempty"`
+	// start and end are the first and last L2 block number of the range.
+	Start uint64 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   uint64 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// blobs is set unless the range is known to be derived from calldata only.
+	Blobs bool `protobuf:"varint,4,opt,name=blobs,proto3" json:"blobs,omitempty"`
+	// blobs_expire_at is when the blobs leave the L1 blob retention window, 0 if unknown or not derived from blobs.
+	BlobsExpireAt         uint64 `protobuf:"varint,5,opt,name=blobs_expire_at,json=blobsExpireAt,proto3" json:"blobs_expire_at,omitempty"`
+	ReconstructableFromL1 bool   `protobuf:"varint,6,opt,name=reconstructable_from_l1,json=reconstructableFromL1,proto3" json:"reconstructable_from_l1,omitempty"`
+}
+
+func (x *DerivedRange) Reset() {
+	*x = DerivedRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DerivedRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivedRange) ProtoMessage() {}
+
+func (x *DerivedRange) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivedRange.ProtoReflect.Descriptor instead.
+func (*DerivedRange) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DerivedRange) GetDerivedFrom() *BlockID {
+	if x != nil {
+		return x.DerivedFrom
+	}
+	return nil
+}
+
+func (x *DerivedRange) GetStart() uint64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *DerivedRange) GetEnd() uint64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *DerivedRange) GetBlobs() bool {
+	if x != nil {
+		return x.Blobs
+	}
+	return false
+}
+
+func (x *DerivedRange) GetBlobsExpireAt() uint64 {
+	if x != nil {
+		return x.BlobsExpireAt
+	}
+	return 0
+}
+
+func (x *DerivedRange) GetReconstructableFromL1() bool {
+	if x != nil {
+		return x.ReconstructableFromL1
+	}
+	return false
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{6}
+}
+
+// FinalityStatus is the finality status of the rollup node.
+// See the optimism_finalityStatus JSON-RPC method for the meaning of the fields.
+type FinalityStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FinalizedL1        *L1BlockRef `protobuf:"bytes,1,opt,name=finalized_l1,json=finalizedL1,proto3" json:"finalized_l1,omitempty"`
+	FinalizedL2        *L2BlockRef `protobuf:"bytes,2,opt,name=finalized_l2,json=finalizedL2,proto3" json:"finalized_l2,omitempty"`
+	ExtraConfirmations uint64      `protobuf:"varint,3,opt,name=extra_confirmations,json=extraConfirmations,proto3" json:"extra_confirmations,omitempty"`
+	LastReason         string      `protobuf:"bytes,4,opt,name=last_reason,json=lastReason,proto3" json:"last_reason,omitempty"`
+	LastError          string      `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	DerivedFromL1      *L1BlockRef `protobuf:"bytes,6,opt,name=derived_from_l1,json=derivedFromL1,proto3" json:"derived_from_l1,omitempty"`
+	LookbackHeadroom   uint64      `protobuf:"varint,7,opt,name=lookback_headroom,json=lookbackHeadroom,proto3" json:"lookback_headroom,omitempty"`
+	Halted             bool        `protobuf:"varint,8,opt,name=halted,proto3" json:"halted,omitempty"`
+	StateDigest        []byte      `protobuf:"bytes,9,opt,name=state_digest,json=stateDigest,proto3" json:"state_digest,omitempty"`
+	Stall              string      `protobuf:"bytes,10,opt,name=stall,proto3" json:"stall,omitempty"`
+	Version            uint64      `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	// fork_conflict is set while finalization is frozen for a detected L2 fork.
+	ForkConflict *BlockID `protobuf:"bytes,12,opt,name=fork_conflict,json=forkConflict,proto3,oneof" json:"fork_conflict,omitempty"`
+	// finalized_ranges lists the most recently finalized L2 ranges.
+	FinalizedRanges []*DerivedRange `protobuf:"bytes,13,rep,name=finalized_ranges,json=finalizedRanges,proto3" json:"finalized_ranges,omitempty"`
+}
+
+func (x *FinalityStatus) Reset() {
+	*x = FinalityStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FinalityStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalityStatus) ProtoMessage() {}
+
+func (x *FinalityStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalityStatus.ProtoReflect.Descriptor instead.
+func (*FinalityStatus) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FinalityStatus) GetFinalizedL1() *L1BlockRef {
+	if x != nil {
+		return x.FinalizedL1
+	}
+	return nil
+}
+
+func (x *FinalityStatus) GetFinalizedL2() *L2BlockRef {
+	if x != nil {
+		return x.FinalizedL2
+	}
+	return nil
+}
+
+func (x *FinalityStatus) GetExtraConfirmations() uint64 {
+	if x != nil {
+		return x.ExtraConfirmations
+	}
+	return 0
+}
+
+func (x *FinalityStatus) GetLastReason() string {
+	if x != nil {
+		return x.LastReason
+	}
+	return ""
+}
+
+func (x *FinalityStatus) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *FinalityStatus) GetDerivedFromL1() *L1BlockRef {
+	if x != nil {
+		return x.DerivedFromL1
+	}
+	return nil
+}
+
+func (x *FinalityStatus) GetLookbackHeadroom() uint64 {
+	if x != nil {
+		return x.LookbackHeadroom
+	}
+	return 0
+}
+
+func (x *FinalityStatus) GetHalted() bool {
+	if x != nil {
+		return x.Halted
+	}
+	return false
+}
+
+func (x *FinalityStatus) GetStateDigest() []byte {
+	if x != nil {
+		return x.StateDigest
+	}
+	return nil
+}
+
+func (x *FinalityStatus) GetStall() string {
+	if x != nil {
+		return x.Stall
+	}
+	return ""
+}
+
+func (x *FinalityStatus) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *FinalityStatus) GetForkConflict() *BlockID {
+	if x != nil {
+		return x.ForkConflict
+	}
+	return nil
+}
+
+func (x *FinalityStatus) GetFinalizedRanges() []*DerivedRange {
+	if x != nil {
+		return x.FinalizedRanges
+	}
+	return nil
+}
+
+type GetDerivedFromRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	L2Number uint64 `protobuf:"varint,1,opt,name=l2_number,json=l2Number,proto3" json:"l2_number,omitempty"`
+}
+
+func (x *GetDerivedFromRequest) Reset() {
+	*x = GetDerivedFromRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDerivedFromRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDerivedFromRequest) ProtoMessage() {}
+
+func (x *GetDerivedFromRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDerivedFromRequest.ProtoReflect.Descriptor instead.
+func (*GetDerivedFromRequest) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetDerivedFromRequest) GetL2Number() uint64 {
+	if x != nil {
+		return x.L2Number
+	}
+	return 0
+}
+
+type GetDerivedFromResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	L1Block *BlockID `protobuf:"bytes,1,opt,name=l1_block,json=l1Block,proto3" json:"l1_block,omitempty"`
+}
+
+func (x *GetDerivedFromResponse) Reset() {
+	*x = GetDerivedFromResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_finality_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDerivedFromResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDerivedFromResponse) ProtoMessage() {}
+
+func (x *GetDerivedFromResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_finality_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDerivedFromResponse.ProtoReflect.Descriptor instead.
+func (*GetDerivedFromResponse) Descriptor() ([]byte, []int) {
+	return file_finality_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetDerivedFromResponse) GetL1Block() *BlockID {
+	if x != nil {
+		return x.L1Block
+	}
+	return nil
+}
+
+var File_finality_proto protoreflect.FileDescriptor
+
+var file_finality_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x14, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c,
+	0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x22, 0x35, 0x0a, 0x07, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49,
+	0x44, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x6d, 0x0a,
+	0x0a, 0x4c, 0x31, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12,
+	0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xd2, 0x01, 0x0a,
+	0x0a, 0x4c, 0x32, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12,
+	0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x09,
+	0x6c, 0x31, 0x5f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c,
+	0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x08,
+	0x6c, 0x31, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0e, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x22, 0x18, 0x0a, 0x16, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x69, 0x6e, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa8, 0x03, 0x0a, 0x0e,
+	0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x4c,
+	0x0a, 0x11, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64,
+	0x5f, 0x6c, 0x32, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x32, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x0f, 0x70, 0x72, 0x65,
+	0x76, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4c, 0x32, 0x12, 0x43, 0x0a, 0x0c,
+	0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x6c, 0x32, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69,
+	0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x32, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x66, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4c,
+	0x32, 0x12, 0x43, 0x0a, 0x0c, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x6c,
+	0x31, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
+	0x73, 0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x31, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x64, 0x4c, 0x31, 0x12, 0x40, 0x0a, 0x0c, 0x64, 0x65, 0x72, 0x69, 0x76, 0x65,
+	0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6f,
+	0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x0b, 0x64, 0x65, 0x72,
+	0x69, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x2c, 0x0a, 0x0f, 0x77, 0x69, 0x74, 0x68,
+	0x64, 0x72, 0x61, 0x77, 0x61, 0x6c, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x48, 0x00, 0x52, 0x0e, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c, 0x52,
+	0x6f, 0x6f, 0x74, 0x88, 0x01, 0x01, 0x12, 0x3a, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73,
+	0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x72, 0x69, 0x76, 0x65, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61,
+	0x6c, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x22, 0xee, 0x01, 0x0a, 0x0c, 0x44, 0x65, 0x72, 0x69, 0x76,
+	0x65, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x0c, 0x64, 0x65, 0x72, 0x69, 0x76,
+	0x65, 0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x0b, 0x64, 0x65,
+	0x72, 0x69, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x65, 0x6e,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x62, 0x6c, 0x6f, 0x62, 0x73,
+	0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x41, 0x74, 0x12,
+	0x36, 0x0a, 0x17, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x6c, 0x31, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x15, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x46, 0x72, 0x6f, 0x6d, 0x4c, 0x31, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x97, 0x05, 0x0a, 0x0e,
+	0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x43,
+	0x0a, 0x0c, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x6c, 0x31, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e,
+	0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x31, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65,
+	0x64, 0x4c, 0x31, 0x12, 0x43, 0x0a, 0x0c, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64,
+	0x5f, 0x6c, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x32, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x66, 0x52, 0x0b, 0x66, 0x69, 0x6e,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x4c, 0x32, 0x12, 0x2f, 0x0a, 0x13, 0x65, 0x78, 0x74, 0x72,
+	0x61, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x65, 0x78, 0x74, 0x72, 0x61, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x6c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x48, 0x0a, 0x0f, 0x64, 0x65, 0x72,
+	0x69, 0x76, 0x65, 0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x6c, 0x31, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69,
+	0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x31, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x66, 0x52, 0x0d, 0x64, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f,
+	0x6d, 0x4c, 0x31, 0x12, 0x2b, 0x0a, 0x11, 0x6c, 0x6f, 0x6f, 0x6b, 0x62, 0x61, 0x63, 0x6b, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10,
+	0x6c, 0x6f, 0x6f, 0x6b, 0x62, 0x61, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x72, 0x6f, 0x6f, 0x6d,
+	0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x68, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x6c, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6c,
+	0x6c, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x47, 0x0a, 0x0d, 0x66,
+	0x6f, 0x72, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69,
+	0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49,
+	0x44, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63,
+	0x74, 0x88, 0x01, 0x01, 0x12, 0x4d, 0x0a, 0x10, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65,
+	0x64, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69,
+	0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x0f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x66, 0x6f, 0x72, 0x6b, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x6c, 0x69, 0x63, 0x74, 0x22, 0x34, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x44, 0x65, 0x72, 0x69,
+	0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x6c, 0x32, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x6c, 0x32, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x52, 0x0a, 0x16, 0x47,
+	0x65, 0x74, 0x44, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x6c, 0x31, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69,
+	0x73, 0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x07, 0x6c, 0x31, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x32,
+	0xc2, 0x02, 0x0a, 0x0f, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x67, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x69, 0x6e,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x12, 0x2c, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73,
+	0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e,
+	0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x61,
+	0x6c, 0x69, 0x7a, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x59, 0x0a, 0x09,
+	0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x24, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69, 0x6e,
+	0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x6b, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x44, 0x65,
+	0x72, 0x69, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x2b, 0x2e, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73,
+	0x6d, 0x2e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x44, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x47, 0x5a, 0x45, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2d, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x73, 0x6d, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2f, 0x6f, 0x70,
+	0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2f, 0x72, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x2f, 0x66, 0x69, 0x6e,
+	0x61, 0x6c, 0x69, 0x74, 0x79, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_finality_proto_rawDescOnce sync.Once
+	file_finality_proto_rawDescData = file_finality_proto_rawDesc
+)
+
+func file_finality_proto_rawDescGZIP() []byte {
+	file_finality_proto_rawDescOnce.Do(func() {
+		file_finality_proto_rawDescData = protoimpl.X.CompressGZIP(file_finality_proto_rawDescData)
+	})
+	return file_finality_proto_rawDescData
+}
+
+var file_finality_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_finality_proto_goTypes = []interface{}{
+	(*BlockID)(nil),                // 0: optimism.finality.v1.BlockID
+	(*L1BlockRef)(nil),             // 1: optimism.finality.v1.L1BlockRef
+	(*L2BlockRef)(nil),             // 2: optimism.finality.v1.L2BlockRef
+	(*StreamFinalizedRequest)(nil), // 3: optimism.finality.v1.StreamFinalizedRequest
+	(*FinalizedEvent)(nil),         // 4: optimism.finality.v1.FinalizedEvent
+	(*DerivedRange)(nil),           // 5: optimism.finality.v1.DerivedRange
+	(*GetStatusRequest)(nil),       // 6: optimism.finality.v1.GetStatusRequest
+	(*FinalityStatus)(nil),         // 7: optimism.finality.v1.FinalityStatus
+	(*GetDerivedFromRequest)(nil),  // 8: optimism.finality.v1.GetDerivedFromRequest
+	(*GetDerivedFromResponse)(nil), // 9: optimism.finality.v1.GetDerivedFromResponse
+}
+var file_finality_proto_depIdxs = []int32{
+	0,  // 0: optimism.finality.v1.L2BlockRef.l1_origin:type_name -> optimism.finality.v1.BlockID
+	2,  // 1: optimism.finality.v1.FinalizedEvent.prev_finalized_l2:type_name -> optimism.finality.v1.L2BlockRef
+	2,  // 2: optimism.finality.v1.FinalizedEvent.finalized_l2:type_name -> optimism.finality.v1.L2BlockRef
+	1,  // 3: optimism.finality.v1.FinalizedEvent.finalized_l1:type_name -> optimism.finality.v1.L1BlockRef
+	0,  // 4: optimism.finality.v1.FinalizedEvent.derived_from:type_name -> optimism.finality.v1.BlockID
+	5,  // 5: optimism.finality.v1.FinalizedEvent.ranges:type_name -> optimism.finality.v1.DerivedRange
+	0,  // 6: optimism.finality.v1.DerivedRange.derived_from:type_name -> optimism.finality.v1.BlockID
+	1,  // 7: optimism.finality.v1.FinalityStatus.finalized_l1:type_name -> optimism.finality.v1.L1BlockRef
+	2,  // 8: optimism.finality.v1.FinalityStatus.finalized_l2:type_name -> optimism.finality.v1.L2BlockRef
+	1,  // 9: optimism.finality.v1.FinalityStatus.derived_from_l1:type_name -> optimism.finality.v1.L1BlockRef
+	0,  // 10: optimism.finality.v1.FinalityStatus.fork_conflict:type_name -> optimism.finality.v1.BlockID
+	5,  // 11: optimism.finality.v1.FinalityStatus.finalized_ranges:type_name -> optimism.finality.v1.DerivedRange
+	0,  // 12: optimism.finality.v1.GetDerivedFromResponse.l1_block:type_name -> optimism.finality.v1.BlockID
+	3,  // 13: optimism.finality.v1.FinalityService.StreamFinalized:input_type -> optimism.finality.v1.StreamFinalizedRequest
+	6,  // 14: optimism.finality.v1.FinalityService.GetStatus:input_type -> optimism.finality.v1.GetStatusRequest
+	8,  // 15: optimism.finality.v1.FinalityService.GetDerivedFrom:input_type -> optimism.finality.v1.GetDerivedFromRequest
+	4,  // 16: optimism.finality.v1.FinalityService.StreamFinalized:output_type -> optimism.finality.v1.FinalizedEvent
+	7,  // 17: optimism.finality.v1.FinalityService.GetStatus:output_type -> optimism.finality.v1.FinalityStatus
+	9,  // 18: optimism.finality.v1.FinalityService.GetDerivedFrom:output_type -> optimism.finality.v1.GetDerivedFromResponse
+	16, // [16:19] is the sub-list for method output_type
+	13, // [13:16] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_finality_proto_init() }
+func file_finality_proto_init() {
+	if File_finality_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_finality_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*L1BlockRef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*L2BlockRef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamFinalizedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FinalizedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DerivedRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FinalityStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDerivedFromRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_finality_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDerivedFromResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_finality_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_finality_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_finality_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_finality_proto_goTypes,
+		DependencyIndexes: file_finality_proto_depIdxs,
+		MessageInfos:      file_finality_proto_msgTypes,
+	}.Build()
+	File_finality_proto = out.File
+	file_finality_proto_rawDesc = nil
+	file_finality_proto_goTypes = nil
+	file_finality_proto_depIdxs = nil
+}
@@ -0,0 +1,215 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: finality.proto
+
+package grpcapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// FinalityServiceClient is the client API for FinalityService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FinalityServiceClient interface {
+	// StreamFinalized streams the advancements of the finalized L2 head, until the client cancels the stream.
+	// Advancements that happen while a previous one is being sent are coalesced into one message.
+	StreamFinalized(ctx context.Context, in *StreamFinalizedRequest, opts ...grpc.CallOption) (FinalityService_StreamFinalizedClient, error)
+	// GetStatus returns the finality status of the rollup node.
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*FinalityStatus, error)
+	// GetDerivedFrom returns the L1 block the given L2 block was fully derived from, according to the finality data.
+	// It fails with NOT_FOUND if the L2 block is not safe yet, or older than the buffered finality data.
+	GetDerivedFrom(ctx context.Context, in *GetDerivedFromRequest, opts ...grpc.CallOption) (*GetDerivedFromResponse, error)
+}
+
+type finalityServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFinalityServiceClient(cc grpc.ClientConnInterface) FinalityServiceClient {
+	return &finalityServiceClient{cc}
+}
+
+func (c *finalityServiceClient) StreamFinalized(ctx context.Context, in *StreamFinalizedRequest, opts ...grpc.CallOption) (FinalityService_StreamFinalizedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FinalityService_ServiceDesc.Streams[0], "/optimism.finality.v1.FinalityService/StreamFinalized", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &finalityServiceStreamFinalizedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FinalityService_StreamFinalizedClient interface {
+	Recv() (*FinalizedEvent, error)
+	grpc.ClientStream
+}
+
+type finalityServiceStreamFinalizedClient struct {
+	grpc.ClientStream
+}
+
+func (x *finalityServiceStreamFinalizedClient) Recv() (*FinalizedEvent, error) {
+	m := new(FinalizedEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *finalityServiceClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*FinalityStatus, error) {
+	out := new(FinalityStatus)
+	err := c.cc.Invoke(ctx, "/optimism.finality.v1.FinalityService/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityServiceClient) GetDerivedFrom(ctx context.Context, in *GetDerivedFromRequest, opts ...grpc.CallOption) (*GetDerivedFromResponse, error) {
+	out := new(GetDerivedFromResponse)
+	err := c.cc.Invoke(ctx, "/optimism.finality.v1.FinalityService/GetDerivedFrom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FinalityServiceServer is the server API for FinalityService service.
+// All implementations must embed UnimplementedFinalityServiceServer
+// for forward compatibility
+type FinalityServiceServer interface {
+	// StreamFinalized streams the advancements of the finalized L2 head, until the client cancels the stream.
+	// Advancements that happen while a previous one is being sent are coalesced into one message.
+	StreamFinalized(*StreamFinalizedRequest, FinalityService_StreamFinalizedServer) error
+	// GetStatus returns the finality status of the rollup node.
+	GetStatus(context.Context, *GetStatusRequest) (*FinalityStatus, error)
+	// GetDerivedFrom returns the L1 block the given L2 block was fully derived from, according to the finality data.
+	// It fails with NOT_FOUND if the L2 block is not safe yet, or older than the buffered finality data.
+	GetDerivedFrom(context.Context, *GetDerivedFromRequest) (*GetDerivedFromResponse, error)
+	mustEmbedUnimplementedFinalityServiceServer()
+}
+
+// UnimplementedFinalityServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFinalityServiceServer struct {
+}
+
+func (UnimplementedFinalityServiceServer) StreamFinalized(*StreamFinalizedRequest, FinalityService_StreamFinalizedServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFinalized not implemented")
+}
+func (UnimplementedFinalityServiceServer) GetStatus(context.Context, *GetStatusRequest) (*FinalityStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedFinalityServiceServer) GetDerivedFrom(context.Context, *GetDerivedFromRequest) (*GetDerivedFromResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDerivedFrom not implemented")
+}
+func (UnimplementedFinalityServiceServer) mustEmbedUnimplementedFinalityServiceServer() {}
+
+// UnsafeFinalityServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FinalityServiceServer will
+// result in compilation errors.
+type UnsafeFinalityServiceServer interface {
+	mustEmbedUnimplementedFinalityServiceServer()
+}
+
+func RegisterFinalityServiceServer(s grpc.ServiceRegistrar, srv FinalityServiceServer) {
+	s.RegisterService(&FinalityService_ServiceDesc, srv)
+}
+
+func _FinalityService_StreamFinalized_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFinalizedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FinalityServiceServer).StreamFinalized(m, &finalityServiceStreamFinalizedServer{stream})
+}
+
+type FinalityService_StreamFinalizedServer interface {
+	Send(*FinalizedEvent) error
+	grpc.ServerStream
+}
+
+type finalityServiceStreamFinalizedServer struct {
+	grpc.ServerStream
+}
+
+func (x *finalityServiceStreamFinalizedServer) Send(m *FinalizedEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FinalityService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/optimism.finality.v1.FinalityService/GetStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityServiceServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityService_GetDerivedFrom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDerivedFromRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityServiceServer).GetDerivedFrom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/optimism.finality.v1.FinalityService/GetDerivedFrom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityServiceServer).GetDerivedFrom(ctx, req.(*GetDerivedFromRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FinalityService_ServiceDesc is the grpc.ServiceDesc for FinalityService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FinalityService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "optimism.finality.v1.FinalityService",
+	HandlerType: (*FinalityServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatus",
+			Handler:    _FinalityService_GetStatus_Handler,
+		},
+		{
+			MethodName: "GetDerivedFrom",
+			Handler:    _FinalityService_GetDerivedFrom_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamFinalized",
+			Handler:       _FinalityService_StreamFinalized_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "finality.proto",
+}
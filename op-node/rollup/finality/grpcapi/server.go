@@ -0,0 +1,168 @@
+// Package grpcapi serves the finality state of the rollup node over gRPC, with the protobuf definitions
+// of finality.proto, so infrastructure that is not written in Go can consume it with typed clients.
+// The JSON-RPC API serves the same queries, but has no schema to generate clients from,
+// and its subscriptions are only served over websockets, while the finality stream is a plain gRPC server stream.
+// The server is only started if finality.grpc-addr is set.
+package grpcapi
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative finality.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FinalizedSubscriptions is the source of the finalized L2 head advancements that are streamed.
+type FinalizedSubscriptions interface {
+	SubscribeFinalized(fn finality.FinalizedSubscriber) (unsubscribe func())
+}
+
+// FinalityDriver serves the finality status and derived-from queries, like the rollup driver.
+type FinalityDriver interface {
+	FinalityStatus(ctx context.Context) (*finality.FinalityStatus, error)
+	FinalityDerivedFrom(ctx context.Context, l2Number uint64) (eth.BlockID, error)
+}
+
+// Server is the gRPC server of the FinalityService.
+type Server struct {
+	UnimplementedFinalityServiceServer
+
+	log       log.Logger
+	finalized FinalizedSubscriptions
+	driver    FinalityDriver
+
+	grpc     *grpc.Server
+	listener net.Listener
+	// closing is closed on Stop, to end the open streams, which would otherwise block a graceful stop.
+	closing   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func NewServer(log log.Logger, finalized FinalizedSubscriptions, driver FinalityDriver) *Server {
+	s := &Server{
+		log:       log,
+		finalized: finalized,
+		driver:    driver,
+		grpc:      grpc.NewServer(),
+		closing:   make(chan struct{}),
+	}
+	RegisterFinalityServiceServer(s.grpc, s)
+	return s
+}
+
+// Start listens on the given address, and serves the FinalityService in the background.
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	s.listener = listener
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.grpc.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			s.log.Error("finality gRPC server failed", "err", err)
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address the server listens on, nil if it was not started.
+func (s *Server) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Stop ends the open streams, and stops the server gracefully, or forcefully once the context is done.
+func (s *Server) Stop(ctx context.Context) error {
+	s.closeOnce.Do(func() { close(s.closing) })
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+	var err error
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.grpc.Stop()
+		err = ctx.Err()
+	}
+	s.wg.Wait()
+	return err
+}
+
+// StreamFinalized streams the advancements of the finalized L2 head. The Finalizer calls subscribers synchronously,
+// so advancements are only queued by the subscriber, and coalesced if the client is slower than finalization.
+func (s *Server) StreamFinalized(req *StreamFinalizedRequest, stream FinalityService_StreamFinalizedServer) error {
+	var mu sync.Mutex
+	var pending *finality.FinalizedEvent
+	wake := make(chan struct{}, 1)
+	unsubscribe := s.finalized.SubscribeFinalized(func(ev finality.FinalizedEvent) {
+		mu.Lock()
+		if pending == nil {
+			pending = &ev
+		} else {
+			pending.Coalesce(ev)
+		}
+		mu.Unlock()
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	})
+	defer unsubscribe()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-s.closing:
+			return status.Error(codes.Unavailable, "finality gRPC server is shutting down")
+		case <-wake:
+		}
+		mu.Lock()
+		ev := pending
+		pending = nil
+		mu.Unlock()
+		if ev == nil {
+			continue
+		}
+		if err := stream.Send(finalizedEventToProto(ev)); err != nil {
+			s.log.Warn("failed to stream finalized L2 head", "finalized_l2", ev.FinalizedL2, "err", err)
+			return err
+		}
+	}
+}
+
+func (s *Server) GetStatus(ctx context.Context, req *GetStatusRequest) (*FinalityStatus, error) {
+	st, err := s.driver.FinalityStatus(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return finalityStatusToProto(st), nil
+}
+
+func (s *Server) GetDerivedFrom(ctx context.Context, req *GetDerivedFromRequest) (*GetDerivedFromResponse, error) {
+	l1Block, err := s.driver.FinalityDerivedFrom(ctx, req.L2Number)
+	if errors.Is(err, finality.ErrDerivedFromUnknown) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &GetDerivedFromResponse{L1Block: blockIDToProto(l1Block)}, nil
+}
@@ -0,0 +1,170 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"math/rand" // nosemgrep
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type fakeBackend struct {
+	mu          sync.Mutex
+	subscribers []finality.FinalizedSubscriber
+
+	status      finality.FinalityStatus
+	derivedFrom map[uint64]eth.BlockID
+}
+
+func (b *fakeBackend) SubscribeFinalized(fn finality.FinalizedSubscriber) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, fn)
+	i := len(b.subscribers) - 1
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.subscribers[i] = nil
+	}
+}
+
+func (b *fakeBackend) emit(ev finality.FinalizedEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, fn := range b.subscribers {
+		if fn != nil {
+			fn(ev)
+		}
+	}
+}
+
+func (b *fakeBackend) subscribed() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := 0
+	for _, fn := range b.subscribers {
+		if fn != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (b *fakeBackend) FinalityStatus(ctx context.Context) (*finality.FinalityStatus, error) {
+	st := b.status
+	return &st, nil
+}
+
+func (b *fakeBackend) FinalityDerivedFrom(ctx context.Context, l2Number uint64) (eth.BlockID, error) {
+	id, ok := b.derivedFrom[l2Number]
+	if !ok {
+		return eth.BlockID{}, fmt.Errorf("%w: L2 block %d", finality.ErrDerivedFromUnknown, l2Number)
+	}
+	return id, nil
+}
+
+func TestServer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	finalizedL1 := testutils.RandomBlockRef(rng)
+	prevL2 := testutils.RandomL2BlockRef(rng)
+	finalizedL2 := testutils.RandomL2BlockRef(rng)
+	conflict := eth.BlockID{Hash: testutils.RandomHash(rng), Number: 42}
+	ranges := []finality.DerivedRange{{DerivedFrom: finalizedL1.ID(), Start: prevL2.Number + 1, End: finalizedL2.Number, Blobs: true,
+		BlobsExpireAt: finalizedL1.Time + 1000}}
+	backend := &fakeBackend{
+		status: finality.FinalityStatus{
+			FinalizedL1:     finalizedL1,
+			FinalizedL2:     finalizedL2,
+			LastReason:      finality.ReasonForkConflict,
+			ForkConflict:    &conflict,
+			Version:         7,
+			FinalizedRanges: ranges,
+		},
+		derivedFrom: map[uint64]eth.BlockID{finalizedL2.Number: finalizedL1.ID()},
+	}
+	srv := NewServer(testlog.Logger(t, log.LevelInfo), backend, backend)
+	require.NoError(t, srv.Start("127.0.0.1:0"))
+	conn, err := grpc.Dial(srv.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := NewFinalityServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Run("status", func(t *testing.T) {
+		st, err := client.GetStatus(ctx, &GetStatusRequest{})
+		require.NoError(t, err)
+		require.Equal(t, finalizedL2.Hash.Bytes(), st.FinalizedL2.Hash)
+		require.Equal(t, finalizedL2.L1Origin.Number, st.FinalizedL2.L1Origin.Number)
+		require.Equal(t, finalizedL1.Number, st.FinalizedL1.Number)
+		require.Equal(t, string(finality.ReasonForkConflict), st.LastReason)
+		require.Equal(t, conflict.Number, st.ForkConflict.Number)
+		require.Equal(t, uint64(7), st.Version)
+		require.Len(t, st.FinalizedRanges, 1)
+		require.False(t, st.FinalizedRanges[0].ReconstructableFromL1)
+	})
+
+	t.Run("derived from", func(t *testing.T) {
+		res, err := client.GetDerivedFrom(ctx, &GetDerivedFromRequest{L2Number: finalizedL2.Number})
+		require.NoError(t, err)
+		require.Equal(t, finalizedL1.Hash.Bytes(), res.L1Block.Hash)
+		_, err = client.GetDerivedFrom(ctx, &GetDerivedFromRequest{L2Number: finalizedL2.Number + 1})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		streamCtx, streamCancel := context.WithCancel(ctx)
+		defer streamCancel()
+		stream, err := client.StreamFinalized(streamCtx, &StreamFinalizedRequest{})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool { return backend.subscribed() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+		root := eth.Bytes32{1}
+		backend.emit(finality.FinalizedEvent{
+			PrevFinalizedL2: prevL2,
+			FinalizedL2:     finalizedL2,
+			FinalizedL1:     finalizedL1,
+			DerivedFrom:     []eth.BlockID{finalizedL1.ID()},
+			WithdrawalRoot:  &root,
+			Ranges:          ranges,
+		})
+		ev, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, prevL2.Number, ev.PrevFinalizedL2.Number)
+		require.Equal(t, finalizedL2.Hash.Bytes(), ev.FinalizedL2.Hash)
+		require.Len(t, ev.DerivedFrom, 1)
+		require.Equal(t, finalizedL1.Number, ev.DerivedFrom[0].Number)
+		require.Equal(t, root[:], ev.WithdrawalRoot)
+		require.Len(t, ev.Ranges, 1)
+		require.Equal(t, finalizedL1.Time+1000, ev.Ranges[0].BlobsExpireAt)
+
+		// the subscription is removed once the client cancels the stream
+		streamCancel()
+		require.Eventually(t, func() bool { return backend.subscribed() == 0 }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		stream, err := client.StreamFinalized(ctx, &StreamFinalizedRequest{})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool { return backend.subscribed() == 1 }, 5*time.Second, 10*time.Millisecond)
+		// open streams do not block the shutdown
+		require.NoError(t, srv.Stop(ctx))
+		_, err = stream.Recv()
+		require.Equal(t, codes.Unavailable, status.Code(err))
+		require.Zero(t, backend.subscribed())
+	})
+}
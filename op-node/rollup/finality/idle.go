@@ -0,0 +1,24 @@
+package finality
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// OnDerivationIdle is called when the derivation pipeline went idle, without new L1 data to derive from.
+// If a new finality signal arrived since the last successful attempt to finalize, then finalization is attempted
+// right away, rather than waiting for derivation to traverse more L1 blocks. On quiet chains, where the L1 origin
+// advances slowly, this bounds the finality lag to the signal interval.
+func (fi *Finalizer) OnDerivationIdle(ctx context.Context) error {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	defer fi.publishStatus()
+	defer fi.reportStall()
+	defer fi.reportSLO()
+	if !fi.signalSinceAttempt || fi.finalizedL1 == (eth.L1BlockRef{}) {
+		return nil
+	}
+	fi.log.Debug("derivation is idle, processing new L1 finality information", "l1_finalized", fi.finalizedL1)
+	return fi.guard(func() error { return fi.tryFinalize(ctx) })
+}
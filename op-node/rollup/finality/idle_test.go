@@ -0,0 +1,52 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerOnDerivationIdle(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+
+	// nothing to do before any signal
+	require.NoError(t, fi.OnDerivationIdle(context.Background()))
+	require.Zero(t, fi.counters.Attempts)
+
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[1]))
+
+	// the attempt on the new signal fails
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, eth.L1BlockRef{}, errors.New("fail"))
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, eth.L1BlockRef{}, errors.New("fail"))
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, chain.l2[0][1], ec.Finalized())
+
+	// derivation goes idle, without traversing more L1 blocks, and the signal is retried right away
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	require.NoError(t, fi.OnDerivationIdle(context.Background()))
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+
+	// no new signal since the last successful attempt
+	attempts := fi.counters.Attempts
+	require.NoError(t, fi.OnDerivationIdle(context.Background()))
+	require.Equal(t, attempts, fi.counters.Attempts)
+}
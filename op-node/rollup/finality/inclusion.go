@@ -0,0 +1,35 @@
+package finality
+
+import (
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// InclusionSource provides the batcher transactions and blobs of the L1 blocks the L2 chain is derived from,
+// like the derivation pipeline.
+type InclusionSource interface {
+	BatchInclusion(l1 eth.BlockID) (derive.BatchInclusion, bool)
+}
+
+// WithInclusionSource attaches the batch inclusion of the derived-from L1 blocks to the finality data,
+// so data-availability auditors can map finalized L2 ranges back to the exact L1 data,
+// and chains with multiple authorized batchers can account the finalized L2 ranges to their batchers.
+func WithInclusionSource(src InclusionSource) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.inclusions = src
+	}
+}
+
+// newL1Source creates the source of L2 blocks derived from the given L1 block,
+// including the batch inclusion of the L1 block, if known.
+func (fi *Finalizer) newL1Source(derivedFrom eth.L1BlockRef) l1Source {
+	source := l1Source{ID: derivedFrom.ID(), ParentHash: derivedFrom.ParentHash, Time: derivedFrom.Time}
+	if fi.inclusions != nil {
+		if inclusion, ok := fi.inclusions.BatchInclusion(derivedFrom.ID()); ok {
+			source.BatchTxs = inclusion.TxHashes
+			source.BlobIndices = inclusion.BlobIndices
+			source.Batchers = inclusion.Batchers
+		}
+	}
+	return source
+}
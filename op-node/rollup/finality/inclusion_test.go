@@ -0,0 +1,95 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type fakeInclusions map[eth.BlockID]derive.BatchInclusion
+
+func (f fakeInclusions) BatchInclusion(l1 eth.BlockID) (derive.BatchInclusion, bool) {
+	inclusion, ok := f[l1]
+	return inclusion, ok
+}
+
+func TestFinalizerInclusion(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	inclusion := derive.BatchInclusion{
+		TxHashes:    []common.Hash{testutils.RandomHash(rng), testutils.RandomHash(rng)},
+		BlobIndices: []uint64{0, 3},
+		Batchers:    []common.Address{testutils.RandomAddress(rng)},
+	}
+	src := fakeInclusions{chain.l1[1].ID(): inclusion}
+	fi := NewFinalizer(logger, &rollup.Config{}, nil, &fakeEngine{}, WithInclusionSource(src))
+
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+	// an older L1 block that is replayed is looked up too
+	src[chain.l1[0].ID()] = inclusion
+	fi.PostProcessSafeL2(chain.l2[0][1], chain.l1[0])
+
+	snapshot := fi.Snapshot()
+	require.Equal(t, []FinalityData{
+		{L2Block: chain.l2[0][1], L1Block: chain.l1[0].ID(), L1Parent: chain.l1[0].ParentHash, L1Time: chain.l1[0].Time, BatchTxs: inclusion.TxHashes, BlobIndices: inclusion.BlobIndices,
+			Batchers: inclusion.Batchers},
+		{L2Block: chain.l2[1][1], L1Block: chain.l1[1].ID(), L1Parent: chain.l1[1].ParentHash, L1Time: chain.l1[1].Time, BatchTxs: inclusion.TxHashes, BlobIndices: inclusion.BlobIndices,
+			Batchers: inclusion.Batchers},
+		{L2Block: chain.l2[2][1], L1Block: chain.l1[2].ID(), L1Parent: chain.l1[2].ParentHash, L1Time: chain.l1[2].Time},
+	}, snapshot.FinalityData)
+
+	// the inclusion data is retained by the snapshot encoding
+	data, err := snapshot.MarshalBinary()
+	require.NoError(t, err)
+	var decoded Snapshot
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, inclusion.TxHashes, decoded.FinalityData[1].BatchTxs)
+	require.Equal(t, inclusion.BlobIndices, decoded.FinalityData[1].BlobIndices)
+	require.Equal(t, inclusion.Batchers, decoded.FinalityData[1].Batchers)
+	require.Empty(t, decoded.FinalityData[2].BatchTxs)
+}
+
+func TestFinalizedEventBatchers(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	batcherA, batcherB := testutils.RandomAddress(rng), testutils.RandomAddress(rng)
+	src := fakeInclusions{
+		chain.l1[1].ID(): {Batchers: []common.Address{batcherA}},
+		chain.l1[2].ID(): {Batchers: []common.Address{batcherA, batcherB}},
+	}
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][0]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithInclusionSource(src))
+	var events []FinalizedEvent
+	fi.SubscribeFinalized(func(ev FinalizedEvent) { events = append(events, ev) })
+
+	for i := range chain.l1 {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Len(t, events, 1)
+	// the L1 block without known batchers is omitted
+	require.Equal(t, []BatcherContribution{
+		{DerivedFrom: chain.l1[1].ID(), Start: chain.l2[1][0].Number, End: chain.l2[1][1].Number, Batchers: []common.Address{batcherA}},
+		{DerivedFrom: chain.l1[2].ID(), Start: chain.l2[2][0].Number, End: chain.l2[2][1].Number, Batchers: []common.Address{batcherA, batcherB}},
+	}, events[0].Batchers)
+	require.Len(t, events[0].DerivedFrom, 3)
+}
@@ -0,0 +1,30 @@
+package finality
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// reportRelations publishes the finalized L2 head, the L1 block it was derived from,
+// and how far the finalized L2 head lags behind the safe L2 head, for dashboards.
+// The derived-from L1 block is only looked up when the finalized L2 head changes,
+// and is reported as unknown if the finalized L2 head is older than the buffered finality data.
+// The lock must be held.
+func (fi *Finalizer) reportRelations() {
+	finalized := fi.finalizedL2
+	if finalized != fi.reportedFinalizedL2 {
+		derivedFrom, err := fi.derivedFrom(finalized.Number)
+		if err != nil {
+			derivedFrom = eth.BlockID{}
+		}
+		fi.reportedFinalizedL2 = finalized
+		fi.metrics.RecordFinalityRelation(finalized, derivedFrom)
+	}
+	var lagBlocks, lagSeconds uint64
+	if safe := fi.progressSafeL2; safe.Number > finalized.Number {
+		lagBlocks = safe.Number - finalized.Number
+		if safe.Time > finalized.Time {
+			lagSeconds = safe.Time - finalized.Time
+		}
+	}
+	fi.metrics.RecordFinalityLag(lagBlocks, lagSeconds)
+}
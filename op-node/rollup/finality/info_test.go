@@ -0,0 +1,46 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerReportRelations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	for _, ref := range chain.l1 {
+		l1F.Mock.On("L1BlockRefByNumber", ref.Number).Return(ref, nil)
+	}
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][0]
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithMetrics(m))
+	for i := 0; i < 3; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[2]))
+	// the finalized L2 head predates the finality data, so the L1 block it was derived from is unknown
+	require.Equal(t, chain.l2[0][0], m.finalized)
+	require.Equal(t, eth.BlockID{}, m.derivedFrom)
+	require.Equal(t, chain.l2[2][1].Number-chain.l2[0][0].Number, m.lagBlocks)
+	require.Equal(t, chain.l2[2][1].Time-chain.l2[0][0].Time, m.lagSeconds)
+
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+	require.Equal(t, chain.l2[1][1], m.finalized)
+	require.Equal(t, chain.l1[1].ID(), m.derivedFrom)
+	require.Equal(t, chain.l2[2][1].Number-chain.l2[1][1].Number, m.lagBlocks)
+	require.Equal(t, chain.l2[2][1].Time-chain.l2[1][1].Time, m.lagSeconds)
+}
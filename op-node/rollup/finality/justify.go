@@ -0,0 +1,77 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// WithRepairUnjustified re-asserts the finalized L2 head of the Finalizer on the engine,
+// when the engine finalized L2 blocks that cannot be justified from finalized L1 data,
+// e.g. when an operator manually set the finalized head of the engine.
+func WithRepairUnjustified() FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.repairUnjustified = true
+	}
+}
+
+// checkJustified verifies that the finalized L2 head of the engine can be justified from finalized L1 data,
+// and reports it if it cannot. It returns the finalized L2 head to continue finalizing from,
+// which is the justified finalized L2 head if the engine was repaired. The lock must be held.
+func (fi *Finalizer) checkJustified(ctx context.Context, finalizedL2 eth.L2BlockRef) (eth.L2BlockRef, error) {
+	// a pending finalized head was set by the Finalizer itself
+	if finalizedL2 == (eth.L2BlockRef{}) || finalizedL2 == fi.pendingFinalized {
+		fi.unjustifiedL2 = eth.L2BlockRef{}
+		return finalizedL2, nil
+	}
+	justified, unjustified := fi.justifiedFinalized(finalizedL2)
+	if !unjustified {
+		fi.unjustifiedL2 = eth.L2BlockRef{}
+		return finalizedL2, nil
+	}
+	if fi.unjustifiedL2 != finalizedL2 {
+		fi.metrics.RecordFinalityUnjustifiedHead()
+		fi.log.Error("engine finalized L2 head is ahead of what finalized L1 data justifies! Was it set manually?",
+			"finalized_l2", finalizedL2, "justified_l2", justified, "finalized_l1", fi.finalizedL1)
+	}
+	fi.unjustifiedL2 = finalizedL2
+	if !fi.repairUnjustified || justified == (eth.L2BlockRef{}) {
+		return finalizedL2, nil
+	}
+	if err := fi.setFinalizedHead(ctx, justified, AuditSourceRepair); err != nil {
+		return finalizedL2, err
+	}
+	if err := fi.updateEngine(ctx, justified); err != nil {
+		return finalizedL2, fmt.Errorf("failed to repair unjustified finalized L2 head %s to %s: %w", finalizedL2, justified, err)
+	}
+	fi.log.Warn("repaired unjustified finalized L2 head", "unjustified_l2", finalizedL2, "finalized_l2", justified)
+	fi.unjustifiedL2 = eth.L2BlockRef{}
+	return justified, nil
+}
+
+// justifiedFinalized determines if the given finalized L2 head is known to be derived from an L1 block
+// that is not finalized yet. If so, it also returns the latest buffered L2 block that is justified, if any.
+// Extra confirmations are not required: they are a local safety margin, not a justification.
+func (fi *Finalizer) justifiedFinalized(finalizedL2 eth.L2BlockRef) (justified eth.L2BlockRef, unjustified bool) {
+	rels := fi.expandedFinalityData()
+	i := sort.Search(len(rels), func(i int) bool {
+		return rels[i].Derived.Number >= finalizedL2.Number
+	})
+	// The first buffered L1 block may not be the first L1 block the finalized L2 head was derived from,
+	// and an L2 block after all buffered data was not derived from any L1 block yet, as far as we know.
+	if i == 0 || i == len(rels) {
+		return eth.L2BlockRef{}, false
+	}
+	if rels[i].Source.ID.Number <= fi.finalizedL1.Number {
+		return eth.L2BlockRef{}, false
+	}
+	for j := i - 1; j >= 0; j-- {
+		// the L2 block refs of expanded entries are incomplete, and cannot be repaired to
+		if rels[j].Source.ID.Number <= fi.finalizedL1.Number && !rels[j].Source.Expanded {
+			return rels[j].Derived, true
+		}
+	}
+	return eth.L2BlockRef{}, true
+}
@@ -0,0 +1,88 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerUnjustifiedHead(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithMetrics(m))
+	for i := 1; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	// the engine finalized an L2 block that was derived from an L1 block after the finalized L1 block
+	ec.finalized = chain.l2[3][0]
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, 1, m.unjustified)
+	status := fi.Status()
+	require.NotNil(t, status.UnjustifiedFinalizedL2)
+	require.Equal(t, chain.l2[3][0], *status.UnjustifiedFinalizedL2)
+	require.Equal(t, chain.l2[3][0], ec.Finalized(), "not repaired by default")
+
+	// repeated detection of the same head is reported once
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, 1, m.unjustified)
+
+	// once the engine is back within the justified range, nothing is reported
+	ec.finalized = chain.l2[2][0]
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Nil(t, fi.Status().UnjustifiedFinalizedL2)
+}
+
+func TestFinalizerRepairUnjustified(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithMetrics(m), WithRepairUnjustified())
+	for i := 1; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	ec.finalized = chain.l2[3][1]
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, 1, m.unjustified)
+	require.Equal(t, chain.l2[2][1], ec.Finalized(), "reset to the latest justified L2 block")
+	require.Equal(t, chain.l2[2][1], ec.applied)
+	require.Nil(t, fi.Status().UnjustifiedFinalizedL2)
+}
+
+func TestFinalizerUnknownJustification(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	ec := &fakeEngine{}
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, ec, WithMetrics(m), WithRepairUnjustified())
+	fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+	fi.PostProcessSafeL2(chain.l2[3][1], chain.l1[3])
+
+	// the finalized head may have been derived from an L1 block before the buffered data
+	ec.finalized = chain.l2[1][1]
+	fi.Finalize(context.Background(), chain.l1[1])
+	// the finalized head is beyond the buffered data
+	ec.finalized = testutils.NextRandomL2Ref(rng, 1, chain.l2[3][1], chain.l1[3].ID())
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Zero(t, m.unjustified)
+	require.Nil(t, fi.Status().UnjustifiedFinalizedL2)
+}
@@ -0,0 +1,49 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FinalizerL1ChainInterface extends FinalizerL1Interface with the chain ID of the L1 source.
+// If the L1 source implements it, the L1 chain of the L1 source is verified against the rollup config,
+// and finality signals are rejected with ErrWrongL1Chain if the L1 source serves a different L1 chain,
+// rather than resetting the derivation pipeline over and over on the resulting block hash mismatches.
+type FinalizerL1ChainInterface interface {
+	FinalizerL1Interface
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// verifyL1Chain verifies that the L1 source serves the L1 chain of the rollup config:
+// it must be of the L1 chain ID, and have the L1 genesis block of the rollup.
+// The L1 chain is only verified once it matched. It returns an ErrWrongL1Chain if it does not match.
+// The lock must be held.
+func (fi *Finalizer) verifyL1Chain(ctx context.Context) error {
+	l1, ok := fi.l1Fetcher.(FinalizerL1ChainInterface)
+	if !ok || fi.l1ChainVerified {
+		return nil
+	}
+	if fi.cfg.L1ChainID != nil {
+		chainID, err := l1.ChainID(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch chain ID of L1 source: %w", err)
+		}
+		if chainID.Cmp(fi.cfg.L1ChainID) != 0 {
+			return &ErrWrongL1Chain{ExpectedChainID: fi.cfg.L1ChainID, ChainID: chainID, ExpectedGenesis: fi.cfg.Genesis.L1}
+		}
+	}
+	if genesis := fi.cfg.Genesis.L1; genesis != (eth.BlockID{}) {
+		ref, err := l1.L1BlockRefByNumber(ctx, genesis.Number)
+		if err != nil {
+			return &ErrL1Unavailable{Number: genesis.Number, Err: err}
+		}
+		if ref.Hash != genesis.Hash {
+			return &ErrWrongL1Chain{ExpectedChainID: fi.cfg.L1ChainID, ExpectedGenesis: genesis, Genesis: ref.ID()}
+		}
+	}
+	fi.l1ChainVerified = true
+	return nil
+}
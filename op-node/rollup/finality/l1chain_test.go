@@ -0,0 +1,95 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// chainL1Source is an L1 source that exposes its L1 chain ID.
+type chainL1Source struct {
+	*testutils.MockL1Source
+	chainID *big.Int
+}
+
+func (s *chainL1Source) ChainID(ctx context.Context) (*big.Int, error) {
+	return s.chainID, nil
+}
+
+var _ FinalizerL1ChainInterface = (*chainL1Source)(nil)
+
+func TestFinalizerWrongL1Chain(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 2)
+	genesis := testutils.RandomBlockRef(rng)
+	cfg := &rollup.Config{L1ChainID: big.NewInt(1)}
+	cfg.Genesis.L1 = genesis.ID()
+
+	setup := func(t *testing.T, chainID int64) (*Finalizer, *chainL1Source, *fakeEngine) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		l1F := &chainL1Source{MockL1Source: &testutils.MockL1Source{}, chainID: big.NewInt(chainID)}
+		t.Cleanup(func() { l1F.AssertExpectations(t) })
+		ec := &fakeEngine{}
+		ec.finalized = chain.l2[0][0]
+		fi := NewFinalizer(logger, cfg, l1F, ec)
+		fi.PostProcessSafeL2(chain.l2[0][1], chain.l1[0])
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[0]))
+		return fi, l1F, ec
+	}
+
+	t.Run("wrong chain ID", func(t *testing.T) {
+		fi, _, ec := setup(t, 11155111)
+		fi.Finalize(context.Background(), chain.l1[0])
+		require.Zero(t, fi.FinalizedL1())
+		require.Equal(t, chain.l2[0][0], ec.Finalized())
+		require.Equal(t, uint64(1), fi.counters.SignalsRejectedWrongChain)
+		require.Zero(t, fi.counters.Attempts)
+	})
+
+	t.Run("wrong genesis", func(t *testing.T) {
+		fi, l1F, _ := setup(t, 1)
+		l1F.ExpectL1BlockRefByNumber(genesis.Number, testutils.RandomBlockRef(rng), nil)
+		fi.Finalize(context.Background(), chain.l1[0])
+		require.Zero(t, fi.FinalizedL1())
+		require.Equal(t, uint64(1), fi.counters.SignalsRejectedWrongChain)
+	})
+
+	t.Run("unverified", func(t *testing.T) {
+		fi, l1F, ec := setup(t, 1)
+		// an unavailable L1 source does not delay finality, the L1 chain is verified again with the next signal
+		l1F.ExpectL1BlockRefByNumber(genesis.Number, genesis, errors.New("unavailable"))
+		l1F.ExpectL1BlockRefByNumber(chain.l1[0].Number, chain.l1[0], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[0].Number, chain.l1[0], nil)
+		fi.Finalize(context.Background(), chain.l1[0])
+		require.Equal(t, chain.l1[0], fi.FinalizedL1())
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+
+		// once verified, the L1 chain is not verified again
+		fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[1]))
+		l1F.ExpectL1BlockRefByNumber(genesis.Number, genesis, nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+		fi.Finalize(context.Background(), chain.l1[1])
+		require.Equal(t, chain.l2[1][1], ec.Finalized())
+		fi.Finalize(context.Background(), chain.l1[1])
+		require.Zero(t, fi.counters.SignalsRejectedWrongChain)
+	})
+}
+
+func TestErrWrongL1Chain(t *testing.T) {
+	err := &ErrWrongL1Chain{ExpectedChainID: big.NewInt(1), ChainID: big.NewInt(10)}
+	require.Equal(t, "L1 source is of chain 10, but the rollup is on L1 chain 1", err.Error())
+	err = &ErrWrongL1Chain{ExpectedChainID: big.NewInt(1)}
+	require.Contains(t, err.Error(), "at the height of the L1 genesis")
+}
@@ -0,0 +1,56 @@
+package finality
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// WithDeferredStart makes the Finalizer queue finality signals until Start is called,
+// so the Finalizer can be constructed, restored and subscribed to before any signal is processed.
+func WithDeferredStart() FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.stopped = true
+	}
+}
+
+// Start allows finality signals to be processed,
+// and processes the latest finality signal that was received while stopped, if any.
+func (fi *Finalizer) Start(ctx context.Context) {
+	fi.mu.Lock()
+	// the event loop does not run yet, so the engine may be read to initialize the finalized L2 head of the status
+	fi.engineFinalized()
+	fi.stopped = false
+	queued := fi.queuedSignal
+	fi.queuedSignal = eth.L1BlockRef{}
+	replay := fi.replay
+	fi.mu.Unlock()
+	if queued == (eth.L1BlockRef{}) {
+		return
+	}
+	fi.log.Info("processing finality signal received before start", "l1_finalized", queued)
+	if replay == nil {
+		replay = fi.Finalize
+	}
+	replay(ctx, queued)
+}
+
+// Stop queues any subsequent finality signals, until Start is called again.
+func (fi *Finalizer) Stop() {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	fi.stopped = true
+}
+
+// deferSignal queues the finality signal if the Finalizer is stopped, and returns true if it did.
+// Only the latest signal is retained, since older signals are superseded by it.
+func (fi *Finalizer) deferSignal(l1Origin eth.L1BlockRef) bool {
+	if !fi.stopped {
+		return false
+	}
+	if l1Origin.Number >= fi.queuedSignal.Number {
+		fi.queuedSignal = l1Origin
+	}
+	fi.log.Debug("queued finality signal until start", "l1_finalized", l1Origin)
+	return true
+}
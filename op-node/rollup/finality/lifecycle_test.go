@@ -0,0 +1,49 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerDeferredStart(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithDeferredStart())
+
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[2]))
+
+	// signals are queued while not started, only the latest is kept
+	fi.Finalize(context.Background(), chain.l1[2])
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, eth.L1BlockRef{}, fi.FinalizedL1())
+	require.Equal(t, chain.l2[0][1], ec.Finalized())
+
+	// the queued signal is processed on start
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	fi.Start(context.Background())
+	require.Equal(t, chain.l1[2], fi.FinalizedL1())
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+
+	// signals are queued again once stopped
+	fi.Stop()
+	fi.Finalize(context.Background(), chain.l1[3])
+	require.Equal(t, chain.l1[2], fi.FinalizedL1())
+}
@@ -0,0 +1,91 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrL1HistoryPending is returned by L1 sources that only make historical L1 blocks available with a delay,
+// like light clients that backfill the L1 blocks before their checkpoint. The sanity checks of attempts to finalize,
+// that need such L1 blocks, are deferred until the L1 source made them available, rather than failing the attempt.
+var ErrL1HistoryPending = errors.New("L1 block is not available from the L1 source yet")
+
+// LightClientSource is the L1 RPC served by a local light client, like Helios,
+// that only serves L1 blocks it verified against the beacon chain.
+type LightClientSource interface {
+	FinalizerL1Interface
+	L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error)
+}
+
+// LightClientL1 adapts an L1 light client as the L1 source and the L1 finality signal source of the Finalizer,
+// so the node can finalize without a full L1 RPC.
+// Light clients sync from a recent checkpoint, and only make older L1 blocks available as they backfill them:
+// L1 blocks that are not found, but are at or below the finalized L1 block, are reported as ErrL1HistoryPending.
+type LightClientL1 struct {
+	log log.Logger
+	l1  LightClientSource
+
+	mu sync.Mutex
+	// finalized is the latest finalized L1 block of the light client.
+	finalized eth.L1BlockRef
+}
+
+var _ FinalizerL1Interface = (*LightClientL1)(nil)
+
+func NewLightClientL1(log log.Logger, l1 LightClientSource) *LightClientL1 {
+	return &LightClientL1{log: log, l1: l1}
+}
+
+// L1BlockRefByLabel fetches the L1 block by label from the light client, and remembers the finalized L1 block.
+func (lc *LightClientL1) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error) {
+	ref, err := lc.l1.L1BlockRefByLabel(ctx, label)
+	if err != nil {
+		return eth.L1BlockRef{}, err
+	}
+	if label == eth.Finalized {
+		lc.mu.Lock()
+		if ref.Number >= lc.finalized.Number {
+			lc.finalized = ref
+		}
+		lc.mu.Unlock()
+	}
+	return ref, nil
+}
+
+func (lc *LightClientL1) L1BlockRefByNumber(ctx context.Context, number uint64) (eth.L1BlockRef, error) {
+	ref, err := lc.l1.L1BlockRefByNumber(ctx, number)
+	if errors.Is(err, ethereum.NotFound) && number <= lc.Finalized().Number {
+		return eth.L1BlockRef{}, fmt.Errorf("L1 block %d: %w", number, ErrL1HistoryPending)
+	}
+	return ref, err
+}
+
+func (lc *LightClientL1) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	ref, err := lc.l1.L1BlockRefByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) && lc.Finalized() != (eth.L1BlockRef{}) {
+		return eth.L1BlockRef{}, fmt.Errorf("L1 block %s: %w", hash, ErrL1HistoryPending)
+	}
+	return ref, err
+}
+
+// Finalized returns the latest finalized L1 block the light client reported, or a zeroed ref if none yet.
+func (lc *LightClientL1) Finalized() eth.L1BlockRef {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	return lc.finalized
+}
+
+// Subscribe polls the finalized L1 block of the light client, and calls fn with every new finalized L1 block,
+// as the L1 finality signal source of the node.
+func (lc *LightClientL1) Subscribe(fn eth.HeadSignalFn, interval time.Duration) ethereum.Subscription {
+	return eth.PollBlockChanges(lc.log, lc, fn, eth.Finalized, interval, time.Second*10)
+}
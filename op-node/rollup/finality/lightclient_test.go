@@ -0,0 +1,88 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/testutil"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// fakeLightClient serves the L1 blocks it verified, and the finalized L1 block.
+type fakeLightClient struct {
+	*testutil.L1
+	finalized eth.L1BlockRef
+}
+
+func (f *fakeLightClient) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error) {
+	if label != eth.Finalized {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return f.finalized, nil
+}
+
+func TestLightClientL1(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	src := &fakeLightClient{L1: testutil.NewL1(chain.l1[2], chain.l1[3]), finalized: chain.l1[2]}
+	lc := NewLightClientL1(testlog.Logger(t, log.LevelInfo), src)
+
+	// without a finalized L1 block, missing L1 blocks are not attributed to the light client history
+	_, err := lc.L1BlockRefByNumber(context.Background(), chain.l1[1].Number)
+	require.ErrorIs(t, err, ethereum.NotFound)
+	require.NotErrorIs(t, err, ErrL1HistoryPending)
+
+	ref, err := lc.L1BlockRefByLabel(context.Background(), eth.Finalized)
+	require.NoError(t, err)
+	require.Equal(t, chain.l1[2], ref)
+	require.Equal(t, chain.l1[2], lc.Finalized())
+
+	// L1 blocks before the checkpoint of the light client are pending
+	_, err = lc.L1BlockRefByNumber(context.Background(), chain.l1[1].Number)
+	require.ErrorIs(t, err, ErrL1HistoryPending)
+	_, err = lc.L1BlockRefByHash(context.Background(), chain.l1[1].Hash)
+	require.ErrorIs(t, err, ErrL1HistoryPending)
+	// L1 blocks beyond the finalized L1 block are not
+	_, err = lc.L1BlockRefByNumber(context.Background(), chain.l1[3].Number+1)
+	require.NotErrorIs(t, err, ErrL1HistoryPending)
+
+	ref, err = lc.L1BlockRefByNumber(context.Background(), chain.l1[3].Number)
+	require.NoError(t, err)
+	require.Equal(t, chain.l1[3], ref)
+}
+
+func TestFinalizerDefersPendingL1History(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	// the light client synced from a checkpoint at the 2nd L1 block, and did not backfill the L1 blocks before it yet
+	src := &fakeLightClient{L1: testutil.NewL1(chain.l1[2], chain.l1[3]), finalized: chain.l1[3]}
+	lc := NewLightClientL1(logger, src)
+	ec := testutil.NewEngine(chain.l2[0][1])
+	fi := NewFinalizer(logger, &rollup.Config{}, lc, ec)
+
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	signal, err := lc.L1BlockRefByLabel(context.Background(), eth.Finalized)
+	require.NoError(t, err)
+	fi.Finalize(context.Background(), signal)
+	// the sanity check of the L1 block the L2 blocks were derived from is deferred, without failing the attempt
+	require.Equal(t, chain.l2[0][1], ec.Finalized())
+	status := fi.Status()
+	require.Equal(t, ReasonL1Pending, status.LastReason)
+	require.Empty(t, status.LastError)
+	require.Equal(t, uint64(1), fi.DebugBundle().Counters.ChecksDeferred)
+
+	// once the light client backfilled the L1 block, the next derivation step finalizes
+	src.SetCanonical(chain.l1[1], chain.l1[2], chain.l1[3])
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+	ec.RequireFinalized(t, chain.l2[1][1])
+	require.Equal(t, ReasonFinalized, fi.Status().LastReason)
+}
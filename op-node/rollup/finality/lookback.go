@@ -0,0 +1,79 @@
+package finality
+
+// WithLookbackGrowth allows the finality data to grow beyond the finality lookback, up to maxLookback entries,
+// when finality signals stall and the entries that are not finalizable yet would otherwise be pruned.
+// This keeps the relations of a long L1 non-finality period, that are needed once L1 finality resumes.
+// The lookback shrinks back once the entries that are not finalizable yet fit in the finality lookback again.
+// Disabled if maxLookback does not exceed the finality lookback.
+func WithLookbackGrowth(maxLookback uint64) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.maxLookback = maxLookback
+	}
+}
+
+// pendingEntries returns the number of finality data entries, including compressed entries,
+// that are not finalizable yet with the current finality signal. The lock must be held.
+func (fi *Finalizer) pendingEntries() uint64 {
+	pending := uint64(0)
+	for i := len(fi.finalityData) - 1; i >= 0; i-- {
+		if fi.finalizable(fi.finalityData[i].Source.ID.Number) {
+			break
+		}
+		pending += 1 + fi.pendingCompressed(i)
+	}
+	return pending
+}
+
+// lookbackGrowth returns true if the finality lookback can grow beyond the configured lookback.
+func (fi *Finalizer) lookbackGrowth() bool {
+	return fi.maxLookback > fi.baseLookback
+}
+
+// adjustLookback grows the finality lookback by the configured lookback, up to the max lookback,
+// before the entries that are not finalizable yet get pruned to make room for a new entry,
+// and shrinks it back to the configured lookback once these entries fit in it again. The lock must be held.
+func (fi *Finalizer) adjustLookback() {
+	if !fi.lookbackGrowth() {
+		return
+	}
+	pending := fi.pendingEntries()
+	switch {
+	case pending >= fi.finalityLookback && fi.finalityLookback < fi.maxLookback:
+		prev := fi.finalityLookback
+		fi.finalityLookback = min(fi.maxLookback, fi.finalityLookback+fi.baseLookback)
+		fi.counters.LookbackGrowths += 1
+		fi.dataLog.Warn("finality signals stall, growing the finality lookback to retain the unfinalized finality data",
+			"prev_lookback", prev, "lookback", fi.finalityLookback, "max_lookback", fi.maxLookback,
+			"pending", pending, "finalized_l1", fi.finalizedL1)
+	case fi.finalityLookback > fi.baseLookback && pending < fi.baseLookback:
+		prev := fi.finalityLookback
+		fi.finalityLookback = fi.baseLookback
+		n := fi.pruneToLookback()
+		fi.dataLog.Info("finality caught up, shrinking the finality lookback",
+			"prev_lookback", prev, "lookback", fi.finalityLookback, "pending", pending, "pruned", n)
+	}
+}
+
+// growLookbackTo grows the finality lookback to retain n entries, up to the max lookback, e.g. to restore
+// the finality data of a snapshot that was taken while the lookback was grown. The lock must be held.
+func (fi *Finalizer) growLookbackTo(n uint64) {
+	if !fi.lookbackGrowth() || n <= fi.finalityLookback {
+		return
+	}
+	fi.finalityLookback = min(fi.maxLookback, n)
+	fi.counters.LookbackGrowths += 1
+}
+
+// pruneToLookback prunes the oldest finality data entries, to retain at most the finality lookback,
+// including the compressed entries. It returns the number of pruned entries. The lock must be held.
+func (fi *Finalizer) pruneToLookback() int {
+	n := 0
+	for len(fi.finalityData) > 1 && uint64(len(fi.finalityData))+fi.compressedEntries > fi.finalityLookback {
+		fi.counters.EntriesPruned += 1
+		fi.checkPruned(fi.finalityData[0])
+		fi.finalityData = fi.finalityData[1:]
+		fi.dropUnanchoredRuns()
+		n += 1
+	}
+	return n
+}
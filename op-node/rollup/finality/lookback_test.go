@@ -0,0 +1,76 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerLookbackGrowth(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 8)
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	for _, ref := range chain.l1 {
+		l1F.Mock.On("L1BlockRefByNumber", ref.Number).Return(ref, nil)
+	}
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithLookbackGrowth(4))
+	fi.finalityLookback = 2
+	fi.baseLookback = 2
+
+	// without finality signals, the lookback grows instead of pruning the unfinalized entries
+	for i := 1; i <= 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+	require.Len(t, fi.finalityData, 4)
+	require.Equal(t, uint64(4), fi.finalityLookback)
+	require.Equal(t, uint64(1), fi.DebugBundle().Counters.LookbackGrowths)
+	require.Zero(t, fi.DebugBundle().Counters.EntriesPrunedUnfinalized)
+	require.Zero(t, fi.Status().LookbackHeadroom)
+	require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn),
+		testlog.NewMessageContainsFilter("growing the finality lookback")))
+
+	// the lookback does not grow beyond the max
+	fi.PostProcessSafeL2(chain.l2[5][1], chain.l1[5])
+	require.Len(t, fi.finalityData, 4)
+	require.Equal(t, uint64(4), fi.finalityLookback)
+	require.Equal(t, uint64(1), fi.DebugBundle().Counters.EntriesPrunedUnfinalized)
+
+	// the retained entries are finalized once finality resumes
+	fi.Finalize(context.Background(), chain.l1[4])
+	require.Equal(t, chain.l2[4][1], ec.Finalized())
+
+	// after catching up, the lookback shrinks back
+	fi.PostProcessSafeL2(chain.l2[6][1], chain.l1[6])
+	require.Equal(t, uint64(2), fi.finalityLookback)
+	require.Len(t, fi.finalityData, 2)
+	require.Equal(t, chain.l1[5].ID(), fi.finalityData[0].Source.ID)
+	require.Equal(t, chain.l1[6].ID(), fi.finalityData[1].Source.ID)
+	require.Equal(t, uint64(1), fi.DebugBundle().Counters.EntriesPrunedUnfinalized)
+	require.NotNil(t, logs.FindLog(testlog.NewMessageContainsFilter("shrinking the finality lookback")))
+}
+
+func TestFinalizerLookbackGrowthDisabled(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	fi := NewFinalizer(testlog.Logger(t, log.LevelInfo), &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{})
+	fi.finalityLookback = 2
+	fi.baseLookback = 2
+
+	for i := 1; i <= 3; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+	require.Len(t, fi.finalityData, 2)
+	require.Equal(t, uint64(2), fi.finalityLookback)
+	require.Equal(t, uint64(1), fi.DebugBundle().Counters.EntriesPrunedUnfinalized)
+}
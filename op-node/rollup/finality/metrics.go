@@ -0,0 +1,97 @@
+package finality
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Metrics is the set of metrics the Finalizer reports to.
+type Metrics interface {
+	RecordL2Ref(name string, ref eth.L2BlockRef)
+	RecordFinalityStaleSignal()
+	RecordFinalityPanic()
+	RecordFinalityUnjustifiedHead()
+	RecordFinalityAttemptFailure(cause string)
+	RecordFinalityCatchUp(remainingL1 uint64)
+	RecordFinalityLookbackHeadroom(entries uint64)
+	RecordFinalityHalted(halted bool)
+	RecordFinalityStateDigest(digest common.Hash)
+	// RecordFinalityStall records the classified stall of finalization, or an empty reason if it is not stalled.
+	RecordFinalityStall(reason string)
+	// RecordFinalityDelay records the number of L1 blocks to traverse between attempts to finalize, as adapted.
+	RecordFinalityDelay(l1Blocks uint64)
+	// RecordFinalityRelation records the finalized L2 head, and the L1 block it was derived from, if known.
+	RecordFinalityRelation(finalizedL2 eth.L2BlockRef, derivedFrom eth.BlockID)
+	// RecordFinalityLag records how far the finalized L2 head lags behind the safe L2 head, in L2 blocks and seconds.
+	RecordFinalityLag(l2Blocks uint64, seconds uint64)
+	// RecordFinalityAttemptDuration records the duration of an attempt to finalize.
+	// The exemplar labels, if any, link the observation to the trace of the attempt.
+	RecordFinalityAttemptDuration(duration time.Duration, exemplar map[string]string)
+	// RecordFinalityEngineCall records the latency of an engine call that applies the finalized L2 head,
+	// as observed by the Finalizer, and whether it succeeded.
+	RecordFinalityEngineCall(duration time.Duration, success bool)
+	// RecordFinalitySLO records the compliance with the finality latency SLO over the given window,
+	// and the burn rate of its error budget.
+	RecordFinalitySLO(window string, compliance float64, burnRate float64)
+	// RecordFinalityPruningCasualty records a finality data entry that was pruned before it was finalized,
+	// and the number of L2 blocks that lost the opportunity to be finalized with it.
+	RecordFinalityPruningCasualty(l2Blocks uint64)
+	// RecordFinalitySafeRegression records a safe head that moved backwards without a reset.
+	RecordFinalitySafeRegression()
+	// RecordFinalityEngineAck records the payload status the engine responded with,
+	// to a forkchoice update that applied a finalized L2 head.
+	RecordFinalityEngineAck(status string)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) RecordL2Ref(name string, ref eth.L2BlockRef) {}
+
+func (noopMetrics) RecordFinalityStaleSignal() {}
+
+func (noopMetrics) RecordFinalityPanic() {}
+
+func (noopMetrics) RecordFinalityUnjustifiedHead() {}
+
+func (noopMetrics) RecordFinalityAttemptFailure(cause string) {}
+
+func (noopMetrics) RecordFinalityCatchUp(remainingL1 uint64) {}
+
+func (noopMetrics) RecordFinalityLookbackHeadroom(entries uint64) {}
+
+func (noopMetrics) RecordFinalityHalted(halted bool) {}
+
+func (noopMetrics) RecordFinalityStateDigest(digest common.Hash) {}
+
+func (noopMetrics) RecordFinalityStall(reason string) {}
+
+func (noopMetrics) RecordFinalityDelay(l1Blocks uint64) {}
+
+func (noopMetrics) RecordFinalityRelation(finalizedL2 eth.L2BlockRef, derivedFrom eth.BlockID) {}
+
+func (noopMetrics) RecordFinalityLag(l2Blocks uint64, seconds uint64) {}
+
+func (noopMetrics) RecordFinalityAttemptDuration(duration time.Duration, exemplar map[string]string) {
+}
+
+func (noopMetrics) RecordFinalityEngineCall(duration time.Duration, success bool) {}
+
+func (noopMetrics) RecordFinalitySLO(window string, compliance float64, burnRate float64) {}
+
+func (noopMetrics) RecordFinalityPruningCasualty(l2Blocks uint64) {}
+
+func (noopMetrics) RecordFinalitySafeRegression() {}
+
+func (noopMetrics) RecordFinalityEngineAck(status string) {}
+
+var _ Metrics = noopMetrics{}
+
+// WithMetrics configures the metrics the Finalizer reports to.
+func WithMetrics(m Metrics) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.metrics = m
+	}
+}
@@ -0,0 +1,101 @@
+package finality
+
+import (
+	"fmt"
+)
+
+// PlasmaChangeAction determines how a restored snapshot is handled,
+// if the plasma DA windows of the rollup config changed since the snapshot was taken, e.g. with a chain upgrade.
+// The finality lookback is sized to the DA windows, so the restored finality data may not fit the new lookback.
+type PlasmaChangeAction string
+
+const (
+	// PlasmaChangeMigrate resizes the restored finality data to the new lookback, and re-validates its entries.
+	PlasmaChangeMigrate PlasmaChangeAction = "migrate"
+	// PlasmaChangeDiscard discards the restored finality data, and only restores the finalized L1 block.
+	PlasmaChangeDiscard PlasmaChangeAction = "discard"
+	// PlasmaChangeFail refuses to restore the snapshot, so an operator can inspect it before the node starts.
+	PlasmaChangeFail PlasmaChangeAction = "fail"
+)
+
+// ParsePlasmaChangeAction parses the PlasmaChangeAction of a flag.
+func ParsePlasmaChangeAction(s string) (PlasmaChangeAction, error) {
+	switch a := PlasmaChangeAction(s); a {
+	case PlasmaChangeMigrate, PlasmaChangeDiscard, PlasmaChangeFail:
+		return a, nil
+	default:
+		return "", fmt.Errorf("unknown finality plasma change action %q", s)
+	}
+}
+
+// WithPlasmaChangeAction configures how a restored snapshot is handled, if the plasma DA windows changed since it was taken.
+// Defaults to PlasmaChangeMigrate.
+func WithPlasmaChangeAction(action PlasmaChangeAction) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.plasmaChangeAction = action
+	}
+}
+
+// ErrPlasmaConfigChanged is returned when a snapshot is restored with PlasmaChangeFail,
+// and the plasma DA windows changed since the snapshot was taken.
+type ErrPlasmaConfigChanged struct {
+	// SnapshotChallengeWindow and SnapshotResolveWindow are the DA windows the snapshot was taken with.
+	SnapshotChallengeWindow, SnapshotResolveWindow uint64
+	// ChallengeWindow and ResolveWindow are the DA windows of the current rollup config.
+	ChallengeWindow, ResolveWindow uint64
+}
+
+func (e *ErrPlasmaConfigChanged) Error() string {
+	return fmt.Sprintf("plasma DA windows changed since the finality snapshot was taken: challenge window %d -> %d, resolve window %d -> %d",
+		e.SnapshotChallengeWindow, e.ChallengeWindow, e.SnapshotResolveWindow, e.ResolveWindow)
+}
+
+// plasmaWindows returns the DA challenge and resolve windows of the rollup config, both 0 if plasma is disabled.
+func (fi *Finalizer) plasmaWindows() (challenge, resolve uint64) {
+	if !fi.cfg.PlasmaEnabled() {
+		return 0, 0
+	}
+	return fi.cfg.PlasmaConfig.DAChallengeWindow, fi.cfg.PlasmaConfig.DAResolveWindow
+}
+
+// migrateSnapshot returns the finality data of the snapshot to restore,
+// applying the PlasmaChangeAction if the plasma DA windows changed since the snapshot was taken.
+// Snapshots of older versions do not record the DA windows, and are restored as-is. The lock must be held.
+func (fi *Finalizer) migrateSnapshot(snapshot *Snapshot) ([]FinalityData, error) {
+	challenge, resolve := fi.plasmaWindows()
+	if snapshot.Lookback == 0 || (snapshot.DAChallengeWindow == challenge && snapshot.DAResolveWindow == resolve) {
+		return snapshot.FinalityData, nil
+	}
+	changed := &ErrPlasmaConfigChanged{
+		SnapshotChallengeWindow: snapshot.DAChallengeWindow, SnapshotResolveWindow: snapshot.DAResolveWindow,
+		ChallengeWindow: challenge, ResolveWindow: resolve,
+	}
+	switch fi.plasmaChangeAction {
+	case PlasmaChangeFail:
+		return nil, changed
+	case PlasmaChangeDiscard:
+		fi.log.Warn("discarding restored finality data, the plasma DA windows changed", "err", changed,
+			"entries", len(snapshot.FinalityData))
+		return nil, nil
+	}
+	// Re-validate the entries: they have to be in ascending order, which the new sizing relies on.
+	data := make([]FinalityData, 0, len(snapshot.FinalityData))
+	invalid := 0
+	for _, fd := range snapshot.FinalityData {
+		if n := len(data); n > 0 && (fd.L1Block.Number <= data[n-1].L1Block.Number || fd.L2Block.Number < data[n-1].L2Block.Number) {
+			invalid += 1
+			continue
+		}
+		data = append(data, fd)
+	}
+	// Resize to the new lookback, keeping the newest entries, which are the ones that are not finalized yet.
+	resized := 0
+	if uint64(len(data)) > fi.finalityLookback {
+		resized = len(data) - int(fi.finalityLookback)
+		data = data[resized:]
+	}
+	fi.log.Warn("migrated restored finality data, the plasma DA windows changed", "err", changed,
+		"prev_lookback", snapshot.Lookback, "lookback", fi.finalityLookback,
+		"entries", len(data), "dropped_invalid", invalid, "dropped_resized", resized)
+	return data, nil
+}
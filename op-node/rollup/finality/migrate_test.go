@@ -0,0 +1,77 @@
+package finality
+
+import (
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerRestorePlasmaChange(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 200)
+	logger := testlog.Logger(t, log.LevelInfo)
+	// the snapshot was taken with DA windows that sized the lookback to all entries
+	snap := &Snapshot{FinalizedL1: chain.l1[1], Lookback: 401, DAChallengeWindow: 200, DAResolveWindow: 200}
+	for i := range chain.l1 {
+		snap.FinalityData = append(snap.FinalityData, FinalityData{L2Block: chain.l2[i][1], L1Block: chain.l1[i].ID()})
+	}
+	// the chain upgrade shrinks the DA windows, and the lookback to the L1 finality lookback
+	cfg := &rollup.Config{PlasmaConfig: &rollup.PlasmaConfig{DAChallengeWindow: 50, DAResolveWindow: 50}}
+	newFinalizer := func(action PlasmaChangeAction) *Finalizer {
+		return NewFinalizer(logger, cfg, &testutils.MockL1Source{}, &fakeEngine{}, WithDeferredStart(), WithPlasmaChangeAction(action))
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		fi := NewFinalizer(logger, &rollup.Config{PlasmaConfig: &rollup.PlasmaConfig{DAChallengeWindow: 200, DAResolveWindow: 200}},
+			&testutils.MockL1Source{}, &fakeEngine{}, WithDeferredStart(), WithPlasmaChangeAction(PlasmaChangeFail))
+		require.NoError(t, fi.Restore(snap))
+		require.Equal(t, snap.FinalityData, fi.Snapshot().FinalityData)
+	})
+
+	t.Run("older snapshot", func(t *testing.T) {
+		older := *snap
+		older.Lookback, older.DAChallengeWindow, older.DAResolveWindow = 0, 0, 0
+		fi := newFinalizer(PlasmaChangeFail)
+		require.NoError(t, fi.Restore(&older))
+		require.Len(t, fi.Snapshot().FinalityData, defaultFinalityLookback)
+	})
+
+	t.Run("migrate", func(t *testing.T) {
+		migrated := *snap
+		migrated.FinalityData = append([]FinalityData{}, snap.FinalityData...)
+		// an entry out of order is dropped, instead of failing the restore
+		migrated.FinalityData[150] = migrated.FinalityData[10]
+		fi := newFinalizer(PlasmaChangeMigrate)
+		require.NoError(t, fi.Restore(&migrated))
+		out := fi.Snapshot()
+		require.Len(t, out.FinalityData, defaultFinalityLookback)
+		require.Equal(t, snap.FinalityData[len(snap.FinalityData)-1], out.FinalityData[len(out.FinalityData)-1])
+		require.NotContains(t, out.FinalityData, snap.FinalityData[150])
+		require.Equal(t, uint64(defaultFinalityLookback), out.Lookback)
+		require.Equal(t, uint64(50), out.DAChallengeWindow)
+		require.Equal(t, chain.l1[1], fi.queuedSignal, "the finalized L1 block is restored")
+	})
+
+	t.Run("discard", func(t *testing.T) {
+		fi := newFinalizer(PlasmaChangeDiscard)
+		require.NoError(t, fi.Restore(snap))
+		require.Empty(t, fi.Snapshot().FinalityData)
+		require.Equal(t, chain.l1[1], fi.queuedSignal, "the finalized L1 block is restored")
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		fi := newFinalizer(PlasmaChangeFail)
+		var changed *ErrPlasmaConfigChanged
+		require.ErrorAs(t, fi.Restore(snap), &changed)
+		require.Equal(t, uint64(200), changed.SnapshotChallengeWindow)
+		require.Equal(t, uint64(50), changed.ChallengeWindow)
+		require.Empty(t, fi.Snapshot().FinalityData)
+	})
+}
@@ -0,0 +1,337 @@
+package finality
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// Sink is an external destination of finalized events, like a webhook or a message queue. See NewSink.
+// Delivery is at-least-once: a message is delivered again until the sink accepts it, also across restarts,
+// so consumers must deduplicate messages by their ID. Sinks that hold connections may implement io.Closer,
+// to be closed with the outbox.
+type Sink interface {
+	// Name identifies the sink in the outbox. It must remain the same across restarts.
+	Name() string
+	// Deliver delivers the message to the sink, and returns nil once the sink accepted it.
+	Deliver(ctx context.Context, msg OutboxMessage) error
+}
+
+// OutboxMessage is a finalized event, as delivered to the sinks of the outbox.
+type OutboxMessage struct {
+	// ID increments with every event of the outbox, for consumers to deduplicate redelivered messages.
+	ID    uint64         `json:"id"`
+	Event FinalizedEvent `json:"event"`
+}
+
+// outboxEntry is a message of the outbox, and the names of the sinks it was delivered to.
+type outboxEntry struct {
+	OutboxMessage
+	Delivered []string `json:"delivered,omitempty"`
+}
+
+// outboxState is the persisted state of the outbox.
+type outboxState struct {
+	NextID  uint64        `json:"next_id"`
+	Entries []outboxEntry `json:"entries"`
+}
+
+// OutboxMetrics is the set of metrics the Outbox reports to.
+type OutboxMetrics interface {
+	RecordFinalityOutboxPersistFailure()
+	RecordFinalityOutboxDropped(count uint64)
+}
+
+type noopOutboxMetrics struct{}
+
+func (noopOutboxMetrics) RecordFinalityOutboxPersistFailure() {}
+
+func (noopOutboxMetrics) RecordFinalityOutboxDropped(count uint64) {}
+
+// sinkState is the delivery state of a sink of the outbox.
+type sinkState struct {
+	sink Sink
+	// attempts counts the failed attempts to deliver the oldest undelivered message, and retryAt is when it is retried.
+	attempts int
+	retryAt  time.Time
+}
+
+const (
+	// maxOutboxEntries bounds the undelivered messages, if a sink is unavailable for a long time.
+	// The oldest messages are dropped beyond that.
+	maxOutboxEntries = 10_000
+	// outboxDeliverTimeout bounds a single delivery to a sink.
+	outboxDeliverTimeout = 30 * time.Second
+)
+
+// Outbox delivers finalized events to external sinks, at least once.
+// Events are persisted to the outbox file before they are delivered, and only removed once every sink accepted them,
+// so they are not lost across node restarts. Each sink receives the events in order,
+// a sink that fails is retried with backoff, without holding up the other sinks.
+// Events are queued by OnFinalized, and persisted by the delivery loop of the outbox,
+// so the finalizer does not wait for the outbox file to be written.
+type Outbox struct {
+	log           log.Logger
+	path          string
+	clock         clock.Clock
+	retryStrategy retry.Strategy
+	metrics       OutboxMetrics
+
+	// queueMu guards the queued events, separately from the state, to not wait for the outbox file to be written.
+	queueMu sync.Mutex
+	queued  []FinalizedEvent
+
+	mu    sync.Mutex
+	state outboxState
+	sinks []*sinkState
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewOutbox creates an outbox that persists to the file at path, and restores the undelivered messages from it.
+// The metrics may be nil.
+func NewOutbox(log log.Logger, path string, sinks []Sink, m OutboxMetrics) (*Outbox, error) {
+	if len(sinks) == 0 {
+		return nil, errors.New("finality outbox has no sinks")
+	}
+	if m == nil {
+		m = noopOutboxMetrics{}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ob := &Outbox{
+		log:           log,
+		path:          path,
+		clock:         clock.SystemClock,
+		retryStrategy: retry.Exponential(),
+		metrics:       m,
+		wake:          make(chan struct{}, 1),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+	names := make(map[string]struct{})
+	for _, sink := range sinks {
+		if _, ok := names[sink.Name()]; ok {
+			cancel()
+			return nil, fmt.Errorf("duplicate finality outbox sink %q", sink.Name())
+		}
+		names[sink.Name()] = struct{}{}
+		ob.sinks = append(ob.sinks, &sinkState{sink: sink})
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		cancel()
+		return nil, fmt.Errorf("failed to read finality outbox: %w", err)
+	} else if err == nil {
+		if err := json.Unmarshal(data, &ob.state); err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to decode finality outbox: %w", err)
+		}
+		log.Info("restored finality outbox", "undelivered", len(ob.state.Entries))
+	}
+	return ob, nil
+}
+
+func (ob *Outbox) Start() {
+	ob.wg.Add(1)
+	go ob.loop()
+	ob.notify()
+}
+
+// Close stops the delivery loop, persists the events that were queued in the meantime, and closes the sinks.
+func (ob *Outbox) Close() {
+	ob.cancel()
+	ob.wg.Wait()
+	ob.admit()
+	for _, st := range ob.sinks {
+		if c, ok := st.sink.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				ob.log.Warn("failed to close finality outbox sink", "sink", st.sink.Name(), "err", err)
+			}
+		}
+	}
+}
+
+// OnFinalized queues the event, to be persisted to the outbox and delivered to the sinks by the delivery loop.
+// It is a FinalizedSubscriber: it does not wait for the event to be persisted, nor for the delivery.
+func (ob *Outbox) OnFinalized(ev FinalizedEvent) {
+	ob.queueMu.Lock()
+	ob.queued = append(ob.queued, ev)
+	ob.queueMu.Unlock()
+	ob.notify()
+}
+
+// admit persists the queued events to the outbox, dropping the oldest undelivered events beyond maxOutboxEntries.
+func (ob *Outbox) admit() {
+	ob.queueMu.Lock()
+	queued := ob.queued
+	ob.queued = nil
+	ob.queueMu.Unlock()
+	if len(queued) == 0 {
+		return
+	}
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+	for _, ev := range queued {
+		ob.state.Entries = append(ob.state.Entries, outboxEntry{OutboxMessage: OutboxMessage{ID: ob.state.NextID, Event: ev}})
+		ob.state.NextID += 1
+	}
+	if n := len(ob.state.Entries) - maxOutboxEntries; n > 0 {
+		ob.log.Error("dropping undelivered finalized events from the outbox, sinks are unavailable",
+			"dropped", n, "oldest", ob.state.Entries[n].ID)
+		ob.metrics.RecordFinalityOutboxDropped(uint64(n))
+		ob.state.Entries = append(ob.state.Entries[:0], ob.state.Entries[n:]...)
+	}
+	ob.persist()
+}
+
+func (ob *Outbox) notify() {
+	select {
+	case ob.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Undelivered returns the number of messages that are not delivered to all sinks yet, including the queued events.
+func (ob *Outbox) Undelivered() int {
+	ob.queueMu.Lock()
+	queued := len(ob.queued)
+	ob.queueMu.Unlock()
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+	return len(ob.state.Entries) + queued
+}
+
+// persist writes the outbox state to the outbox file. The lock must be held.
+// Failures are counted in the metrics: the events are still delivered, but may be lost or redelivered after a restart.
+func (ob *Outbox) persist() {
+	data, err := json.Marshal(&ob.state)
+	if err == nil {
+		err = writeFileAtomic(ob.path, data)
+	}
+	if err != nil {
+		ob.log.Error("failed to persist finality outbox", "err", err)
+		ob.metrics.RecordFinalityOutboxPersistFailure()
+	}
+}
+
+func (ob *Outbox) loop() {
+	defer ob.wg.Done()
+	for {
+		retryAt, retrying := ob.deliver(ob.ctx)
+		var timer clock.Timer
+		var timeout <-chan time.Time
+		if retrying {
+			timer = ob.clock.NewTimer(retryAt.Sub(ob.clock.Now()))
+			timeout = timer.Ch()
+		}
+		select {
+		case <-ob.ctx.Done():
+		case <-ob.wake:
+		case <-timeout:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ob.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// deliver delivers the undelivered messages to the sinks that are due, and drops the messages every sink accepted.
+// It returns when the earliest failed sink is to be retried, if any.
+func (ob *Outbox) deliver(ctx context.Context) (retryAt time.Time, retrying bool) {
+	ob.admit()
+	delivered := 0
+	ob.mu.Lock()
+	entries := slices.Clone(ob.state.Entries)
+	ob.mu.Unlock()
+
+	for _, st := range ob.sinks {
+		if now := ob.clock.Now(); now.Before(st.retryAt) {
+			retryAt, retrying = earliest(retryAt, retrying, st.retryAt)
+			continue
+		}
+		name := st.sink.Name()
+		for _, entry := range entries {
+			if slices.Contains(entry.Delivered, name) {
+				continue
+			}
+			dctx, cancel := context.WithTimeout(ctx, outboxDeliverTimeout)
+			err := st.sink.Deliver(dctx, entry.OutboxMessage)
+			cancel()
+			if err != nil {
+				if ctx.Err() != nil {
+					return time.Time{}, false
+				}
+				delay := ob.retryStrategy.Duration(st.attempts)
+				st.attempts += 1
+				st.retryAt = ob.clock.Now().Add(delay)
+				ob.log.Warn("failed to deliver finalized event to sink, retrying",
+					"sink", name, "id", entry.ID, "attempts", st.attempts, "retry_in", delay, "err", err)
+				retryAt, retrying = earliest(retryAt, retrying, st.retryAt)
+				break
+			}
+			st.attempts = 0
+			ob.markDelivered(entry.ID, name)
+			delivered += 1
+		}
+	}
+
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+	remaining := ob.state.Entries[:0]
+	for _, entry := range ob.state.Entries {
+		if !ob.deliveredToAll(entry) {
+			remaining = append(remaining, entry)
+		}
+	}
+	ob.state.Entries = remaining
+	if delivered > 0 {
+		ob.persist()
+	}
+	return retryAt, retrying
+}
+
+// markDelivered records that the message was delivered to the sink, unless it was dropped in the meantime.
+func (ob *Outbox) markDelivered(id uint64, name string) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+	entries := ob.state.Entries
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].ID >= id })
+	if i < len(entries) && entries[i].ID == id {
+		entries[i].Delivered = append(entries[i].Delivered, name)
+	}
+}
+
+// deliveredToAll returns true if the message was delivered to all configured sinks. The lock must be held.
+func (ob *Outbox) deliveredToAll(entry outboxEntry) bool {
+	for _, st := range ob.sinks {
+		if !slices.Contains(entry.Delivered, st.sink.Name()) {
+			return false
+		}
+	}
+	return true
+}
+
+func earliest(t time.Time, ok bool, next time.Time) (time.Time, bool) {
+	if !ok || next.Before(t) {
+		return next, true
+	}
+	return t, true
+}
@@ -0,0 +1,113 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeSink struct {
+	name      string
+	err       error
+	delivered []OutboxMessage
+}
+
+func (s *fakeSink) Name() string { return s.name }
+
+func (s *fakeSink) Deliver(ctx context.Context, msg OutboxMessage) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.delivered = append(s.delivered, msg)
+	return nil
+}
+
+func TestOutbox(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	a, b := &fakeSink{name: "a"}, &fakeSink{name: "b", err: errors.New("unavailable")}
+	newOutbox := func() *Outbox {
+		ob, err := NewOutbox(logger, path, []Sink{a, b}, nil)
+		require.NoError(t, err)
+		ob.clock = cl
+		ob.retryStrategy = retry.Fixed(time.Minute)
+		return ob
+	}
+	ob := newOutbox()
+	ev1 := FinalizedEvent{FinalizedL2: eth.L2BlockRef{Number: 10}}
+	ev2 := FinalizedEvent{FinalizedL2: eth.L2BlockRef{Number: 20}}
+	ob.OnFinalized(ev1)
+	ob.OnFinalized(ev2)
+	// the events are queued, and only persisted by the delivery loop
+	require.Equal(t, 2, ob.Undelivered())
+	require.NoFileExists(t, path)
+
+	// a failing sink is retried later, without holding up the other sinks
+	retryAt, retrying := ob.deliver(context.Background())
+	require.True(t, retrying)
+	require.Equal(t, cl.Now().Add(time.Minute), retryAt)
+	require.Equal(t, []OutboxMessage{{ID: 0, Event: ev1}, {ID: 1, Event: ev2}}, a.delivered)
+	require.Equal(t, 2, ob.Undelivered())
+
+	// the undelivered events survive a restart, and are not delivered again to the sinks that accepted them
+	ob = newOutbox()
+	require.Equal(t, 2, ob.Undelivered())
+	b.err = nil
+	_, retrying = ob.deliver(context.Background())
+	require.False(t, retrying)
+	require.Len(t, a.delivered, 2)
+	require.Equal(t, []OutboxMessage{{ID: 0, Event: ev1}, {ID: 1, Event: ev2}}, b.delivered)
+	require.Equal(t, 0, ob.Undelivered())
+
+	// message IDs continue after the restart
+	ob = newOutbox()
+	ob.OnFinalized(ev1)
+	ob.deliver(context.Background())
+	require.Equal(t, uint64(2), a.delivered[2].ID)
+}
+
+type fakeOutboxMetrics struct {
+	persistFailures int
+	dropped         uint64
+}
+
+func (m *fakeOutboxMetrics) RecordFinalityOutboxPersistFailure() { m.persistFailures += 1 }
+
+func (m *fakeOutboxMetrics) RecordFinalityOutboxDropped(count uint64) { m.dropped += count }
+
+func TestOutboxPersistFailure(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	// the directory of the outbox file does not exist, so the outbox cannot be persisted
+	path := filepath.Join(t.TempDir(), "missing", "outbox.json")
+	m := &fakeOutboxMetrics{}
+	a := &fakeSink{name: "a", err: errors.New("unavailable")}
+	ob, err := NewOutbox(logger, path, []Sink{a}, m)
+	require.NoError(t, err)
+	ob.retryStrategy = retry.Fixed(time.Minute)
+	ob.OnFinalized(FinalizedEvent{FinalizedL2: eth.L2BlockRef{Number: 10}})
+	ob.deliver(context.Background())
+	require.Equal(t, 1, m.persistFailures)
+	// the event is still delivered, once the sink is available
+	require.Equal(t, 1, ob.Undelivered())
+}
+
+func TestOutboxSinks(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	_, err := NewOutbox(logger, path, nil, nil)
+	require.Error(t, err)
+	_, err = NewOutbox(logger, path, []Sink{&fakeSink{name: "a"}, &fakeSink{name: "a"}}, nil)
+	require.ErrorContains(t, err, "duplicate")
+}
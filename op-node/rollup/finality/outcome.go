@@ -0,0 +1,89 @@
+package finality
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// FinalityAction is the action the rollup driver has to take after a finalization step.
+type FinalityAction uint8
+
+const (
+	// ActionNone requires no action: the step succeeded, or there was nothing to finalize.
+	ActionNone FinalityAction = iota
+	// ActionRetry requires the driver to retry later, e.g. after a L1 or engine RPC failure.
+	ActionRetry
+	// ActionReset requires the driver to reset the derivation pipeline, e.g. after a L1 reorg.
+	ActionReset
+	// ActionHalt requires the driver to stop, e.g. when the finalized L2 head is inconsistent.
+	ActionHalt
+)
+
+func (a FinalityAction) String() string {
+	switch a {
+	case ActionNone:
+		return "none"
+	case ActionRetry:
+		return "retry"
+	case ActionReset:
+		return "reset"
+	case ActionHalt:
+		return "halt"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(a))
+	}
+}
+
+// FinalityOutcome describes the result of a finalization step, and the action the driver has to take.
+// It makes the contract between the finalizer and the driver explicit,
+// where the finalizer previously only signaled the action by wrapping its errors with a derive error level.
+type FinalityOutcome struct {
+	Action FinalityAction
+	// Err is the error that caused the action, nil for ActionNone.
+	Err error
+}
+
+// OutcomeOf maps the error of a finalization step to its outcome, by its derive error level.
+// Errors without a level are retried, as the driver does with any other unclassified derivation error.
+func OutcomeOf(err error) FinalityOutcome {
+	switch {
+	case err == nil:
+		return FinalityOutcome{}
+	case errors.Is(err, derive.ErrCritical):
+		return FinalityOutcome{Action: ActionHalt, Err: err}
+	case errors.Is(err, derive.ErrReset):
+		return FinalityOutcome{Action: ActionReset, Err: err}
+	default:
+		return FinalityOutcome{Action: ActionRetry, Err: err}
+	}
+}
+
+// AsError returns the outcome as an error with the derive error level of its action,
+// for callers that handle finalization steps by their error, like the engine FinalizerHooks.
+// The error of the outcome is returned as-is if it already has that level.
+func (o FinalityOutcome) AsError() error {
+	err := o.Err
+	if err == nil {
+		if o.Action == ActionNone {
+			return nil
+		}
+		err = fmt.Errorf("finalizer requires action %s", o.Action)
+	}
+	switch o.Action {
+	case ActionHalt:
+		if !errors.Is(err, derive.ErrCritical) {
+			return derive.NewCriticalError(err)
+		}
+	case ActionReset:
+		if !errors.Is(err, derive.ErrReset) {
+			return derive.NewResetError(err)
+		}
+	case ActionRetry:
+		if !errors.Is(err, derive.ErrTemporary) {
+			return derive.NewTemporaryError(err)
+		}
+	}
+	return err
+}
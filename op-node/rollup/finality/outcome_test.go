@@ -0,0 +1,75 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestOutcomeOf(t *testing.T) {
+	plain := errors.New("boom")
+	for _, tc := range []struct {
+		err    error
+		action FinalityAction
+		level  error
+	}{
+		{err: nil, action: ActionNone},
+		{err: plain, action: ActionRetry, level: derive.ErrTemporary},
+		{err: derive.NewTemporaryError(plain), action: ActionRetry, level: derive.ErrTemporary},
+		{err: derive.NewResetError(plain), action: ActionReset, level: derive.ErrReset},
+		{err: derive.NewCriticalError(plain), action: ActionHalt, level: derive.ErrCritical},
+	} {
+		outcome := OutcomeOf(tc.err)
+		require.Equal(t, tc.action, outcome.Action, "error %v", tc.err)
+		require.Equal(t, tc.err, outcome.Err)
+		if tc.level == nil {
+			require.NoError(t, outcome.AsError())
+			continue
+		}
+		require.ErrorIs(t, outcome.AsError(), tc.level)
+		require.ErrorIs(t, outcome.AsError(), plain)
+	}
+	// leveled errors are passed through as-is
+	resetErr := derive.NewResetError(plain)
+	require.Equal(t, resetErr, OutcomeOf(resetErr).AsError())
+	// actions without an error still carry their level
+	require.ErrorIs(t, FinalityOutcome{Action: ActionHalt}.AsError(), derive.ErrCritical)
+}
+
+func TestFinalizerOutcome(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	for _, ref := range chain.l1 {
+		l1F.Mock.On("L1BlockRefByNumber", ref.Number).Return(ref, nil)
+	}
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][0]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	require.Equal(t, FinalityOutcome{}, fi.OnDerivationL1EndOutcome(context.Background(), chain.l1[1]))
+	require.Equal(t, FinalityOutcome{}, fi.FinalizeOutcome(context.Background(), chain.l1[1]))
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+
+	// a finalized head that the engine fails to apply is retried
+	ec.fcuErr = errors.New("engine down")
+	fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+	outcome := fi.FinalizeOutcome(context.Background(), chain.l1[2])
+	require.Equal(t, ActionRetry, outcome.Action)
+	require.ErrorIs(t, outcome.Err, ec.fcuErr)
+	require.ErrorIs(t, outcome.AsError(), derive.ErrTemporary)
+	// the retry backs off, so the next derived L1 block does not require any action yet
+	require.Equal(t, FinalityOutcome{}, fi.OnDerivationL1EndOutcome(context.Background(), chain.l1[2]))
+}
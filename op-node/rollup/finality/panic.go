@@ -0,0 +1,56 @@
+package finality
+
+import (
+	"runtime/debug"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// maxConsecutivePanics is the number of consecutive panics after which finalization is temporarily disabled.
+	maxConsecutivePanics = 3
+	// panicCooldown is the duration finalization is disabled for, after repeated panics.
+	panicCooldown = 10 * time.Minute
+)
+
+// guard runs a finalization step, and recovers from any panic in it, e.g. from a misbehaving engine client,
+// so a panic does not take down the driver. A panic is reported as a critical error,
+// and returned as a temporary error, so derivation continues and finalization is retried later.
+// After repeated consecutive panics, finalization is disabled for a cooldown period.
+// The lock must be held.
+func (fi *Finalizer) guard(fn func() error) (err error) {
+	if fi.halted {
+		fi.recordAttempt(ReasonHalted, nil)
+		return nil
+	}
+	if fi.forkConflict != (eth.BlockID{}) {
+		fi.recordAttempt(ReasonForkConflict, nil)
+		return nil
+	}
+	if fi.clock.Now().Before(fi.disabledUntil) {
+		fi.recordAttempt(ReasonDisabled, nil)
+		return nil
+	}
+	defer func() {
+		r := recover()
+		if r == nil {
+			fi.panics = 0
+			return
+		}
+		panicErr := &ErrFinalizerPanic{Value: r, Stack: debug.Stack()}
+		fi.panics += 1
+		fi.metrics.RecordFinalityPanic()
+		fi.log.Error("critical finalizer failure, recovered from panic",
+			"err", panicErr, "panics", fi.panics, "stack", string(panicErr.Stack))
+		if fi.panics >= maxConsecutivePanics {
+			fi.disabledUntil = fi.clock.Now().Add(panicCooldown)
+			fi.panics = 0
+			fi.log.Error("temporarily disabling finalization after repeated panics", "disabled_until", fi.disabledUntil)
+		}
+		fi.recordAttempt(ReasonError, panicErr)
+		err = derive.NewTemporaryError(panicErr)
+	}()
+	return fn()
+}
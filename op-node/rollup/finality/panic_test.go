@@ -0,0 +1,77 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// panickingEngine simulates a misbehaving engine client
+type panickingEngine struct {
+	fakeEngine
+	panics bool
+}
+
+func (f *panickingEngine) Finalized() eth.L2BlockRef {
+	if f.panics {
+		panic("engine client failure")
+	}
+	return f.fakeEngine.Finalized()
+}
+
+func TestFinalizerPanic(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelCrit)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &panickingEngine{panics: true}
+	ec.finalized = chain.l2[0][1]
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithClock(clk), WithMetrics(m))
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+
+	// the panic is recovered
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, 1, m.panics)
+	require.Equal(t, ReasonError, fi.lastReason)
+
+	// and returned as temporary error to the driver
+	err := fi.OnDerivationL1End(context.Background(), chain.l1[2])
+	require.ErrorIs(t, err, derive.ErrTemporary)
+	var panicErr *ErrFinalizerPanic
+	require.True(t, errors.As(err, &panicErr))
+	require.Equal(t, "engine client failure", panicErr.Value)
+	require.Equal(t, 2, m.panics)
+
+	// repeated panics disable finalization
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, 3, m.panics)
+	ec.panics = false
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[2]))
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, ReasonDisabled, fi.Status().LastReason)
+	require.Equal(t, chain.l2[0][1], ec.Finalized())
+
+	// finalization resumes after the cooldown
+	clk.AdvanceTime(panicCooldown)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+	require.Equal(t, 3, m.panics)
+}
@@ -0,0 +1,102 @@
+package finality
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync/atomic"
+
+	"github.com/lib/pq"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/api"
+)
+
+// postgresSnapshotsTable holds the snapshots of the PostgresStateStore, one row per key.
+const postgresSnapshotsTable = "op_node_finality_snapshots"
+
+// PostgresStateStore stores the snapshot as a row of a Postgres table, keyed by the key of the store,
+// so the nodes of a fleet share the snapshot through a database, and several fleets can share the table.
+// The table is created on the first save, if it does not exist yet.
+type PostgresStateStore struct {
+	db  *sql.DB
+	key string
+
+	tableReady atomic.Bool
+}
+
+var _ FinalityStateStore = (*PostgresStateStore)(nil)
+
+// NewPostgresStateStore creates a store for the snapshot with the given key, in the database of the lib/pq connection string.
+// Connections are only established on use.
+func NewPostgresStateStore(dsn string, key string) (*PostgresStateStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open finality state store database: %w", err)
+	}
+	return &PostgresStateStore{db: db, key: key}, nil
+}
+
+// parsePostgresURL parses a postgres://[user@]host[:port]/database?key=snapshot URL,
+// and returns the connection string without the key, and the key.
+// A password in the URL is refused: lib/pq reads it from the PGPASSWORD environment variable or the PGPASSFILE file.
+// Further query parameters, like sslmode, are passed to lib/pq.
+func parsePostgresURL(u *url.URL) (dsn string, key string, err error) {
+	if _, ok := u.User.Password(); ok {
+		return "", "", errors.New("finality state store Postgres URL must not contain a password, set PGPASSWORD or PGPASSFILE instead")
+	}
+	query := u.Query()
+	key = query.Get("key")
+	if u.Host == "" || key == "" {
+		return "", "", errors.New("finality state store Postgres URL must be postgres://host/database?key=snapshot")
+	}
+	query.Del("key")
+	dsnURL := *u
+	dsnURL.RawQuery = query.Encode()
+	return dsnURL.String(), key, nil
+}
+
+func (s *PostgresStateStore) SaveSnapshot(ctx context.Context, snapshot *Snapshot) error {
+	data, err := snapshot.MarshalCompressed()
+	if err != nil {
+		return fmt.Errorf("failed to encode finality snapshot: %w", err)
+	}
+	if !s.tableReady.Load() {
+		_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+postgresSnapshotsTable+` (
+			key TEXT PRIMARY KEY,
+			snapshot BYTEA NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`)
+		if err != nil {
+			return fmt.Errorf("failed to create finality snapshots table: %w", err)
+		}
+		s.tableReady.Store(true)
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO `+postgresSnapshotsTable+` (key, snapshot) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET snapshot = EXCLUDED.snapshot, updated_at = now()`, s.key, data)
+	if err != nil {
+		return fmt.Errorf("failed to save finality snapshot: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresStateStore) LoadSnapshot(ctx context.Context) (*Snapshot, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx, `SELECT snapshot FROM `+postgresSnapshotsTable+` WHERE key = $1`, s.key).Scan(&data)
+	var pqErr *pq.Error
+	if errors.Is(err, sql.ErrNoRows) || (errors.As(err, &pqErr) && pqErr.Code == "42P01") { // 42P01: undefined_table
+		return nil, ErrNoSnapshot
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load finality snapshot: %w", err)
+	}
+	snapshot, err := api.DecodeSnapshot(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode finality snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+func (s *PostgresStateStore) Close() error {
+	return s.db.Close()
+}
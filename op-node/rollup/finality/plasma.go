@@ -28,9 +28,9 @@ type PlasmaFinalizer struct {
 
 func NewPlasmaFinalizer(log log.Logger, cfg *rollup.Config,
 	l1Fetcher FinalizerL1Interface, ec FinalizerEngine,
-	backend PlasmaBackend) *PlasmaFinalizer {
+	backend PlasmaBackend, opts ...FinalizerOption) *PlasmaFinalizer {
 
-	inner := NewFinalizer(log, cfg, l1Fetcher, ec)
+	inner := NewFinalizer(log, cfg, l1Fetcher, ec, opts...)
 
 	// In alt-da mode, the finalization signal is proxied through the plasma manager.
 	// Finality signal will come from the DA contract or L1 finality whichever is last.
@@ -48,3 +48,9 @@ func NewPlasmaFinalizer(log log.Logger, cfg *rollup.Config,
 func (fi *PlasmaFinalizer) Finalize(ctx context.Context, l1Origin eth.L1BlockRef) {
 	fi.backend.Finalize(l1Origin)
 }
+
+// FinalizeOutcome proxies the L1 finality signal to the plasma backend, like Finalize, which requires no action.
+func (fi *PlasmaFinalizer) FinalizeOutcome(ctx context.Context, l1Origin eth.L1BlockRef) FinalityOutcome {
+	fi.Finalize(ctx, l1Origin)
+	return FinalityOutcome{}
+}
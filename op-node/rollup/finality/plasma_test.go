@@ -84,7 +84,7 @@ func TestPlasmaFinalityData(t *testing.T) {
 	}
 
 	ec := &fakeEngine{}
-	ec.SetFinalizedHead(refA1)
+	ec.finalized = refA1
 
 	// Simulate plasma finality by waiting for the finalized-inclusion
 	// of a commitment to turn into undisputed finalized data.
@@ -0,0 +1,102 @@
+package finality
+
+import (
+	"context"
+	"slices"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// PrepareFinalize verifies the L1 blocks that the finalization attempt of a list of L1 finality signals,
+// sorted by block number like FinalizeRange, depends on, without using the engine.
+// It is intended to run outside of the driver event loop, as soon as the signals are received,
+// so the subsequent FinalizeRange of the signals on the event loop does not have to wait for L1 requests.
+// The signals are only accepted by FinalizeRange, the finality state is not changed.
+// It returns the latest of the signals, or false if none of the signals can be accepted.
+// If verifying an L1 block panicked, the signals are not prepared, and an ErrFinalizerPanic is returned.
+func (fi *Finalizer) PrepareFinalize(ctx context.Context, signals []eth.L1BlockRef) (eth.L1BlockRef, bool, error) {
+	fi.mu.Lock()
+	if len(signals) == 0 || !slices.IsSortedFunc(signals, compareSignals) || fi.halted {
+		fi.mu.Unlock()
+		return eth.L1BlockRef{}, false, nil
+	}
+	signal := signals[len(signals)-1]
+	if signal.Number < fi.finalizedL1.Number {
+		fi.mu.Unlock()
+		return eth.L1BlockRef{}, false, nil
+	}
+	r, found := fi.finalityData.Finalizable(fi.lastSetFinalized, func(source l1Source) bool {
+		return source.ID.Number+fi.extraConfirmations <= signal.Number
+	}, func(finalityRelation, bool) bool { return true })
+	fi.mu.Unlock()
+	if fi.trustSignal || !found {
+		return signal, true, nil
+	}
+	// The L1 source is not guarded by the lock, the blocks are verified without it,
+	// to not block the event loop on the Finalizer in the meantime.
+	ids := []eth.BlockID{signal.ID()}
+	if r.Source.ID != signal.ID() {
+		ids = append(ids, r.Source.ID)
+	}
+	canonical := make([]bool, len(ids))
+	recovered := make([]any, len(ids))
+	ctx, cancel := context.WithTimeout(ctx, canonicalCheckTimeout)
+	defer cancel()
+	var g errgroup.Group
+	for i, id := range ids {
+		i, id := i, id
+		g.Go(recoverCheck(&recovered[i], func() error {
+			ref, err := fi.l1Fetcher.L1BlockRefByNumber(ctx, id.Number)
+			canonical[i] = err == nil && ref.Hash == id.Hash
+			return err
+		}))
+	}
+	err := g.Wait()
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	for _, r := range recovered {
+		if r == nil {
+			continue
+		}
+		// the L1 source misbehaves: none of the verified blocks are trusted, and the signals are not prepared
+		panicErr := &ErrFinalizerPanic{Value: r}
+		fi.metrics.RecordFinalityPanic()
+		fi.log.Error("critical finalizer failure, recovered from panic while verifying L1 blocks",
+			"l1_finalized", signal, "err", panicErr)
+		return eth.L1BlockRef{}, false, panicErr
+	}
+	if err != nil {
+		// the finalization attempt verifies the L1 blocks again, and reports any failure
+		fi.log.Debug("failed to verify L1 blocks ahead of finalization", "l1_finalized", signal, "err", err)
+	}
+	// the verified blocks are only used once the signal is accepted, see acceptSignal
+	fi.preparedFor = signal
+	clear(fi.preparedL1)
+	for i, id := range ids {
+		if canonical[i] {
+			fi.preparedL1[id.Number] = id.Hash
+		}
+	}
+	return signal, true, nil
+}
+
+// PrepareFinalize does not prepare the plasma backend, the signals are applied by FinalizeRange.
+func (fi *PlasmaFinalizer) PrepareFinalize(ctx context.Context, signals []eth.L1BlockRef) (eth.L1BlockRef, bool, error) {
+	signal, ok := latestSignal(signals)
+	return signal, ok, nil
+}
+
+// PrepareFinalize does not prepare following the primary rollup node, the signals are only a cue for FinalizeRange.
+func (fi *FollowFinalizer) PrepareFinalize(ctx context.Context, signals []eth.L1BlockRef) (eth.L1BlockRef, bool, error) {
+	signal, ok := latestSignal(signals)
+	return signal, ok, nil
+}
+
+func latestSignal(signals []eth.L1BlockRef) (eth.L1BlockRef, bool) {
+	if len(signals) == 0 {
+		return eth.L1BlockRef{}, false
+	}
+	return signals[len(signals)-1], true
+}
@@ -0,0 +1,94 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerPrepareFinalize(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][0]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+	for i := range chain.l1 {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[i]))
+	}
+
+	// the signal and the L1 block to finalize from are verified ahead of the attempt, without the engine
+	signals := []eth.L1BlockRef{chain.l1[1], chain.l1[2]}
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	signal, ok, err := fi.PrepareFinalize(context.Background(), signals)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, chain.l1[2], signal)
+	require.Equal(t, chain.l2[0][0], ec.Finalized())
+	require.Zero(t, fi.counters.Attempts)
+	// the signals are only accepted by the attempt
+	require.Equal(t, eth.L1BlockRef{}, fi.FinalizedL1())
+
+	// the attempt applies the finalized L2 head without any L1 requests
+	outcome := fi.FinalizeRangeOutcome(context.Background(), signals)
+	require.Equal(t, ActionNone, outcome.Action)
+	require.Equal(t, chain.l1[2], fi.FinalizedL1())
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+	require.Equal(t, uint64(1), fi.counters.Attempts)
+
+	// signals that cannot be accepted are not prepared
+	_, ok, err = fi.PrepareFinalize(context.Background(), []eth.L1BlockRef{chain.l1[2], chain.l1[0]})
+	require.NoError(t, err)
+	require.False(t, ok)
+	_, ok, err = fi.PrepareFinalize(context.Background(), []eth.L1BlockRef{chain.l1[0]})
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+// panickingL1 is an L1 source that panics on every request.
+type panickingL1 struct {
+	testutils.MockL1Source
+}
+
+func (p *panickingL1) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	panic("L1 client failure")
+}
+
+func TestFinalizerPrepareFinalizePanic(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][0]
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, &panickingL1{}, ec, WithMetrics(m))
+	for i := range chain.l1 {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[i]))
+	}
+
+	// the panic of the fetch is recovered, reported, and the signals are not prepared
+	_, ok, err := fi.PrepareFinalize(context.Background(), []eth.L1BlockRef{chain.l1[1], chain.l1[2]})
+	var panicErr *ErrFinalizerPanic
+	require.ErrorAs(t, err, &panicErr)
+	require.Equal(t, "L1 client failure", panicErr.Value)
+	require.False(t, ok)
+	require.Equal(t, 1, m.panics)
+	require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelError),
+		testlog.NewMessageContainsFilter("recovered from panic while verifying L1 blocks")))
+	require.Equal(t, eth.L1BlockRef{}, fi.preparedFor)
+	require.Empty(t, fi.preparedL1)
+	require.Equal(t, chain.l2[0][0], ec.Finalized())
+}
@@ -0,0 +1,30 @@
+package finality
+
+import (
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+// applyProfile applies the finality profile of the chain config, as the defaults of the Finalizer.
+// The finalizer options are applied after, so the finality flags of the node take precedence over the profile.
+func (fi *Finalizer) applyProfile(cfg *rollup.Config) {
+	params, err := cfg.FinalityParams()
+	if err != nil {
+		fi.log.Warn("ignoring invalid finality profile of the chain config", "err", err)
+		return
+	}
+	fi.profileLookback = params.Lookback
+	if params.Delay != 0 {
+		fi.finalityDelay = params.Delay
+	}
+	fi.trustSignal = params.TrustSignal
+	fi.extraConfirmations = params.ExtraConfirmations
+}
+
+// l1Lookback returns the L1 finality lookback: the lookback of the finality profile if set,
+// or else the lookback sized to the L1 beacon chain.
+func (fi *Finalizer) l1Lookback() uint64 {
+	if fi.profileLookback != 0 {
+		return fi.profileLookback
+	}
+	return l1FinalityLookback(fi.l1SlotsPerEpoch)
+}
@@ -0,0 +1,38 @@
+package finality
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestFinalizerProfile(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+
+	fi := NewFinalizer(logger, &rollup.Config{}, nil, &fakeEngine{})
+	require.Equal(t, uint64(finalityDelay), fi.finalityDelay)
+	require.Equal(t, uint64(defaultFinalityLookback), fi.finalityLookback)
+
+	cfg := &rollup.Config{
+		FinalityProfile:   rollup.FinalityProfileDevnet,
+		FinalityOverrides: &rollup.FinalityProfile{Lookback: 300, TrustSignal: true, ExtraConfirmations: 2},
+	}
+	fi = NewFinalizer(logger, cfg, nil, &fakeEngine{})
+	require.Equal(t, uint64(1), fi.finalityDelay)
+	require.Equal(t, uint64(300), fi.finalityLookback)
+	require.True(t, fi.trustSignal)
+	require.Equal(t, uint64(2), fi.extraConfirmations)
+
+	// the finalizer options take precedence over the profile
+	fi = NewFinalizer(logger, cfg, nil, &fakeEngine{}, WithExtraConfirmations(5))
+	require.Equal(t, uint64(5), fi.extraConfirmations)
+
+	// an unknown profile is ignored
+	fi = NewFinalizer(logger, &rollup.Config{FinalityProfile: "fast"}, nil, &fakeEngine{})
+	require.Equal(t, uint64(finalityDelay), fi.finalityDelay)
+}
@@ -0,0 +1,28 @@
+package finality
+
+// catchUpL1 returns the L1 block number that derivation has to fully derive up to,
+// for finalization to catch up with the finalized L1 block, and the number of L1 blocks left to derive until then.
+// The remaining count is 0 if derivation is already past the finalized L1 block, i.e. the node is not syncing.
+// The lock must be held.
+func (fi *Finalizer) catchUpL1() (target uint64, remaining uint64) {
+	if fi.finalizedL1.Number == 0 || fi.finalizedL1.Number < fi.extraConfirmations {
+		return 0, 0
+	}
+	target = fi.finalizedL1.Number - fi.extraConfirmations
+	if fi.derivedFromL1.Number > target {
+		return target, 0
+	}
+	return target, target - fi.derivedFromL1.Number + 1
+}
+
+// reportProgress publishes how far derivation lags behind the finalized L1 block.
+// If newSignal is set, it hints operators when finalization is expected to catch up.
+// The lock must be held.
+func (fi *Finalizer) reportProgress(newSignal bool) {
+	target, remaining := fi.catchUpL1()
+	fi.metrics.RecordFinalityCatchUp(remaining)
+	if newSignal && remaining > 0 {
+		fi.log.Info("finalization will catch up after deriving up to L1 block", "l1_block", target,
+			"derived_from", fi.derivedFromL1, "remaining", remaining, "finalized_l1", fi.finalizedL1)
+	}
+}
@@ -0,0 +1,61 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerCatchUpProgress(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 6)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, &fakeEngine{}, WithMetrics(m))
+
+	// no finality signal yet, nothing to catch up with
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[1]))
+	require.Equal(t, uint64(0), m.catchUp)
+	require.Equal(t, uint64(0), fi.Status().CatchUpL1)
+	require.Equal(t, chain.l1[1], fi.Status().DerivedFromL1)
+
+	// the finalized L1 block is ahead of derivation, the node is syncing
+	fi.Finalize(context.Background(), chain.l1[4])
+	require.Equal(t, uint64(4), m.catchUp)
+	require.Equal(t, chain.l1[4].Number, fi.Status().CatchUpL1)
+
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+	require.Equal(t, uint64(2), m.catchUp)
+	require.Equal(t, chain.l1[4].Number, fi.Status().CatchUpL1)
+
+	// derivation is past the finalized L1 block
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[5]))
+	require.Equal(t, uint64(0), m.catchUp)
+	require.Equal(t, uint64(0), fi.Status().CatchUpL1)
+}
+
+func TestFinalizerCatchUpExtraConfirmations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 6)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, &fakeEngine{}, WithMetrics(m), WithExtraConfirmations(2))
+
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[1]))
+	fi.Finalize(context.Background(), chain.l1[5])
+	// L2 blocks have to be derived from 2 L1 blocks below the finalized L1 block
+	require.Equal(t, chain.l1[3].Number, fi.Status().CatchUpL1)
+	require.Equal(t, uint64(3), m.catchUp)
+}
@@ -0,0 +1,97 @@
+package finality
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// signalReceipt is the latest finality signal received from a signal source.
+type signalReceipt struct {
+	source     string
+	ref        eth.L1BlockRef
+	receivedAt time.Time
+}
+
+// WithSignalWeights assigns a trust weight to each signal source, by label.
+// A finality signal is only accepted once sources with a combined weight of at least threshold signaled
+// the same, or a later, L1 block. Sources without weight are recorded, but never contribute.
+// This is useful when migrating between L1 providers, to require agreement of the old and new provider.
+func WithSignalWeights(weights map[string]uint64, threshold uint64) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.signalWeights = weights
+		fi.signalThreshold = max(threshold, 1)
+	}
+}
+
+// ParseSignalWeights parses a comma-separated list of trust weights of signal sources, e.g. "l1=1,l1_hash=1".
+func ParseSignalWeights(spec string) (map[string]uint64, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	weights := make(map[string]uint64)
+	for _, kv := range strings.Split(spec, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(kv), "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("expected source=weight, got %q", kv)
+		}
+		weight, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight of signal source %q: %q", k, v)
+		}
+		weights[k] = weight
+	}
+	return weights, nil
+}
+
+// weighSignal records the signal of the given source, and returns the L1 block that is backed by enough
+// trust weight to be accepted, with its provenance. It returns false if no L1 block is backed by enough weight.
+// Without trust weights, every signal is accepted as-is. The lock must be held.
+func (fi *Finalizer) weighSignal(l1Origin eth.L1BlockRef, source string) (eth.L1BlockRef, SignalProvenance, bool) {
+	now := fi.clock.Now()
+	if fi.signalWeights == nil {
+		return l1Origin, SignalProvenance{Source: source, ReceivedAt: now}, true
+	}
+	if fi.signalReceipts == nil {
+		fi.signalReceipts = make(map[string]signalReceipt)
+	}
+	if prev, ok := fi.signalReceipts[source]; !ok || l1Origin.Number >= prev.ref.Number {
+		fi.signalReceipts[source] = signalReceipt{source: source, ref: l1Origin, receivedAt: now}
+	}
+	receipts := make([]signalReceipt, 0, len(fi.signalReceipts))
+	for _, r := range fi.signalReceipts {
+		if fi.signalWeights[r.source] > 0 {
+			receipts = append(receipts, r)
+		}
+	}
+	// the latest signals first, ordered by label for determinism
+	sort.Slice(receipts, func(i, j int) bool {
+		if receipts[i].ref.Number != receipts[j].ref.Number {
+			return receipts[i].ref.Number > receipts[j].ref.Number
+		}
+		return receipts[i].source < receipts[j].source
+	})
+	weight := uint64(0)
+	for _, r := range receipts {
+		weight += fi.signalWeights[r.source]
+		if weight >= fi.signalThreshold {
+			return r.ref, SignalProvenance{Source: r.source, ReceivedAt: r.receivedAt, Weight: weight}, true
+		}
+	}
+	fi.log.Debug("finality signal is not backed by enough trust weight yet", "source", source,
+		"signaled_finalized_l1", l1Origin, "weight", weight, "threshold", fi.signalThreshold)
+	return eth.L1BlockRef{}, SignalProvenance{}, false
+}
+
+// provenance returns the provenance of the accepted finality signal, if any. The lock must be held.
+func (fi *Finalizer) provenance() *SignalProvenance {
+	if fi.signalProvenance == (SignalProvenance{}) {
+		return nil
+	}
+	prov := fi.signalProvenance
+	return &prov
+}
@@ -0,0 +1,93 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerSignalProvenance(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithClock(clk))
+	require.Nil(t, fi.Status().SignalProvenance)
+
+	for i := 1; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+	var events []FinalizedEvent
+	fi.SubscribeFinalized(func(ev FinalizedEvent) {
+		events = append(events, ev)
+	})
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	fi.FinalizeFrom(context.Background(), chain.l1[2], "light-client")
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+
+	expected := &SignalProvenance{Source: "light-client", ReceivedAt: clk.Now()}
+	require.Equal(t, expected, fi.Status().SignalProvenance)
+	require.Len(t, events, 1)
+	require.Equal(t, expected, events[0].Provenance)
+}
+
+func TestFinalizerSignalWeights(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 5)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	weights := map[string]uint64{"old": 1, "new": 1}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithClock(clk), WithSignalWeights(weights, 2))
+	for i := 1; i < 5; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	// a single source, or sources without weight, are not enough
+	fi.FinalizeFrom(context.Background(), chain.l1[3], "old")
+	fi.FinalizeFrom(context.Background(), chain.l1[3], "unknown")
+	require.Equal(t, chain.l2[0][1], ec.Finalized())
+	require.Nil(t, fi.Status().SignalProvenance)
+
+	// the sources agree on the older of their signals
+	clk.AdvanceTime(time.Second)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	fi.FinalizeFrom(context.Background(), chain.l1[2], "new")
+	require.Equal(t, chain.l1[2], fi.FinalizedL1())
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+	require.Equal(t, &SignalProvenance{Source: "new", ReceivedAt: clk.Now(), Weight: 2}, fi.Status().SignalProvenance)
+}
+
+func TestParseSignalWeights(t *testing.T) {
+	weights, err := ParseSignalWeights("")
+	require.NoError(t, err)
+	require.Nil(t, weights)
+
+	weights, err = ParseSignalWeights("l1=1, l1_hash=2")
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{SignalSourceL1: 1, SignalSourceHash: 2}, weights)
+
+	_, err = ParseSignalWeights("l1")
+	require.ErrorContains(t, err, "expected source=weight")
+	_, err = ParseSignalWeights("l1=-1")
+	require.ErrorContains(t, err, "invalid weight")
+}
@@ -0,0 +1,185 @@
+package finality
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// PruningState is the finality state that pruning decisions are based on.
+type PruningState struct {
+	// Latest is the latest L1 block in the finality data.
+	Latest eth.BlockID
+	// FinalizedL1 is the latest L1 finality signal.
+	FinalizedL1 eth.L1BlockRef
+	// FinalizedL2 is the finalized L2 head the Finalizer last set on the engine.
+	FinalizedL2 eth.L2BlockRef
+}
+
+// PruningPolicy decides which finality data entries are retained.
+// The lookback of the policy is a hard capacity limit, on top of which the policy may prune the oldest entries earlier,
+// e.g. to drop entries that are already finalized, while keeping entries for pending alt-DA challenges longer.
+type PruningPolicy interface {
+	// Lookback returns the maximum number of entries to retain, given the default lookback of the chain.
+	Lookback(defaultLookback uint64) uint64
+	// Prune returns true if the oldest entry should be pruned.
+	// It is called after every new entry, for as long as it returns true, and at least one entry is retained.
+	Prune(oldest FinalityData, state PruningState) bool
+}
+
+// WithPruningPolicy overrides the pruning of the finality data. By default, the oldest entries are only pruned
+// to stay within the finality lookback of the chain.
+func WithPruningPolicy(p PruningPolicy) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.pruning = p
+	}
+}
+
+// CapacityPruning only prunes the oldest entries to stay within a capacity.
+type CapacityPruning struct {
+	// Capacity is the maximum number of entries to retain. The default lookback of the chain is used if 0.
+	Capacity uint64
+}
+
+func (p CapacityPruning) Lookback(defaultLookback uint64) uint64 {
+	if p.Capacity == 0 {
+		return defaultLookback
+	}
+	return p.Capacity
+}
+
+func (p CapacityPruning) Prune(oldest FinalityData, state PruningState) bool {
+	return false
+}
+
+// AgePruning prunes the entries of L1 blocks more than MaxAge L1 blocks behind the latest entry.
+type AgePruning struct {
+	CapacityPruning
+	MaxAge uint64
+}
+
+func (p AgePruning) Prune(oldest FinalityData, state PruningState) bool {
+	return oldest.L1Block.Number+p.MaxAge < state.Latest.Number
+}
+
+// FinalizedPruning prunes the entries of L2 blocks below the finalized L2 head,
+// which are not needed to advance finalization anymore.
+type FinalizedPruning struct {
+	CapacityPruning
+}
+
+func (p FinalizedPruning) Prune(oldest FinalityData, state PruningState) bool {
+	return oldest.L2Block.Number < state.FinalizedL2.Number
+}
+
+// AnyPruning combines pruning policies: it retains the maximum lookback of the policies,
+// and prunes an entry if any of the policies prunes it.
+type AnyPruning []PruningPolicy
+
+func (p AnyPruning) Lookback(defaultLookback uint64) uint64 {
+	lookback := uint64(0)
+	for _, policy := range p {
+		lookback = max(lookback, policy.Lookback(defaultLookback))
+	}
+	if lookback == 0 {
+		return defaultLookback
+	}
+	return lookback
+}
+
+func (p AnyPruning) Prune(oldest FinalityData, state PruningState) bool {
+	for _, policy := range p {
+		if policy.Prune(oldest, state) {
+			return true
+		}
+	}
+	return false
+}
+
+// PruningConfig configures the pruning policy of the finality data.
+type PruningConfig struct {
+	// Capacity is the maximum number of entries to retain. The default lookback of the chain is used if 0.
+	Capacity uint64 `json:"capacity"`
+	// MaxAge prunes the entries of L1 blocks more than MaxAge L1 blocks behind the latest entry. Disabled if 0.
+	MaxAge uint64 `json:"max_age"`
+	// Finalized prunes the entries of L2 blocks below the finalized L2 head.
+	Finalized bool `json:"finalized"`
+}
+
+// Enabled returns true if the pruning differs from the default pruning.
+func (c PruningConfig) Enabled() bool {
+	return c.Capacity != 0 || c.MaxAge != 0 || c.Finalized
+}
+
+// Policy returns the pruning policy: an entry is pruned if any of the configured policies prunes it.
+func (c PruningConfig) Policy() PruningPolicy {
+	capacity := CapacityPruning{Capacity: c.Capacity}
+	policy := AnyPruning{capacity}
+	if c.MaxAge != 0 {
+		policy = append(policy, AgePruning{CapacityPruning: capacity, MaxAge: c.MaxAge})
+	}
+	if c.Finalized {
+		policy = append(policy, FinalizedPruning{CapacityPruning: capacity})
+	}
+	return policy
+}
+
+// ParsePruningConfig parses a comma-separated list of pruning policies, e.g. "capacity=2000,age=300,finalized".
+func ParsePruningConfig(spec string) (PruningConfig, error) {
+	var cfg PruningConfig
+	if spec == "" {
+		return cfg, nil
+	}
+	for _, kv := range strings.Split(spec, ",") {
+		k, v, hasValue := strings.Cut(strings.TrimSpace(kv), "=")
+		switch k {
+		case "capacity", "age":
+			if !hasValue {
+				return PruningConfig{}, fmt.Errorf("expected %s=value, got %q", k, kv)
+			}
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil || n == 0 {
+				return PruningConfig{}, fmt.Errorf("invalid %s of pruning policy: %q", k, v)
+			}
+			if k == "capacity" {
+				cfg.Capacity = n
+			} else {
+				cfg.MaxAge = n
+			}
+		case "finalized":
+			if hasValue {
+				return PruningConfig{}, fmt.Errorf("pruning policy %q takes no value, got %q", k, kv)
+			}
+			cfg.Finalized = true
+		default:
+			return PruningConfig{}, fmt.Errorf("unknown pruning policy %q", k)
+		}
+	}
+	return cfg, nil
+}
+
+// applyPruning prunes the oldest finality data entries, as decided by the pruning policy.
+// It returns the number of pruned entries. The lock must be held.
+func (fi *Finalizer) applyPruning() int {
+	if len(fi.finalityData) == 0 {
+		return 0
+	}
+	state := PruningState{
+		Latest:      fi.finalityData[len(fi.finalityData)-1].Source.ID,
+		FinalizedL1: fi.finalizedL1,
+		FinalizedL2: fi.lastSetFinalized,
+	}
+	n := 0
+	for len(fi.finalityData) > 1 && fi.pruning.Prune(toFinalityData(fi.finalityData[0]), state) {
+		fi.counters.EntriesPruned += 1
+		fi.checkPruned(fi.finalityData[0])
+		fi.finalityData = fi.finalityData[1:]
+		n += 1
+	}
+	if n > 0 {
+		fi.dataLog.Debug("pruned finality data", "entries", n, "oldest_l1", fi.finalityData[0].Source.ID)
+	}
+	return n
+}
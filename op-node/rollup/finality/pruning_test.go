@@ -0,0 +1,101 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestPruningPolicies(t *testing.T) {
+	entry := func(l1, l2 uint64) FinalityData {
+		return FinalityData{L1Block: eth.BlockID{Number: l1}, L2Block: eth.L2BlockRef{Number: l2}}
+	}
+	state := PruningState{Latest: eth.BlockID{Number: 100}, FinalizedL2: eth.L2BlockRef{Number: 50}}
+
+	require.Equal(t, uint64(10), CapacityPruning{}.Lookback(10))
+	require.Equal(t, uint64(20), CapacityPruning{Capacity: 20}.Lookback(10))
+	require.False(t, CapacityPruning{}.Prune(entry(0, 0), state))
+
+	age := AgePruning{MaxAge: 10}
+	require.True(t, age.Prune(entry(89, 0), state))
+	require.False(t, age.Prune(entry(90, 0), state))
+	require.Equal(t, uint64(10), age.Lookback(10))
+
+	finalized := FinalizedPruning{}
+	require.True(t, finalized.Prune(entry(0, 49), state))
+	require.False(t, finalized.Prune(entry(0, 50), state))
+
+	combined := AnyPruning{CapacityPruning{Capacity: 30}, age, finalized}
+	require.Equal(t, uint64(30), combined.Lookback(10))
+	require.True(t, combined.Prune(entry(89, 60), state))
+	require.True(t, combined.Prune(entry(95, 49), state))
+	require.False(t, combined.Prune(entry(95, 60), state))
+	require.Equal(t, uint64(10), AnyPruning{}.Lookback(10))
+}
+
+func TestParsePruningConfig(t *testing.T) {
+	cfg, err := ParsePruningConfig("")
+	require.NoError(t, err)
+	require.False(t, cfg.Enabled())
+
+	cfg, err = ParsePruningConfig("capacity=2000, age=300,finalized")
+	require.NoError(t, err)
+	require.Equal(t, PruningConfig{Capacity: 2000, MaxAge: 300, Finalized: true}, cfg)
+	require.True(t, cfg.Enabled())
+	policy := cfg.Policy()
+	require.Equal(t, uint64(2000), policy.Lookback(100))
+	state := PruningState{Latest: eth.BlockID{Number: 1000}, FinalizedL2: eth.L2BlockRef{Number: 50}}
+	require.True(t, policy.Prune(FinalityData{L1Block: eth.BlockID{Number: 600}, L2Block: eth.L2BlockRef{Number: 60}}, state), "too old")
+	require.True(t, policy.Prune(FinalityData{L1Block: eth.BlockID{Number: 900}, L2Block: eth.L2BlockRef{Number: 40}}, state), "finalized")
+	require.False(t, policy.Prune(FinalityData{L1Block: eth.BlockID{Number: 900}, L2Block: eth.L2BlockRef{Number: 60}}, state))
+
+	for _, spec := range []string{"capacity", "capacity=0", "age=x", "finalized=1", "oldest"} {
+		_, err = ParsePruningConfig(spec)
+		require.Error(t, err, spec)
+	}
+}
+
+func TestFinalizerFinalizedPruning(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][0]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithPruningPolicy(FinalizedPruning{CapacityPruning{Capacity: 100}}))
+	require.Equal(t, uint64(100), fi.finalityLookback)
+
+	for i := 0; i < 3; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[i]))
+	}
+	require.Len(t, fi.finalityData, 3)
+
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+
+	// the entries below the finalized L2 head are pruned on the next entry, the finalized entry itself is retained
+	fi.PostProcessSafeL2(chain.l2[3][1], chain.l1[3])
+	require.Len(t, fi.finalityData, 3)
+	require.Equal(t, chain.l1[1].ID(), fi.finalityData[0].Source.ID)
+	require.Equal(t, uint64(1), fi.counters.EntriesPruned)
+	require.Zero(t, fi.counters.EntriesPrunedUnfinalized)
+
+	// finalization continues from the retained entries
+	l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+	fi.Finalize(context.Background(), chain.l1[3])
+	require.Equal(t, chain.l2[3][1], ec.Finalized())
+}
@@ -0,0 +1,202 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// ReceiptSigner signs finalized-range receipts, like the P2P signer of the sequencer.
+type ReceiptSigner interface {
+	Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (sig *[65]byte, err error)
+}
+
+type ReceiptOutputSource interface {
+	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+}
+
+const receiptSignTimeout = 10 * time.Second
+
+// ReceiptIssuer issues a finalized-range receipt for every advancement of the finalized L2 head,
+// for off-chain services, like bridges, that want attestations tied directly to the finality of the node.
+// Receipts are issued asynchronously, advancements that happen while a receipt is being issued
+// are coalesced into the next receipt, so the ranges of consecutive receipts are always contiguous.
+// An advancement that fails to be issued a receipt is retried with backoff, coalesced with any later advancements.
+type ReceiptIssuer struct {
+	log           log.Logger
+	chainID       *big.Int
+	outputs       ReceiptOutputSource
+	signer        ReceiptSigner
+	clock         clock.Clock
+	retryStrategy retry.Strategy
+
+	mu sync.Mutex
+	// pending is the advancement that has not been issued a receipt yet.
+	pending *FinalizedEvent
+	// attempts counts the failed attempts to issue the pending receipt, and retryAt is when it is retried.
+	attempts int
+	retryAt  time.Time
+	// receipts are the most recently issued receipts, in ascending order, at most maxReceipts.
+	receipts    []FinalizedRangeReceipt
+	maxReceipts int
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewReceiptIssuer(log log.Logger, chainID *big.Int, outputs ReceiptOutputSource, signer ReceiptSigner, maxReceipts int) *ReceiptIssuer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ReceiptIssuer{
+		log:           log,
+		chainID:       chainID,
+		outputs:       outputs,
+		signer:        signer,
+		clock:         clock.SystemClock,
+		retryStrategy: retry.Exponential(),
+		maxReceipts:   maxReceipts,
+		wake:          make(chan struct{}, 1),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+func (ri *ReceiptIssuer) Start() {
+	ri.wg.Add(1)
+	go ri.loop()
+}
+
+func (ri *ReceiptIssuer) Close() {
+	ri.cancel()
+	ri.wg.Wait()
+}
+
+// OnFinalized queues a receipt for the advancement of the finalized L2 head. It is a FinalizedSubscriber, and does not block.
+func (ri *ReceiptIssuer) OnFinalized(ev FinalizedEvent) {
+	ri.mu.Lock()
+	if ri.pending == nil {
+		ri.pending = &ev
+	} else {
+		// coalesce with the advancement that is still pending
+		ri.pending.Coalesce(ev)
+	}
+	ri.mu.Unlock()
+	select {
+	case ri.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Receipts returns the retained receipts of the ranges that finalize the given L2 block number or later blocks.
+func (ri *ReceiptIssuer) Receipts(fromL2 uint64) []FinalizedRangeReceipt {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	i := sort.Search(len(ri.receipts), func(i int) bool {
+		return ri.receipts[i].Range.FinalizedL2.Number >= fromL2
+	})
+	return append([]FinalizedRangeReceipt(nil), ri.receipts[i:]...)
+}
+
+func (ri *ReceiptIssuer) loop() {
+	defer ri.wg.Done()
+	for {
+		retryAt, retrying := ri.issuePending(ri.ctx)
+		var timer clock.Timer
+		var timeout <-chan time.Time
+		if retrying {
+			timer = ri.clock.NewTimer(retryAt.Sub(ri.clock.Now()))
+			timeout = timer.Ch()
+		}
+		select {
+		case <-ri.ctx.Done():
+		case <-ri.wake:
+		case <-timeout:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ri.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// issuePending issues a receipt for the pending advancement, unless a failed attempt is still backing off.
+// A failed advancement is pending again, coalesced with the advancements that were queued in the meantime.
+// It returns when the pending advancement is to be retried, if it failed.
+func (ri *ReceiptIssuer) issuePending(ctx context.Context) (retryAt time.Time, retrying bool) {
+	ri.mu.Lock()
+	ev := ri.pending
+	if ev == nil {
+		ri.mu.Unlock()
+		return time.Time{}, false
+	}
+	if ri.clock.Now().Before(ri.retryAt) {
+		ri.mu.Unlock()
+		return ri.retryAt, true
+	}
+	ri.pending = nil
+	ri.mu.Unlock()
+	receipt, err := ri.issue(ctx, ev)
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	if err != nil {
+		if ri.pending != nil {
+			ev.Coalesce(*ri.pending)
+		}
+		ri.pending = ev
+		if ctx.Err() != nil {
+			return time.Time{}, false
+		}
+		delay := ri.retryStrategy.Duration(ri.attempts)
+		ri.attempts += 1
+		ri.retryAt = ri.clock.Now().Add(delay)
+		ri.log.Error("failed to issue finalized-range receipt, retrying", "prev_finalized_l2", ev.PrevFinalizedL2,
+			"finalized_l2", ev.FinalizedL2, "attempts", ri.attempts, "retry_in", delay, "err", err)
+		return ri.retryAt, true
+	}
+	ri.attempts = 0
+	ri.log.Debug("issued finalized-range receipt", "prev_finalized_l2", ev.PrevFinalizedL2,
+		"finalized_l2", ev.FinalizedL2, "finalized_l1", ev.FinalizedL1)
+	ri.receipts = append(ri.receipts, *receipt)
+	if len(ri.receipts) > ri.maxReceipts {
+		ri.receipts = ri.receipts[len(ri.receipts)-ri.maxReceipts:]
+	}
+	// advancements that were queued while issuing are issued right away
+	return time.Time{}, false
+}
+
+func (ri *ReceiptIssuer) issue(ctx context.Context, ev *FinalizedEvent) (*FinalizedRangeReceipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, receiptSignTimeout)
+	defer cancel()
+	output, err := ri.outputs.OutputV0AtBlock(ctx, ev.FinalizedL2.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch output of finalized L2 block %s: %w", ev.FinalizedL2, err)
+	}
+	r := FinalizedRange{
+		PrevFinalizedL2: ev.PrevFinalizedL2.ID(),
+		FinalizedL2:     ev.FinalizedL2.ID(),
+		OutputRoot:      eth.OutputRoot(output),
+		FinalizedL1:     ev.FinalizedL1.ID(),
+	}
+	msg, err := r.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode finalized range: %w", err)
+	}
+	sig, err := ri.signer.Sign(ctx, SigningDomainFinalizedRangeV1, ri.chainID, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign finalized range: %w", err)
+	}
+	return &FinalizedRangeReceipt{Range: r, Signature: sig[:]}, nil
+}
@@ -0,0 +1,132 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type fakeOutputs struct{}
+
+func (fakeOutputs) OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error) {
+	return &eth.OutputV0{StateRoot: eth.Bytes32(blockHash), BlockHash: blockHash}, nil
+}
+
+func TestReceiptIssuer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	priv := testutils.RandomKey()
+	chainID := big.NewInt(42)
+	ri := NewReceiptIssuer(logger, chainID, fakeOutputs{}, p2p.NewLocalSigner(priv), 2)
+	ri.Start()
+	defer ri.Close()
+
+	ri.OnFinalized(FinalizedEvent{PrevFinalizedL2: chain.l2[0][1], FinalizedL2: chain.l2[1][1], FinalizedL1: chain.l1[1]})
+	require.Eventually(t, func() bool { return len(ri.Receipts(0)) == 1 }, 5*time.Second, 10*time.Millisecond)
+	ri.OnFinalized(FinalizedEvent{PrevFinalizedL2: chain.l2[1][1], FinalizedL2: chain.l2[2][1], FinalizedL1: chain.l1[2]})
+	require.Eventually(t, func() bool { return len(ri.Receipts(0)) == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	receipt := ri.Receipts(0)[1]
+	require.Equal(t, FinalizedRange{
+		PrevFinalizedL2: chain.l2[1][1].ID(),
+		FinalizedL2:     chain.l2[2][1].ID(),
+		OutputRoot:      eth.OutputRoot(&eth.OutputV0{StateRoot: eth.Bytes32(chain.l2[2][1].Hash), BlockHash: chain.l2[2][1].Hash}),
+		FinalizedL1:     chain.l1[2].ID(),
+	}, receipt.Range)
+
+	// a bridge verifies the receipt by recovering the signer of the signing hash of the range
+	msg, err := receipt.Range.MarshalBinary()
+	require.NoError(t, err)
+	hash, err := p2p.SigningHash(SigningDomainFinalizedRangeV1, chainID, msg)
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(hash[:], receipt.Signature)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(priv.PublicKey), crypto.PubkeyToAddress(*pub))
+
+	// only the most recent receipts are retained
+	ri.OnFinalized(FinalizedEvent{PrevFinalizedL2: chain.l2[2][1], FinalizedL2: chain.l2[3][1], FinalizedL1: chain.l1[3]})
+	require.Eventually(t, func() bool {
+		receipts := ri.Receipts(0)
+		return len(receipts) == 2 && receipts[1].Range.FinalizedL2 == chain.l2[3][1].ID()
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, ri.Receipts(chain.l2[3][1].Number), 1)
+	require.Empty(t, ri.Receipts(chain.l2[3][1].Number+1))
+}
+
+func TestReceiptIssuerCoalesce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	ri := NewReceiptIssuer(testlog.Logger(t, log.LevelInfo), big.NewInt(42), fakeOutputs{}, p2p.NewLocalSigner(testutils.RandomKey()), 10)
+
+	// advancements before the issuer processes them are combined into a single contiguous range
+	ri.OnFinalized(FinalizedEvent{PrevFinalizedL2: chain.l2[0][1], FinalizedL2: chain.l2[1][1], FinalizedL1: chain.l1[1]})
+	ri.OnFinalized(FinalizedEvent{PrevFinalizedL2: chain.l2[1][1], FinalizedL2: chain.l2[3][1], FinalizedL1: chain.l1[3]})
+	ri.Start()
+	defer ri.Close()
+	require.Eventually(t, func() bool { return len(ri.Receipts(0)) == 1 }, 5*time.Second, 10*time.Millisecond)
+	r := ri.Receipts(0)[0].Range
+	require.Equal(t, chain.l2[0][1].ID(), r.PrevFinalizedL2)
+	require.Equal(t, chain.l2[3][1].ID(), r.FinalizedL2)
+	require.Equal(t, chain.l1[3].ID(), r.FinalizedL1)
+}
+
+type failingOutputs struct {
+	fakeOutputs
+	err error
+}
+
+func (o *failingOutputs) OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+	return o.fakeOutputs.OutputV0AtBlock(ctx, blockHash)
+}
+
+func TestReceiptIssuerRetry(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	outputs := &failingOutputs{err: errors.New("unavailable")}
+	ri := NewReceiptIssuer(testlog.Logger(t, log.LevelInfo), big.NewInt(42), outputs, p2p.NewLocalSigner(testutils.RandomKey()), 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	ri.clock = cl
+	ri.retryStrategy = retry.Fixed(time.Minute)
+
+	// a failed advancement is retried with backoff
+	ri.OnFinalized(FinalizedEvent{PrevFinalizedL2: chain.l2[0][1], FinalizedL2: chain.l2[1][1], FinalizedL1: chain.l1[1]})
+	retryAt, retrying := ri.issuePending(context.Background())
+	require.True(t, retrying)
+	require.Equal(t, cl.Now().Add(time.Minute), retryAt)
+	require.Empty(t, ri.Receipts(0))
+
+	// and coalesced with the later advancements, so no range is skipped
+	ri.OnFinalized(FinalizedEvent{PrevFinalizedL2: chain.l2[1][1], FinalizedL2: chain.l2[3][1], FinalizedL1: chain.l1[3]})
+	outputs.err = nil
+	_, retrying = ri.issuePending(context.Background())
+	require.True(t, retrying, "still backing off")
+	require.Empty(t, ri.Receipts(0))
+
+	cl.AdvanceTime(time.Minute)
+	_, retrying = ri.issuePending(context.Background())
+	require.False(t, retrying)
+	receipts := ri.Receipts(0)
+	require.Len(t, receipts, 1)
+	require.Equal(t, chain.l2[0][1].ID(), receipts[0].Range.PrevFinalizedL2)
+	require.Equal(t, chain.l2[3][1].ID(), receipts[0].Range.FinalizedL2)
+}
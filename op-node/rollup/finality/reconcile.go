@@ -0,0 +1,23 @@
+package finality
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// reconcileFinalized re-applies the finalized L2 head the Finalizer last set on the engine,
+// if the engine reports an older finalized head, e.g. after it was restored from an older snapshot.
+// Without this the engine stays behind until finalization advances again.
+// A failed re-application is retried like any other pending finalized head. The lock must be held.
+func (fi *Finalizer) reconcileFinalized(ctx context.Context) error {
+	applied := fi.lastSetFinalized
+	regressed := fi.engineFinalized()
+	if applied == (eth.L2BlockRef{}) || fi.pendingFinalized != (eth.L2BlockRef{}) || regressed.Number >= applied.Number {
+		return nil
+	}
+	fi.counters.EngineRegressions += 1
+	fi.log.Warn("engine finalized L2 head regressed below the applied finalized head, re-applying it",
+		"engine_finalized_l2", regressed, "finalized_l2", applied)
+	return fi.applyFinalizedFrom(ctx, applied, AuditSourceReconcile)
+}
@@ -0,0 +1,66 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerReconcileRegressedEngine(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithClock(clk))
+	var events []FinalizedEvent
+	fi.SubscribeFinalized(func(ev FinalizedEvent) { events = append(events, ev) })
+
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[1]))
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+	require.Len(t, events, 1)
+
+	// the engine is restored from an older snapshot
+	ec.finalized = chain.l2[0][1]
+
+	// the re-application fails while the engine is unavailable, and is retried
+	ec.fcuErr = errors.New("engine unavailable")
+	require.ErrorIs(t, fi.OnDerivationL1End(context.Background(), chain.l1[2]), ec.fcuErr)
+	require.Equal(t, uint64(1), fi.counters.EngineRegressions)
+	require.Len(t, events, 1)
+	ec.fcuErr = nil
+	clk.AdvanceTime(time.Hour)
+
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[2]))
+	require.Equal(t, chain.l2[1][1], ec.applied)
+	require.Equal(t, uint64(1), fi.counters.EngineRegressions)
+	require.Len(t, events, 2)
+	require.Equal(t, chain.l2[0][1], events[1].PrevFinalizedL2)
+	require.Equal(t, chain.l2[1][1], events[1].FinalizedL2)
+	trail := fi.AuditTrail()
+	require.Len(t, trail, 3)
+	require.Equal(t, AuditSourceReconcile, trail[1].Source)
+	require.Equal(t, chain.l2[1][1], trail[1].Next)
+
+	// nothing to reconcile once the engine caught up
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+	require.Equal(t, uint64(1), fi.counters.EngineRegressions)
+}
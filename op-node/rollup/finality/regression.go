@@ -0,0 +1,37 @@
+package finality
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// WithSafeRegressionHalt halts finalization when the safe head moves backwards without a reset,
+// rather than only reporting it, until an operator resumes finalization with ResumeFinality.
+// Finality data of a regressed safe head cannot be trusted, as it stems from a faulty derivation pipeline.
+func WithSafeRegressionHalt() FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.haltOnSafeRegression = true
+	}
+}
+
+// checkSafeRegression detects a safe head that moved backwards, compared to the latest finality data entry,
+// while derivation did not move back to an older L1 block. The finality data is cleared on every reset,
+// so a regression indicates a derivation pipeline bug, which would otherwise silently corrupt the finality data.
+// The lock must be held.
+func (fi *Finalizer) checkSafeRegression(prev finalityRelation, l2Safe eth.L2BlockRef, derivedFrom eth.L1BlockRef) {
+	if derivedFrom.Number < prev.Source.ID.Number || l2Safe.Number >= prev.Derived.Number {
+		return
+	}
+	fi.counters.SafeRegressions += 1
+	fi.metrics.RecordFinalitySafeRegression()
+	fi.log.Error("safe head moved backwards without a reset! Is the derivation pipeline faulty?",
+		"safe_l2", l2Safe, "derived_from", derivedFrom, "prev_safe_l2", prev.Derived, "prev_derived_from", prev.Source.ID,
+		"finalized_l2", fi.finalizedL2, "finalized_l1", fi.finalizedL1, "entries", len(fi.finalityData),
+		"derived_from_l1", fi.derivedFromL1)
+	if !fi.haltOnSafeRegression || fi.halted {
+		return
+	}
+	fi.halted = true
+	fi.metrics.RecordFinalityHalted(true)
+	fi.log.Error("halting finalization after a safe head regression. Resume with admin_resumeFinality once resolved",
+		"safe_l2", l2Safe, "prev_safe_l2", prev.Derived)
+}
@@ -0,0 +1,60 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/testutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestFinalizerSafeRegression(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+
+	t.Run("detect", func(t *testing.T) {
+		m := &fakeMetrics{}
+		fi := NewFinalizer(testlog.Logger(t, log.LevelCrit), &rollup.Config{}, testutil.NewL1(chain.l1...),
+			testutil.NewEngine(chain.l2[0][1]), WithMetrics(m))
+		fi.PostProcessSafeL2(chain.l2[0][1], chain.l1[0])
+		fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+		// replays of older L1 blocks are not regressions
+		fi.PostProcessSafeL2(chain.l2[0][1], chain.l1[0])
+		require.Zero(t, m.regressions)
+
+		// the safe head moves backwards, from the same and from a newer L1 block
+		fi.PostProcessSafeL2(chain.l2[1][0], chain.l1[1])
+		fi.PostProcessSafeL2(chain.l2[0][1], chain.l1[2])
+		require.Equal(t, 2, m.regressions)
+		require.Equal(t, uint64(2), fi.DebugBundle().Counters.SafeRegressions)
+		require.False(t, fi.Status().Halted)
+
+		// after a reset, the safe head may move backwards
+		fi.Reset()
+		fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+		fi.PostProcessSafeL2(chain.l2[3][1], chain.l1[3])
+		require.Equal(t, 2, m.regressions)
+	})
+
+	t.Run("halt", func(t *testing.T) {
+		ec := testutil.NewEngine(chain.l2[0][1])
+		fi := NewFinalizer(testlog.Logger(t, log.LevelCrit), &rollup.Config{}, testutil.NewL1(chain.l1...), ec,
+			WithSafeRegressionHalt())
+		fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+		fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+		fi.PostProcessSafeL2(chain.l2[1][0], chain.l1[3])
+		require.True(t, fi.Status().Halted)
+
+		// the finality data of the regressed safe head is not finalized
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[0][1], ec.Finalized())
+		require.NoError(t, fi.ResumeFinality())
+		require.False(t, fi.Status().Halted)
+	})
+}
@@ -0,0 +1,66 @@
+package finality
+
+import (
+	"errors"
+)
+
+// triggers of reloads of the finality tunables, as recorded in the tunables audit trail.
+const (
+	TunablesTriggerRPC     = "rpc"
+	TunablesTriggerSignal  = "signal"
+	TunablesTriggerStartup = "startup"
+)
+
+// ErrAdaptiveDelay is returned when the finality delay is reloaded, while it is adapted to the cost of the L1 fetches.
+var ErrAdaptiveDelay = errors.New("the finality delay is adaptive, and cannot be reloaded")
+
+// Tunables returns the current finality tunables.
+func (fi *Finalizer) Tunables() FinalityTunables {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.tunables()
+}
+
+// tunables returns the current finality tunables. The lock must be held.
+func (fi *Finalizer) tunables() FinalityTunables {
+	return FinalityTunables{
+		FinalityDelay:      fi.finalityDelay,
+		ExtraConfirmations: fi.extraConfirmations,
+		TrustSignal:        fi.trustSignal,
+		StallThreshold:     fi.stallThreshold,
+	}
+}
+
+// ReloadTunables applies the update to the finality tunables at once, and returns the resulting tunables.
+// The buffered finality data is retained, and the new tunables apply from the next attempt to finalize on.
+// Reloads that change the tunables are logged and recorded in the tunables audit trail, with the trigger of the reload.
+func (fi *Finalizer) ReloadTunables(update FinalityTunablesUpdate, trigger string) (FinalityTunables, error) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	prev := fi.tunables()
+	if err := update.Check(); err != nil {
+		return prev, err
+	}
+	next := update.Apply(prev)
+	if next.FinalityDelay != prev.FinalityDelay && fi.adaptive != nil {
+		return prev, ErrAdaptiveDelay
+	}
+	if next == prev {
+		return prev, nil
+	}
+	fi.finalityDelay = next.FinalityDelay
+	fi.extraConfirmations = next.ExtraConfirmations
+	fi.trustSignal = next.TrustSignal
+	fi.stallThreshold = next.StallThreshold
+	fi.log.Warn("reloaded finality tunables", "trigger", trigger,
+		"prev_delay", prev.FinalityDelay, "delay", next.FinalityDelay,
+		"prev_extra_confirmations", prev.ExtraConfirmations, "extra_confirmations", next.ExtraConfirmations,
+		"prev_trust_signal", prev.TrustSignal, "trust_signal", next.TrustSignal,
+		"prev_stall_threshold", prev.StallThreshold, "stall_threshold", next.StallThreshold)
+	if len(fi.tunablesAudit) >= auditTrailSize {
+		fi.tunablesAudit = append(fi.tunablesAudit[:0], fi.tunablesAudit[1:]...)
+	}
+	fi.tunablesAudit = append(fi.tunablesAudit, FinalityTunablesChange{Time: fi.clock.Now(), Prev: prev, Next: next, Trigger: trigger})
+	fi.publishStatus()
+	return next, nil
+}
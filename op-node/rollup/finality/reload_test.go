@@ -0,0 +1,71 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerReloadTunables(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	for _, ref := range chain.l1 {
+		l1F.Mock.On("L1BlockRefByNumber", ref.Number).Return(ref, nil)
+	}
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+	for i := 1; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	extra := uint64(2)
+	threshold := time.Hour
+	tunables, err := fi.ReloadTunables(FinalityTunablesUpdate{ExtraConfirmations: &extra, StallThreshold: &threshold}, TunablesTriggerRPC)
+	require.NoError(t, err)
+	require.Equal(t, FinalityTunables{FinalityDelay: finalityDelay, ExtraConfirmations: 2, StallThreshold: time.Hour}, tunables)
+	require.Equal(t, tunables, fi.Tunables())
+	require.Equal(t, uint64(2), fi.CachedStatus().ExtraConfirmations, "the new tunables are published")
+
+	// the buffered finality data is retained, and finalized with the new tunables
+	fi.Finalize(context.Background(), chain.l1[3])
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+
+	// reloads that do not change anything are not audited
+	_, err = fi.ReloadTunables(FinalityTunablesUpdate{ExtraConfirmations: &extra}, TunablesTriggerSignal)
+	require.NoError(t, err)
+	audit := fi.DebugBundle().TunablesAudit
+	require.Len(t, audit, 1)
+	require.Equal(t, TunablesTriggerRPC, audit[0].Trigger)
+	require.Equal(t, uint64(0), audit[0].Prev.ExtraConfirmations)
+	require.Equal(t, uint64(2), audit[0].Next.ExtraConfirmations)
+
+	zero := uint64(0)
+	_, err = fi.ReloadTunables(FinalityTunablesUpdate{FinalityDelay: &zero}, TunablesTriggerRPC)
+	require.Error(t, err)
+	require.Equal(t, tunables, fi.Tunables(), "invalid reloads are not applied")
+
+	negative, moreExtra := -time.Minute, uint64(3)
+	_, err = fi.ReloadTunables(FinalityTunablesUpdate{ExtraConfirmations: &moreExtra, StallThreshold: &negative}, TunablesTriggerRPC)
+	require.Error(t, err)
+	require.Equal(t, tunables, fi.Tunables(), "invalid reloads are not applied in part")
+}
+
+func TestFinalizerReloadTunablesAdaptive(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{}, WithAdaptiveDelay(8, 128))
+	delay := uint64(16)
+	_, err := fi.ReloadTunables(FinalityTunablesUpdate{FinalityDelay: &delay}, TunablesTriggerRPC)
+	require.ErrorIs(t, err, ErrAdaptiveDelay)
+}
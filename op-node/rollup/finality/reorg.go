@@ -0,0 +1,56 @@
+package finality
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// conflictsWith returns true if the L1 block the relation was derived from is known not to be on the chain of the
+// finalized signal, from the hash and the parent-hash of either block.
+func (s l1Source) conflictsWith(signal eth.L1BlockRef) bool {
+	switch {
+	case s.Expanded:
+		return false // the hash of expanded entries is only an identifier of the entry, not of the L1 block
+	case s.ID.Number == signal.Number:
+		return s.ID.Hash != signal.Hash
+	case s.ID.Number+1 == signal.Number:
+		return s.ID.Hash != signal.ParentHash
+	case s.ID.Number == signal.Number+1:
+		return s.ParentHash != (common.Hash{}) && s.ParentHash != signal.Hash
+	default:
+		return false
+	}
+}
+
+// detectL1Reorg checks the finality data against the ancestry of a new finality signal.
+// If L1 reorged the buffered data, the conflicting suffix of the finality data is pruned proactively,
+// since anything derived from, or after, a reorged-out L1 block is reorged out too,
+// and a pipeline reset back to the divergence point is requested with the next processed L1 block,
+// rather than only discovering the reorg when a later attempt to finalize fails its canonical checks.
+// The lock must be held.
+func (fi *Finalizer) detectL1Reorg(signal eth.L1BlockRef) {
+	i := 0
+	for i < len(fi.finalityData) && !fi.finalityData[i].Source.conflictsWith(signal) {
+		i++
+	}
+	if i == len(fi.finalityData) {
+		return
+	}
+	diverged := fi.finalityData[i].Source.ID
+	pruned := len(fi.finalityData) - i
+	fi.finalityData = fi.finalityData[:i]
+	fi.dropRuns(func(_ *compressedRun, end uint64) bool { return end >= diverged.Number })
+	gaps := fi.gaps[:0]
+	for _, gap := range fi.gaps {
+		if gap.to.Source.ID.Number < diverged.Number {
+			gaps = append(gaps, gap)
+		}
+	}
+	fi.gaps = gaps
+	fi.counters.EntriesPruned += uint64(pruned)
+	fi.counters.ReorgsDetected += 1
+	fi.log.Warn("finality signal conflicts with buffered finality data, L1 reorged",
+		"signaled_finalized_l1", signal, "diverged_at", diverged, "pruned", pruned)
+	fi.reorg = &ErrL1Reorg{Signal: signal, DivergedAt: diverged}
+}
@@ -0,0 +1,69 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/testutil"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestL1SourceConflicts(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	signal := chain.l1[2]
+	for i, ref := range chain.l1 {
+		require.False(t, l1Source{ID: ref.ID(), ParentHash: ref.ParentHash}.conflictsWith(signal), "canonical block %d", i)
+	}
+	other := testutils.RandomHash(rng)
+	// at the height of the signal, its parent, and its child
+	require.True(t, l1Source{ID: eth.BlockID{Hash: other, Number: chain.l1[2].Number}}.conflictsWith(signal))
+	require.True(t, l1Source{ID: eth.BlockID{Hash: other, Number: chain.l1[1].Number}}.conflictsWith(signal))
+	require.True(t, l1Source{ID: chain.l1[3].ID(), ParentHash: other}.conflictsWith(signal))
+	// the parent-hash of restored entries may be unknown
+	require.False(t, l1Source{ID: chain.l1[3].ID()}.conflictsWith(signal))
+	// expanded entries are not identified by their L1 block hash
+	require.False(t, l1Source{ID: eth.BlockID{Hash: other, Number: chain.l1[2].Number}, Expanded: true}.conflictsWith(signal))
+}
+
+func TestFinalizerL1Reorg(t *testing.T) {
+	fx := testutil.NewFixture(4)
+	chain, l1F, ec := fx.Chain, fx.L1, fx.Engine
+	logger := testlog.Logger(t, log.LevelInfo)
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+	for i := 1; i < len(chain.L1); i++ {
+		fi.PostProcessSafeL2(chain.L2[i][1], chain.L1[i])
+	}
+
+	// L1 reorged out the buffered L1 blocks 2 and 3, and finalized the other block 2
+	reorged := l1F.Reorg(fx.Rng, chain.L1[2].Number)[0]
+	fi.Finalize(context.Background(), reorged)
+	require.Len(t, fi.finalityData, 1)
+	require.Equal(t, uint64(1), fi.counters.ReorgsDetected)
+	// the L2 blocks derived from before the divergence point can still finalize
+	ec.RequireFinalized(t, chain.L2[1][1])
+
+	// the reset is requested, scoped to the divergence point
+	err := fi.OnDerivationL1End(context.Background(), chain.L1[3])
+	require.ErrorIs(t, err, derive.ErrReset)
+	var reorgErr *ErrL1Reorg
+	require.True(t, errors.As(err, &reorgErr))
+	require.Equal(t, chain.L1[2].ID(), reorgErr.DivergedAt)
+	require.Equal(t, reorged, reorgErr.Signal)
+	require.True(t, fi.resetRequested)
+
+	// and only once
+	fi.Reset()
+	fi.OnResetComplete(context.Background())
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), reorged))
+}
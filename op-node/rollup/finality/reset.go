@@ -0,0 +1,63 @@
+package finality
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// failure causes of attempts to finalize, as reported to metrics.
+const (
+	// FailureReset is the cause of attempts that require a pipeline reset, e.g. because the L1 chain reorged.
+	FailureReset = "reset"
+	// FailureL1Unavailable is the cause of attempts that could not fetch L1 data.
+	FailureL1Unavailable = "l1_unavailable"
+	// FailurePanic is the cause of attempts that panicked.
+	FailurePanic = "panic"
+	// FailureInvariant is the cause of attempts that were refused for violating the finalized head invariants.
+	FailureInvariant = "invariant"
+	// FailureOther is the cause of any other failed attempt.
+	FailureOther = "other"
+)
+
+// failureCause classifies the error of a failed attempt to finalize.
+func failureCause(err error) string {
+	var l1Err *ErrL1Unavailable
+	var panicErr *ErrFinalizerPanic
+	var invariantErr *ErrFinalizedNotMonotonic
+	switch {
+	case errors.Is(err, derive.ErrReset):
+		return FailureReset
+	case errors.As(err, &l1Err):
+		return FailureL1Unavailable
+	case errors.As(err, &panicErr):
+		return FailurePanic
+	case errors.As(err, &invariantErr):
+		return FailureInvariant
+	default:
+		return FailureOther
+	}
+}
+
+// OnResetComplete is called by the driver once the pipeline and engine reset completed.
+// If the reset was caused by an attempt to finalize, finalization is re-attempted immediately,
+// instead of waiting for derivation to traverse finalityDelay more L1 blocks.
+func (fi *Finalizer) OnResetComplete(ctx context.Context) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	defer fi.publishStatus()
+	if !fi.resetRequested {
+		return
+	}
+	fi.resetRequested = false
+	if fi.finalizedL1 == (eth.L1BlockRef{}) {
+		return
+	}
+	fi.log.Info("re-attempting finalization after reset", "finalized_l1", fi.finalizedL1)
+	fi.triedFinalizeAt = 0
+	if err := fi.guard(func() error { return fi.tryFinalize(ctx) }); err != nil {
+		fi.log.Warn("failed to finalize after reset", "err", err)
+	}
+}
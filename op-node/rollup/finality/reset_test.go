@@ -0,0 +1,72 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerOnResetComplete(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithMetrics(m))
+	for i := 1; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	// nothing to re-attempt before any reset
+	fi.OnResetComplete(context.Background())
+
+	// the derived-from L1 block was reorged out, which requires a reset
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, testutils.RandomBlockRef(rng), nil)
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, map[string]int{FailureReset: 1}, m.failures)
+	require.Equal(t, chain.l2[0][1], ec.Finalized())
+
+	// the reset completes, and finalization is re-attempted right away
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	fi.OnResetComplete(context.Background())
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+
+	// later resets that were not caused by finalization do not re-attempt
+	fi.OnResetComplete(context.Background())
+}
+
+func TestFinalizerFailureCauses(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, &fakeEngine{}, WithMetrics(m))
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+
+	// a transient L1 error does not request a re-attempt after reset
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, eth.L1BlockRef{}, errors.New("fail"))
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, eth.L1BlockRef{}, errors.New("fail")) // the derived-from block is fetched concurrently
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, map[string]int{FailureL1Unavailable: 1}, m.failures)
+	fi.OnResetComplete(context.Background())
+
+	require.Equal(t, FailureOther, failureCause(errors.New("other")))
+	require.Equal(t, FailurePanic, failureCause(&ErrFinalizerPanic{Value: "boom"}))
+}
@@ -0,0 +1,80 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// UnsafeRollbackFinalized rewinds the finalized L2 head of the engine to the given older L2 block,
+// and drops the finality signal, the attempts to finalize, and the finality data of the L2 blocks after the target,
+// so the finalized head does not advance past the target again until newly derived L2 blocks are finalized.
+// This breaks the finality guarantees of the node, and is for devnet chaos testing and recovery drills only.
+// This must be called on the event loop, since it updates the engine.
+func (fi *Finalizer) UnsafeRollbackFinalized(ctx context.Context, target eth.L2BlockRef) error {
+	// the canonical target is resolved without the lock, to not block the status queries on the L2 RPC
+	if fi.l2Blocks != nil {
+		canonical, err := fi.l2Blocks.L2BlockRefByNumber(ctx, target.Number)
+		if err != nil {
+			return fmt.Errorf("failed to fetch L2 block %d to roll back to: %w", target.Number, err)
+		}
+		if canonical.Hash != target.Hash {
+			return fmt.Errorf("cannot roll back finalized L2 head to non-canonical L2 block %s, canonical is %s", target.ID(), canonical.ID())
+		}
+		target = canonical
+	}
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	defer fi.publishStatus()
+	current := fi.engineFinalized()
+	if target.Number > current.Number {
+		return fmt.Errorf("cannot roll back finalized L2 head %s to newer L2 block %s", current, target)
+	}
+	fi.log.Warn("rolling back finalized L2 head, this is for devnets only!", "finalized_l2", current, "target", target,
+		"dropped_finalized_l1", fi.finalizedL1)
+	// the rollback intentionally breaks the monotonicity of the finalized head, bypass its check
+	fi.recordAudit(FinalizedHeadUpdate{Time: fi.clock.Now(), Prev: fi.lastSetFinalized, Next: target, Source: AuditSourceRollback})
+	fi.lastSetFinalized = target
+	fi.finalizedL2 = target
+	fi.truncateFinalityData(target)
+	fi.finalizedL1 = eth.L1BlockRef{}
+	fi.signalProvenance = SignalProvenance{}
+	fi.triedFinalizeAt = 0
+	clear(fi.verifiedL1)
+	fi.pendingFinalized = eth.L2BlockRef{}
+	fi.pendingFrom = eth.L2BlockRef{}
+	fi.pendingAttempts = 0
+	fi.pendingRetryAt = time.Time{}
+	fi.counters.Rollbacks += 1
+	if err := fi.updateEngine(ctx, target); err != nil {
+		return fmt.Errorf("failed to apply rolled back finalized L2 head %s: %w", target, err)
+	}
+	return nil
+}
+
+// truncateFinalityData drops the finality data of the L2 blocks after the given L2 block,
+// with the compressed runs and the gaps of the dropped entries. The lock must be held.
+func (fi *Finalizer) truncateFinalityData(l2 eth.L2BlockRef) {
+	i := 0
+	for i < len(fi.finalityData) && fi.finalityData[i].Derived.Number <= l2.Number {
+		i++
+	}
+	if i == len(fi.finalityData) {
+		return
+	}
+	from := fi.finalityData[i].Source.ID.Number
+	dropped := len(fi.finalityData) - i
+	fi.finalityData = fi.finalityData[:i]
+	fi.dropRuns(func(_ *compressedRun, end uint64) bool { return end >= from })
+	gaps := fi.gaps[:0]
+	for _, gap := range fi.gaps {
+		if gap.to.Source.ID.Number < from {
+			gaps = append(gaps, gap)
+		}
+	}
+	fi.gaps = gaps
+	fi.counters.EntriesPruned += uint64(dropped)
+	fi.log.Warn("dropped finality data after the rolled back finalized L2 head", "target", l2, "dropped", dropped)
+}
@@ -0,0 +1,57 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerUnsafeRollback(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	for _, ref := range chain.l1 {
+		l1F.Mock.On("L1BlockRefByNumber", ref.Number).Return(ref, nil)
+	}
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+	for i := 1; i < len(chain.l1); i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+
+	require.Error(t, fi.UnsafeRollbackFinalized(context.Background(), chain.l2[3][1]), "cannot roll forward")
+
+	require.NoError(t, fi.UnsafeRollbackFinalized(context.Background(), chain.l2[1][1]))
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+	require.Equal(t, chain.l2[1][1], ec.applied)
+	require.Equal(t, eth.L1BlockRef{}, fi.FinalizedL1())
+	require.Equal(t, uint64(1), fi.counters.Rollbacks)
+	trail := fi.AuditTrail()
+	require.Equal(t, FinalizedHeadUpdate{Time: trail[len(trail)-1].Time, Prev: chain.l2[2][1], Next: chain.l2[1][1],
+		Source: AuditSourceRollback}, trail[len(trail)-1])
+
+	// the finality data after the target is dropped, the finalized head does not advance past the target again
+	require.Len(t, fi.finalityData, 1)
+	fi.Finalize(context.Background(), chain.l1[3])
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+	require.Equal(t, chain.l2[1][1], fi.FinalizedL2())
+
+	// newly derived L2 blocks are finalized again
+	next := eth.L2BlockRef{Hash: testutils.RandomHash(rng), Number: chain.l2[3][1].Number + 1}
+	fi.PostProcessSafeL2(next, chain.l1[3])
+	fi.Finalize(context.Background(), chain.l1[3])
+	require.Equal(t, next, ec.Finalized())
+}
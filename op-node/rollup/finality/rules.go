@@ -0,0 +1,72 @@
+package finality
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+// ruleActivation is a finality rule of the rollup config, with the L2 block timestamp it activates at.
+type ruleActivation struct {
+	time uint64
+	rule rollup.FinalityRule
+}
+
+// WithDisputeGameReader provides the dispute games to the finality rules of the rollup config that gate on them.
+// Unlike WithDisputeGameGate, the L2 blocks before the activation of such a rule are not gated.
+func WithDisputeGameReader(games DisputeGameReader) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.disputeGameGate.ruleGames = games
+	}
+}
+
+// finalityRules returns the finality rules of the rollup config that are scheduled, in order of activation.
+func finalityRules(cfg *rollup.Config) []ruleActivation {
+	var out []ruleActivation
+	for _, rule := range cfg.FinalityRules {
+		if t := cfg.ForkActivationTime(rule.Fork); t != nil {
+			out = append(out, ruleActivation{time: *t, rule: rule})
+		}
+	}
+	// hardforks may activate at the same time, the rule of the later hardfork takes precedence
+	slices.SortStableFunc(out, func(a, b ruleActivation) int {
+		return cmp.Compare(a.time, b.time)
+	})
+	return out
+}
+
+// ruleAt returns the finality rule that is active at the given L2 block timestamp, if any.
+func (fi *Finalizer) ruleAt(l2Time uint64) (rollup.FinalityRule, bool) {
+	for i := len(fi.rules) - 1; i >= 0; i-- {
+		if fi.rules[i].time <= l2Time {
+			return fi.rules[i].rule, true
+		}
+	}
+	return rollup.FinalityRule{}, false
+}
+
+// rulesGateOnDisputeGames returns true if any of the scheduled finality rules gates on dispute games.
+func (fi *Finalizer) rulesGateOnDisputeGames() bool {
+	for _, r := range fi.rules {
+		if r.rule.DisputeGameGated {
+			return true
+		}
+	}
+	return false
+}
+
+// rulesGate checks a candidate to finalize against the extra confirmations of the finality rule
+// that is active at the timestamp of its L2 block. The dispute games of the rules are checked by the disputeGameGate.
+type rulesGate struct {
+	noopGate
+	fi *Finalizer
+}
+
+func (g *rulesGate) accept(a *finalizeAttempt, r finalityRelation, found bool) (bool, FinalizeReason) {
+	rule, active := g.fi.ruleAt(r.Derived.Time)
+	if active && r.Source.ID.Number+rule.ExtraConfirmations > g.fi.finalizedL1.Number {
+		return false, ""
+	}
+	return true, ""
+}
@@ -0,0 +1,86 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerRules(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	// the hardfork activates with the L2 blocks derived from the third L1 block
+	forkTime := chain.l2[2][0].Time
+	newFinalizer := func(t *testing.T, rule rollup.FinalityRule, opts ...FinalizerOption) (*Finalizer, *fakeEngine, *testutils.MockL1Source) {
+		logger := testlog.Logger(t, log.LevelCrit)
+		l1F := &testutils.MockL1Source{}
+		t.Cleanup(func() { l1F.AssertExpectations(t) })
+		ec := &fakeEngine{}
+		ec.finalized = chain.l2[0][1]
+		cfg := &rollup.Config{EcotoneTime: &forkTime, FinalityRules: []rollup.FinalityRule{rule}}
+		fi := NewFinalizer(logger, cfg, l1F, ec, opts...)
+		for i := 1; i < 4; i++ {
+			fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		}
+		return fi, ec, l1F
+	}
+
+	t.Run("dispute-game-gated", func(t *testing.T) {
+		games := &fakeDisputeGames{resolved: chain.l2[0][1].Number}
+		fi, ec, l1F := newFinalizer(t, rollup.FinalityRule{Fork: rollup.Ecotone, DisputeGameGated: true},
+			WithDisputeGameReader(games))
+
+		// the blocks before the fork are not gated
+		l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[1][1], ec.Finalized())
+
+		// the blocks after the fork are finalized once backed by a dispute game,
+		// without refetching the signal, which is also the L1 block they were derived from
+		games.resolved = chain.l2[3][1].Number
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[3][1], ec.Finalized())
+	})
+
+	t.Run("no-dispute-games", func(t *testing.T) {
+		fi, ec, l1F := newFinalizer(t, rollup.FinalityRule{Fork: rollup.Ecotone, DisputeGameGated: true})
+		l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[1][1], ec.Finalized(), "blocks after the fork cannot be backed by a dispute game")
+
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[1][1], ec.Finalized())
+		require.Equal(t, ReasonDisputeGameGated, fi.Status().LastReason)
+	})
+
+	t.Run("extra-confirmations", func(t *testing.T) {
+		fi, ec, l1F := newFinalizer(t, rollup.FinalityRule{Fork: rollup.Ecotone, ExtraConfirmations: 1})
+		l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+		fi.Finalize(context.Background(), chain.l1[2])
+		require.Equal(t, chain.l2[1][1], ec.Finalized(), "blocks after the fork require an extra confirmation")
+
+		l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[2][1], ec.Finalized())
+	})
+
+	t.Run("unscheduled", func(t *testing.T) {
+		fi, ec, l1F := newFinalizer(t, rollup.FinalityRule{Fork: rollup.Fjord, DisputeGameGated: true})
+		l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+		l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+		fi.Finalize(context.Background(), chain.l1[3])
+		require.Equal(t, chain.l2[3][1], ec.Finalized(), "the rule of an unscheduled fork is inactive")
+	})
+}
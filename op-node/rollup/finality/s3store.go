@@ -0,0 +1,107 @@
+package finality
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/api"
+)
+
+// S3Config configures the S3StateStore.
+type S3Config struct {
+	Endpoint string
+	Bucket   string
+	// Key is the key of the snapshot object in the bucket.
+	Key string
+	// Region of the bucket, to not look it up before the first request. Optional.
+	Region string
+	// Insecure connects to the endpoint without TLS, e.g. to a local MinIO server.
+	Insecure bool
+}
+
+// S3StateStore stores the snapshot as an object in S3-compatible object storage, with signed requests.
+// The requests are signed with the AWS credentials of the environment, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY,
+// or else of the shared credentials file, AWS_SHARED_CREDENTIALS_FILE or ~/.aws/credentials, with the AWS_PROFILE profile.
+type S3StateStore struct {
+	cfg    S3Config
+	client *minio.Client
+}
+
+var _ FinalityStateStore = (*S3StateStore)(nil)
+
+func NewS3StateStore(cfg S3Config) (*S3StateStore, error) {
+	creds := credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvAWS{},
+		&credentials.FileAWSCredentials{},
+	})
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: !cfg.Insecure,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create finality state store S3 client: %w", err)
+	}
+	return &S3StateStore{cfg: cfg, client: client}, nil
+}
+
+// parseS3URL parses a s3://host/bucket/key URL, with the region and insecure=true as optional query parameters.
+// Credentials in the URL are refused, see S3StateStore.
+func parseS3URL(u *url.URL) (S3Config, error) {
+	if u.User != nil {
+		return S3Config{}, errors.New("finality state store S3 URL must not contain credentials, set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or a shared credentials file instead")
+	}
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	if u.Host == "" || bucket == "" || key == "" {
+		return S3Config{}, errors.New("finality state store S3 URL must be s3://host/bucket/key")
+	}
+	cfg := S3Config{
+		Endpoint: u.Host,
+		Bucket:   bucket,
+		Key:      key,
+		Region:   u.Query().Get("region"),
+		Insecure: u.Query().Get("insecure") == "true",
+	}
+	return cfg, nil
+}
+
+func (s *S3StateStore) SaveSnapshot(ctx context.Context, snapshot *Snapshot) error {
+	data, err := snapshot.MarshalCompressed()
+	if err != nil {
+		return fmt.Errorf("failed to encode finality snapshot: %w", err)
+	}
+	_, err = s.client.PutObject(ctx, s.cfg.Bucket, s.cfg.Key, bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	if err != nil {
+		return fmt.Errorf("failed to upload finality snapshot: %w", err)
+	}
+	return nil
+}
+
+func (s *S3StateStore) LoadSnapshot(ctx context.Context) (*Snapshot, error) {
+	obj, err := s.client.GetObject(ctx, s.cfg.Bucket, s.cfg.Key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download finality snapshot: %w", err)
+	}
+	defer obj.Close()
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrNoSnapshot
+		}
+		return nil, fmt.Errorf("failed to download finality snapshot: %w", err)
+	}
+	snapshot, err := api.DecodeSnapshot(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode finality snapshot: %w", err)
+	}
+	return snapshot, nil
+}
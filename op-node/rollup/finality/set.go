@@ -0,0 +1,122 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ChainFinality is the finality status of a single chain in a FinalizerSet.
+type ChainFinality struct {
+	ChainID     uint64         `json:"chain_id"`
+	FinalizedL1 eth.L1BlockRef `json:"finalized_l1"`
+	FinalizedL2 eth.L2BlockRef `json:"finalized_l2"`
+}
+
+// FinalizerSet owns a Finalizer per L2 chain, all sharing the same L1 fetcher and L1 finality signal source.
+// This is used by services that run the derivation of many L2 chains in one process.
+type FinalizerSet struct {
+	mu sync.Mutex
+
+	log       log.Logger
+	l1Fetcher FinalizerL1Interface
+	// metrics returns the metrics of the Finalizer of a chain, labeled by chain ID.
+	metrics func(chainID uint64) Metrics
+
+	finalizers map[uint64]*Finalizer
+}
+
+// NewFinalizerSet creates an empty FinalizerSet. The metrics of the Finalizer of each chain are created with metrics,
+// and must be labeled by chain ID, so the chains do not collide. No metrics are reported if metrics is nil.
+func NewFinalizerSet(log log.Logger, l1Fetcher FinalizerL1Interface, metrics func(chainID uint64) Metrics) *FinalizerSet {
+	if metrics == nil {
+		metrics = func(chainID uint64) Metrics { return noopMetrics{} }
+	}
+	return &FinalizerSet{
+		log:        log,
+		l1Fetcher:  l1Fetcher,
+		metrics:    metrics,
+		finalizers: make(map[uint64]*Finalizer),
+	}
+}
+
+// AddChain creates and registers the Finalizer of the chain of the given rollup config.
+// The Finalizer reports to the metrics of the chain, as created by the set.
+func (s *FinalizerSet) AddChain(cfg *rollup.Config, ec FinalizerEngine, opts ...FinalizerOption) (*Finalizer, error) {
+	if cfg.L2ChainID == nil || !cfg.L2ChainID.IsUint64() {
+		return nil, fmt.Errorf("invalid L2 chain ID: %v", cfg.L2ChainID)
+	}
+	chainID := cfg.L2ChainID.Uint64()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.finalizers[chainID]; ok {
+		return nil, fmt.Errorf("finalizer of chain %d already exists", chainID)
+	}
+	opts = append([]FinalizerOption{WithMetrics(s.metrics(chainID))}, opts...)
+	fi := NewFinalizer(s.log.New("chain", chainID), cfg, s.l1Fetcher, ec, opts...)
+	s.finalizers[chainID] = fi
+	return fi, nil
+}
+
+// RemoveChain unregisters the Finalizer of the given chain, if any.
+func (s *FinalizerSet) RemoveChain(chainID uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.finalizers, chainID)
+}
+
+// Finalizer returns the Finalizer of the given chain, if any.
+func (s *FinalizerSet) Finalizer(chainID uint64) (*Finalizer, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fi, ok := s.finalizers[chainID]
+	return fi, ok
+}
+
+// Finalize fans out a single L1 finality signal to the Finalizers of all chains.
+func (s *FinalizerSet) Finalize(ctx context.Context, l1Origin eth.L1BlockRef) {
+	ids, finalizers := s.sorted()
+	for i, fi := range finalizers {
+		s.log.Debug("fanning out L1 finality signal", "chain", ids[i], "l1", l1Origin)
+		fi.Finalize(ctx, l1Origin)
+	}
+}
+
+// Status returns the finality status of all chains, ordered by chain ID.
+func (s *FinalizerSet) Status() []ChainFinality {
+	ids, finalizers := s.sorted()
+	out := make([]ChainFinality, 0, len(finalizers))
+	for i, fi := range finalizers {
+		fi.mu.Lock()
+		out = append(out, ChainFinality{
+			ChainID:     ids[i],
+			FinalizedL1: fi.finalizedL1,
+			FinalizedL2: fi.finalizedL2,
+		})
+		fi.mu.Unlock()
+	}
+	return out
+}
+
+// sorted returns a snapshot of the Finalizers, ordered by chain ID,
+// so the set is not locked while the Finalizers themselves are busy.
+func (s *FinalizerSet) sorted() (ids []uint64, finalizers []*Finalizer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids = make([]uint64, 0, len(s.finalizers))
+	for id := range s.finalizers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	finalizers = make([]*Finalizer, 0, len(ids))
+	for _, id := range ids {
+		finalizers = append(finalizers, s.finalizers[id])
+	}
+	return ids, finalizers
+}
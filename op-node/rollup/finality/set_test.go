@@ -0,0 +1,79 @@
+package finality
+
+import (
+	"context"
+	"math/big"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerSet(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	// a second L2 chain, derived from the same L1 chain
+	var otherL2 []eth.L2BlockRef
+	l2 := eth.L2BlockRef{Hash: testutils.RandomHash(rng)}
+	otherGenesis := l2
+	for _, l1 := range chain.l1 {
+		l2 = testutils.NextRandomL2Ref(rng, 1, l2, l1.ID())
+		otherL2 = append(otherL2, l2)
+	}
+
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	chainMetrics := make(map[uint64]*fakeMetrics)
+	set := NewFinalizerSet(logger, l1F, func(chainID uint64) Metrics {
+		chainMetrics[chainID] = &fakeMetrics{}
+		return chainMetrics[chainID]
+	})
+
+	ecA, ecB := &fakeEngine{}, &fakeEngine{}
+	ecA.finalized = chain.l2[0][0]
+	ecB.finalized = otherGenesis
+	fiA, err := set.AddChain(&rollup.Config{L2ChainID: big.NewInt(10)}, ecA)
+	require.NoError(t, err)
+	fiB, err := set.AddChain(&rollup.Config{L2ChainID: big.NewInt(8453)}, ecB)
+	require.NoError(t, err)
+	_, err = set.AddChain(&rollup.Config{L2ChainID: big.NewInt(10)}, &fakeEngine{})
+	require.ErrorContains(t, err, "already exists")
+
+	for i := range chain.l1 {
+		fiA.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+		fiB.PostProcessSafeL2(otherL2[i], chain.l1[i])
+	}
+
+	// a single signal finalizes both chains
+	for i := 0; i < 4; i++ {
+		l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	}
+	set.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, chain.l2[1][1], ecA.Finalized())
+	require.Equal(t, otherL2[1], ecB.Finalized())
+	require.Equal(t, []ChainFinality{
+		{ChainID: 10, FinalizedL1: chain.l1[1], FinalizedL2: chain.l2[1][1]},
+		{ChainID: 8453, FinalizedL1: chain.l1[1], FinalizedL2: otherL2[1]},
+	}, set.Status())
+	// every chain reports to its own metrics
+	require.Equal(t, chain.l2[1][1], chainMetrics[10].finalized)
+	require.Equal(t, otherL2[1], chainMetrics[8453].finalized)
+
+	// removed chains are no longer signaled
+	set.RemoveChain(8453)
+	_, ok := set.Finalizer(8453)
+	require.False(t, ok)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	set.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[2][1], ecA.Finalized())
+	require.Equal(t, otherL2[1], ecB.Finalized())
+}
@@ -0,0 +1,61 @@
+package finality
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// WithSettlement tracks a settled L2 head next to the finalized L2 head:
+// the finalized L2 head is only gated on L1 finality, the settled L2 head is additionally backed
+// by a dispute game that resolved in favor of the proposed output root, as of the finalized L1 block.
+// This lets consumers choose their risk model from the same node. Unlike WithDisputeGameGate,
+// it does not hold back the finalized L2 head.
+func WithSettlement(games DisputeGameReader) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.settlement = games
+	}
+}
+
+// FinalizedL2 returns the finalized L2 head, which is gated on L1 finality only.
+func (fi *Finalizer) FinalizedL2() eth.L2BlockRef {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.finalizedL2
+}
+
+// SettledL2 returns the settled L2 head: the finalized L2 blocks that are also backed by a resolved dispute game.
+// This returns a zeroed ref if settlement is not tracked, or nothing settled yet.
+func (fi *Finalizer) SettledL2() eth.L2BlockRef {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.settledL2
+}
+
+// trySettle advances the settled L2 head, up to the finalized L2 head, to the latest L2 block derived from
+// finalized L1 data that is backed by a resolved dispute game. Failures only hold back the settled L2 head,
+// and are retried with the next attempt to finalize. The lock must be held.
+func (fi *Finalizer) trySettle(ctx context.Context, finalizedL2 eth.L2BlockRef) {
+	if fi.settlement == nil || len(fi.finalityData) == 0 || fi.settledL2.Number >= finalizedL2.Number {
+		return
+	}
+	resolved, err := fi.settlement.ResolvedL2BlockNumber(ctx, fi.finalizedL1.ID())
+	if err != nil {
+		fi.log.Warn("failed to fetch resolved dispute games, not settling L2 blocks",
+			"finalized_l1", fi.finalizedL1, "err", err)
+		return
+	}
+	final := func(source l1Source) bool {
+		return source.ID.Number+fi.extraConfirmations <= fi.finalizedL1.Number
+	}
+	accept := func(r finalityRelation, found bool) bool {
+		return r.Derived.Number <= resolved && r.Derived.Number <= finalizedL2.Number
+	}
+	r, found := fi.finalityData.Finalizable(fi.settledL2, final, accept)
+	if !found {
+		return
+	}
+	fi.log.Info("settled L2 blocks", "prev_settled_l2", fi.settledL2, "settled_l2", r.Derived, "resolved_l2", resolved)
+	fi.settledL2 = r.Derived
+	fi.metrics.RecordL2Ref("l2_settled", r.Derived)
+}
@@ -0,0 +1,65 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerSettlement(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	games := &fakeDisputeGames{resolved: chain.l2[0][1].Number}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithSettlement(games))
+	require.Equal(t, eth.L2BlockRef{}, fi.SettledL2())
+
+	for i := 1; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	// the finalized L2 head is not held back by the dispute games
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[2][1], fi.FinalizedL2())
+	require.Equal(t, eth.L2BlockRef{}, fi.SettledL2(), "no dispute game backs the L2 blocks yet")
+	require.Equal(t, &eth.L2BlockRef{}, fi.Status().SettledL2)
+
+	// a dispute game resolved for an L2 block in between buffered blocks
+	games.resolved = chain.l2[1][1].Number + 1
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[1][1], fi.SettledL2())
+
+	// the settled L2 head does not pass the finalized L2 head
+	games.resolved = chain.l2[3][1].Number
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[2][1], fi.SettledL2())
+	require.Equal(t, chain.l2[2][1], *fi.Status().SettledL2)
+
+	// once finalized, the settled L2 head follows
+	l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[3].Number, chain.l1[3], nil)
+	fi.Finalize(context.Background(), chain.l1[3])
+	require.Equal(t, chain.l2[3][1], fi.FinalizedL2())
+	require.Equal(t, chain.l2[3][1], fi.SettledL2())
+}
+
+func TestFinalizerSettlementDisabled(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{})
+	require.Nil(t, fi.Status().SettledL2)
+}
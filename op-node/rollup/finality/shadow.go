@@ -0,0 +1,181 @@
+package finality
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// shadowEngine captures the finalized head decided by a shadow Finalizer, without applying it to the engine.
+type shadowEngine struct {
+	// base is the real engine, used for the initial finalized head, before the shadow made any decision.
+	base      FinalizerEngine
+	finalized eth.L2BlockRef
+}
+
+func (e *shadowEngine) Finalized() eth.L2BlockRef {
+	if e.finalized == (eth.L2BlockRef{}) {
+		return e.base.Finalized()
+	}
+	return e.finalized
+}
+
+// SetFinalizedHead records the finalized head, it is never applied to the engine.
+func (e *shadowEngine) SetFinalizedHead(ctx context.Context, ref eth.L2BlockRef) error {
+	e.finalized = ref
+	return nil
+}
+
+var _ FinalizerEngine = (*shadowEngine)(nil)
+
+// ShadowFinalizer wraps a regular Finalizer, and runs a second shadow Finalizer next to it,
+// which is fed by an alternative L1 source, to compare the finalized L2 heads of the two.
+// The decisions of the shadow Finalizer are only logged and metered, and never applied to the engine.
+// This is useful to evaluate new L1 RPC providers or finality signal sources.
+type ShadowFinalizer struct {
+	*Finalizer
+
+	shadow   *Finalizer
+	shadowEc *shadowEngine
+
+	divergences uint64
+}
+
+func NewShadowFinalizer(log log.Logger, cfg *rollup.Config, primary *Finalizer, shadowL1 FinalizerL1Interface) *ShadowFinalizer {
+	shadowEc := &shadowEngine{base: primary.ec}
+	shadow := NewFinalizer(log.New("finalizer", "shadow"), cfg, shadowL1, shadowEc)
+	return &ShadowFinalizer{
+		Finalizer: primary,
+		shadow:    shadow,
+		shadowEc:  shadowEc,
+	}
+}
+
+// ShadowFinalize applies a L1 finality signal of the alternative L1 source to the shadow Finalizer.
+func (fi *ShadowFinalizer) ShadowFinalize(ctx context.Context, l1Origin eth.L1BlockRef) {
+	fi.shadow.Finalize(ctx, l1Origin)
+	fi.compare()
+}
+
+func (fi *ShadowFinalizer) Finalize(ctx context.Context, l1Origin eth.L1BlockRef) {
+	fi.FinalizeOutcome(ctx, l1Origin)
+}
+
+// FinalizeOutcome returns the outcome of the primary Finalizer only, the shadow never requires any action.
+func (fi *ShadowFinalizer) FinalizeOutcome(ctx context.Context, l1Origin eth.L1BlockRef) FinalityOutcome {
+	outcome := fi.Finalizer.FinalizeOutcome(ctx, l1Origin)
+	fi.compare()
+	return outcome
+}
+
+func (fi *ShadowFinalizer) OnDerivationL1End(ctx context.Context, derivedFrom eth.L1BlockRef) error {
+	return fi.OnDerivationL1EndOutcome(ctx, derivedFrom).AsError()
+}
+
+func (fi *ShadowFinalizer) OnDerivationL1EndOutcome(ctx context.Context, derivedFrom eth.L1BlockRef) FinalityOutcome {
+	if outcome := fi.shadow.OnDerivationL1EndOutcome(ctx, derivedFrom); outcome.Err != nil {
+		fi.shadow.log.Warn("shadow finalizer failed to process L1 block", "derived_from", derivedFrom,
+			"action", outcome.Action, "err", outcome.Err)
+	}
+	outcome := fi.Finalizer.OnDerivationL1EndOutcome(ctx, derivedFrom)
+	fi.compare()
+	return outcome
+}
+
+func (fi *ShadowFinalizer) OnL1TraversedOutcome(ctx context.Context, traversed eth.L1BlockRef) FinalityOutcome {
+	if outcome := fi.shadow.OnL1TraversedOutcome(ctx, traversed); outcome.Err != nil {
+		fi.shadow.log.Warn("shadow finalizer failed to process traversed L1 block", "traversed", traversed,
+			"action", outcome.Action, "err", outcome.Err)
+	}
+	outcome := fi.Finalizer.OnL1TraversedOutcome(ctx, traversed)
+	fi.compare()
+	return outcome
+}
+
+func (fi *ShadowFinalizer) OnDerivationIdle(ctx context.Context) error {
+	if err := fi.shadow.OnDerivationIdle(ctx); err != nil {
+		fi.shadow.log.Warn("shadow finalizer failed to process idle derivation", "err", err)
+	}
+	err := fi.Finalizer.OnDerivationIdle(ctx)
+	fi.compare()
+	return err
+}
+
+func (fi *ShadowFinalizer) PostProcessSafeL2(l2Safe eth.L2BlockRef, derivedFrom eth.L1BlockRef) {
+	fi.Finalizer.PostProcessSafeL2(l2Safe, derivedFrom)
+	fi.shadow.PostProcessSafeL2(l2Safe, derivedFrom)
+}
+
+func (fi *ShadowFinalizer) OnL2ForkConflict(conflict eth.BlockID) {
+	fi.Finalizer.OnL2ForkConflict(conflict)
+	fi.shadow.OnL2ForkConflict(conflict)
+}
+
+func (fi *ShadowFinalizer) Reset() {
+	fi.Finalizer.Reset()
+	fi.shadow.Reset()
+}
+
+// ResumeFinality resumes the halted finalization of both the primary and the shadow.
+func (fi *ShadowFinalizer) ResumeFinality() error {
+	err := fi.Finalizer.ResumeFinality()
+	_ = fi.shadow.ResumeFinality()
+	return err
+}
+
+// UnsafeRollbackFinalized rolls back the finalized head of both the primary and the shadow.
+func (fi *ShadowFinalizer) UnsafeRollbackFinalized(ctx context.Context, target eth.L2BlockRef) error {
+	if err := fi.Finalizer.UnsafeRollbackFinalized(ctx, target); err != nil {
+		return err
+	}
+	_ = fi.shadow.UnsafeRollbackFinalized(ctx, target)
+	return nil
+}
+
+// Divergences returns the number of times the shadow finalized a different L2 block than the primary.
+func (fi *ShadowFinalizer) Divergences() uint64 {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.divergences
+}
+
+// compare checks the finalized L2 head of the shadow against the primary.
+// The shadow may lag behind or be ahead of the primary, but a different block at the same height is a divergence.
+func (fi *ShadowFinalizer) compare() {
+	fi.shadow.mu.Lock()
+	shadowFinalized := fi.shadowEc.finalized
+	fi.shadow.mu.Unlock()
+	if shadowFinalized == (eth.L2BlockRef{}) {
+		return // shadow did not finalize anything yet
+	}
+
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	fi.metrics.RecordL2Ref("l2_finalized_shadow", shadowFinalized)
+	primaryFinalized := fi.engineFinalized()
+	expected := primaryFinalized
+	if shadowFinalized.Number != primaryFinalized.Number {
+		// look up what the primary derived at the same height, if still buffered
+		expected = eth.L2BlockRef{}
+		for _, r := range fi.finalityData {
+			if r.Derived.Number == shadowFinalized.Number {
+				expected = r.Derived
+				break
+			}
+		}
+	}
+	if expected == (eth.L2BlockRef{}) {
+		fi.log.Debug("shadow finalizer is not comparable to primary",
+			"primary_finalized", primaryFinalized, "shadow_finalized", shadowFinalized)
+		return
+	}
+	if expected.Hash != shadowFinalized.Hash {
+		fi.divergences += 1
+		fi.log.Error("shadow finalizer diverged from primary",
+			"expected", expected, "shadow_finalized", shadowFinalized,
+			"primary_finalized", primaryFinalized, "divergences", fi.divergences)
+	}
+}
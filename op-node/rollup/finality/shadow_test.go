@@ -0,0 +1,57 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestShadowFinalizer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	// the alternative L1 source serves a different block at the height of the third one
+	alt := testutils.NextRandomRef(rng, chain.l1[1])
+
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	shadowL1F := &testutils.MockL1Source{}
+	defer shadowL1F.AssertExpectations(t)
+
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	cfg := &rollup.Config{}
+	fi := NewShadowFinalizer(logger, cfg, NewFinalizer(logger, cfg, l1F, ec), shadowL1F)
+
+	for i := 0; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	// the shadow alone finalizes, without changing the engine
+	shadowL1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	shadowL1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	fi.ShadowFinalize(context.Background(), chain.l1[1])
+	require.Equal(t, chain.l2[0][1], ec.Finalized(), "shadow decisions are not applied")
+	require.Equal(t, chain.l2[1][1], fi.shadowEc.finalized)
+	require.Zero(t, fi.Divergences(), "shadow is ahead, but consistent with the primary")
+
+	// the primary catches up
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+	require.Zero(t, fi.Divergences(), "shadow is behind, but consistent with the primary")
+
+	// the shadow finalizes a block that the primary did not derive
+	fi.shadowEc.finalized = testutils.NextRandomL2Ref(rng, 1, chain.l2[2][0], alt.ID())
+	fi.compare()
+	require.Equal(t, uint64(1), fi.Divergences())
+}
@@ -0,0 +1,62 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FinalizeHash applies an L1 finality signal that is only identified by its L1 block hash,
+// as some light-client sources provide. It returns the resolved L1 block ref of the signal.
+func (fi *Finalizer) FinalizeHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	ref, err := fi.resolveSignal(ctx, hash)
+	if err != nil {
+		return eth.L1BlockRef{}, err
+	}
+	fi.FinalizeFrom(ctx, ref, SignalSourceHash)
+	return ref, nil
+}
+
+// resolveSignal resolves the full L1 block ref of a finality signal by its hash,
+// and validates that it is on the canonical L1 chain, unless the signal is trusted.
+// The L1 source is not guarded by the lock, it must not be held.
+func (fi *Finalizer) resolveSignal(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	ref, err := fi.l1Fetcher.L1BlockRefByHash(ctx, hash)
+	if err != nil {
+		return eth.L1BlockRef{}, fmt.Errorf("failed to resolve L1 finality signal %s: %w", hash, err)
+	}
+	if fi.trustSignal {
+		return ref, nil
+	}
+	canonical, err := fi.l1Fetcher.L1BlockRefByNumber(ctx, ref.Number)
+	if err != nil {
+		return eth.L1BlockRef{}, &ErrL1Unavailable{Number: ref.Number, Err: err}
+	}
+	if canonical.Hash != ref.Hash {
+		return eth.L1BlockRef{}, &ErrSignalNotCanonical{Signal: ref, Canonical: canonical}
+	}
+	return ref, nil
+}
+
+// FinalizeHash applies an L1 finality signal by its L1 block hash, through the plasma backend.
+func (fi *PlasmaFinalizer) FinalizeHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	ref, err := fi.resolveSignal(ctx, hash)
+	if err != nil {
+		return eth.L1BlockRef{}, err
+	}
+	fi.Finalize(ctx, ref)
+	return ref, nil
+}
+
+// FinalizeHash applies an L1 finality signal by its L1 block hash, while following the primary node.
+func (fi *FollowFinalizer) FinalizeHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	ref, err := fi.resolveSignal(ctx, hash)
+	if err != nil {
+		return eth.L1BlockRef{}, err
+	}
+	fi.Finalize(ctx, ref)
+	return ref, nil
+}
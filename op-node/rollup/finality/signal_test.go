@@ -0,0 +1,91 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerFinalizeHash(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+	for i := 1; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	// the signal is resolved by hash, and only applied if it is canonical
+	reorged := chain.l1[2]
+	reorged.Hash = testutils.RandomHash(rng)
+	l1F.ExpectL1BlockRefByHash(reorged.Hash, reorged, nil)
+	l1F.ExpectL1BlockRefByNumber(reorged.Number, chain.l1[2], nil)
+	_, err := fi.FinalizeHash(context.Background(), reorged.Hash)
+	var notCanonical *ErrSignalNotCanonical
+	require.ErrorAs(t, err, &notCanonical)
+	require.Equal(t, eth.L1BlockRef{}, fi.FinalizedL1())
+
+	// the L1 source fails to resolve the signal
+	l1F.ExpectL1BlockRefByHash(chain.l1[2].Hash, eth.L1BlockRef{}, errors.New("fail"))
+	_, err = fi.FinalizeHash(context.Background(), chain.l1[2].Hash)
+	require.ErrorContains(t, err, "fail")
+
+	l1F.ExpectL1BlockRefByHash(chain.l1[2].Hash, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	ref, err := fi.FinalizeHash(context.Background(), chain.l1[2].Hash)
+	require.NoError(t, err)
+	require.Equal(t, chain.l1[2], ref)
+	require.Equal(t, chain.l1[2], fi.FinalizedL1())
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+}
+
+func TestFinalizerFinalizeHashTrusted(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 2)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, &fakeEngine{}, WithTrustSignal())
+
+	// a trusted signal is only resolved
+	l1F.ExpectL1BlockRefByHash(chain.l1[1].Hash, chain.l1[1], nil)
+	ref, err := fi.FinalizeHash(context.Background(), chain.l1[1].Hash)
+	require.NoError(t, err)
+	require.Equal(t, chain.l1[1], ref)
+	require.Equal(t, chain.l1[1], fi.FinalizedL1())
+}
+
+func TestPlasmaFinalizerFinalizeHash(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 2)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	var signals []eth.L1BlockRef
+	backend := &fakePlasmaBackend{plasmaFn: func(ref eth.L1BlockRef) { signals = append(signals, ref) }}
+	fi := NewPlasmaFinalizer(logger, &rollup.Config{}, l1F, &fakeEngine{}, backend)
+
+	// the resolved signal is proxied to the plasma backend
+	l1F.ExpectL1BlockRefByHash(chain.l1[1].Hash, chain.l1[1], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	_, err := fi.FinalizeHash(context.Background(), chain.l1[1].Hash)
+	require.NoError(t, err)
+	require.Equal(t, []eth.L1BlockRef{chain.l1[1]}, signals)
+	require.Equal(t, eth.L1BlockRef{}, fi.FinalizedL1())
+}
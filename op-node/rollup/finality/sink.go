@@ -0,0 +1,227 @@
+package finality
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/segmentio/kafka-go"
+)
+
+// NewSink creates the outbox sink for the given URL:
+//   - an http:// or https:// URL for a webhook, which receives every message as JSON in a POST request,
+//   - a nats://host:port/subject or nats+tls://host:port/subject URL for a subject of a NATS JetStream stream,
+//     optionally with the path of a NATS credentials file as creds query parameter, see JetStreamSink,
+//   - a kafka://broker:port/topic or kafka+tls://broker:port/topic URL for a Kafka topic,
+//     with further brokers of the cluster separated by commas, see KafkaSink.
+//
+// Secrets in the URLs of NATS and Kafka sinks are refused, they are only read from the credentials file.
+func NewSink(sinkURL string) (Sink, error) {
+	u, err := url.Parse(sinkURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid finality outbox sink URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return NewWebhookSink(sinkURL, http.DefaultClient), nil
+	case "nats", "nats+tls", "kafka", "kafka+tls":
+	default:
+		return nil, fmt.Errorf("unsupported finality outbox sink scheme %q", u.Scheme)
+	}
+	if u.User != nil {
+		return nil, fmt.Errorf("finality outbox %s sink URL must not contain credentials", u.Scheme)
+	}
+	target := strings.TrimPrefix(u.Path, "/")
+	if target == "" || u.Host == "" {
+		return nil, fmt.Errorf("finality outbox sink URL %q has no host or no subject or topic", sinkURL)
+	}
+	useTLS := strings.HasSuffix(u.Scheme, "+tls")
+	if strings.HasPrefix(u.Scheme, "nats") {
+		return NewJetStreamSink(u.Host, target, useTLS, u.Query().Get("creds")), nil
+	}
+	return NewKafkaSink(strings.Split(u.Host, ","), target, useTLS), nil
+}
+
+// WebhookSink posts every message as JSON to a webhook.
+// The ID of the message is also set as the Idempotency-Key header.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+var _ Sink = (*WebhookSink)(nil)
+
+func NewWebhookSink(url string, client *http.Client) *WebhookSink {
+	return &WebhookSink{url: url, client: client}
+}
+
+func (s *WebhookSink) Name() string {
+	return redactURL(s.url)
+}
+
+func (s *WebhookSink) Deliver(ctx context.Context, msg OutboxMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode finalized event: %w", err)
+	}
+	return postJSON(ctx, s.client, s.url, "application/json", body, strconv.FormatUint(msg.ID, 10))
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, contentType string, body []byte, idempotencyKey string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post finalized event: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to post finalized event: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// JetStreamSink publishes every message as JSON to a subject of a NATS JetStream stream,
+// and only accepts it once JetStream acknowledged that the stream persisted it.
+// The ID of the message is set as the Nats-Msg-Id header, so JetStream drops redelivered messages
+// within the duplicate window of the stream. A stream must be configured to capture the subject.
+// The connection is established on the first delivery, and re-established once it was closed.
+type JetStreamSink struct {
+	addr    string
+	subject string
+	useTLS  bool
+	creds   string
+
+	nc *nats.Conn
+	js jetstream.JetStream
+}
+
+var _ Sink = (*JetStreamSink)(nil)
+
+// NewJetStreamSink creates a sink for the subject of a JetStream stream on the NATS server at addr.
+// The credentials file is optional.
+func NewJetStreamSink(addr string, subject string, useTLS bool, creds string) *JetStreamSink {
+	return &JetStreamSink{addr: addr, subject: subject, useTLS: useTLS, creds: creds}
+}
+
+func (s *JetStreamSink) Name() string {
+	return "nats://" + s.addr + "/" + s.subject
+}
+
+func (s *JetStreamSink) Deliver(ctx context.Context, msg OutboxMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode finalized event: %w", err)
+	}
+	if s.nc == nil || s.nc.IsClosed() {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+	if _, err := s.js.Publish(ctx, s.subject, payload, jetstream.WithMsgID(strconv.FormatUint(msg.ID, 10))); err != nil {
+		return fmt.Errorf("failed to publish finalized event to JetStream: %w", err)
+	}
+	return nil
+}
+
+func (s *JetStreamSink) connect() error {
+	opts := []nats.Option{nats.Name("op-node-finality"), nats.Timeout(outboxDeliverTimeout)}
+	if s.useTLS {
+		opts = append(opts, nats.Secure())
+	}
+	if s.creds != "" {
+		opts = append(opts, nats.UserCredentials(s.creds))
+	}
+	nc, err := nats.Connect("nats://"+s.addr, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS server: %w", err)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+	s.nc, s.js = nc, js
+	return nil
+}
+
+func (s *JetStreamSink) Close() error {
+	if s.nc != nil {
+		s.nc.Close()
+	}
+	return nil
+}
+
+// KafkaSink produces every message as a JSON record to a Kafka topic, keyed by the ID of the message,
+// and only accepts it once all in-sync replicas of the partition acknowledged the record.
+type KafkaSink struct {
+	name   string
+	writer *kafka.Writer
+}
+
+var _ Sink = (*KafkaSink)(nil)
+
+// NewKafkaSink creates a sink for the topic of the Kafka cluster with the given brokers.
+func NewKafkaSink(brokers []string, topic string, useTLS bool) *KafkaSink {
+	transport := &kafka.Transport{ClientID: "op-node-finality"}
+	if useTLS {
+		transport.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return &KafkaSink{
+		name: "kafka://" + strings.Join(brokers, ",") + "/" + topic,
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			// the outbox retries failed deliveries with backoff itself
+			MaxAttempts:  1,
+			BatchSize:    1,
+			WriteTimeout: outboxDeliverTimeout,
+			Transport:    transport,
+		},
+	}
+}
+
+func (s *KafkaSink) Name() string {
+	return s.name
+}
+
+func (s *KafkaSink) Deliver(ctx context.Context, msg OutboxMessage) error {
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode finalized event: %w", err)
+	}
+	if err := s.writer.WriteMessages(ctx, kafka.Message{Key: []byte(strconv.FormatUint(msg.ID, 10)), Value: value}); err != nil {
+		return fmt.Errorf("failed to produce finalized event to Kafka: %w", err)
+	}
+	return nil
+}
+
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
+
+// redactURL returns the URL without credentials, to identify a sink without leaking secrets into logs and the outbox.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	u.User = nil
+	return u.String()
+}
@@ -0,0 +1,147 @@
+package finality
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// l1FinalizationEpochs is the number of L1 epochs after which L1 blocks finalize on a healthy beacon chain.
+const l1FinalizationEpochs = 2
+
+// defaultL1SlotsPerEpoch is the number of slots per epoch of the mainnet beacon chain.
+const defaultL1SlotsPerEpoch = 32
+
+// sloShortWindowDivisor sizes the short SLO window relative to the SLO window, like a 5 minute window for 1 hour,
+// to confirm that a high burn rate over the SLO window is still ongoing.
+const sloShortWindowDivisor = 12
+
+// WithLatencySLO reports the compliance with the end-to-end finality latency SLO, and the burn rate of its
+// error budget, over a sliding window, e.g. 95% of L2 blocks finalized within 2x the L1 finality time.
+// L2 blocks that are not finalized yet, but already beyond the target latency, count as breached right away,
+// so a stall of finality burns the error budget before it resolves. Disabled if any of the SLO parameters is 0.
+func WithLatencySLO(slo LatencySLO) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.slo = slo
+	}
+}
+
+// sloSample is the latency compliance of a single advancement of the finalized L2 head.
+type sloSample struct {
+	at       time.Time
+	total    uint64
+	breached uint64
+}
+
+// sloEnabled returns true if the latency SLO is configured.
+func (fi *Finalizer) sloEnabled() bool {
+	return fi.slo.Objective > 0 && fi.slo.Objective < 1 && fi.slo.Window > 0 && fi.slo.TargetFactor > 0
+}
+
+// sloTarget returns the target latency of the SLO, relative to the L1 finality time. The lock must be held.
+func (fi *Finalizer) sloTarget() time.Duration {
+	slots := fi.l1SlotsPerEpoch
+	if slots == 0 {
+		slots = defaultL1SlotsPerEpoch
+	}
+	blockTime := fi.l1BlockTime
+	if blockTime == 0 {
+		blockTime = defaultL1BlockTime
+	}
+	l1Finality := time.Duration(l1FinalizationEpochs*slots) * blockTime
+	return time.Duration(float64(l1Finality) * fi.slo.TargetFactor)
+}
+
+// breachedBetween returns the number of L2 blocks after prev, up to and including next,
+// with a timestamp before the deadline, in unix seconds. The lock must be held.
+func (fi *Finalizer) breachedBetween(prev, next eth.L2BlockRef, deadline uint64) uint64 {
+	total := next.Number - prev.Number
+	if next.Time < deadline {
+		return total
+	}
+	if fi.cfg.BlockTime == 0 {
+		return 0
+	}
+	// L2 blocks at or below next.Number - within are within the target
+	within := (next.Time-deadline)/fi.cfg.BlockTime + 1
+	if within >= total {
+		return 0
+	}
+	return total - within
+}
+
+// observeSLO records the latency compliance of the L2 blocks finalized by the advancement of the finalized L2 head
+// from prev to next. The lock must be held.
+func (fi *Finalizer) observeSLO(prev, next eth.L2BlockRef) {
+	if !fi.sloEnabled() || prev == (eth.L2BlockRef{}) || next.Number <= prev.Number {
+		return
+	}
+	now := fi.clock.Now()
+	deadline := now.Add(-fi.sloTarget()).Unix()
+	fi.sloSamples = append(fi.sloSamples, sloSample{
+		at:       now,
+		total:    next.Number - prev.Number,
+		breached: fi.breachedBetween(prev, next, uint64(max(deadline, 0))),
+	})
+}
+
+// sloWindow computes the latency compliance over the window, including the overdue L2 blocks. The lock must be held.
+func (fi *Finalizer) sloWindow(now time.Time, window time.Duration, overdue uint64) LatencySLOWindow {
+	out := LatencySLOWindow{Window: window, Total: overdue, Breached: overdue}
+	since := now.Add(-window)
+	for i := len(fi.sloSamples) - 1; i >= 0 && fi.sloSamples[i].at.After(since); i-- {
+		out.Total += fi.sloSamples[i].total
+		out.Breached += fi.sloSamples[i].breached
+	}
+	out.Compliance = 1
+	if out.Total > 0 {
+		out.Compliance = 1 - float64(out.Breached)/float64(out.Total)
+	}
+	out.BurnRate = (1 - out.Compliance) / (1 - fi.slo.Objective)
+	return out
+}
+
+// sloStatus prunes the samples beyond the SLO window, and computes the latency compliance. The lock must be held.
+func (fi *Finalizer) sloStatus() LatencySLOStatus {
+	now := fi.clock.Now()
+	since := now.Add(-fi.slo.Window)
+	i := 0
+	for i < len(fi.sloSamples) && !fi.sloSamples[i].at.After(since) {
+		i++
+	}
+	fi.sloSamples = fi.sloSamples[i:]
+
+	target := fi.sloTarget()
+	overdue := uint64(0)
+	if finalized := fi.finalizedL2; finalized != (eth.L2BlockRef{}) && fi.progressSafeL2.Number > finalized.Number {
+		deadline := now.Add(-target).Unix()
+		// the safe L2 blocks that are not finalized yet, and already beyond the target latency
+		overdue = fi.breachedBetween(finalized, fi.progressSafeL2, uint64(max(deadline, 0)))
+	}
+	return LatencySLOStatus{
+		SLO:    fi.slo,
+		Target: target,
+		Long:   fi.sloWindow(now, fi.slo.Window, overdue),
+		Short:  fi.sloWindow(now, fi.slo.Window/sloShortWindowDivisor, overdue),
+	}
+}
+
+// reportSLO publishes the latency compliance and burn rates. The lock must be held.
+func (fi *Finalizer) reportSLO() {
+	if !fi.sloEnabled() {
+		return
+	}
+	status := fi.sloStatus()
+	fi.metrics.RecordFinalitySLO("long", status.Long.Compliance, status.Long.BurnRate)
+	fi.metrics.RecordFinalitySLO("short", status.Short.Compliance, status.Short.BurnRate)
+}
+
+// LatencySLOStatus returns the compliance with the finality latency SLO, or false if no SLO is configured.
+func (fi *Finalizer) LatencySLOStatus() (LatencySLOStatus, bool) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	if !fi.sloEnabled() {
+		return LatencySLOStatus{}, false
+	}
+	return fi.sloStatus(), true
+}
@@ -0,0 +1,78 @@
+package finality
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerLatencySLO(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	clk := clock.NewDeterministicClock(time.Unix(10_000, 0))
+	ec := &fakeEngine{}
+	m := &fakeMetrics{}
+	slo := LatencySLO{Objective: 0.9, TargetFactor: 1, Window: time.Hour}
+	fi := NewFinalizer(logger, &rollup.Config{BlockTime: 2}, &testutils.MockL1Source{}, ec,
+		WithClock(clk), WithMetrics(m), WithLatencySLO(slo))
+
+	status, ok := fi.LatencySLOStatus()
+	require.True(t, ok)
+	// 2 mainnet epochs of L1 blocks
+	require.Equal(t, 768*time.Second, status.Target)
+	require.Equal(t, 1.0, status.Long.Compliance)
+	require.Zero(t, status.Long.BurnRate)
+
+	// the deadline is at 10_000-768 = 9232: all L2 blocks of the first advancement are beyond the target latency,
+	// and the L2 blocks after 9232 of the second advancement are within it.
+	fi.observeSLO(eth.L2BlockRef{Number: 0, Time: 9000}, eth.L2BlockRef{Number: 100, Time: 9200})
+	fi.observeSLO(eth.L2BlockRef{Number: 100, Time: 9200}, eth.L2BlockRef{Number: 200, Time: 9400})
+	fi.reportSLO()
+	status, _ = fi.LatencySLOStatus()
+	require.Equal(t, uint64(200), status.Long.Total)
+	require.Equal(t, uint64(115), status.Long.Breached)
+	require.InDelta(t, 0.425, status.Long.Compliance, 1e-9)
+	require.InDelta(t, 5.75, status.Long.BurnRate, 1e-9)
+	require.InDelta(t, 5.75, m.sloBurnRates["long"], 1e-9)
+	require.InDelta(t, 5.75, m.sloBurnRates["short"], 1e-9)
+
+	// the samples leave the short window first, and then the SLO window
+	clk.AdvanceTime(10 * time.Minute)
+	status, _ = fi.LatencySLOStatus()
+	require.Zero(t, status.Short.Total)
+	require.Equal(t, uint64(200), status.Long.Total)
+	clk.AdvanceTime(time.Hour)
+	status, _ = fi.LatencySLOStatus()
+	require.Zero(t, status.Long.Total)
+	require.Equal(t, 1.0, status.Long.Compliance)
+
+	// safe L2 blocks that are not finalized yet, but beyond the target latency, count as breached right away
+	now := uint64(clk.Now().Unix())
+	ec.finalized = eth.L2BlockRef{Number: 200, Time: now - 1000}
+	fi.PostProcessSafeL2(eth.L2BlockRef{Number: 600, Time: now}, eth.L1BlockRef{Number: 1})
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), eth.L1BlockRef{Number: 1}))
+	status, _ = fi.LatencySLOStatus()
+	// the L2 blocks with a timestamp before now-768 are overdue: 201 up to 215, 216 is at exactly now-768
+	require.Equal(t, uint64(15), status.Long.Breached)
+	require.Equal(t, uint64(15), status.Short.Total)
+	require.Zero(t, status.Long.Compliance)
+	require.NotNil(t, fi.DebugBundle().LatencySLO)
+}
+
+func TestFinalizerLatencySLODisabled(t *testing.T) {
+	fi := NewFinalizer(testlog.Logger(t, log.LevelInfo), &rollup.Config{BlockTime: 2}, &testutils.MockL1Source{}, &fakeEngine{})
+	_, ok := fi.LatencySLOStatus()
+	require.False(t, ok)
+	fi.observeSLO(eth.L2BlockRef{Number: 0}, eth.L2BlockRef{Number: 100})
+	require.Empty(t, fi.sloSamples)
+	require.Nil(t, fi.DebugBundle().LatencySLO)
+}
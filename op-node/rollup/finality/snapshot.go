@@ -0,0 +1,24 @@
+package finality
+
+// Snapshot captures the current state of the Finalizer.
+func (fi *Finalizer) Snapshot() *Snapshot {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.snapshot()
+}
+
+// snapshot captures the current state of the Finalizer. The lock must be held.
+func (fi *Finalizer) snapshot() *Snapshot {
+	data := make([]FinalityData, 0, len(fi.finalityData))
+	for _, r := range fi.finalityData {
+		data = append(data, toFinalityData(r))
+	}
+	challenge, resolve := fi.plasmaWindows()
+	return &Snapshot{
+		FinalizedL1:       fi.finalizedL1,
+		FinalityData:      data,
+		Lookback:          fi.finalityLookback,
+		DAChallengeWindow: challenge,
+		DAResolveWindow:   resolve,
+	}
+}
@@ -0,0 +1,56 @@
+package finality
+
+import (
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestSnapshotEncoding(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, &fakeEngine{})
+	for i := range chain.l1 {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+	fi.finalizedL1 = chain.l1[0]
+
+	t.Run("snapshot", func(t *testing.T) {
+		snap := fi.Snapshot()
+		require.Len(t, snap.FinalityData, 3)
+		data, err := snap.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, byte(EncodingV0), data[0])
+
+		var out Snapshot
+		require.NoError(t, out.UnmarshalBinary(data))
+		require.Equal(t, *snap, out)
+	})
+
+	t.Run("finality-data", func(t *testing.T) {
+		fd := fi.Snapshot().FinalityData[1]
+		data, err := fd.MarshalBinary()
+		require.NoError(t, err)
+
+		var out FinalityData
+		require.NoError(t, out.UnmarshalBinary(data))
+		require.Equal(t, fd, out)
+	})
+
+	t.Run("unknown-version", func(t *testing.T) {
+		data, err := fi.Snapshot().MarshalBinary()
+		require.NoError(t, err)
+		data[0] = 0xff
+		var out Snapshot
+		require.ErrorIs(t, out.UnmarshalBinary(data), ErrUnknownEncoding)
+		require.Error(t, out.UnmarshalBinary(nil))
+	})
+}
@@ -0,0 +1,94 @@
+package finality
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// spanBatchBufferSize is the maximum number of span batches to track, awaiting finalization.
+const spanBatchBufferSize = 1024
+
+// WithSpanBatches tracks the span batch boundaries of the derived L2 chain, and finalizes whole span batches only.
+// The engine only promotes the safe head at the end of a span batch, so every safe head update ends a span batch.
+// The finalized span batches are included in the finalized events, for batch-level accounting.
+func WithSpanBatches() FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.trackSpans = true
+	}
+}
+
+// trackSpan records the span batch that ends with the given safe head. The lock must be held.
+func (fi *Finalizer) trackSpan(l2Safe eth.L2BlockRef, derivedFrom eth.L1BlockRef) {
+	if !fi.trackSpans {
+		return
+	}
+	last := fi.lastSafeL2
+	fi.lastSafeL2 = l2Safe
+	if last == (eth.L2BlockRef{}) || l2Safe.Number <= last.Number {
+		// The start of the span batch is unknown on startup, and after the safe head reorged.
+		if l2Safe.Number <= last.Number {
+			fi.spans = fi.spans[:0]
+		}
+		return
+	}
+	if len(fi.spans) >= spanBatchBufferSize {
+		fi.spans = append(fi.spans[:0], fi.spans[1:]...)
+	}
+	fi.spans = append(fi.spans, SpanBatchBoundary{
+		Start:       eth.BlockID{Number: last.Number + 1},
+		End:         l2Safe,
+		DerivedFrom: derivedFrom.ID(),
+	})
+}
+
+// alignToSpan lowers the finalized L2 head to the end of the span batch before it,
+// if it is not the end of a span batch itself, so span batches are finalized atomically.
+// The lock must be held.
+func (fi *Finalizer) alignToSpan(prevFinalizedL2, finalizedL2 eth.L2BlockRef) eth.L2BlockRef {
+	if !fi.trackSpans || len(fi.spans) == 0 ||
+		finalizedL2.Number < fi.spans[0].Start.Number || finalizedL2.Number > fi.spans[len(fi.spans)-1].End.Number {
+		// The span batches of the finalized L2 head are not tracked, e.g. when they were derived before startup.
+		return finalizedL2
+	}
+	aligned := prevFinalizedL2
+	for _, span := range fi.spans {
+		if span.End.Number > finalizedL2.Number {
+			break
+		}
+		if span.End.Number == finalizedL2.Number {
+			return finalizedL2
+		}
+		if span.End.Number > aligned.Number {
+			aligned = span.End
+		}
+	}
+	fi.log.Debug("aligning finalized L2 head to span batch boundary", "finalized_l2", finalizedL2, "aligned", aligned)
+	return aligned
+}
+
+// spanGate lowers the L2 block to finalize to a span batch boundary, see alignToSpan.
+type spanGate struct {
+	noopGate
+	fi *Finalizer
+}
+
+func (g *spanGate) check(ctx context.Context, a *finalizeAttempt) (FinalizeReason, error) {
+	a.finalizedL2 = g.fi.alignToSpan(a.prevFinalizedL2, a.finalizedL2)
+	return "", nil
+}
+
+// popFinalizedSpans removes and returns the tracked span batches that were finalized by finalizedL2.
+// The lock must be held.
+func (fi *Finalizer) popFinalizedSpans(finalizedL2 eth.L2BlockRef) []SpanBatchBoundary {
+	i := 0
+	for i < len(fi.spans) && fi.spans[i].End.Number <= finalizedL2.Number {
+		i++
+	}
+	if i == 0 {
+		return nil
+	}
+	finalized := append([]SpanBatchBoundary(nil), fi.spans[:i]...)
+	fi.spans = append(fi.spans[:0], fi.spans[i:]...)
+	return finalized
+}
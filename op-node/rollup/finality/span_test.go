@@ -0,0 +1,79 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerSpanBatches(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithSpanBatches())
+	var events []FinalizedEvent
+	fi.SubscribeFinalized(func(ev FinalizedEvent) {
+		events = append(events, ev)
+	})
+
+	// the start of the first span batch after startup is unknown
+	fi.PostProcessSafeL2(chain.l2[0][1], chain.l1[0])
+	// a span batch per L2 block in the first L1 block, and a single span batch in the next ones
+	fi.PostProcessSafeL2(chain.l2[1][0], chain.l1[1])
+	fi.PostProcessSafeL2(chain.l2[1][0], chain.l1[1])
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+	fi.PostProcessSafeL2(chain.l2[3][1], chain.l1[3])
+	require.Len(t, fi.spans, 4)
+
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, chain.l2[2][1], ec.Finalized())
+	require.Len(t, events, 1)
+	require.Equal(t, []SpanBatchBoundary{
+		{Start: eth.BlockID{Number: chain.l2[1][0].Number}, End: chain.l2[1][0], DerivedFrom: chain.l1[1].ID()},
+		{Start: eth.BlockID{Number: chain.l2[1][1].Number}, End: chain.l2[1][1], DerivedFrom: chain.l1[1].ID()},
+		{Start: eth.BlockID{Number: chain.l2[2][0].Number}, End: chain.l2[2][1], DerivedFrom: chain.l1[2].ID()},
+	}, events[0].SpanBatches)
+	// the span batch that is not finalized yet remains
+	require.Len(t, fi.spans, 1)
+
+	// the tracked span batches are invalidated by a reset
+	fi.Reset()
+	require.Empty(t, fi.spans)
+}
+
+func TestFinalizerAlignToSpan(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(logger, &rollup.Config{}, &testutils.MockL1Source{}, ec, WithSpanBatches())
+	for i := 0; i < 4; i++ {
+		fi.PostProcessSafeL2(chain.l2[i][1], chain.l1[i])
+	}
+
+	// the end of a span batch is not changed
+	require.Equal(t, chain.l2[2][1], fi.alignToSpan(chain.l2[0][1], chain.l2[2][1]))
+	// a block within a span batch is lowered to the end of the previous span batch
+	require.Equal(t, chain.l2[1][1], fi.alignToSpan(chain.l2[0][1], chain.l2[2][0]))
+	// without a previous span batch, the finalized L2 head does not advance
+	require.Equal(t, chain.l2[0][1], fi.alignToSpan(chain.l2[0][1], chain.l2[1][0]))
+	// untracked span batches are not aligned
+	require.Equal(t, chain.l2[0][0], fi.alignToSpan(eth.L2BlockRef{}, chain.l2[0][0]))
+}
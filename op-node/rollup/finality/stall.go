@@ -0,0 +1,70 @@
+package finality
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// defaultStallThreshold is how long the finalized L2 head may not advance, before finalization is considered stalled.
+// On a healthy chain the finalized L2 head advances every few L1 epochs, bounded by the finalityDelay.
+const defaultStallThreshold = 30 * time.Minute
+
+// WithStallThreshold configures how long the finalized L2 head may not advance,
+// before finalization is considered stalled, and the stall is classified. Disabled if 0.
+func WithStallThreshold(threshold time.Duration) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.stallThreshold = threshold
+	}
+}
+
+// trackDerivationProgress records when derivation last advanced the safe L2 head. The lock must be held.
+func (fi *Finalizer) trackDerivationProgress(l2Safe eth.L2BlockRef) {
+	if l2Safe.Number > fi.progressSafeL2.Number || fi.progressSafeL2 == (eth.L2BlockRef{}) {
+		fi.progressSafeL2 = l2Safe
+		fi.safeAdvancedAt = fi.clock.Now()
+	}
+}
+
+// classifyStall determines whether finalization is stalled, and if so, whether derivation is stuck too,
+// to point operators at the subsystem that causes the stall. The lock must be held.
+func (fi *Finalizer) classifyStall() StallReason {
+	if fi.stallThreshold == 0 {
+		return StallNone
+	}
+	now := fi.clock.Now()
+	if now.Sub(latest(fi.lastAppliedAt, fi.startedAt)) < fi.stallThreshold {
+		return StallNone
+	}
+	if now.Sub(latest(fi.safeAdvancedAt, fi.startedAt)) >= fi.stallThreshold {
+		return StallDerivation
+	}
+	return StallFinality
+}
+
+// reportStall publishes the classified stall, and logs changes of it. The lock must be held.
+func (fi *Finalizer) reportStall() {
+	reason := fi.classifyStall()
+	fi.metrics.RecordFinalityStall(string(reason))
+	if reason == fi.lastStall {
+		return
+	}
+	fi.lastStall = reason
+	switch reason {
+	case StallDerivation:
+		fi.log.Warn("finalization is stalled, because derivation is not advancing the safe L2 head",
+			"finalized_l2", fi.finalizedL2, "safe_l2", fi.progressSafeL2, "derived_from", fi.derivedFromL1)
+	case StallFinality:
+		fi.log.Warn("finalization is stalled, despite derivation advancing the safe L2 head",
+			"finalized_l2", fi.finalizedL2, "finalized_l1", fi.finalizedL1, "last_reason", fi.lastReason)
+	default:
+		fi.log.Info("finalization is no longer stalled", "finalized_l2", fi.finalizedL2)
+	}
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
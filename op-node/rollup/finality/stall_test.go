@@ -0,0 +1,51 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerStall(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	for _, ref := range chain.l1 {
+		l1F.Mock.On("L1BlockRefByNumber", ref.Number).Return(ref, nil)
+	}
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][0]
+	m := &fakeMetrics{}
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithClock(cl), WithMetrics(m), WithStallThreshold(10*time.Minute))
+	fi.PostProcessSafeL2(chain.l2[0][1], chain.l1[0])
+	require.Equal(t, StallNone, fi.Status().Stall)
+
+	// neither finalization nor derivation advanced
+	cl.AdvanceTime(11 * time.Minute)
+	require.NoError(t, fi.OnDerivationIdle(context.Background()))
+	require.Equal(t, StallDerivation, fi.Status().Stall)
+	require.Equal(t, string(StallDerivation), m.stall)
+
+	// derivation advances, but finalization does not
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[1]))
+	require.Equal(t, StallFinality, fi.Status().Stall)
+	require.Equal(t, string(StallFinality), m.stall)
+
+	fi.Finalize(context.Background(), chain.l1[1])
+	require.Equal(t, chain.l2[1][1], ec.Finalized())
+	require.Equal(t, StallNone, fi.Status().Stall)
+	require.Equal(t, "", m.stall)
+}
@@ -0,0 +1,80 @@
+package finality
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Status returns a snapshot of the finality state, including why the last attempt to finalize did or did not advance.
+func (fi *Finalizer) Status() FinalityStatus {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	return fi.status()
+}
+
+// status returns a snapshot of the finality state. The lock must be held.
+func (fi *Finalizer) status() FinalityStatus {
+	status := FinalityStatus{
+		FinalizedL1:        fi.finalizedL1,
+		FinalizedL2:        fi.finalizedL2,
+		ExtraConfirmations: fi.extraConfirmations,
+		LastReason:         fi.lastReason,
+		LastError:          fi.lastError,
+		DerivedFromL1:      fi.derivedFromL1,
+		LookbackHeadroom:   fi.lookbackHeadroom(),
+		SignalProvenance:   fi.provenance(),
+		Halted:             fi.halted,
+		StateDigest:        fi.stateDigest(),
+		Stall:              fi.classifyStall(),
+		Version:            fi.statusVersion,
+		FinalizedRanges:    fi.recentFinalizedRanges(),
+	}
+	if target, remaining := fi.catchUpL1(); remaining > 0 {
+		status.CatchUpL1 = target
+	}
+	if fi.unjustifiedL2 != (eth.L2BlockRef{}) {
+		unjustified := fi.unjustifiedL2
+		status.UnjustifiedFinalizedL2 = &unjustified
+	}
+	if fi.forkConflict != (eth.BlockID{}) {
+		conflict := fi.forkConflict
+		status.ForkConflict = &conflict
+	}
+	if fi.settlement != nil {
+		settled := fi.settledL2
+		status.SettledL2 = &settled
+	}
+	return status
+}
+
+// classifyNoAdvance determines why there are no L2 blocks to finalize with the current finality signal.
+func (fi *Finalizer) classifyNoAdvance() FinalizeReason {
+	if len(fi.finalityData) == 0 {
+		return ReasonNoQualifyingData
+	}
+	if fi.finalizedL1.Number < fi.finalityData[0].Source.ID.Number+fi.extraConfirmations {
+		return ReasonSignalOlderThanBuffer
+	}
+	return ReasonEngineAhead
+}
+
+// recordAttempt stores the outcome of an attempt to finalize L2 blocks.
+func (fi *Finalizer) recordAttempt(reason FinalizeReason, err error) {
+	if err != nil {
+		reason = ReasonError
+		fi.lastError = err.Error()
+		cause := failureCause(err)
+		fi.metrics.RecordFinalityAttemptFailure(cause)
+		if cause == FailureReset {
+			// re-attempt once the driver completed the reset
+			fi.resetRequested = true
+			fi.counters.ResetsTriggered += 1
+		}
+	} else {
+		fi.lastError = ""
+	}
+	if reason != ReasonFinalized && reason != ReasonLimited && reason != fi.lastReason {
+		fi.log.Debug("finalization attempt did not advance", "reason", reason, "finalized_l1", fi.finalizedL1, "err", err)
+	}
+	fi.lastReason = reason
+	fi.checkBreaker(err)
+}
@@ -0,0 +1,91 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerStatus(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][1]
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec, WithClock(clk))
+	require.Equal(t, ReasonNone, fi.Status().LastReason)
+
+	// nothing buffered yet
+	fi.Finalize(context.Background(), chain.l1[0])
+	require.Equal(t, ReasonNoQualifyingData, fi.Status().LastReason)
+
+	// the signal is older than anything buffered
+	fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+	fi.PostProcessSafeL2(chain.l2[3][1], chain.l1[3])
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+	require.Equal(t, ReasonSignalOlderThanBuffer, fi.Status().LastReason)
+
+	// the L1 source fails
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, eth.L1BlockRef{}, errors.New("fail"))
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, eth.L1BlockRef{}, errors.New("fail")) // the derived-from block is fetched concurrently
+	fi.Finalize(context.Background(), chain.l1[2])
+	status := fi.Status()
+	require.Equal(t, ReasonError, status.LastReason)
+	require.ErrorContains(t, errors.New(status.LastError), "fail")
+
+	// the signal finalizes
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[2].Number, chain.l1[2], nil)
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[3]))
+	require.Equal(t, FinalityStatus{
+		FinalizedL1:   chain.l1[2],
+		FinalizedL2:   chain.l2[2][1],
+		LastReason:    ReasonFinalized,
+		DerivedFromL1: chain.l1[3],
+		// the L2 blocks derived from the latest L1 block are not finalized yet
+		LookbackHeadroom: defaultFinalityLookback - 1,
+		SignalProvenance: &SignalProvenance{Source: SignalSourceL1, ReceivedAt: clk.Now()},
+		StateDigest:      fi.StateDigest(),
+		// the status changed with every processed signal and L1 block
+		Version: 4,
+		FinalizedRanges: []DerivedRange{
+			{DerivedFrom: chain.l1[2].ID(), Start: chain.l2[0][1].Number + 1, End: chain.l2[2][1].Number, Blobs: true,
+				BlobsExpireAt: chain.l1[2].Time + uint64(DefaultBlobRetention/time.Second), ReconstructableFromL1: true},
+		},
+	}, fi.Status())
+
+	// the engine already finalized everything that was derived from the finalized L1 chain
+	ec.finalized = chain.l2[3][1]
+	fi.Finalize(context.Background(), chain.l1[2])
+	require.Equal(t, ReasonEngineAhead, fi.Status().LastReason)
+}
+
+func TestFinalizerStatusEngineAccess(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 2)
+	ec := &panickingEngine{}
+	ec.finalized = chain.l2[0][1]
+	fi := NewFinalizer(testlog.Logger(t, log.LevelInfo), &rollup.Config{}, &testutils.MockL1Source{}, ec)
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[0]))
+
+	// the status is served outside of the event loop, and does not read the engine, which the event loop owns
+	ec.panics = true
+	require.Equal(t, chain.l2[0][1], fi.Status().FinalizedL2)
+	require.Equal(t, chain.l2[0][1], fi.CachedStatus().FinalizedL2)
+	require.Equal(t, chain.l2[0][1], fi.FinalizedL2())
+}
@@ -0,0 +1,64 @@
+package finality
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// publishedStatus is a published snapshot of the finality status, with its JSON encoding.
+// It is immutable once published.
+type publishedStatus struct {
+	status  FinalityStatus
+	encoded json.RawMessage
+}
+
+// publishStatus publishes the current finality status for CachedStatus, if it changed since it was last published,
+// and bumps the status version. It is called at the end of every finality signal, processed L1 block, and reset.
+// The lock must be held.
+func (fi *Finalizer) publishStatus() {
+	prev := fi.published.Load()
+	status := fi.status()
+	encoded, err := json.Marshal(status)
+	if err != nil {
+		fi.log.Error("failed to encode finality status", "err", err)
+		return
+	}
+	if prev != nil && bytes.Equal(prev.encoded, encoded) {
+		return
+	}
+	fi.statusVersion += 1
+	status.Version = fi.statusVersion
+	if encoded, err = json.Marshal(status); err != nil {
+		fi.log.Error("failed to encode finality status", "err", err)
+		return
+	}
+	fi.published.Store(&publishedStatus{status: status, encoded: encoded})
+}
+
+// loadPublished returns the published finality status, and publishes it first if nothing was published yet.
+// The status does not read the engine, so it may be published from outside of the event loop.
+func (fi *Finalizer) loadPublished() *publishedStatus {
+	if p := fi.published.Load(); p != nil {
+		return p
+	}
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	if fi.published.Load() == nil {
+		fi.publishStatus()
+	}
+	return fi.published.Load()
+}
+
+// CachedStatus returns the last published finality status, without taking the lock of the Finalizer,
+// for high-frequency monitoring. Changes of the finality state between the published events,
+// like new safe L2 blocks, are published at the end of the L1 block they were derived from.
+// Clients can compare the Version of the status to detect changes.
+func (fi *Finalizer) CachedStatus() FinalityStatus {
+	return fi.loadPublished().status
+}
+
+// CachedStatusJSON returns the JSON encoding of CachedStatus, as pre-serialized when it was published.
+// The returned bytes are shared, and must not be modified.
+func (fi *Finalizer) CachedStatusJSON() json.RawMessage {
+	return fi.loadPublished().encoded
+}
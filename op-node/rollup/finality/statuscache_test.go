@@ -0,0 +1,54 @@
+package finality
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizerCachedStatus(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	for _, ref := range chain.l1 {
+		l1F.Mock.On("L1BlockRefByNumber", ref.Number).Return(ref, nil)
+	}
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][0]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+
+	// the first read publishes the status
+	status := fi.CachedStatus()
+	require.Equal(t, uint64(1), status.Version)
+	require.Equal(t, fi.Status(), status)
+
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[1]))
+	require.Equal(t, uint64(2), fi.CachedStatus().Version)
+
+	// signals which do not change the status do not bump the version
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[1]))
+	require.NoError(t, fi.OnDerivationIdle(context.Background()))
+	require.Equal(t, uint64(2), fi.CachedStatus().Version)
+
+	fi.Finalize(context.Background(), chain.l1[1])
+	status = fi.CachedStatus()
+	require.Equal(t, uint64(3), status.Version)
+	require.Equal(t, chain.l2[1][1], status.FinalizedL2)
+	require.Equal(t, fi.Status(), status)
+
+	var decoded FinalityStatus
+	require.NoError(t, json.Unmarshal(fi.CachedStatusJSON(), &decoded))
+	require.Equal(t, status.Version, decoded.Version)
+	require.Equal(t, status.FinalizedL2, decoded.FinalizedL2)
+}
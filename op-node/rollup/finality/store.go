@@ -0,0 +1,312 @@
+package finality
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/api"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrNoSnapshot = errors.New("no finality snapshot stored")
+
+// FinalityStateStore persists snapshots of the finality state, so it can be restored on restart,
+// or shared between nodes, to bootstrap new replicas with the finality state of the fleet.
+type FinalityStateStore interface {
+	// SaveSnapshot stores the snapshot, replacing any previously stored snapshot.
+	SaveSnapshot(ctx context.Context, s *Snapshot) error
+	// LoadSnapshot returns the stored snapshot, or ErrNoSnapshot if none is stored yet.
+	LoadSnapshot(ctx context.Context) (*Snapshot, error)
+}
+
+// NewFinalityStateStore creates the state store for the given URL:
+//   - a file:// URL for a local file,
+//   - an s3://host/bucket/key URL for an object in S3-compatible object storage, see NewS3StateStore,
+//   - an http:// or https:// URL for an object in object storage that does not require request signing,
+//   - a postgres://host/database?key=snapshot URL for a row of a Postgres table, see PostgresStateStore.
+//
+// Secrets in the URLs of S3 and Postgres stores are refused, they are only read from the environment or a file.
+func NewFinalityStateStore(storeURL string) (FinalityStateStore, error) {
+	u, err := url.Parse(storeURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid finality state store URL: %w", err)
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, errors.New("finality state store file URL has no path")
+		}
+		return NewFileStateStore(u.Path), nil
+	case "s3":
+		cfg, err := parseS3URL(u)
+		if err != nil {
+			return nil, err
+		}
+		return NewS3StateStore(cfg)
+	case "http", "https":
+		return NewObjectStateStore(storeURL, http.DefaultClient), nil
+	case "postgres", "postgresql":
+		dsn, key, err := parsePostgresURL(u)
+		if err != nil {
+			return nil, err
+		}
+		return NewPostgresStateStore(dsn, key)
+	default:
+		return nil, fmt.Errorf("unsupported finality state store scheme %q", u.Scheme)
+	}
+}
+
+// FileStateStore stores the snapshot in a local file.
+type FileStateStore struct {
+	path string
+}
+
+var _ FinalityStateStore = (*FileStateStore)(nil)
+
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path: path}
+}
+
+// SaveSnapshot writes the snapshot to a temporary file first, and then moves it in place,
+// so a crash while saving does not leave a partially written snapshot behind.
+func (s *FileStateStore) SaveSnapshot(_ context.Context, snapshot *Snapshot) error {
+	data, err := snapshot.MarshalCompressed()
+	if err != nil {
+		return fmt.Errorf("failed to encode finality snapshot: %w", err)
+	}
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to save finality snapshot: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes the data to a temporary file first, and then moves it in place,
+// so a crash while writing does not leave a partially written file behind.
+// The file is synced before it is moved, and the directory after, so the file survives a power loss once written.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to move file in place: %w", err)
+	}
+	if err := syncDir(dir); err != nil {
+		return fmt.Errorf("failed to sync directory: %w", err)
+	}
+	return nil
+}
+
+// syncDir syncs the directory, to persist the files that were moved into it.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+func (s *FileStateStore) LoadSnapshot(_ context.Context) (*Snapshot, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoSnapshot
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read finality snapshot: %w", err)
+	}
+	snapshot, err := api.DecodeSnapshot(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode finality snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// ObjectStateStore stores the snapshot as an object in object storage, with plain HTTP PUT and GET requests.
+// The requests are not authenticated: this only works with storage that authorizes the node by network,
+// or through an authenticating proxy. Use the S3StateStore for storage that requires signed requests.
+type ObjectStateStore struct {
+	url    string
+	client *http.Client
+}
+
+var _ FinalityStateStore = (*ObjectStateStore)(nil)
+
+func NewObjectStateStore(url string, client *http.Client) *ObjectStateStore {
+	return &ObjectStateStore{url: url, client: client}
+}
+
+func (s *ObjectStateStore) SaveSnapshot(ctx context.Context, snapshot *Snapshot) error {
+	data, err := snapshot.MarshalCompressed()
+	if err != nil {
+		return fmt.Errorf("failed to encode finality snapshot: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create finality snapshot upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload finality snapshot: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to upload finality snapshot: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *ObjectStateStore) LoadSnapshot(ctx context.Context) (*Snapshot, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create finality snapshot download request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download finality snapshot: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNoSnapshot
+	} else if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("failed to download finality snapshot: unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read finality snapshot: %w", err)
+	}
+	snapshot, err := api.DecodeSnapshot(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode finality snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// SnapshotSource provides the finality state to persist, like the Finalizer.
+type SnapshotSource interface {
+	Snapshot() *Snapshot
+}
+
+const statePersistTimeout = 30 * time.Second
+
+// StatePersister saves a snapshot of the finality state to the store, after every finalized L2 head advancement.
+// Snapshots are saved asynchronously, advancements that happen while a snapshot is being saved
+// are covered by the next snapshot.
+type StatePersister struct {
+	log    log.Logger
+	source SnapshotSource
+	store  FinalityStateStore
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewStatePersister(log log.Logger, source SnapshotSource, store FinalityStateStore) *StatePersister {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &StatePersister{
+		log:    log,
+		source: source,
+		store:  store,
+		wake:   make(chan struct{}, 1),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (sp *StatePersister) Start() {
+	sp.wg.Add(1)
+	go sp.loop()
+}
+
+// Close stops saving snapshots, and closes the store if it holds connections.
+func (sp *StatePersister) Close() {
+	sp.cancel()
+	sp.wg.Wait()
+	if c, ok := sp.store.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			sp.log.Warn("failed to close finality state store", "err", err)
+		}
+	}
+}
+
+// OnFinalized queues a snapshot to be saved. It is a FinalizedSubscriber, and does not block.
+func (sp *StatePersister) OnFinalized(ev FinalizedEvent) {
+	select {
+	case sp.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (sp *StatePersister) loop() {
+	defer sp.wg.Done()
+	for {
+		select {
+		case <-sp.ctx.Done():
+			return
+		case <-sp.wake:
+		}
+		snapshot := sp.source.Snapshot()
+		ctx, cancel := context.WithTimeout(sp.ctx, statePersistTimeout)
+		err := sp.store.SaveSnapshot(ctx, snapshot)
+		cancel()
+		if err != nil {
+			sp.log.Error("failed to save finality snapshot", "finalized_l1", snapshot.FinalizedL1, "err", err)
+			continue
+		}
+		sp.log.Debug("saved finality snapshot", "finalized_l1", snapshot.FinalizedL1, "entries", len(snapshot.FinalityData))
+	}
+}
+
+// Restore merges the finality state of a snapshot into the Finalizer, e.g. as persisted before a restart,
+// or as shared by another node of the same chain. It is to be called before the Finalizer is started.
+// The finalized L1 block of the snapshot is applied like a finality signal once the Finalizer starts,
+// and is verified to be canonical before any L2 block is finalized with it.
+// If the plasma DA windows changed since the snapshot was taken, the PlasmaChangeAction applies,
+// and an ErrPlasmaConfigChanged is returned if the snapshot is not to be restored.
+func (fi *Finalizer) Restore(snapshot *Snapshot) error {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	defer fi.publishStatus()
+	data, err := fi.migrateSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+	fi.growLookbackTo(uint64(len(data)))
+	for _, fd := range data {
+		source := l1Source{ID: fd.L1Block, ParentHash: fd.L1Parent, Time: fd.L1Time, BatchTxs: fd.BatchTxs,
+			BlobIndices: fd.BlobIndices, Batchers: fd.Batchers}
+		fi.trackFinalityData(fd.L2Block, source)
+	}
+	if snapshot.FinalizedL1 != (eth.L1BlockRef{}) && !fi.deferSignal(snapshot.FinalizedL1) {
+		fi.log.Warn("restored finality snapshot after the finalizer started, ignoring its finalized L1 block",
+			"finalized_l1", snapshot.FinalizedL1)
+	}
+	fi.log.Info("restored finality snapshot", "finalized_l1", snapshot.FinalizedL1, "entries", len(data))
+	return nil
+}
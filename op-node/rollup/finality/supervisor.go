@@ -0,0 +1,110 @@
+package finality
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SafeSubscriber is called synchronously on every update of the finality data with a new local-safe L2 block,
+// and the L1 block it was fully derived from. Subscribers must not block, and must not call back into the Finalizer.
+type SafeSubscriber func(l2Safe eth.L2BlockRef, derivedFrom eth.BlockID)
+
+type safeSubscription struct {
+	fn SafeSubscriber
+}
+
+// SubscribeSafe registers a subscriber to local-safe L2 block updates of the finality data.
+// The returned function removes the subscription again.
+func (fi *Finalizer) SubscribeSafe(fn SafeSubscriber) (unsubscribe func()) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	sub := &safeSubscription{fn: fn}
+	fi.safeSubscribers = append(fi.safeSubscribers, sub)
+	return func() {
+		fi.mu.Lock()
+		defer fi.mu.Unlock()
+		for i, s := range fi.safeSubscribers {
+			if s == sub {
+				fi.safeSubscribers = append(fi.safeSubscribers[:i], fi.safeSubscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// emitSafe notifies all safe subscribers of the new local-safe L2 block. The lock must be held.
+func (fi *Finalizer) emitSafe(l2Safe eth.L2BlockRef, derivedFrom eth.BlockID) {
+	for _, sub := range fi.safeSubscribers {
+		sub.fn(l2Safe, derivedFrom)
+	}
+}
+
+// SupervisorBridge streams the local-safe and finalized updates of a Finalizer to op-supervisor, in-process,
+// so the supervisor does not have to re-derive the relations of L2 blocks to L1 blocks the Finalizer maintains.
+// Updates supersede earlier updates of the same kind: if the consumer falls behind,
+// the oldest undelivered updates are dropped, rather than blocking the Finalizer.
+type SupervisorBridge struct {
+	updates chan SupervisorUpdate
+	// mu serializes the producers, so dropping the oldest update and queueing the new one is atomic.
+	mu      sync.Mutex
+	dropped atomic.Uint64
+
+	unsubscribeSafe      func()
+	unsubscribeFinalized func()
+	closeOnce            sync.Once
+}
+
+// NewSupervisorBridge subscribes a bridge to the updates of the Finalizer,
+// buffering at most buffer undelivered updates. Close unsubscribes it again.
+func NewSupervisorBridge(fi *Finalizer, buffer int) *SupervisorBridge {
+	b := &SupervisorBridge{updates: make(chan SupervisorUpdate, max(buffer, 1))}
+	b.unsubscribeSafe = fi.SubscribeSafe(func(l2Safe eth.L2BlockRef, derivedFrom eth.BlockID) {
+		b.send(SupervisorUpdate{L2Block: l2Safe.ID(), DerivedFrom: derivedFrom})
+	})
+	b.unsubscribeFinalized = fi.SubscribeFinalized(func(ev FinalizedEvent) {
+		update := SupervisorUpdate{L2Block: ev.FinalizedL2.ID(), Finalized: true}
+		if len(ev.DerivedFrom) > 0 {
+			update.DerivedFrom = ev.DerivedFrom[len(ev.DerivedFrom)-1]
+		}
+		b.send(update)
+	})
+	return b
+}
+
+// Updates returns the channel of updates. It is not closed by Close.
+func (b *SupervisorBridge) Updates() <-chan SupervisorUpdate {
+	return b.updates
+}
+
+// Dropped returns the number of updates that were dropped, because the consumer fell behind.
+func (b *SupervisorBridge) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Close unsubscribes the bridge from the Finalizer.
+func (b *SupervisorBridge) Close() {
+	b.closeOnce.Do(func() {
+		b.unsubscribeSafe()
+		b.unsubscribeFinalized()
+	})
+}
+
+// send queues the update, and drops the oldest undelivered update if the buffer is full.
+func (b *SupervisorBridge) send(update SupervisorUpdate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for {
+		select {
+		case b.updates <- update:
+			return
+		default:
+		}
+		select {
+		case <-b.updates:
+			b.dropped.Add(1)
+		default:
+		}
+	}
+}
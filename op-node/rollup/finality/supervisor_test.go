@@ -0,0 +1,56 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestSupervisorBridge(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	logger := testlog.Logger(t, log.LevelInfo)
+	l1F := &testutils.MockL1Source{}
+	defer l1F.AssertExpectations(t)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[0][0]
+	fi := NewFinalizer(logger, &rollup.Config{}, l1F, ec)
+	b := NewSupervisorBridge(fi, 8)
+	defer b.Close()
+
+	fi.PostProcessSafeL2(chain.l2[1][0], chain.l1[1])
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	// unchanged finality data is not streamed
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	l1F.ExpectL1BlockRefByNumber(chain.l1[1].Number, chain.l1[1], nil)
+	fi.Finalize(context.Background(), chain.l1[1])
+
+	require.Equal(t, SupervisorUpdate{L2Block: chain.l2[1][0].ID(), DerivedFrom: chain.l1[1].ID()}, <-b.Updates())
+	require.Equal(t, SupervisorUpdate{L2Block: chain.l2[1][1].ID(), DerivedFrom: chain.l1[1].ID()}, <-b.Updates())
+	require.Equal(t, SupervisorUpdate{L2Block: chain.l2[1][1].ID(), DerivedFrom: chain.l1[1].ID(), Finalized: true}, <-b.Updates())
+	require.Empty(t, b.Updates())
+
+	t.Run("slow consumer", func(t *testing.T) {
+		fi := NewFinalizer(logger, &rollup.Config{}, l1F, &fakeEngine{})
+		b := NewSupervisorBridge(fi, 1)
+		fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+		fi.PostProcessSafeL2(chain.l2[2][1], chain.l1[2])
+		require.Equal(t, uint64(1), b.Dropped())
+		require.Equal(t, SupervisorUpdate{L2Block: chain.l2[2][1].ID(), DerivedFrom: chain.l1[2].ID()}, <-b.Updates())
+
+		// no updates after closing
+		b.Close()
+		b.Close()
+		fi.PostProcessSafeL2(chain.l2[2][0], chain.l1[2])
+		require.Empty(t, b.Updates())
+	})
+}
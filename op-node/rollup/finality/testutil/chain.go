@@ -0,0 +1,32 @@
+// Package testutil provides scriptable test doubles of the dependencies of the Finalizer,
+// for the tests of the finality package and of projects that embed the Finalizer.
+package testutil
+
+import (
+	"math/rand" // nosemgrep
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// Chain is a random L1 chain, with two L2 blocks derived from each L1 block.
+type Chain struct {
+	L1 []eth.L1BlockRef
+	L2 [][2]eth.L2BlockRef
+}
+
+// NewChain returns a random chain of n L1 blocks.
+func NewChain(rng *rand.Rand, n int) *Chain {
+	c := &Chain{}
+	l1 := testutils.RandomBlockRef(rng)
+	l2 := eth.L2BlockRef{Hash: testutils.RandomHash(rng), Time: l1.Time, L1Origin: l1.ID()}
+	for i := 0; i < n; i++ {
+		l1 = testutils.NextRandomRef(rng, l1)
+		a := testutils.NextRandomL2Ref(rng, 1, l2, l1.ID())
+		b := testutils.NextRandomL2Ref(rng, 1, a, l1.ID())
+		l2 = b
+		c.L1 = append(c.L1, l1)
+		c.L2 = append(c.L2, [2]eth.L2BlockRef{a, b})
+	}
+	return c
+}
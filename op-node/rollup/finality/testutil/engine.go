@@ -0,0 +1,117 @@
+package testutil
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Engine is a scriptable engine, like the engine controller the Finalizer applies the finalized L2 head to.
+// It records every finalized head that is applied with a successful forkchoice update.
+type Engine struct {
+	mu        sync.Mutex
+	finalized eth.L2BlockRef
+	applied   []eth.L2BlockRef
+	// errs are the errors of the next forkchoice updates, in order, before err applies.
+	errs []error
+	// err is the error of every forkchoice update, to simulate an unavailable engine.
+	err error
+	// acks are the payload statuses of the next successful forkchoice updates, in order, before VALID applies.
+	acks    []eth.ExecutePayloadStatus
+	lastAck engine.ForkchoiceAck
+}
+
+// NewEngine returns an engine with the given finalized L2 head.
+func NewEngine(finalized eth.L2BlockRef) *Engine {
+	return &Engine{finalized: finalized}
+}
+
+func (e *Engine) Finalized() eth.L2BlockRef {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.finalized
+}
+
+// SetFinalizedHead sets the finalized L2 head, and applies it with a scripted forkchoice update.
+func (e *Engine) SetFinalizedHead(ctx context.Context, ref eth.L2BlockRef) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.finalized = ref
+	if len(e.errs) > 0 {
+		err := e.errs[0]
+		e.errs = e.errs[1:]
+		return err
+	}
+	if e.err != nil {
+		return e.err
+	}
+	status := eth.ExecutionValid
+	if len(e.acks) > 0 {
+		status = e.acks[0]
+		e.acks = e.acks[1:]
+	}
+	e.lastAck = engine.ForkchoiceAck{Finalized: ref.ID(), Status: status}
+	if status != eth.ExecutionValid {
+		return nil
+	}
+	e.applied = append(e.applied, ref)
+	return nil
+}
+
+// LastForkchoiceAck returns the payload status of the last successful forkchoice update.
+func (e *Engine) LastForkchoiceAck() engine.ForkchoiceAck {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.lastAck
+}
+
+// AckNext scripts the payload statuses the next successful forkchoice updates respond with, in order.
+// A forkchoice update that is not acknowledged as VALID does not apply the finalized head.
+func (e *Engine) AckNext(statuses ...eth.ExecutePayloadStatus) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.acks = append(e.acks, statuses...)
+}
+
+// FailNext scripts the next forkchoice updates to fail with the given errors, in order.
+func (e *Engine) FailNext(errs ...error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errs = append(e.errs, errs...)
+}
+
+// SetError makes every forkchoice update fail with the given error, until it is set to nil again.
+func (e *Engine) SetError(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+}
+
+// Applied returns the finalized head last applied with a successful forkchoice update, zero if none was applied.
+func (e *Engine) Applied() eth.L2BlockRef {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.applied) == 0 {
+		return eth.L2BlockRef{}
+	}
+	return e.applied[len(e.applied)-1]
+}
+
+// AppliedHistory returns every finalized head applied with a successful forkchoice update, in order.
+func (e *Engine) AppliedHistory() []eth.L2BlockRef {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]eth.L2BlockRef(nil), e.applied...)
+}
+
+// RequireFinalized asserts the finalized L2 head of the engine, and that it was applied to the engine.
+func (e *Engine) RequireFinalized(t testing.TB, expected eth.L2BlockRef) {
+	t.Helper()
+	require.Equal(t, expected, e.Finalized(), "finalized L2 head")
+	require.Equal(t, expected, e.Applied(), "applied finalized L2 head")
+}
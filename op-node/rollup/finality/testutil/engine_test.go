@@ -0,0 +1,46 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestEngine(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := NewChain(rng, 4)
+	e := NewEngine(chain.L2[0][1])
+	ctx := context.Background()
+
+	require.NoError(t, e.SetFinalizedHead(ctx, chain.L2[1][1]))
+	e.RequireFinalized(t, chain.L2[1][1])
+
+	// a scripted failure does not apply the finalized head
+	unavailable := errors.New("unavailable")
+	e.FailNext(unavailable)
+	require.ErrorIs(t, e.SetFinalizedHead(ctx, chain.L2[2][1]), unavailable)
+	require.Equal(t, chain.L2[2][1], e.Finalized())
+	require.Equal(t, chain.L2[1][1], e.Applied())
+	require.NoError(t, e.SetFinalizedHead(ctx, chain.L2[2][1]))
+
+	e.SetError(unavailable)
+	require.ErrorIs(t, e.SetFinalizedHead(ctx, chain.L2[2][1]), unavailable)
+	require.ErrorIs(t, e.SetFinalizedHead(ctx, chain.L2[2][1]), unavailable)
+	e.SetError(nil)
+	require.Equal(t, []eth.L2BlockRef{chain.L2[1][1], chain.L2[2][1]}, e.AppliedHistory())
+
+	// a forkchoice update that is not acknowledged as VALID does not apply the finalized head
+	e.AckNext(eth.ExecutionSyncing)
+	require.NoError(t, e.SetFinalizedHead(ctx, chain.L2[3][1]))
+	require.Equal(t, eth.ExecutionSyncing, e.LastForkchoiceAck().Status)
+	require.Equal(t, chain.L2[3][1].ID(), e.LastForkchoiceAck().Finalized)
+	require.Equal(t, chain.L2[2][1], e.Applied())
+	require.NoError(t, e.SetFinalizedHead(ctx, chain.L2[3][1]))
+	require.Equal(t, eth.ExecutionValid, e.LastForkchoiceAck().Status)
+	e.RequireFinalized(t, chain.L2[3][1])
+}
@@ -0,0 +1,26 @@
+package testutil
+
+import (
+	"math/rand" // nosemgrep
+)
+
+// Fixture is the starting point of a finalizer test: a random chain,
+// a scripted L1 serving that chain, and an engine with the first L2 block of the chain finalized.
+type Fixture struct {
+	Rng    *rand.Rand
+	Chain  *Chain
+	L1     *L1
+	Engine *Engine
+}
+
+// NewFixture returns a fixture over a random chain of n L1 blocks, seeded deterministically.
+func NewFixture(n int) *Fixture {
+	rng := rand.New(rand.NewSource(1234))
+	chain := NewChain(rng, n)
+	return &Fixture{
+		Rng:    rng,
+		Chain:  chain,
+		L1:     NewL1(chain.L1...),
+		Engine: NewEngine(chain.L2[0][0]),
+	}
+}
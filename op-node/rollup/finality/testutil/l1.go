@@ -0,0 +1,112 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"math/rand" // nosemgrep
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// L1 is a scriptable L1 block source, like the L1 RPC the Finalizer fetches L1 blocks from.
+// It serves the canonical chain by number, and any block it ever served by hash, including reorged-out blocks.
+// Unknown blocks are not found.
+type L1 struct {
+	mu        sync.Mutex
+	canonical map[uint64]eth.L1BlockRef
+	byHash    map[common.Hash]eth.L1BlockRef
+	// errs are the errors of the next fetches by number, in order.
+	errs    map[uint64][]error
+	fetches map[uint64]int
+}
+
+// NewL1 returns a L1 block source with the given canonical blocks.
+func NewL1(blocks ...eth.L1BlockRef) *L1 {
+	s := &L1{
+		canonical: make(map[uint64]eth.L1BlockRef),
+		byHash:    make(map[common.Hash]eth.L1BlockRef),
+		errs:      make(map[uint64][]error),
+		fetches:   make(map[uint64]int),
+	}
+	s.SetCanonical(blocks...)
+	return s
+}
+
+// SetCanonical makes the given blocks canonical, replacing the canonical blocks of the same numbers.
+func (s *L1) SetCanonical(blocks ...eth.L1BlockRef) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ref := range blocks {
+		s.canonical[ref.Number] = ref
+		s.byHash[ref.Hash] = ref
+	}
+}
+
+// Reorg replaces the canonical blocks from the given number onwards with random blocks of the same numbers and times,
+// and returns the new canonical blocks, in ascending order.
+func (s *L1) Reorg(rng *rand.Rand, from uint64) []eth.L1BlockRef {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var reorged []eth.L1BlockRef
+	parent, hasParent := s.canonical[from-1]
+	for n := from; ; n++ {
+		old, ok := s.canonical[n]
+		if !ok {
+			break
+		}
+		ref := old
+		ref.Hash = testutils.RandomHash(rng)
+		if hasParent {
+			ref.ParentHash = parent.Hash
+		}
+		s.canonical[n] = ref
+		s.byHash[ref.Hash] = ref
+		reorged = append(reorged, ref)
+		parent, hasParent = ref, true
+	}
+	return reorged
+}
+
+// FailNext scripts the next fetches of the given block number to fail with the given errors, in order.
+func (s *L1) FailNext(number uint64, errs ...error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errs[number] = append(s.errs[number], errs...)
+}
+
+// Fetches returns the number of fetches of the given block number so far, including failed fetches.
+func (s *L1) Fetches(number uint64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches[number]
+}
+
+func (s *L1) L1BlockRefByNumber(ctx context.Context, number uint64) (eth.L1BlockRef, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetches[number] += 1
+	if errs := s.errs[number]; len(errs) > 0 {
+		s.errs[number] = errs[1:]
+		return eth.L1BlockRef{}, errs[0]
+	}
+	ref, ok := s.canonical[number]
+	if !ok {
+		return eth.L1BlockRef{}, fmt.Errorf("L1 block %d: %w", number, ethereum.NotFound)
+	}
+	return ref, nil
+}
+
+func (s *L1) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ref, ok := s.byHash[hash]
+	if !ok {
+		return eth.L1BlockRef{}, fmt.Errorf("L1 block %s: %w", hash, ethereum.NotFound)
+	}
+	return ref, nil
+}
@@ -0,0 +1,47 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+func TestL1(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := NewChain(rng, 4)
+	l1 := NewL1(chain.L1...)
+	ctx := context.Background()
+
+	ref, err := l1.L1BlockRefByNumber(ctx, chain.L1[2].Number)
+	require.NoError(t, err)
+	require.Equal(t, chain.L1[2], ref)
+	_, err = l1.L1BlockRefByNumber(ctx, chain.L1[3].Number+1)
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	// scripted errors apply to the next fetches only
+	unavailable := errors.New("unavailable")
+	l1.FailNext(chain.L1[1].Number, unavailable)
+	_, err = l1.L1BlockRefByNumber(ctx, chain.L1[1].Number)
+	require.ErrorIs(t, err, unavailable)
+	ref, err = l1.L1BlockRefByNumber(ctx, chain.L1[1].Number)
+	require.NoError(t, err)
+	require.Equal(t, chain.L1[1], ref)
+	require.Equal(t, 2, l1.Fetches(chain.L1[1].Number))
+
+	// a reorg replaces the canonical chain from the given block, reorged-out blocks are still served by hash
+	reorged := l1.Reorg(rng, chain.L1[2].Number)
+	require.Len(t, reorged, 2)
+	require.Equal(t, chain.L1[1].Hash, reorged[0].ParentHash)
+	require.Equal(t, reorged[0].Hash, reorged[1].ParentHash)
+	ref, err = l1.L1BlockRefByNumber(ctx, chain.L1[3].Number)
+	require.NoError(t, err)
+	require.Equal(t, reorged[1], ref)
+	ref, err = l1.L1BlockRefByHash(ctx, chain.L1[3].Hash)
+	require.NoError(t, err)
+	require.Equal(t, chain.L1[3], ref)
+}
@@ -0,0 +1,46 @@
+package finality
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// WithMinInterval applies a new finalized L2 head to the engine at most once per interval,
+// and only once it advanced by at least minBlocks L2 blocks, batching the intermediate advancements.
+// Some execution clients re-run expensive indexing on every finalized head change. Either is disabled if 0.
+func WithMinInterval(interval time.Duration, minBlocks uint64) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.throttleGate.minInterval = interval
+		fi.throttleGate.minBlocks = minBlocks
+	}
+}
+
+// throttleGate batches advancements of the finalized L2 head with later advancements, see WithMinInterval.
+type throttleGate struct {
+	noopGate
+	fi *Finalizer
+	// minInterval and minBlocks throttle how often the finalized L2 head is applied to the engine. Disabled if 0.
+	minInterval time.Duration
+	minBlocks   uint64
+}
+
+// check holds back the advancement, to check again on the next derivation step.
+// An advancement that is limited by the max advance budget is as large as allowed, and is not held back by minBlocks.
+func (g *throttleGate) check(ctx context.Context, a *finalizeAttempt) (FinalizeReason, error) {
+	if g.throttled(a.prevFinalizedL2, a.finalizedL2, a.limited) {
+		g.fi.triedFinalizeAt = 0
+		return ReasonThrottled, nil
+	}
+	return "", nil
+}
+
+// throttled returns true if the advancement of the finalized L2 head is to be batched with later advancements.
+func (g *throttleGate) throttled(prevFinalizedL2, finalizedL2 eth.L2BlockRef, limited bool) bool {
+	fi := g.fi
+	if g.minInterval != 0 && !fi.lastAppliedAt.IsZero() && fi.clock.Now().Before(fi.lastAppliedAt.Add(g.minInterval)) {
+		return true
+	}
+	return g.minBlocks != 0 && !limited && finalizedL2.Number < prevFinalizedL2.Number+g.minBlocks
+}
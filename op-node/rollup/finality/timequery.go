@@ -0,0 +1,69 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrNotFinalizedAtTime is returned when the L2 chain is not finalized up to the queried time yet.
+var ErrNotFinalizedAtTime = errors.New("L2 chain is not finalized at the given time yet")
+
+// L2BlockSource looks up L2 blocks by number, to resolve finalized L2 blocks that are not buffered.
+type L2BlockSource interface {
+	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+}
+
+// WithL2BlockSource configures the L2 block lookups of FinalizedAtTime. Without it, only buffered L2 blocks are resolved.
+func WithL2BlockSource(l2 L2BlockSource) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.l2Blocks = l2
+	}
+}
+
+// FinalizedAtTime returns the newest finalized L2 block with a timestamp at or before the given time,
+// i.e. the finalized state of the L2 chain as of that time, for systems that snapshot state "as of time T".
+// It returns ErrNotFinalizedAtTime if the L2 blocks up to the given time are not all finalized yet.
+func (fi *Finalizer) FinalizedAtTime(ctx context.Context, timestamp uint64) (eth.L2BlockRef, error) {
+	fi.mu.Lock()
+	finalizedL2 := fi.finalizedL2
+	var buffered eth.L2BlockRef
+	num, err := fi.cfg.TargetBlockNumber(timestamp)
+	if err == nil {
+		for _, r := range fi.finalityData {
+			if r.Derived.Number == num {
+				buffered = r.Derived
+				break
+			}
+		}
+	}
+	l2Blocks := fi.l2Blocks
+	fi.mu.Unlock()
+
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("no L2 block at time %d: %w", timestamp, err)
+	}
+	if finalizedL2 == (eth.L2BlockRef{}) || num > finalizedL2.Number {
+		return eth.L2BlockRef{}, fmt.Errorf("%w: finalized L2 head %s, L2 block %d at time %d", ErrNotFinalizedAtTime,
+			finalizedL2, num, timestamp)
+	}
+	if num == finalizedL2.Number {
+		return finalizedL2, nil
+	}
+	if buffered != (eth.L2BlockRef{}) {
+		return buffered, nil
+	}
+	if l2Blocks == nil {
+		return eth.L2BlockRef{}, fmt.Errorf("L2 block %d at time %d is not buffered, and no L2 block source is configured", num, timestamp)
+	}
+	ref, err := l2Blocks.L2BlockRefByNumber(ctx, num)
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to fetch L2 block %d at time %d: %w", num, timestamp, err)
+	}
+	if ref.Time > timestamp {
+		return eth.L2BlockRef{}, fmt.Errorf("L2 block %s has time %d, after the queried time %d", ref, ref.Time, timestamp)
+	}
+	return ref, nil
+}
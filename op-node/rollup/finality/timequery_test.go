@@ -0,0 +1,71 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestFinalizedAtTime(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	cfg := &rollup.Config{BlockTime: 1}
+	cfg.Genesis.L2Time = chain.l2[0][0].Time - 1
+	logger := testlog.Logger(t, log.LevelInfo)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[2][1]
+	l2 := &testutils.MockL2Client{}
+	defer l2.AssertExpectations(t)
+	fi := NewFinalizer(logger, cfg, &testutils.MockL1Source{}, ec, WithL2BlockSource(l2))
+	ctx := context.Background()
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	// the finalized L2 head of the engine is observed on the event loop, when derivation progresses
+	require.NoError(t, fi.OnDerivationL1End(ctx, chain.l1[1]))
+
+	// the finalized head itself
+	ref, err := fi.FinalizedAtTime(ctx, chain.l2[2][1].Time)
+	require.NoError(t, err)
+	require.Equal(t, chain.l2[2][1], ref)
+
+	// a buffered L2 block is resolved without L2 lookup
+	ref, err = fi.FinalizedAtTime(ctx, chain.l2[1][1].Time)
+	require.NoError(t, err)
+	require.Equal(t, chain.l2[1][1], ref)
+
+	// other finalized L2 blocks are looked up
+	l2.ExpectL2BlockRefByNumber(chain.l2[0][0].Number, chain.l2[0][0], nil)
+	ref, err = fi.FinalizedAtTime(ctx, chain.l2[0][0].Time)
+	require.NoError(t, err)
+	require.Equal(t, chain.l2[0][0], ref)
+
+	// the L2 chain is not finalized up to this time yet
+	_, err = fi.FinalizedAtTime(ctx, chain.l2[3][0].Time)
+	require.ErrorIs(t, err, ErrNotFinalizedAtTime)
+
+	// before genesis
+	_, err = fi.FinalizedAtTime(ctx, cfg.Genesis.L2Time-1)
+	require.Error(t, err)
+}
+
+func TestFinalizedAtTimeWithoutL2BlockSource(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 3)
+	cfg := &rollup.Config{BlockTime: 1}
+	cfg.Genesis.L2Time = chain.l2[0][0].Time - 1
+	logger := testlog.Logger(t, log.LevelInfo)
+	ec := &fakeEngine{}
+	ec.finalized = chain.l2[2][1]
+	fi := NewFinalizer(logger, cfg, &testutils.MockL1Source{}, ec)
+	require.NoError(t, fi.OnDerivationL1End(context.Background(), chain.l1[0]))
+
+	_, err := fi.FinalizedAtTime(context.Background(), chain.l2[0][0].Time)
+	require.ErrorContains(t, err, "no L2 block source")
+}
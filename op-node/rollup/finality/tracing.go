@@ -0,0 +1,50 @@
+package finality
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// traceIDLabel is the exemplar label that links finalization metrics to a trace.
+const traceIDLabel = "trace_id"
+
+// WithTraceIDs enables trace-ID exemplars on the finalization latency metrics, so a latency spike links directly
+// to the trace of the corresponding attempt to finalize. traceID returns the ID of the trace the context belongs to,
+// or an empty string if the context is not traced.
+func WithTraceIDs(traceID func(ctx context.Context) string) FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.traceID = traceID
+	}
+}
+
+// WithAttemptTraceIDs assigns a random trace ID to every attempt to finalize that is not traced otherwise,
+// and logs the outcome of the attempt with it. op-node does not export traces, so the exemplar of a latency spike
+// links to the log line of the corresponding attempt instead.
+func WithAttemptTraceIDs() FinalizerOption {
+	return func(fi *Finalizer) {
+		fi.attemptTraceIDs = true
+	}
+}
+
+// traceExemplar returns the exemplar labels that link an observation to the trace of the context, if any.
+func (fi *Finalizer) traceExemplar(ctx context.Context) map[string]string {
+	var id string
+	if fi.traceID != nil {
+		id = fi.traceID(ctx)
+	}
+	if id == "" && fi.attemptTraceIDs {
+		id = newTraceID()
+	}
+	if id == "" {
+		return nil
+	}
+	return map[string]string{traceIDLabel: id}
+}
+
+// newTraceID returns a random trace ID, in the W3C trace-context format.
+func newTraceID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
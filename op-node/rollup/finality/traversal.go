@@ -0,0 +1,21 @@
+package finality
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// OnL1TraversedOutcome is called when derivation traversed the given L1 block without deriving any L2 blocks from it,
+// like L1 blocks without batches, where OnDerivationL1EndOutcome is called for L1 blocks that L2 blocks were derived from.
+//
+// Traversed L1 blocks count towards the finalityDelay like any other L1 block, so long runs of batch-less L1 blocks
+// do not postpone the next attempt to finalize beyond the finalityDelay.
+func (fi *Finalizer) OnL1TraversedOutcome(ctx context.Context, traversed eth.L1BlockRef) FinalityOutcome {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	if traversed.Number > fi.derivedFromL1.Number {
+		fi.counters.L1BlocksTraversed += 1
+	}
+	return fi.onL1Progress(ctx, traversed)
+}
@@ -0,0 +1,59 @@
+package finality
+
+import (
+	"context"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/testutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestFinalizerL1Traversed(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 200)
+	l1 := testutil.NewL1(chain.l1...)
+	ec := testutil.NewEngine(chain.l2[0][1])
+	fi := NewFinalizer(testlog.Logger(t, log.LevelInfo), &rollup.Config{}, l1, ec)
+
+	fi.PostProcessSafeL2(chain.l2[0][1], chain.l1[0])
+	fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[1])
+	require.Equal(t, FinalityOutcome{}, fi.OnDerivationL1EndOutcome(context.Background(), chain.l1[1]))
+	fi.Finalize(context.Background(), chain.l1[0])
+	require.Equal(t, uint64(1), fi.DebugBundle().Counters.Attempts)
+
+	// a long run of L1 blocks without batches: no L2 blocks are derived, but the traversed L1 blocks
+	// count towards the finality delay, so attempts to finalize happen every finalityDelay+1 L1 blocks.
+	var attemptsAt []uint64
+	for i := 2; i < len(chain.l1); i++ {
+		fi.PostProcessSafeL2(chain.l2[1][1], chain.l1[i])
+		prev := fi.DebugBundle().Counters.Attempts
+		require.Equal(t, FinalityOutcome{}, fi.OnL1TraversedOutcome(context.Background(), chain.l1[i]))
+		if fi.DebugBundle().Counters.Attempts > prev {
+			attemptsAt = append(attemptsAt, chain.l1[i].Number)
+		}
+		// repeated reports of the same traversed L1 block are not counted again
+		require.Equal(t, FinalityOutcome{}, fi.OnL1TraversedOutcome(context.Background(), chain.l1[i]))
+	}
+	first := chain.l1[2].Number
+	require.Equal(t, []uint64{first, first + finalityDelay + 1, first + 2*(finalityDelay+1), first + 3*(finalityDelay+1)}, attemptsAt)
+	require.Equal(t, uint64(len(chain.l1)-2), fi.DebugBundle().Counters.L1BlocksTraversed)
+	require.Equal(t, chain.l1[len(chain.l1)-1], fi.Status().DerivedFromL1)
+
+	// the L2 blocks derived before the run of empty L1 blocks finalize once L1 finalized beyond them
+	fi.Finalize(context.Background(), chain.l1[150])
+	ec.RequireFinalized(t, chain.l2[1][1])
+}
+
+func TestRecordingControllerL1Traversed(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 1)
+	rc := NewRecordingController(nil)
+	require.Equal(t, FinalityOutcome{}, rc.OnL1TraversedOutcome(context.Background(), chain.l1[0]))
+	require.Equal(t, []ControllerCall{{Method: "OnL1Traversed", L1: chain.l1[0]}}, rc.Calls())
+}
@@ -0,0 +1,169 @@
+package finality
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// WithdrawalRootSource provides the output of L2 blocks, including the proven storage root of the L2ToL1MessagePasser,
+// like the L2 execution client.
+type WithdrawalRootSource interface {
+	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+}
+
+const withdrawalRootFetchTimeout = 10 * time.Second
+
+// WithdrawalRootTracker fetches the withdrawal root of every new finalized L2 head, and caches the most recent ones,
+// so withdrawal-proving services can consume withdrawal roots tied to finality without additional RPC round trips.
+// The finalized events are passed on to its own subscribers with the withdrawal root of the finalized L2 head set,
+// or without it if it could not be fetched, so no finalized event is held back or dropped.
+// Events are processed asynchronously, in order. Advancements that happen while an event is being processed
+// are coalesced into the next event.
+type WithdrawalRootTracker struct {
+	log      log.Logger
+	outputs  WithdrawalRootSource
+	maxRoots int
+
+	mu sync.Mutex
+	// pending is the advancement whose withdrawal root has not been fetched yet.
+	pending *FinalizedEvent
+	// roots are the most recently fetched withdrawal roots, in ascending order, at most maxRoots.
+	roots       []FinalizedWithdrawalRoot
+	subscribers []*finalizedSubscription
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewWithdrawalRootTracker(log log.Logger, outputs WithdrawalRootSource, maxRoots int) *WithdrawalRootTracker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WithdrawalRootTracker{
+		log:      log,
+		outputs:  outputs,
+		maxRoots: maxRoots,
+		wake:     make(chan struct{}, 1),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+func (wt *WithdrawalRootTracker) Start() {
+	wt.wg.Add(1)
+	go wt.loop()
+}
+
+func (wt *WithdrawalRootTracker) Close() {
+	wt.cancel()
+	wt.wg.Wait()
+}
+
+// OnFinalized queues the advancement to fetch the withdrawal root of. It is a FinalizedSubscriber, and does not block.
+func (wt *WithdrawalRootTracker) OnFinalized(ev FinalizedEvent) {
+	wt.mu.Lock()
+	if wt.pending == nil {
+		wt.pending = &ev
+	} else {
+		// coalesce with the advancement that is still pending
+		wt.pending.Coalesce(ev)
+	}
+	wt.mu.Unlock()
+	select {
+	case wt.wake <- struct{}{}:
+	default:
+	}
+}
+
+// SubscribeFinalized registers a subscriber to the finalized events, with the withdrawal root of the finalized L2 head.
+// Subscribers are called from the tracker, and must not block. The returned function removes the subscription again.
+func (wt *WithdrawalRootTracker) SubscribeFinalized(fn FinalizedSubscriber) (unsubscribe func()) {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	sub := &finalizedSubscription{fn: fn}
+	wt.subscribers = append(wt.subscribers, sub)
+	return func() {
+		wt.mu.Lock()
+		defer wt.mu.Unlock()
+		for i, s := range wt.subscribers {
+			if s == sub {
+				wt.subscribers = append(wt.subscribers[:i], wt.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// WithdrawalRoots returns the cached withdrawal roots of the finalized L2 heads at the given L2 block number or later.
+func (wt *WithdrawalRootTracker) WithdrawalRoots(fromL2 uint64) []FinalizedWithdrawalRoot {
+	wt.mu.Lock()
+	defer wt.mu.Unlock()
+	i := sort.Search(len(wt.roots), func(i int) bool {
+		return wt.roots[i].L2Block.Number >= fromL2
+	})
+	return append([]FinalizedWithdrawalRoot(nil), wt.roots[i:]...)
+}
+
+func (wt *WithdrawalRootTracker) loop() {
+	defer wt.wg.Done()
+	for {
+		select {
+		case <-wt.ctx.Done():
+			return
+		case <-wt.wake:
+		}
+		wt.mu.Lock()
+		ev := wt.pending
+		wt.pending = nil
+		wt.mu.Unlock()
+		if ev == nil {
+			continue
+		}
+		root, err := wt.fetch(wt.ctx, ev)
+		if err != nil {
+			if wt.ctx.Err() != nil {
+				return
+			}
+			wt.log.Warn("failed to fetch withdrawal root of finalized L2 head, passing on the event without it",
+				"finalized_l2", ev.FinalizedL2, "err", err)
+			ev.WithdrawalRoot = nil
+		} else {
+			ev.WithdrawalRoot = &root.WithdrawalRoot
+		}
+		wt.mu.Lock()
+		if err == nil {
+			wt.roots = append(wt.roots, root)
+			if len(wt.roots) > wt.maxRoots {
+				wt.roots = wt.roots[len(wt.roots)-wt.maxRoots:]
+			}
+		}
+		subscribers := append([]*finalizedSubscription(nil), wt.subscribers...)
+		wt.mu.Unlock()
+		for _, sub := range subscribers {
+			sub.fn(*ev)
+		}
+	}
+}
+
+func (wt *WithdrawalRootTracker) fetch(ctx context.Context, ev *FinalizedEvent) (FinalizedWithdrawalRoot, error) {
+	ctx, cancel := context.WithTimeout(ctx, withdrawalRootFetchTimeout)
+	defer cancel()
+	output, err := wt.outputs.OutputV0AtBlock(ctx, ev.FinalizedL2.Hash)
+	if err != nil {
+		return FinalizedWithdrawalRoot{}, fmt.Errorf("failed to fetch output of finalized L2 block %s: %w", ev.FinalizedL2, err)
+	}
+	return FinalizedWithdrawalRoot{
+		L2Block:        ev.FinalizedL2.ID(),
+		WithdrawalRoot: output.MessagePasserStorageRoot,
+		OutputRoot:     eth.OutputRoot(output),
+		FinalizedL1:    ev.FinalizedL1.ID(),
+	}, nil
+}
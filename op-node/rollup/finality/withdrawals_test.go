@@ -0,0 +1,82 @@
+package finality
+
+import (
+	"context"
+	"errors"
+	"math/rand" // nosemgrep
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeWithdrawalOutputs struct {
+	mu     sync.Mutex
+	failed map[common.Hash]bool
+}
+
+func (f *fakeWithdrawalOutputs) OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failed[blockHash] {
+		return nil, errors.New("proof unavailable")
+	}
+	return &eth.OutputV0{StateRoot: eth.Bytes32{1}, MessagePasserStorageRoot: eth.Bytes32(blockHash), BlockHash: blockHash}, nil
+}
+
+func TestWithdrawalRootTracker(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	chain := newTestChain(rng, 4)
+	outputs := &fakeWithdrawalOutputs{failed: map[common.Hash]bool{chain.l2[2][1].Hash: true}}
+	wt := NewWithdrawalRootTracker(testlog.Logger(t, log.LevelInfo), outputs, 2)
+
+	var mu sync.Mutex
+	var events []FinalizedEvent
+	wt.SubscribeFinalized(func(ev FinalizedEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, ev)
+	})
+	received := func() []FinalizedEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]FinalizedEvent(nil), events...)
+	}
+	wt.Start()
+	defer wt.Close()
+
+	wt.OnFinalized(FinalizedEvent{PrevFinalizedL2: chain.l2[0][1], FinalizedL2: chain.l2[1][1], FinalizedL1: chain.l1[1]})
+	require.Eventually(t, func() bool { return len(received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	root := eth.Bytes32(chain.l2[1][1].Hash)
+	require.Equal(t, &root, received()[0].WithdrawalRoot)
+	require.Equal(t, []FinalizedWithdrawalRoot{{
+		L2Block:        chain.l2[1][1].ID(),
+		WithdrawalRoot: root,
+		OutputRoot: eth.OutputRoot(&eth.OutputV0{StateRoot: eth.Bytes32{1},
+			MessagePasserStorageRoot: root, BlockHash: chain.l2[1][1].Hash}),
+		FinalizedL1: chain.l1[1].ID(),
+	}}, wt.WithdrawalRoots(0))
+
+	// an event whose withdrawal root cannot be fetched is passed on without it
+	wt.OnFinalized(FinalizedEvent{PrevFinalizedL2: chain.l2[1][1], FinalizedL2: chain.l2[2][1], FinalizedL1: chain.l1[2]})
+	require.Eventually(t, func() bool { return len(received()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.Nil(t, received()[1].WithdrawalRoot)
+	require.Len(t, wt.WithdrawalRoots(0), 1)
+
+	// only the most recent withdrawal roots are retained
+	wt.OnFinalized(FinalizedEvent{PrevFinalizedL2: chain.l2[2][1], FinalizedL2: chain.l2[3][0], FinalizedL1: chain.l1[3]})
+	wt.OnFinalized(FinalizedEvent{PrevFinalizedL2: chain.l2[3][0], FinalizedL2: chain.l2[3][1], FinalizedL1: chain.l1[3]})
+	require.Eventually(t, func() bool {
+		roots := wt.WithdrawalRoots(0)
+		return len(roots) == 2 && roots[1].L2Block == chain.l2[3][1].ID()
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, wt.WithdrawalRoots(chain.l2[3][1].Number), 1)
+	require.Empty(t, wt.WithdrawalRoots(chain.l2[3][1].Number+1))
+}
@@ -95,6 +95,12 @@ func LoadOPStackRollupConfig(chainID uint64) (*Config, error) {
 	if superChain.Config.ProtocolVersionsAddr != nil { // Set optional protocol versions address
 		cfg.ProtocolVersionsAddress = common.Address(*superChain.Config.ProtocolVersionsAddr)
 	}
+	switch chConfig.SuperchainLevel {
+	case superchain.Standard:
+		cfg.FinalityProfile = FinalityProfileStandard
+	case superchain.Frontier:
+		cfg.FinalityProfile = FinalityProfileFrontier
+	}
 	if chainID == pgnSepolia {
 		cfg.MaxSequencerDrift = 1000
 		cfg.SeqWindowSize = 7200
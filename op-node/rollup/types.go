@@ -49,6 +49,67 @@ type Genesis struct {
 	SystemConfig eth.SystemConfig `json:"system_config"`
 }
 
+// FinalityRule describes the finalization requirements of L2 blocks at or past the activation of a hardfork,
+// on top of the finalization requirements the node is configured with.
+type FinalityRule struct {
+	// Fork is the hardfork the rule activates with. A rule of a hardfork that is not scheduled is inactive.
+	Fork ForkName `json:"fork"`
+	// DisputeGameGated requires finalized L2 blocks to be backed by a resolved dispute game.
+	DisputeGameGated bool `json:"dispute_game_gated,omitempty"`
+	// ExtraConfirmations requires the L1 block an L2 block was derived from to be this many blocks
+	// older than the finalized L1 block, before the L2 block is finalized.
+	ExtraConfirmations uint64 `json:"extra_confirmations,omitempty"`
+}
+
+// FinalityProfile is a set of finality parameters of the node, that are not part of consensus.
+// Zero fields keep the default of the node. Finality flags of the node take precedence over the profile.
+type FinalityProfile struct {
+	// Lookback is the number of L1 blocks to track the derived L2 blocks of for finalization,
+	// instead of the lookback sized to the L1 beacon chain. It is still extended to the alt-DA challenge windows.
+	Lookback uint64 `json:"lookback,omitempty"`
+	// Delay is the number of L1 blocks to traverse before trying to finalize L2 blocks again.
+	Delay uint64 `json:"delay,omitempty"`
+	// TrustSignal skips the canonical-chain sanity checks of the L1 finality signal.
+	TrustSignal bool `json:"trust_signal,omitempty"`
+	// ExtraConfirmations requires the L1 block an L2 block was derived from to be this many blocks
+	// older than the finalized L1 block, before the L2 block is finalized.
+	ExtraConfirmations uint64 `json:"extra_confirmations,omitempty"`
+}
+
+// names of the finality profiles
+const (
+	// FinalityProfileStandard is the profile of the standard chains of the superchain registry: the vetted defaults.
+	FinalityProfileStandard = "standard"
+	// FinalityProfileFrontier is the profile of the frontier chains of the superchain registry.
+	FinalityProfileFrontier = "frontier"
+	// FinalityProfileDevnet is a profile for devnets, that tries to finalize on every L1 block.
+	FinalityProfileDevnet = "devnet"
+)
+
+// FinalityProfiles are the named finality profiles, that chain configs can refer to by name.
+var FinalityProfiles = map[string]FinalityProfile{
+	FinalityProfileStandard: {},
+	FinalityProfileFrontier: {},
+	FinalityProfileDevnet:   {Delay: 1},
+}
+
+// Merge returns the profile, with the non-zero fields of the overrides applied on top.
+func (p FinalityProfile) Merge(overrides FinalityProfile) FinalityProfile {
+	if overrides.Lookback != 0 {
+		p.Lookback = overrides.Lookback
+	}
+	if overrides.Delay != 0 {
+		p.Delay = overrides.Delay
+	}
+	if overrides.TrustSignal {
+		p.TrustSignal = true
+	}
+	if overrides.ExtraConfirmations != 0 {
+		p.ExtraConfirmations = overrides.ExtraConfirmations
+	}
+	return p
+}
+
 type PlasmaConfig struct {
 	// L1 DataAvailabilityChallenge contract proxy address
 	DAChallengeAddress common.Address `json:"da_challenge_contract_address,omitempty"`
@@ -129,6 +190,18 @@ type Config struct {
 	// Plasma Config. We are in the process of migrating to the PlasmaConfig from these legacy top level values
 	PlasmaConfig *PlasmaConfig `json:"plasma_config,omitempty"`
 
+	// FinalityRules optionally changes the finalization behavior of the node at hardfork activations,
+	// in order of activation. This is not part of consensus: it only affects which L2 blocks are considered finalized.
+	FinalityRules []FinalityRule `json:"finality_rules,omitempty"`
+
+	// FinalityProfile optionally names the finality profile of the chain, see FinalityProfiles.
+	// Chains of the superchain registry use the profile of their superchain level.
+	// This is not part of consensus: it only affects which L2 blocks are considered finalized, and when.
+	FinalityProfile string `json:"finality_profile,omitempty"`
+
+	// FinalityOverrides optionally overrides parameters of the finality profile, for custom chains.
+	FinalityOverrides *FinalityProfile `json:"finality_overrides,omitempty"`
+
 	// L1 DataAvailabilityChallenge contract proxy address
 	LegacyDAChallengeAddress common.Address `json:"da_challenge_contract_address,omitempty"`
 
@@ -309,6 +382,12 @@ func (cfg *Config) Check() error {
 	if err := validatePlasmaConfig(cfg); err != nil {
 		return err
 	}
+	if err := validateFinalityRules(cfg.FinalityRules); err != nil {
+		return err
+	}
+	if _, err := cfg.FinalityParams(); err != nil {
+		return err
+	}
 
 	if err := checkFork(cfg.RegolithTime, cfg.CanyonTime, Regolith, Canyon); err != nil {
 		return err
@@ -363,6 +442,41 @@ func validatePlasmaConfig(cfg *Config) error {
 	return nil
 }
 
+// FinalityParams returns the finality parameters of the chain: its named finality profile, with its overrides applied.
+// Chains without a profile use the standard profile.
+func (cfg *Config) FinalityParams() (FinalityProfile, error) {
+	name := cfg.FinalityProfile
+	if name == "" {
+		name = FinalityProfileStandard
+	}
+	profile, ok := FinalityProfiles[name]
+	if !ok {
+		return FinalityProfile{}, fmt.Errorf("unknown finality profile %q", name)
+	}
+	if cfg.FinalityOverrides != nil {
+		profile = profile.Merge(*cfg.FinalityOverrides)
+	}
+	return profile, nil
+}
+
+// validateFinalityRules checks that the finality rules activate with known hardforks, in order of activation.
+func validateFinalityRules(rules []FinalityRule) error {
+	next := Bedrock
+	for i, rule := range rules {
+		if _, ok := nextFork[rule.Fork]; !ok {
+			return fmt.Errorf("finality rule %d has unknown fork %q", i, rule.Fork)
+		}
+		for next != rule.Fork {
+			if next == None {
+				return fmt.Errorf("finality rule %d of fork %s is out of order", i, rule.Fork)
+			}
+			next = nextFork[next]
+		}
+		next = nextFork[next]
+	}
+	return nil
+}
+
 // checkFork checks that fork A is before or at the same time as fork B
 func checkFork(a, b *uint64, aName, bName ForkName) error {
 	if a == nil && b == nil {
@@ -417,6 +531,28 @@ func (c *Config) IsFjordActivationBlock(l2BlockTime uint64) bool {
 		!c.IsFjord(l2BlockTime-c.BlockTime)
 }
 
+// ForkActivationTime returns the activation time of the given hardfork, or nil if it is not scheduled.
+func (c *Config) ForkActivationTime(fork ForkName) *uint64 {
+	switch fork {
+	case Bedrock:
+		return new(uint64)
+	case Regolith:
+		return c.RegolithTime
+	case Canyon:
+		return c.CanyonTime
+	case Delta:
+		return c.DeltaTime
+	case Ecotone:
+		return c.EcotoneTime
+	case Fjord:
+		return c.FjordTime
+	case Interop:
+		return c.InteropTime
+	default:
+		return nil
+	}
+}
+
 // IsInterop returns true if the Interop hardfork is active at or past the given timestamp.
 func (c *Config) IsInterop(timestamp uint64) bool {
 	return c.InteropTime != nil && timestamp >= *c.InteropTime
@@ -507,6 +507,34 @@ func TestConfig_Check(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "FinalityRuleUnknownFork",
+			modifier: func(cfg *Config) {
+				cfg.FinalityRules = []FinalityRule{{Fork: "granite"}}
+			},
+			expectedErr: fmt.Errorf("finality rule 0 has unknown fork \"granite\""),
+		},
+		{
+			name: "FinalityRulesOutOfOrder",
+			modifier: func(cfg *Config) {
+				cfg.FinalityRules = []FinalityRule{{Fork: Fjord}, {Fork: Ecotone}}
+			},
+			expectedErr: fmt.Errorf("finality rule 1 of fork ecotone is out of order"),
+		},
+		{
+			name: "FinalityRulesOK",
+			modifier: func(cfg *Config) {
+				cfg.FinalityRules = []FinalityRule{{Fork: Bedrock, ExtraConfirmations: 1}, {Fork: Interop, DisputeGameGated: true}}
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "FinalityProfileUnknown",
+			modifier: func(cfg *Config) {
+				cfg.FinalityProfile = "fast"
+			},
+			expectedErr: fmt.Errorf("unknown finality profile \"fast\""),
+		},
 	}
 
 	for _, test := range forkTests {
@@ -519,6 +547,19 @@ func TestConfig_Check(t *testing.T) {
 	}
 }
 
+func TestFinalityParams(t *testing.T) {
+	cfg := randConfig()
+	params, err := cfg.FinalityParams()
+	require.NoError(t, err)
+	require.Equal(t, FinalityProfiles[FinalityProfileStandard], params)
+
+	cfg.FinalityProfile = FinalityProfileDevnet
+	cfg.FinalityOverrides = &FinalityProfile{Lookback: 1000, ExtraConfirmations: 2}
+	params, err = cfg.FinalityParams()
+	require.NoError(t, err)
+	require.Equal(t, FinalityProfile{Lookback: 1000, Delay: 1, ExtraConfirmations: 2}, params)
+}
+
 func TestTimestampForBlock(t *testing.T) {
 	config := randConfig()
 
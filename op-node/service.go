@@ -23,6 +23,7 @@ import (
 	p2pcli "github.com/ethereum-optimism/optimism/op-node/p2p/cli"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 )
@@ -46,6 +47,52 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 	configPersistence := NewConfigPersistence(ctx)
 
 	driverConfig := NewDriverConfig(ctx)
+	finalityFaults, err := finality.ParseFaultConfig(ctx.String(flags.FinalityFaultInjection.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid finality fault injection: %w", err)
+	}
+	driverConfig.Finality.Faults = finalityFaults
+	finalityPruning, err := finality.ParsePruningConfig(ctx.String(flags.FinalityPruning.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid finality pruning: %w", err)
+	}
+	driverConfig.Finality.Pruning = finalityPruning
+	signalWeights, err := finality.ParseSignalWeights(ctx.String(flags.FinalitySignalWeights.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid finality signal weights: %w", err)
+	}
+	driverConfig.Finality.SignalWeights = signalWeights
+	replicaPolicy, err := finality.ParseCrossValidationPolicy(ctx.String(flags.FinalityReplicaPolicy.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid finality replica policy: %w", err)
+	}
+	driverConfig.Finality.ReplicaPolicy = replicaPolicy
+	plasmaChangeAction, err := finality.ParsePlasmaChangeAction(ctx.String(flags.FinalityPlasmaChangeAction.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid finality plasma change action: %w", err)
+	}
+	driverConfig.Finality.PlasmaChangeAction = plasmaChangeAction
+	finalityMode, err := finality.ParseMode(ctx.String(flags.FinalityMode.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid finality mode: %w", err)
+	}
+	driverConfig.Finality.Mode = finalityMode
+	var disputeGameFactory common.Address
+	if ctx.IsSet(flags.FinalityDisputeGameFactory.Name) {
+		addr := ctx.String(flags.FinalityDisputeGameFactory.Name)
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid finality dispute game factory address: %q", addr)
+		}
+		disputeGameFactory = common.HexToAddress(addr)
+	}
+	var committeeRegistry common.Address
+	if ctx.IsSet(flags.FinalityCommitteeRegistry.Name) {
+		addr := ctx.String(flags.FinalityCommitteeRegistry.Name)
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid finality committee registry address: %q", addr)
+		}
+		committeeRegistry = common.HexToAddress(addr)
+	}
 
 	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx)
 	if err != nil {
@@ -111,6 +158,29 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		ConductorRpcTimeout: ctx.Duration(flags.ConductorRpcTimeoutFlag.Name),
 
 		Plasma: plasma.ReadCLIConfig(ctx),
+
+		FinalityFollow:       ctx.String(flags.FinalityFollow.Name),
+		FinalityReplica:      ctx.String(flags.FinalityReplica.Name),
+		FinalityShadowL1:     ctx.String(flags.FinalityShadowL1.Name),
+		FinalityBeaconEvents: ctx.String(flags.FinalityBeaconEvents.Name),
+		FinalityLightClient:  ctx.String(flags.FinalityLightClient.Name),
+		FinalityReceipts:     ctx.Bool(flags.FinalityReceipts.Name),
+		FinalityStateStore:   ctx.String(flags.FinalityStateStore.Name),
+
+		FinalityDisputeGameFactory: disputeGameFactory,
+		FinalityDisputeGameType:    uint32(ctx.Uint(flags.FinalityDisputeGameType.Name)),
+
+		FinalityCommitteeRegistry:     committeeRegistry,
+		FinalityCommitteeAttestations: ctx.String(flags.FinalityCommitteeAttestations.Name),
+
+		FinalityEngineAnnounce:  ctx.Bool(flags.FinalityEngineAnnounce.Name),
+		FinalityOutbox:          ctx.String(flags.FinalityOutbox.Name),
+		FinalityOutboxSinks:     ctx.StringSlice(flags.FinalityOutboxSinks.Name),
+		FinalityOutboxEnriched:  ctx.Bool(flags.FinalityOutboxEnriched.Name),
+		FinalityIndexPath:       ctx.String(flags.FinalityIndexPath.Name),
+		FinalityGRPCAddr:        ctx.String(flags.FinalityGRPCAddr.Name),
+		FinalityWithdrawalRoots: ctx.Bool(flags.FinalityWithdrawalRoots.Name),
+		FinalityTunablesFile:    ctx.String(flags.FinalityTunablesFile.Name),
 	}
 
 	if err := cfg.LoadPersisted(log); err != nil {
@@ -190,11 +260,46 @@ func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 
 func NewDriverConfig(ctx *cli.Context) *driver.Config {
 	return &driver.Config{
-		VerifierConfDepth:   ctx.Uint64(flags.VerifierL1Confs.Name),
-		SequencerConfDepth:  ctx.Uint64(flags.SequencerL1Confs.Name),
-		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
-		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
-		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		VerifierConfDepth:        ctx.Uint64(flags.VerifierL1Confs.Name),
+		SequencerConfDepth:       ctx.Uint64(flags.SequencerL1Confs.Name),
+		SequencerEnabled:         ctx.Bool(flags.SequencerEnabledFlag.Name),
+		SequencerStopped:         ctx.Bool(flags.SequencerStoppedFlag.Name),
+		SequencerMaxSafeLag:      ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		SequencerFinalityLagSlow: ctx.Uint64(flags.SequencerFinalityLagSlowFlag.Name),
+		SequencerMaxFinalityLag:  ctx.Uint64(flags.SequencerMaxFinalityLagFlag.Name),
+		Finality: driver.FinalityConfig{
+			MaxAdvance:         ctx.Uint64(flags.FinalityMaxAdvance.Name),
+			MaxSignalAge:       ctx.Duration(flags.FinalityMaxSignalAge.Name),
+			BlobRetention:      ctx.Duration(flags.FinalityBlobRetention.Name),
+			ExtraConfirmations: ctx.Uint64(flags.FinalityExtraConfirmations.Name),
+			MinInterval:        ctx.Duration(flags.FinalityMinInterval.Name),
+			MinBlocks:          ctx.Uint64(flags.FinalityMinBlocks.Name),
+			EngineCallTimeout:  ctx.Duration(flags.FinalityEngineCallTimeout.Name),
+			TraceIDs:           ctx.Bool(flags.FinalityTraceIDs.Name),
+			MaxLookback:        ctx.Uint64(flags.FinalityMaxLookback.Name),
+			Compression:        ctx.Uint64(flags.FinalityCompression.Name),
+			LatencySLO: finality.LatencySLO{
+				Objective:    ctx.Float64(flags.FinalitySLOObjective.Name),
+				TargetFactor: ctx.Float64(flags.FinalitySLOTargetFactor.Name),
+				Window:       ctx.Duration(flags.FinalitySLOWindow.Name),
+			},
+			AdaptiveDelayMin:     ctx.Uint64(flags.FinalityAdaptiveDelayMin.Name),
+			AdaptiveDelayMax:     ctx.Uint64(flags.FinalityAdaptiveDelayMax.Name),
+			TrustSignal:          ctx.Bool(flags.FinalityTrustSignal.Name),
+			AncestryCheck:        ctx.Uint64(flags.FinalityAncestryCheck.Name),
+			RepairUnjustified:    ctx.Bool(flags.FinalityRepairUnjustified.Name),
+			Backfill:             ctx.Bool(flags.FinalityBackfill.Name),
+			SpanBatches:          ctx.Bool(flags.FinalitySpanBatches.Name),
+			L1SlotsPerEpoch:      ctx.Uint64(flags.FinalityL1SlotsPerEpoch.Name),
+			BeaconEpochs:         ctx.Bool(flags.FinalityBeaconEpochs.Name),
+			SignalThreshold:      ctx.Uint64(flags.FinalitySignalThreshold.Name),
+			MaxMismatches:        ctx.Int(flags.FinalityMaxMismatches.Name),
+			HaltOnSafeRegression: ctx.Bool(flags.FinalityHaltOnSafeRegression.Name),
+			DisputeGameGate:      ctx.Bool(flags.FinalityDisputeGameGate.Name),
+			Settlement:           ctx.Bool(flags.FinalitySettlement.Name),
+			FakeSignals:          ctx.Bool(flags.FinalityFakeSignals.Name),
+			UnsafeRollback:       ctx.Bool(flags.FinalityUnsafeRollback.Name),
+		},
 	}
 }
 
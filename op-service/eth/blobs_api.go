@@ -53,6 +53,7 @@ type APIGenesisResponse struct {
 
 type ReducedConfigData struct {
 	SecondsPerSlot Uint64String `json:"SECONDS_PER_SLOT"`
+	SlotsPerEpoch  Uint64String `json:"SLOTS_PER_EPOCH"`
 }
 
 type APIConfigResponse struct {
@@ -510,4 +510,19 @@ const (
 
 	GetPayloadV2 EngineAPIMethod = "engine_getPayloadV2"
 	GetPayloadV3 EngineAPIMethod = "engine_getPayloadV3"
+
+	// AnnounceFinalizedV1 is an optional Engine API extension, to announce the L1 origin of the finalized L2 block.
+	// It is only used if the execution client lists it in the exchanged capabilities.
+	AnnounceFinalizedV1 EngineAPIMethod = "engine_announceFinalizedV1"
 )
+
+// FinalizedAnnouncementV1 announces the finalized L2 block to the execution client,
+// with the L1 block it was derived from, for execution clients that index L1-origin info at finalization time.
+type FinalizedAnnouncementV1 struct {
+	// finalized L2 block, as set with the finalized block hash of the forkchoice state
+	FinalizedBlockHash common.Hash    `json:"finalizedBlockHash"`
+	FinalizedNumber    Uint64Quantity `json:"finalizedNumber"`
+	// L1 block the finalized L2 block was derived from
+	DerivedFromHash   common.Hash    `json:"derivedFromHash"`
+	DerivedFromNumber Uint64Quantity `json:"derivedFromNumber"`
+}
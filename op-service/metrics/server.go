@@ -11,8 +11,9 @@ import (
 
 func StartServer(r *prometheus.Registry, hostname string, port int) (*httputil.HTTPServer, error) {
 	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
+	// OpenMetrics is negotiated with the scraper, and is required to expose exemplars.
 	h := promhttp.InstrumentMetricHandler(
-		r, promhttp.HandlerFor(r, promhttp.HandlerOpts{}),
+		r, promhttp.HandlerFor(r, promhttp.HandlerOpts{EnableOpenMetrics: true}),
 	)
 	return httputil.StartHTTPServer(addr, h)
 }
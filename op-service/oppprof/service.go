@@ -27,6 +27,9 @@ type Service struct {
 
 	cpuFile    io.Closer
 	httpServer *httputil.HTTPServer
+
+	// handlers are additional debug handlers, served next to the pprof handlers.
+	handlers map[string]http.Handler
 }
 
 func New(listenEnabled bool, listenAddr string, listenPort int, profType profileType, profileDir, profileFilename string) *Service {
@@ -40,6 +43,15 @@ func New(listenEnabled bool, listenAddr string, listenPort int, profType profile
 	}
 }
 
+// Handle registers an additional debug handler for the given pattern, to serve next to the pprof handlers.
+// It must be called before Start.
+func (s *Service) Handle(pattern string, handler http.Handler) {
+	if s.handlers == nil {
+		s.handlers = make(map[string]http.Handler)
+	}
+	s.handlers[pattern] = handler
+}
+
 func (s *Service) Start() error {
 	switch s.profileType {
 	case "cpu":
@@ -106,6 +118,9 @@ func (s *Service) startServer() error {
 	mux.Handle("/debug/pprof/profile", http.HandlerFunc(httpPprof.Profile))
 	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(httpPprof.Symbol))
 	mux.Handle("/debug/pprof/trace", http.HandlerFunc(httpPprof.Trace))
+	for pattern, handler := range s.handlers {
+		mux.Handle(pattern, handler)
+	}
 
 	addr := net.JoinHostPort(s.listenAddr, strconv.Itoa(s.listenPort))
 
@@ -0,0 +1,139 @@
+package sources
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// BeaconFinalizedCheckpoint is the data of a finalized_checkpoint event of the Beacon API event stream.
+type BeaconFinalizedCheckpoint struct {
+	Block               common.Hash `json:"block"`
+	State               common.Hash `json:"state"`
+	Epoch               string      `json:"epoch"`
+	ExecutionOptimistic bool        `json:"execution_optimistic"`
+}
+
+type L1BlockRefByHashFetcher interface {
+	L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error)
+}
+
+// BeaconFinalitySource subscribes to the finalized_checkpoint events of a beacon node,
+// and converts the finalized checkpoints to L1 block references, as a lower latency alternative
+// to polling the finalized block of the execution node.
+type BeaconFinalitySource struct {
+	log      log.Logger
+	endpoint string
+	// stream is the HTTP client of the event stream, which, unlike the regular Beacon API client, has no timeout.
+	stream *http.Client
+	beacon *BeaconHTTPClient
+	l1     L1BlockRefByHashFetcher
+}
+
+func NewBeaconFinalitySource(log log.Logger, endpoint string, l1 L1BlockRefByHashFetcher) *BeaconFinalitySource {
+	return &BeaconFinalitySource{
+		log:      log,
+		endpoint: endpoint,
+		stream:   &http.Client{},
+		beacon:   NewBeaconHTTPClient(client.NewBasicHTTPClient(endpoint, log)),
+		l1:       l1,
+	}
+}
+
+// Subscribe calls fn with every finalized L1 block, and resubscribes to the event stream when it fails.
+func (s *BeaconFinalitySource) Subscribe(fn eth.HeadSignalFn) ethereum.Subscription {
+	return event.ResubscribeErr(time.Second*10, func(_ context.Context, err error) (event.Subscription, error) {
+		if err != nil {
+			s.log.Warn("resubscribing after failed beacon finality events subscription", "err", err)
+		}
+		return event.NewSubscription(func(quit <-chan struct{}) error {
+			// the resubscribe context only covers establishing the subscription, not the lifetime of the stream
+			streamCtx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				select {
+				case <-quit:
+					cancel()
+				case <-streamCtx.Done():
+				}
+			}()
+			err := s.run(streamCtx, fn)
+			if streamCtx.Err() != nil {
+				return nil // unsubscribed
+			}
+			return err
+		}), nil
+	})
+}
+
+func (s *BeaconFinalitySource) run(ctx context.Context, fn eth.HeadSignalFn) error {
+	target, err := url.Parse(s.endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to parse endpoint URL: %w", err)
+	}
+	target = target.JoinPath(eventsMethod)
+	target.RawQuery = url.Values{"topics": []string{"finalized_checkpoint"}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to construct request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := s.stream.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to open beacon event stream: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		errMsg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to open beacon event stream with status %d: %s", resp.StatusCode, string(errMsg))
+	}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := bytes.CutPrefix(scanner.Bytes(), []byte("data:"))
+		if !ok {
+			continue // only the data lines are relevant, there is only a single event topic
+		}
+		if err := s.onCheckpoint(ctx, bytes.TrimSpace(data), fn); err != nil {
+			s.log.Warn("failed to process finalized checkpoint event", "err", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("beacon event stream failed: %w", err)
+	}
+	return io.EOF
+}
+
+func (s *BeaconFinalitySource) onCheckpoint(ctx context.Context, data []byte, fn eth.HeadSignalFn) error {
+	var checkpoint BeaconFinalizedCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return fmt.Errorf("failed to decode finalized checkpoint: %w", err)
+	}
+	if checkpoint.ExecutionOptimistic {
+		return fmt.Errorf("finalized checkpoint of epoch %s is execution-optimistic", checkpoint.Epoch)
+	}
+	hash, err := s.beacon.BeaconBlockExecutionHash(ctx, checkpoint.Block)
+	if err != nil {
+		return fmt.Errorf("failed to fetch execution payload of finalized checkpoint block %s: %w", checkpoint.Block, err)
+	}
+	ref, err := s.l1.L1BlockRefByHash(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch finalized L1 block %s: %w", hash, err)
+	}
+	s.log.Debug("received finalized checkpoint", "epoch", checkpoint.Epoch, "l1", ref)
+	fn(ctx, ref)
+	return nil
+}
@@ -0,0 +1,61 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestBeaconFinalitySource(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	blockRoot := testutils.RandomHash(rng)
+	finalized := testutils.RandomBlockRef(rng)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "finalized_checkpoint", r.URL.Query().Get("topics"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		// an execution-optimistic checkpoint is ignored
+		_, _ = fmt.Fprintf(w, "event: finalized_checkpoint\ndata: {\"block\":\"%s\",\"state\":\"%s\",\"epoch\":\"1\",\"execution_optimistic\":true}\n\n",
+			testutils.RandomHash(rng), testutils.RandomHash(rng))
+		_, _ = fmt.Fprintf(w, "event: finalized_checkpoint\ndata: {\"block\":\"%s\",\"state\":\"%s\",\"epoch\":\"2\",\"execution_optimistic\":false}\n\n",
+			blockRoot, testutils.RandomHash(rng))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	mux.HandleFunc("/eth/v2/beacon/blocks/"+blockRoot.String(), func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"data":{"message":{"body":{"execution_payload":{"block_hash":"%s"}}}}}`, finalized.Hash)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	l1 := &testutils.MockL1Source{}
+	defer l1.AssertExpectations(t)
+	l1.ExpectL1BlockRefByHash(finalized.Hash, finalized, nil)
+
+	src := NewBeaconFinalitySource(testlog.Logger(t, log.LevelDebug), srv.URL, l1)
+	signals := make(chan eth.L1BlockRef, 2)
+	sub := src.Subscribe(func(ctx context.Context, sig eth.L1BlockRef) {
+		signals <- sig
+	})
+	defer sub.Unsubscribe()
+
+	select {
+	case sig := <-signals:
+		require.Equal(t, finalized, sig)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for finalized signal")
+	}
+}
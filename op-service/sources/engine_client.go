@@ -172,6 +172,20 @@ func (s *EngineAPIClient) GetPayload(ctx context.Context, payloadInfo eth.Payloa
 	return &result, nil
 }
 
+// ExchangeCapabilities exchanges the supported Engine API methods with the execution client,
+// and returns the methods the execution client supports.
+func (s *EngineAPIClient) ExchangeCapabilities(ctx context.Context, methods []eth.EngineAPIMethod) ([]eth.EngineAPIMethod, error) {
+	var result []eth.EngineAPIMethod
+	err := s.RPC.CallContext(ctx, &result, "engine_exchangeCapabilities", methods)
+	return result, err
+}
+
+// AnnounceFinalizedV1 announces the finalized L2 block, with the L1 block it was derived from, to the execution client.
+// The execution client has to support the extension, see ExchangeCapabilities.
+func (s *EngineAPIClient) AnnounceFinalizedV1(ctx context.Context, announcement *eth.FinalizedAnnouncementV1) error {
+	return s.RPC.CallContext(ctx, nil, string(eth.AnnounceFinalizedV1), announcement)
+}
+
 func (s *EngineAPIClient) SignalSuperchainV1(ctx context.Context, recommended, required params.ProtocolVersion) (params.ProtocolVersion, error) {
 	var result params.ProtocolVersion
 	err := s.RPC.CallContext(ctx, &result, "engine_signalSuperchainV1", &catalyst.SuperchainSignal{
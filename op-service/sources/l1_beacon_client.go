@@ -13,6 +13,7 @@ import (
 	"sync"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -24,6 +25,8 @@ const (
 	specMethod           = "eth/v1/config/spec"
 	genesisMethod        = "eth/v1/beacon/genesis"
 	sidecarsMethodPrefix = "eth/v1/beacon/blob_sidecars/"
+	blocksMethodPrefix   = "eth/v2/beacon/blocks/"
+	eventsMethod         = "eth/v1/events"
 )
 
 type L1BeaconClientConfig struct {
@@ -116,6 +119,25 @@ func (cl *BeaconHTTPClient) BeaconGenesis(ctx context.Context) (eth.APIGenesisRe
 	return genesisResp, nil
 }
 
+// BeaconBlockExecutionHash returns the hash of the execution payload of the beacon block with the given root.
+func (cl *BeaconHTTPClient) BeaconBlockExecutionHash(ctx context.Context, blockRoot common.Hash) (common.Hash, error) {
+	var resp struct {
+		Data struct {
+			Message struct {
+				Body struct {
+					ExecutionPayload struct {
+						BlockHash common.Hash `json:"block_hash"`
+					} `json:"execution_payload"`
+				} `json:"body"`
+			} `json:"message"`
+		} `json:"data"`
+	}
+	if err := cl.apiReq(ctx, &resp, blocksMethodPrefix+blockRoot.String(), nil); err != nil {
+		return common.Hash{}, err
+	}
+	return resp.Data.Message.Body.ExecutionPayload.BlockHash, nil
+}
+
 func (cl *BeaconHTTPClient) BeaconBlobSideCars(ctx context.Context, fetchAllSidecars bool, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error) {
 	reqPath := path.Join(sidecarsMethodPrefix, strconv.FormatUint(slot, 10))
 	var reqQuery url.Values
@@ -218,6 +240,35 @@ func (cl *L1BeaconClient) GetTimeToSlotFn(ctx context.Context) (TimeToSlotFn, er
 	return cl.timeToSlotFn, nil
 }
 
+// GetSlotClock returns the genesis time and the seconds per slot of the beacon chain, from the beacon spec.
+func (cl *L1BeaconClient) GetSlotClock(ctx context.Context) (genesisTime uint64, secondsPerSlot uint64, err error) {
+	genesis, err := cl.cl.BeaconGenesis(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	config, err := cl.cl.ConfigSpec(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	if config.Data.SecondsPerSlot == 0 {
+		return 0, 0, fmt.Errorf("got bad value for seconds per slot: %v", config.Data.SecondsPerSlot)
+	}
+	return uint64(genesis.Data.GenesisTime), uint64(config.Data.SecondsPerSlot), nil
+}
+
+// GetSlotsPerEpoch returns the number of slots per epoch of the beacon chain, from the beacon spec.
+func (cl *L1BeaconClient) GetSlotsPerEpoch(ctx context.Context) (uint64, error) {
+	config, err := cl.cl.ConfigSpec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	slotsPerEpoch := uint64(config.Data.SlotsPerEpoch)
+	if slotsPerEpoch == 0 {
+		return 0, fmt.Errorf("got bad value for slots per epoch: %v", config.Data.SlotsPerEpoch)
+	}
+	return slotsPerEpoch, nil
+}
+
 func (cl *L1BeaconClient) fetchSidecars(ctx context.Context, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error) {
 	var errs []error
 	for i := 0; i < cl.pool.Len(); i++ {
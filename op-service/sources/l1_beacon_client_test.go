@@ -228,3 +228,18 @@ func TestClientPoolSeveral(t *testing.T) {
 		p.MoveToNext()
 	}
 }
+
+func TestBeaconClientSlotsPerEpoch(t *testing.T) {
+	ctx := context.Background()
+	p := mocks.NewBeaconClient(t)
+	c := NewL1BeaconClient(p, L1BeaconClientConfig{})
+	p.EXPECT().ConfigSpec(ctx).Return(eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: 2, SlotsPerEpoch: 16}}, nil).Once()
+	slotsPerEpoch, err := c.GetSlotsPerEpoch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(16), slotsPerEpoch)
+
+	// beacon nodes that do not report the slots per epoch are rejected
+	p.EXPECT().ConfigSpec(ctx).Return(eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: 2}}, nil).Once()
+	_, err = c.GetSlotsPerEpoch(ctx)
+	require.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality/api"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -39,6 +40,66 @@ func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
 	return output, err
 }
 
+func (r *RollupClient) FinalityStatus(ctx context.Context) (*api.FinalityStatus, error) {
+	var output *api.FinalityStatus
+	err := r.rpc.CallContext(ctx, &output, "optimism_finalityStatus", hexutil.Uint64(api.LatestStatusSchema))
+	return output, err
+}
+
+func (r *RollupClient) FinalitySnapshot(ctx context.Context) (*api.Snapshot, error) {
+	var output *api.Snapshot
+	err := r.rpc.CallContext(ctx, &output, "optimism_finalitySnapshot")
+	return output, err
+}
+
+func (r *RollupClient) FinalityAudit(ctx context.Context) ([]api.FinalizedHeadUpdate, error) {
+	var output []api.FinalizedHeadUpdate
+	err := r.rpc.CallContext(ctx, &output, "optimism_finalityAudit")
+	return output, err
+}
+
+func (r *RollupClient) FinalityCasualties(ctx context.Context) ([]api.PruningCasualty, error) {
+	var output []api.PruningCasualty
+	err := r.rpc.CallContext(ctx, &output, "optimism_finalityCasualties")
+	return output, err
+}
+
+func (r *RollupClient) FinalizedAtTime(ctx context.Context, timestamp uint64) (eth.L2BlockRef, error) {
+	var output eth.L2BlockRef
+	err := r.rpc.CallContext(ctx, &output, "optimism_finalizedAtTime", hexutil.Uint64(timestamp))
+	return output, err
+}
+
+func (r *RollupClient) FinalityDerivedFrom(ctx context.Context, l2Number uint64) (eth.BlockID, error) {
+	var output eth.BlockID
+	err := r.rpc.CallContext(ctx, &output, "optimism_finalityDerivedFrom", hexutil.Uint64(l2Number))
+	return output, err
+}
+
+func (r *RollupClient) FinalizedAt(ctx context.Context, l2Number uint64) (*api.FinalizationRecord, error) {
+	var output *api.FinalizationRecord
+	err := r.rpc.CallContext(ctx, &output, "optimism_finalizedAt", hexutil.Uint64(l2Number))
+	return output, err
+}
+
+func (r *RollupClient) FinalizedWithdrawalRoots(ctx context.Context, fromL2 uint64) ([]api.FinalizedWithdrawalRoot, error) {
+	var output []api.FinalizedWithdrawalRoot
+	err := r.rpc.CallContext(ctx, &output, "optimism_finalizedWithdrawalRoots", hexutil.Uint64(fromL2))
+	return output, err
+}
+
+func (r *RollupClient) FinalizedAtLeast(ctx context.Context, l2Number uint64) (bool, error) {
+	var output bool
+	err := r.rpc.CallContext(ctx, &output, "optimism_finalizedAtLeast", hexutil.Uint64(l2Number))
+	return output, err
+}
+
+func (r *RollupClient) EstimateFinality(ctx context.Context, l2Number uint64) (*api.FinalityEstimate, error) {
+	var output *api.FinalityEstimate
+	err := r.rpc.CallContext(ctx, &output, "optimism_estimateFinality", hexutil.Uint64(l2Number))
+	return output, err
+}
+
 func (r *RollupClient) RollupConfig(ctx context.Context) (*rollup.Config, error) {
 	var output *rollup.Config
 	err := r.rpc.CallContext(ctx, &output, "optimism_rollupConfig")
@@ -71,6 +132,20 @@ func (r *RollupClient) PostUnsafePayload(ctx context.Context, payload *eth.Execu
 	return r.rpc.CallContext(ctx, nil, "admin_postUnsafePayload", payload)
 }
 
+func (r *RollupClient) ResumeFinality(ctx context.Context) error {
+	return r.rpc.CallContext(ctx, nil, "admin_resumeFinality")
+}
+
+func (r *RollupClient) UnsafeRollbackFinalized(ctx context.Context, target eth.L2BlockRef) error {
+	return r.rpc.CallContext(ctx, nil, "admin_unsafeRollbackFinalized", target)
+}
+
+func (r *RollupClient) SetFakeFinalizedL1(ctx context.Context, number uint64) (eth.L1BlockRef, error) {
+	var ref eth.L1BlockRef
+	err := r.rpc.CallContext(ctx, &ref, "admin_setFakeFinalizedL1", hexutil.Uint64(number))
+	return ref, err
+}
+
 func (r *RollupClient) SetLogLevel(ctx context.Context, lvl slog.Level) error {
 	return r.rpc.CallContext(ctx, nil, "admin_setLogLevel", lvl.String())
 }